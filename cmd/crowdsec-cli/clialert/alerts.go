@@ -180,6 +180,23 @@ func (cli *cliAlerts) displayOneAlert(alert *models.Alert, withDetail bool) erro
 		table.Render()
 	}
 
+	if alert.Cti != nil {
+		fmt.Printf("\n - CTI  :\n")
+
+		table := cstable.New(color.Output, cfg.Cscli.Color)
+		table.SetRowLines(false)
+		table.SetHeaders("Key", "Value")
+		table.AddRow("reputation", alert.Cti.Reputation)
+		table.AddRow("confidence", alert.Cti.Confidence)
+		table.AddRow("background noise", strconv.FormatInt(alert.Cti.BackgroundNoiseScore, 10))
+		table.AddRow("ip range score", strconv.FormatInt(alert.Cti.IPRangeScore, 10))
+		table.AddRow("behaviors", strings.Join(alert.Cti.Behaviors, ", "))
+		table.AddRow("classifications", strings.Join(alert.Cti.Classifications, ", "))
+		table.AddRow("false positives", strings.Join(alert.Cti.FalsePositives, ", "))
+		table.AddRow("fetched at", alert.Cti.FetchedAt)
+		table.Render()
+	}
+
 	if withDetail {
 		fmt.Printf("\n - Events  :\n")
 
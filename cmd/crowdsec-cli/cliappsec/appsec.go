@@ -0,0 +1,204 @@
+package cliappsec
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/jedib0t/go-pretty/v6/table"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/cstable"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/require"
+	appsecacquisition "github.com/crowdsecurity/crowdsec/pkg/acquisition/modules/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+)
+
+type configGetter func() *csconfig.Config
+
+type cliAppsec struct {
+	cfg configGetter
+}
+
+func New(cfg configGetter) *cliAppsec {
+	return &cliAppsec{
+		cfg: cfg,
+	}
+}
+
+func (cli *cliAppsec) NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "appsec [command]",
+		Short:             "Test the AppSec component",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(cli.newReplayCmd())
+
+	return cmd
+}
+
+func (cli *cliAppsec) loadConfig(configs []string, configPath string) (*appsec.AppsecConfig, error) {
+	hub, err := require.Hub(cli.cfg(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := appsec.LoadAppsecRules(hub); err != nil {
+		return nil, fmt.Errorf("unable to load appsec rules: %w", err)
+	}
+
+	appsecCfg := appsec.AppsecConfig{Logger: log.WithField("component", "appsec_config")}
+
+	if configPath != "" {
+		if err := appsecCfg.LoadByPath(configPath); err != nil {
+			return nil, fmt.Errorf("unable to load appsec_config: %w", err)
+		}
+
+		return &appsecCfg, nil
+	}
+
+	for _, name := range configs {
+		if err := appsecCfg.Load(name); err != nil {
+			return nil, fmt.Errorf("unable to load appsec_config: %w", err)
+		}
+	}
+
+	return &appsecCfg, nil
+}
+
+func (cli *cliAppsec) printResult(out io.Writer, result appsecacquisition.ReplayResult) {
+	req := result.Captured.Request
+
+	fmt.Fprintf(out, "%s %s%s from %s\n", req.Method, req.ClientHost, req.URI, req.ClientIP)
+
+	captured := "captured: " + result.Captured.Reason
+	if result.Captured.Action != "" {
+		captured += " (" + result.Captured.Action + ")"
+	}
+
+	if !result.Captured.Time.IsZero() {
+		captured += " at " + result.Captured.Time.Format("2006-01-02 15:04:05")
+	}
+
+	fmt.Fprintln(out, "  "+captured)
+
+	if result.Err != nil {
+		fmt.Fprintf(out, "  error: %s\n\n", result.Err)
+		return
+	}
+
+	if len(result.Hooks) > 0 {
+		fmt.Fprintln(out, "  hooks:")
+
+		for _, hook := range result.Hooks {
+			fmt.Fprintln(out, "    "+hook)
+		}
+	}
+
+	if len(result.Rules) == 0 {
+		fmt.Fprintln(out, "  no rule matched")
+	} else {
+		t := cstable.NewLight(out, cli.cfg().Cscli.Color).Writer
+		t.AppendHeader(table.Row{"ID", "Message", "Band", "Disruptive", "Targets"})
+
+		for _, rule := range result.Rules {
+			t.AppendRow(table.Row{rule.ID, rule.Message, rule.Band, strconv.FormatBool(rule.Disruptive), strings.Join(rule.Targets, ", ")})
+		}
+
+		fmt.Fprintln(out, t.Render())
+	}
+
+	response := result.Response
+
+	remediation := "remediation: " + response.Action
+	if response.InBandInterrupt {
+		remediation += fmt.Sprintf(" (blocked in band, user response %d, remediation component response %d)",
+			response.UserHTTPResponseCode, response.BouncerHTTPResponseCode)
+	}
+
+	if result.OutOfBandInterrupt {
+		remediation += ", out of band interruption"
+	}
+
+	fmt.Fprintf(out, "  %s\n\n", remediation)
+}
+
+func (cli *cliAppsec) replay(file string, configs []string, configPath string) error {
+	captured, err := appsec.ReadCapture(file)
+	if err != nil {
+		return err
+	}
+
+	if len(captured) == 0 {
+		return fmt.Errorf("no request found in %s", file)
+	}
+
+	appsecCfg, err := cli.loadConfig(configs, configPath)
+	if err != nil {
+		return err
+	}
+
+	results, err := appsecacquisition.Replay(appsecCfg, captured, cli.cfg().ConfigPaths.DataDir)
+	if err != nil {
+		return err
+	}
+
+	blocked := 0
+
+	for _, result := range results {
+		cli.printResult(os.Stdout, result)
+
+		if result.Err == nil && result.Response.InBandInterrupt {
+			blocked++
+		}
+	}
+
+	fmt.Fprintf(os.Stdout, "%d requests replayed, %d blocked in band.\n", len(results), blocked)
+
+	return nil
+}
+
+func (cli *cliAppsec) newReplayCmd() *cobra.Command {
+	var (
+		configs    []string
+		configPath string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "replay <capture file>",
+		Short: "Replay captured requests through a local appsec configuration",
+		Long: `Replay the requests captured by an appsec datasource with capture enabled, and print for each one
+the rules matched, the hooks run and the final remediation.
+The capture file is <data_dir>/appsec_capture_<datasource>.jsonl by default.`,
+		Example: `# Replay with the default appsec configuration.
+cscli appsec replay /var/lib/crowdsec/data/appsec_capture_appsec.jsonl
+
+# Replay with installed appsec configurations.
+cscli appsec replay capture.jsonl --appsec-config crowdsecurity/appsec-default --appsec-config crowdsecurity/crs
+
+# Replay with a configuration being written.
+cscli appsec replay capture.jsonl --appsec-config-path ./my-appsec-config.yaml`,
+		Args:              args.ExactArgs(1),
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("appsec-config") && configPath != "" {
+				return errors.New("--appsec-config and --appsec-config-path are mutually exclusive")
+			}
+
+			return cli.replay(args[0], configs, configPath)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringSliceVar(&configs, "appsec-config", []string{"crowdsecurity/appsec-default"}, "Installed appsec configurations to load")
+	flags.StringVar(&configPath, "appsec-config-path", "", "Path of an appsec configuration to load")
+
+	return cmd
+}
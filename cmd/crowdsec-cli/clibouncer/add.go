@@ -10,14 +10,20 @@ import (
 
 	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
 	middlewares "github.com/crowdsecurity/crowdsec/pkg/apiserver/middlewares/v1"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
 	"github.com/crowdsecurity/crowdsec/pkg/types"
 )
 
-func (cli *cliBouncers) add(ctx context.Context, bouncerName string, key string) error {
+func (cli *cliBouncers) add(ctx context.Context, bouncerName string, key string, filterSpecs []string) error {
 	var err error
 
 	keyLength := 32
 
+	filters, err := database.ParseBouncerFilters(filterSpecs)
+	if err != nil {
+		return fmt.Errorf("invalid bouncer filters: %w", err)
+	}
+
 	if key == "" {
 		key, err = middlewares.GenerateAPIKey(keyLength)
 		if err != nil {
@@ -25,11 +31,17 @@ func (cli *cliBouncers) add(ctx context.Context, bouncerName string, key string)
 		}
 	}
 
-	_, err = cli.db.CreateBouncer(ctx, bouncerName, "", middlewares.HashSHA512(key), types.ApiKeyAuthType, false)
+	b, err := cli.db.CreateBouncer(ctx, bouncerName, "", middlewares.HashSHA512(key), types.ApiKeyAuthType, false)
 	if err != nil {
 		return fmt.Errorf("unable to create bouncer: %w", err)
 	}
 
+	if len(filterSpecs) > 0 {
+		if err = cli.db.UpdateBouncerFilters(ctx, filters, b.ID); err != nil {
+			return err
+		}
+	}
+
 	switch cli.cfg().Cscli.Output {
 	case "human":
 		fmt.Printf("API key for '%s':\n\n", bouncerName)
@@ -50,17 +62,22 @@ func (cli *cliBouncers) add(ctx context.Context, bouncerName string, key string)
 }
 
 func (cli *cliBouncers) newAddCmd() *cobra.Command {
-	var key string
+	var (
+		key     string
+		filters []string
+	)
 
 	cmd := &cobra.Command{
 		Use:   "add MyBouncerName",
 		Short: "add a single bouncer to the database",
 		Example: `cscli bouncers add MyBouncerName
-cscli bouncers add MyBouncerName --key <random-key>`,
+cscli bouncers add MyBouncerName --key <random-key>
+cscli bouncers add MyBouncerName --filter scopes=ip,range --filter origins=crowdsec,cscli
+cscli bouncers add MyBouncerName --filter 'scenarios=crowdsecurity/ssh-*' --filter exclude_countries=FR --filter max_decisions=10000`,
 		Args:              args.ExactArgs(1),
 		DisableAutoGenTag: true,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return cli.add(cmd.Context(), args[0], key)
+			return cli.add(cmd.Context(), args[0], key, filters)
 		},
 	}
 
@@ -68,6 +85,7 @@ cscli bouncers add MyBouncerName --key <random-key>`,
 	flags.StringP("length", "l", "", "length of the api key")
 	_ = flags.MarkDeprecated("length", "use --key instead")
 	flags.StringVarP(&key, "key", "k", "", "api key for the bouncer")
+	flags.StringArrayVar(&filters, "filter", nil, "server-side decision filter as key=value (scopes, origins, scenarios, exclude_countries, exclude_as, max_decisions), can be repeated")
 
 	return cmd
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
 	"github.com/crowdsecurity/crowdsec/pkg/database"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 )
 
 type configGetter = func() *csconfig.Config
@@ -65,18 +66,19 @@ Note: This command requires database direct access, so is intended to be run on
 
 // bouncerInfo contains only the data we want for inspect/list
 type bouncerInfo struct {
-	CreatedAt    time.Time  `json:"created_at"`
-	UpdatedAt    time.Time  `json:"updated_at"`
-	Name         string     `json:"name"`
-	Revoked      bool       `json:"revoked"`
-	IPAddress    string     `json:"ip_address"`
-	Type         string     `json:"type"`
-	Version      string     `json:"version"`
-	LastPull     *time.Time `json:"last_pull"`
-	AuthType     string     `json:"auth_type"`
-	OS           string     `json:"os,omitempty"`
-	Featureflags []string   `json:"featureflags,omitempty"`
-	AutoCreated  bool       `json:"auto_created"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+	Name         string                 `json:"name"`
+	Revoked      bool                   `json:"revoked"`
+	IPAddress    string                 `json:"ip_address"`
+	Type         string                 `json:"type"`
+	Version      string                 `json:"version"`
+	LastPull     *time.Time             `json:"last_pull"`
+	AuthType     string                 `json:"auth_type"`
+	OS           string                 `json:"os,omitempty"`
+	Featureflags []string               `json:"featureflags,omitempty"`
+	AutoCreated  bool                   `json:"auto_created"`
+	Filters      *schema.BouncerFilters `json:"filters,omitempty"`
 }
 
 func newBouncerInfo(b *ent.Bouncer) bouncerInfo {
@@ -93,6 +95,7 @@ func newBouncerInfo(b *ent.Bouncer) bouncerInfo {
 		OS:           clientinfo.GetOSNameAndVersion(b),
 		Featureflags: clientinfo.GetFeatureFlagList(b),
 		AutoCreated:  b.AutoCreated,
+		Filters:      b.Filters,
 	}
 }
 
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 
 	"github.com/fatih/color"
 	"github.com/jedib0t/go-pretty/v6/table"
@@ -48,6 +49,27 @@ func (cli *cliBouncers) inspectHuman(out io.Writer, bouncer *ent.Bouncer) {
 		t.AppendRow(table.Row{"Feature Flags", ff})
 	}
 
+	if f := bouncer.Filters; f != nil {
+		for _, row := range []struct {
+			name   string
+			values []string
+		}{
+			{"Filter: scopes", f.Scopes},
+			{"Filter: origins", f.Origins},
+			{"Filter: scenarios", f.Scenarios},
+			{"Filter: excluded countries", f.ExcludeCountries},
+			{"Filter: excluded AS", f.ExcludeAS},
+		} {
+			if len(row.values) > 0 {
+				t.AppendRow(table.Row{row.name, strings.Join(row.values, ", ")})
+			}
+		}
+
+		if f.MaxDecisions > 0 {
+			t.AppendRow(table.Row{"Filter: max decisions", f.MaxDecisions})
+		}
+	}
+
 	fmt.Fprint(out, t.Render())
 }
 
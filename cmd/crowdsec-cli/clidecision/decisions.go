@@ -166,6 +166,7 @@ func (cli *cliDecisions) NewCommand() *cobra.Command {
 	cmd.AddCommand(cli.newAddCmd())
 	cmd.AddCommand(cli.newDeleteCmd())
 	cmd.AddCommand(cli.newImportCmd())
+	cmd.AddCommand(cli.newExportCmd())
 
 	return cmd
 }
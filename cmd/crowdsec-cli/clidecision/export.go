@@ -0,0 +1,127 @@
+package clidecision
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/require"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/decisionexport"
+)
+
+type exportOpts struct {
+	format              string
+	output              string
+	setName             string
+	scopes              string
+	origins             string
+	scenariosContaining string
+	bouncer             string
+}
+
+func (cli *cliDecisions) export(ctx context.Context, db *database.Client, opts exportOpts) error {
+	if _, err := decisionexport.ContentType(opts.format); err != nil {
+		return err
+	}
+
+	filters := map[string][]string{
+		"scopes": {opts.scopes},
+	}
+
+	if opts.origins != "" {
+		filters["origins"] = []string{opts.origins}
+	}
+
+	if opts.scenariosContaining != "" {
+		filters["scenarios_containing"] = []string{opts.scenariosContaining}
+	}
+
+	maxDecisions := 0
+
+	if opts.bouncer != "" {
+		b, err := db.SelectBouncerByName(ctx, opts.bouncer)
+		if err != nil {
+			return fmt.Errorf("unable to read bouncer '%s': %w", opts.bouncer, err)
+		}
+
+		database.ApplyBouncerFilters(filters, b.Filters)
+
+		if b.Filters != nil {
+			maxDecisions = b.Filters.MaxDecisions
+		}
+	}
+
+	decisions, err := db.QueryAllDecisionsWithFilters(ctx, filters)
+	if err != nil {
+		return fmt.Errorf("unable to retrieve decisions: %w", err)
+	}
+
+	if maxDecisions > 0 && len(decisions) > maxDecisions {
+		decisions = decisions[:maxDecisions]
+	}
+
+	var out io.Writer = os.Stdout
+
+	if opts.output != "" && opts.output != "-" {
+		f, err := os.Create(opts.output)
+		if err != nil {
+			return fmt.Errorf("unable to create output file: %w", err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	return decisionexport.Write(out, opts.format, decisions, decisionexport.Options{SetName: opts.setName})
+}
+
+func (cli *cliDecisions) newExportCmd() *cobra.Command {
+	opts := exportOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "export [options]",
+		Short: "Export active decisions in a firewall-ready format [requires local API]",
+		Long: `Export the active decisions, reading them directly from the database.
+The output is the same as the /v1/decisions/export endpoint of the Local API.
+
+Supported formats: ` + strings.Join(decisionexport.Formats(), ", "),
+		Example: `cscli decisions export --format plaintext
+cscli decisions export --format ipset --set-name crowdsec-blacklists -o /tmp/crowdsec.ipset
+cscli decisions export --format nftables --origins crowdsec,cscli
+cscli decisions export --format haproxy --bouncer my-haproxy`,
+		Args:              args.NoArgs,
+		DisableAutoGenTag: true,
+		// override the parent: we need the database, not the API client
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return require.LAPI(cli.cfg())
+		},
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			db, err := require.DBClient(ctx, cli.cfg().DbConfig)
+			if err != nil {
+				return err
+			}
+
+			return cli.export(ctx, db, opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVarP(&opts.format, "format", "f", "plaintext", "Output format: "+strings.Join(decisionexport.Formats(), ", "))
+	flags.StringVarP(&opts.output, "output", "o", "", "Output file (default: standard output)")
+	flags.StringVar(&opts.setName, "set-name", decisionexport.DefaultSetName, "Name of the set, list or map (nftables, ipset, mikrotik)")
+	flags.StringVar(&opts.scopes, "scopes", "ip,range", "Comma separated scopes of decisions to export")
+	flags.StringVar(&opts.origins, "origins", "", "Comma separated origins of decisions to export")
+	flags.StringVar(&opts.scenariosContaining, "scenarios-containing", "", "Comma separated words, only export decisions whose scenario contains one of them")
+	flags.StringVar(&opts.bouncer, "bouncer", "", "Apply the server-side filters of this bouncer")
+
+	return cmd
+}
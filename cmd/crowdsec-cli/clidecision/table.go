@@ -16,7 +16,22 @@ func (cli *cliDecisions) decisionsTable(out io.Writer, alerts *models.GetAlertsR
 	t := cstable.New(out, wantColor)
 	t.SetRowLines(false)
 
+	// only show the escalation column if a profile has an escalation policy
+	printEscalation := false
+
+	for _, alertItem := range *alerts {
+		for _, decisionItem := range alertItem.Decisions {
+			if decisionItem.EscalationStep > 0 {
+				printEscalation = true
+			}
+		}
+	}
+
 	header := []string{"ID", "Source", "Scope:Value", "Reason", "Action", "Country", "AS", "Events", "expiration", "Alert ID"}
+	if printEscalation {
+		header = append(header, "Escalation")
+	}
+
 	if printMachine {
 		header = append(header, "Machine")
 	}
@@ -47,6 +62,15 @@ func (cli *cliDecisions) decisionsTable(out io.Writer, alerts *models.GetAlertsR
 				strconv.Itoa(int(alertItem.ID)),
 			}
 
+			if printEscalation {
+				step := ""
+				if decisionItem.EscalationStep > 0 {
+					step = strconv.Itoa(int(decisionItem.EscalationStep))
+				}
+
+				row = append(row, step)
+			}
+
 			if printMachine {
 				row = append(row, alertItem.MachineID)
 			}
@@ -0,0 +1,203 @@
+package clifeeds
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/require"
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/feeds"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+type configGetter func() *csconfig.Config
+
+type cliFeeds struct {
+	cfg configGetter
+}
+
+func New(cfg configGetter) *cliFeeds {
+	return &cliFeeds{
+		cfg: cfg,
+	}
+}
+
+func (cli *cliFeeds) NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feeds [action]",
+		Short: "Manage the threat-intel feeds imported by the local API",
+		Long: `Threat-intel feeds are configured in the api.server.feeds section of config.yaml.
+The local API pulls them at their interval and converts their content to decisions.
+The federation peers of api.server.federation.peers are listed too, with the "peer" format.`,
+		DisableAutoGenTag: true,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return require.LAPI(cli.cfg())
+		},
+	}
+
+	cmd.AddCommand(cli.newListCmd())
+
+	return cmd
+}
+
+// feedInfo is a configured feed, with the outcome of its last pull
+type feedInfo struct {
+	Name      string        `json:"name"`
+	Format    string        `json:"format"`
+	Source    string        `json:"source"`
+	Interval  string        `json:"interval"`
+	Duration  string        `json:"duration,omitempty"`
+	Decisions int           `json:"active_decisions"`
+	Status    *feeds.Status `json:"status,omitempty"`
+}
+
+func (cli *cliFeeds) list(ctx context.Context) error {
+	cfg := cli.cfg()
+
+	db, err := require.DBClient(ctx, cfg.DbConfig)
+	if err != nil {
+		return err
+	}
+
+	counts, err := db.QueryDecisionCountByScenario(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to count decisions: %w", err)
+	}
+
+	active := map[string]int{}
+	activePeers := map[string]int{}
+
+	for _, count := range counts {
+		switch count.Origin {
+		case types.FeedOrigin:
+			active[count.Scenario] += count.Count
+		case types.FederationOrigin:
+			// federation:<peer>:<origin>/<scenario>
+			parts := strings.SplitN(count.Scenario, ":", 3)
+			if len(parts) == 3 {
+				activePeers[parts[1]] += count.Count
+			}
+		}
+	}
+
+	infos := make([]feedInfo, 0, len(cfg.API.Server.Feeds))
+
+	for _, feed := range cfg.API.Server.Feeds {
+		status, err := feeds.GetStatus(ctx, db, feed.Name)
+		if err != nil {
+			return err
+		}
+
+		infos = append(infos, feedInfo{
+			Name:      feed.Name,
+			Format:    feed.Format,
+			Source:    feed.Source(),
+			Interval:  feed.Interval.String(),
+			Duration:  feed.Duration.String(),
+			Decisions: active[feeds.Scenario(feed.Name)],
+			Status:    status,
+		})
+	}
+
+	if cfg.API.Server.Federation != nil {
+		for _, peer := range cfg.API.Server.Federation.Peers {
+			status, err := feeds.GetPeerStatus(ctx, db, peer.Name)
+			if err != nil {
+				return err
+			}
+
+			infos = append(infos, feedInfo{
+				Name:      peer.Name,
+				Format:    "peer",
+				Source:    peer.URL,
+				Interval:  peer.Interval.String(),
+				Decisions: activePeers[peer.Name],
+				Status:    status,
+			})
+		}
+	}
+
+	switch cfg.Cscli.Output {
+	case "human":
+		if len(infos) == 0 {
+			fmt.Println("No feed or peer configured.")
+			return nil
+		}
+
+		feedsTable(color.Output, cfg.Cscli.Color, infos)
+	case "json":
+		x, err := json.MarshalIndent(infos, "", " ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize feeds: %w", err)
+		}
+
+		fmt.Println(string(x))
+	case "raw":
+		csvwriter := csv.NewWriter(os.Stdout)
+
+		if err := csvwriter.Write([]string{"name", "format", "source", "interval", "duration", "active_decisions", "last_success", "entries", "error"}); err != nil {
+			return err
+		}
+
+		for _, info := range infos {
+			lastSuccess, entries, lastError := "", "", ""
+
+			if info.Status != nil {
+				if !info.Status.LastSuccess.IsZero() {
+					lastSuccess = info.Status.LastSuccess.Format(time.RFC3339)
+				}
+
+				entries = strconv.Itoa(info.Status.Entries)
+				lastError = info.Status.Error
+			}
+
+			row := []string{
+				info.Name,
+				info.Format,
+				info.Source,
+				info.Interval,
+				info.Duration,
+				strconv.Itoa(info.Decisions),
+				lastSuccess,
+				entries,
+				lastError,
+			}
+
+			if err := csvwriter.Write(row); err != nil {
+				return err
+			}
+		}
+
+		csvwriter.Flush()
+	default:
+		return errors.New("unknown output format")
+	}
+
+	return nil
+}
+
+func (cli *cliFeeds) newListCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List the configured feeds and peers, and the status of their last pull",
+		Example:           `cscli feeds list`,
+		Args:              args.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli.list(cmd.Context())
+		},
+	}
+
+	return cmd
+}
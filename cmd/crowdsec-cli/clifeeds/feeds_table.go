@@ -0,0 +1,43 @@
+package clifeeds
+
+import (
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/cstable"
+	"github.com/crowdsecurity/crowdsec/pkg/emoji"
+)
+
+func feedsTable(out io.Writer, wantColor string, infos []feedInfo) {
+	t := cstable.New(out, wantColor)
+	t.SetRowLines(false)
+	t.SetHeaders("Name", "Format", "Source", "Interval", "Decisions", "Entries", "Last Pull", "Status")
+
+	for _, info := range infos {
+		entries, lastPull, status := "", "never", ""
+
+		if info.Status != nil {
+			entries = strconv.Itoa(info.Status.Entries)
+			lastPull = info.Status.LastAttempt.Local().Format(time.DateTime)
+
+			status = emoji.CheckMark
+			if info.Status.Error != "" {
+				status = emoji.Warning + " " + info.Status.Error
+			}
+		}
+
+		t.AddRow(
+			info.Name,
+			info.Format,
+			info.Source,
+			info.Interval,
+			strconv.Itoa(info.Decisions),
+			entries,
+			lastPull,
+			status,
+		)
+	}
+
+	t.Render()
+}
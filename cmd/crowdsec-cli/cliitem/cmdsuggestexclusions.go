@@ -0,0 +1,177 @@
+package cliitem
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/learning"
+)
+
+type suggestedHook struct {
+	Filter string   `yaml:"filter"`
+	Apply  []string `yaml:"apply"`
+}
+
+// learningFile returns the file given on the command line, or the only one in the data directory
+func learningFile(dataDir string, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	files, err := filepath.Glob(filepath.Join(dataDir, "appsec_learning_*.json"))
+	if err != nil {
+		return "", err
+	}
+
+	switch len(files) {
+	case 0:
+		return "", fmt.Errorf("no learning data found in %s, is the learning mode enabled in an appsec-config?", dataDir)
+	case 1:
+		return files[0], nil
+	default:
+		return "", fmt.Errorf("several learning files found, pick one of: %s", strings.Join(files, ", "))
+	}
+}
+
+func renderExclusions(out io.Writer, data *learning.Data, suggestions learning.Suggestions, name string) error {
+	hooks := make([]*yaml.Node, 0, len(suggestions.Exclusions))
+
+	for _, e := range suggestions.Exclusions {
+		band := "InBand"
+		if e.Band == "outofband" {
+			band = "OutBand"
+		}
+
+		hook := &yaml.Node{}
+
+		if err := hook.Encode(suggestedHook{
+			Filter: fmt.Sprintf("Is%s && req.RequestURI matches %s", band, strconv.Quote(e.Regexp)),
+			Apply:  []string{fmt.Sprintf("Remove%sRuleByID(%d)", band, e.RuleID)},
+		}); err != nil {
+			return err
+		}
+
+		hook.HeadComment = fmt.Sprintf("rule %d (%s) on %s, matching %s\n%d matches from %d clients, for %d requests",
+			e.RuleID, e.Message, e.URI, strings.Join(e.Targets, ", "), e.Hits, e.Clients, e.Requests)
+
+		hooks = append(hooks, hook)
+	}
+
+	doc := &yaml.Node{
+		Kind: yaml.MappingNode,
+		HeadComment: fmt.Sprintf("Exclusions learned from %s to %s.\nReview them before use: each one disables a rule for all the requests matching its filter.",
+			data.StartedAt.Format(time.RFC3339), data.UpdatedAt.Format(time.RFC3339)),
+		Content: []*yaml.Node{
+			{Kind: yaml.ScalarNode, Value: "name"},
+			{Kind: yaml.ScalarNode, Value: name},
+			{Kind: yaml.ScalarNode, Value: "pre_eval"},
+			{Kind: yaml.SequenceNode, Content: hooks},
+		},
+	}
+
+	if len(suggestions.Allowlist) > 0 {
+		lines := []string{"Clients matching rules during the whole learning period, to allowlist if they are legitimate (monitoring, scanners...):"}
+
+		for _, entry := range suggestions.Allowlist {
+			lines = append(lines, fmt.Sprintf("  cscli allowlists add <allowlist> %s -d \"%d matching requests on %d URIs\"", entry.IP, entry.Hits, entry.URIs))
+		}
+
+		doc.FootComment = strings.Join(lines, "\n")
+	}
+
+	enc := yaml.NewEncoder(out)
+	enc.SetIndent(2)
+
+	if err := enc.Encode(doc); err != nil {
+		return err
+	}
+
+	return enc.Close()
+}
+
+func (cli cliItem) suggestExclusions(args []string, opts learning.SuggestOptions, name string, outputFile string) error {
+	path, err := learningFile(cli.cfg().ConfigPaths.DataDir, args)
+	if err != nil {
+		return err
+	}
+
+	data, err := learning.Load(path)
+	if err != nil {
+		return err
+	}
+
+	suggestions := learning.Suggest(data, opts)
+
+	if len(suggestions.Exclusions) == 0 && len(suggestions.Allowlist) == 0 {
+		fmt.Fprintf(os.Stderr, "No exclusion to suggest from %s, the rules were not matched often enough.\n", path)
+		return nil
+	}
+
+	out := os.Stdout
+
+	if outputFile != "" {
+		out, err = os.Create(outputFile)
+		if err != nil {
+			return err
+		}
+
+		defer out.Close()
+	}
+
+	if err := renderExclusions(out, data, suggestions, name); err != nil {
+		return fmt.Errorf("unable to write exclusions: %w", err)
+	}
+
+	if outputFile != "" {
+		fmt.Fprintf(os.Stderr, "%d exclusions written to %s, review them then add the file to an appsec-config.\n", len(suggestions.Exclusions), outputFile)
+	}
+
+	return nil
+}
+
+func (cli cliItem) newSuggestExclusionsCmd() *cobra.Command {
+	var (
+		opts       learning.SuggestOptions
+		name       string
+		outputFile string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "suggest-exclusions [learning file]",
+		Short: "Suggest exclusions from the matches recorded in learning mode",
+		Long: `Suggest the pre_eval hooks disabling the rules matched consistently by the traffic recorded in learning mode.
+The learning data is read from <data_dir>/appsec_learning_<datasource>.json by default.`,
+		Example: `# Print the suggested exclusions.
+cscli appsec-configs suggest-exclusions
+
+# Only suggest the rules matched 100 times by 10 different clients, and write them in a file to review.
+cscli appsec-configs suggest-exclusions /var/lib/crowdsec/data/appsec_learning_appsec.json --min-hits 100 --min-clients 10 --output-file learned-exclusions.yaml`,
+		Args:              args.MaximumNArgs(1),
+		DisableAutoGenTag: true,
+		RunE: func(_ *cobra.Command, args []string) error {
+			if opts.MinHits < 1 || opts.MinClients < 1 {
+				return errors.New("--min-hits and --min-clients must be positive")
+			}
+
+			return cli.suggestExclusions(args, opts, name, outputFile)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.IntVar(&opts.MinHits, "min-hits", 10, "Minimum number of matches of a rule on a URI")
+	flags.IntVar(&opts.MinClients, "min-clients", 3, "Minimum number of clients matching a rule on a URI")
+	flags.StringVar(&name, "name", "custom/learned-exclusions", "Name of the generated appsec-config")
+	flags.StringVar(&outputFile, "output-file", "", "Write the exclusions to a file instead of the standard output")
+
+	return cmd
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 
+	"github.com/spf13/cobra"
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
@@ -107,6 +108,9 @@ cscli appsec-configs list -a
 # List specific appsec-configs (installed or not).
 cscli appsec-configs list crowdsecurity/virtual-patching crowdsecurity/generic-rules`,
 		},
+		extraCommands: func(cli cliItem) []*cobra.Command {
+			return []*cobra.Command{cli.newSuggestExclusionsCmd()}
+		},
 	}
 }
 
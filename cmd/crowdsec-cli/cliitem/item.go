@@ -38,6 +38,7 @@ type cliItem struct {
 	inspectHelp   cliHelp
 	inspectDetail func(item *cwhub.Item) error
 	listHelp      cliHelp
+	extraCommands func(cli cliItem) []*cobra.Command // specific to the item type
 }
 
 func (cli cliItem) NewCommand() *cobra.Command {
@@ -56,6 +57,10 @@ func (cli cliItem) NewCommand() *cobra.Command {
 	cmd.AddCommand(cli.newInspectCmd())
 	cmd.AddCommand(cli.newListCmd())
 
+	if cli.extraCommands != nil {
+		cmd.AddCommand(cli.extraCommands(cli)...)
+	}
+
 	return cmd
 }
 
@@ -76,6 +76,7 @@ func (cli *cliNotifications) NewCommand() *cobra.Command {
 	cmd.AddCommand(cli.newInspectCmd())
 	cmd.AddCommand(cli.newReinjectCmd())
 	cmd.AddCommand(cli.newTestCmd())
+	cmd.AddCommand(cli.newQueueCmd())
 
 	return cmd
 }
@@ -409,7 +410,7 @@ cscli notifications reinject <alert_id> -a '{"remediation": true,"scenario":"not
 			}
 
 			for id, profile := range profiles {
-				_, matched, err := profile.EvaluateProfile(alert)
+				_, matched, err := profile.EvaluateProfile(ctx, alert)
 				if err != nil {
 					return fmt.Errorf("can't evaluate profile %s: %w", profile.Cfg.Name, err)
 				}
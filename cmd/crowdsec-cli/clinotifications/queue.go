@@ -0,0 +1,299 @@
+package clinotifications
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/jedib0t/go-pretty/v6/text"
+	log "github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/cstable"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/require"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+type queueFilterOpts struct {
+	statuses  string
+	plugin    string
+	olderThan string
+	limit     int
+}
+
+func (o queueFilterOpts) toFilter(ids []string) (database.NotificationFilter, error) {
+	filter := database.NotificationFilter{
+		Plugin: o.plugin,
+		Limit:  o.limit,
+	}
+
+	for _, id := range ids {
+		n, err := strconv.Atoi(id)
+		if err != nil {
+			return filter, fmt.Errorf("invalid notification id '%s'", id)
+		}
+
+		filter.IDs = append(filter.IDs, n)
+	}
+
+	if o.statuses != "" && o.statuses != "all" {
+		for _, s := range strings.Split(o.statuses, ",") {
+			status := notification.Status(strings.TrimSpace(s))
+			if err := notification.StatusValidator(status); err != nil {
+				return filter, fmt.Errorf("invalid status '%s': must be one of pending, sending, delivered, failed or all", s)
+			}
+
+			filter.Statuses = append(filter.Statuses, status)
+		}
+	}
+
+	if o.olderThan != "" {
+		d, err := types.ParseDuration(o.olderThan)
+		if err != nil {
+			return filter, fmt.Errorf("invalid duration '%s': %w", o.olderThan, err)
+		}
+
+		filter.OlderThan = time.Now().UTC().Add(-d)
+	}
+
+	return filter, nil
+}
+
+func (cli *cliNotifications) newQueueCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "queue [action]",
+		Short: "Manage the notification delivery queue [requires local API]",
+		Long: `Notifications are stored in the database until they are delivered.
+Notifications that ran out of retries are kept in the 'failed' state until they are retried or purged.`,
+		DisableAutoGenTag: true,
+		// override the parent: we need the database, not the API client
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return require.LAPI(cli.cfg())
+		},
+	}
+
+	cmd.AddCommand(cli.newQueueListCmd())
+	cmd.AddCommand(cli.newQueueRetryCmd())
+	cmd.AddCommand(cli.newQueuePurgeCmd())
+
+	return cmd
+}
+
+func notificationQueueTable(out io.Writer, wantColor string, notifications []*ent.Notification) {
+	t := cstable.New(out, wantColor)
+	t.SetRowLines(false)
+	t.SetHeaders("ID", "Plugin", "Status", "Alerts", "Attempts", "Created At", "Next Attempt", "Last Error")
+	t.SetAlignment(text.AlignLeft, text.AlignLeft, text.AlignLeft, text.AlignLeft, text.AlignLeft, text.AlignLeft, text.AlignLeft, text.AlignLeft)
+
+	for _, n := range notifications {
+		nextAttempt := ""
+		if n.Status == notification.StatusPending {
+			nextAttempt = n.NextAttemptAt.Format(time.RFC3339)
+		}
+
+		t.AddRow(
+			strconv.Itoa(n.ID),
+			n.Plugin,
+			string(n.Status),
+			strconv.Itoa(n.AlertCount),
+			strconv.Itoa(n.Attempts),
+			n.CreatedAt.Format(time.RFC3339),
+			nextAttempt,
+			n.LastError,
+		)
+	}
+
+	t.Render()
+}
+
+func (cli *cliNotifications) queueList(ctx context.Context, db *database.Client, filter database.NotificationFilter) error {
+	cfg := cli.cfg()
+
+	notifications, err := db.ListNotifications(ctx, filter)
+	if err != nil {
+		return fmt.Errorf("unable to list notifications: %w", err)
+	}
+
+	switch cfg.Cscli.Output {
+	case "human":
+		if len(notifications) == 0 {
+			fmt.Println("No notification found")
+			return nil
+		}
+
+		notificationQueueTable(color.Output, cfg.Cscli.Color, notifications)
+	case "json":
+		x, err := json.MarshalIndent(notifications, "", " ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize notifications: %w", err)
+		}
+
+		fmt.Println(string(x))
+	case "raw":
+		csvwriter := csv.NewWriter(os.Stdout)
+
+		if err := csvwriter.Write([]string{"id", "plugin", "status", "alerts", "attempts", "created_at", "next_attempt_at", "last_error"}); err != nil {
+			return fmt.Errorf("failed to write raw header: %w", err)
+		}
+
+		for _, n := range notifications {
+			err := csvwriter.Write([]string{
+				strconv.Itoa(n.ID),
+				n.Plugin,
+				string(n.Status),
+				strconv.Itoa(n.AlertCount),
+				strconv.Itoa(n.Attempts),
+				n.CreatedAt.Format(time.RFC3339),
+				n.NextAttemptAt.Format(time.RFC3339),
+				n.LastError,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to write raw content: %w", err)
+			}
+		}
+
+		csvwriter.Flush()
+	default:
+		return errors.New("unknown output format")
+	}
+
+	return nil
+}
+
+func (cli *cliNotifications) newQueueListCmd() *cobra.Command {
+	opts := queueFilterOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "list [options]",
+		Short: "List the queued notifications",
+		Example: `cscli notifications queue list
+cscli notifications queue list --status failed
+cscli notifications queue list --plugin slack_default --status all`,
+		Args:              args.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := cmd.Context()
+
+			filter, err := opts.toFilter(nil)
+			if err != nil {
+				return err
+			}
+
+			db, err := require.DBClient(ctx, cli.cfg().DbConfig)
+			if err != nil {
+				return err
+			}
+
+			return cli.queueList(ctx, db, filter)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.statuses, "status", "pending,failed", "Comma separated statuses: pending, sending, delivered, failed or all")
+	flags.StringVar(&opts.plugin, "plugin", "", "Only show the notifications of this plugin")
+	flags.IntVarP(&opts.limit, "limit", "l", 100, "Maximum number of notifications to show (0 for no limit)")
+
+	return cmd
+}
+
+func (cli *cliNotifications) newQueueRetryCmd() *cobra.Command {
+	opts := queueFilterOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "retry [notification_id...]",
+		Short: "Schedule failed notifications for immediate delivery",
+		Long:  `Put failed notifications back in the queue. Without arguments, all the failed notifications are retried.`,
+		Example: `cscli notifications queue retry
+cscli notifications queue retry 12 13
+cscli notifications queue retry --plugin slack_default`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			filter, err := opts.toFilter(args)
+			if err != nil {
+				return err
+			}
+
+			filter.Statuses = []notification.Status{notification.StatusFailed}
+
+			db, err := require.DBClient(ctx, cli.cfg().DbConfig)
+			if err != nil {
+				return err
+			}
+
+			nb, err := db.RetryNotifications(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("unable to retry notifications: %w", err)
+			}
+
+			log.Infof("%d notification(s) scheduled for delivery", nb)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.plugin, "plugin", "", "Only retry the notifications of this plugin")
+
+	return cmd
+}
+
+func (cli *cliNotifications) newQueuePurgeCmd() *cobra.Command {
+	opts := queueFilterOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "purge [notification_id...]",
+		Short: "Delete notifications from the queue",
+		Example: `cscli notifications queue purge
+cscli notifications queue purge --status delivered --older-than 24h
+cscli notifications queue purge --status all --plugin slack_default
+cscli notifications queue purge 12 13`,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx := cmd.Context()
+
+			// explicit ids are deleted regardless of their status, unless asked otherwise
+			if len(args) > 0 && !cmd.Flags().Changed("status") {
+				opts.statuses = "all"
+			}
+
+			filter, err := opts.toFilter(args)
+			if err != nil {
+				return err
+			}
+
+			db, err := require.DBClient(ctx, cli.cfg().DbConfig)
+			if err != nil {
+				return err
+			}
+
+			nb, err := db.PurgeNotifications(ctx, filter)
+			if err != nil {
+				return fmt.Errorf("unable to purge notifications: %w", err)
+			}
+
+			log.Infof("%d notification(s) deleted", nb)
+
+			return nil
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.StringVar(&opts.statuses, "status", "failed", "Comma separated statuses: pending, sending, delivered, failed or all")
+	flags.StringVar(&opts.plugin, "plugin", "", "Only delete the notifications of this plugin")
+	flags.StringVar(&opts.olderThan, "older-than", "", "Only delete the notifications created before this duration (ie. 24h)")
+
+	return cmd
+}
@@ -0,0 +1,290 @@
+package cliprofiles
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/fatih/color"
+	"github.com/spf13/cobra"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/args"
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/require"
+	v1 "github.com/crowdsecurity/crowdsec/pkg/apiserver/controllers/v1"
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/csprofiles"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+type configGetter func() *csconfig.Config
+
+type cliProfiles struct {
+	cfg configGetter
+}
+
+func New(cfg configGetter) *cliProfiles {
+	return &cliProfiles{
+		cfg: cfg,
+	}
+}
+
+func (cli *cliProfiles) NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "profiles [action]",
+		Short:             "Test the profiles of the local API",
+		DisableAutoGenTag: true,
+		PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
+			return require.LAPI(cli.cfg())
+		},
+	}
+
+	cmd.AddCommand(cli.newTestCmd())
+
+	return cmd
+}
+
+// testResult is the outcome of an alert, with the current and candidate profiles
+type testResult struct {
+	AlertID   int64                        `json:"alert_id,omitempty"`
+	Scenario  string                       `json:"scenario"`
+	Source    string                       `json:"source"`
+	Current   *csprofiles.SimulationResult `json:"current"`
+	Candidate *csprofiles.SimulationResult `json:"candidate,omitempty"`
+	Changed   bool                         `json:"changed"`
+}
+
+type testOpts struct {
+	profilesFile string
+	alertsFile   string
+	since        string
+	limit        int
+	scenario     string
+	diffOnly     bool
+}
+
+func loadAlertsFile(path string) ([]*models.Alert, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts := []*models.Alert{}
+
+	if err := json.Unmarshal(content, &alerts); err != nil {
+		alert := &models.Alert{}
+		if err2 := json.Unmarshal(content, alert); err2 != nil {
+			return nil, fmt.Errorf("while parsing %s: %w", path, err)
+		}
+
+		alerts = append(alerts, alert)
+	}
+
+	return alerts, nil
+}
+
+func (cli *cliProfiles) loadAlerts(ctx context.Context, db *database.Client, opts testOpts) ([]*models.Alert, error) {
+	if opts.alertsFile != "" {
+		return loadAlertsFile(opts.alertsFile)
+	}
+
+	filter := map[string][]string{
+		"since": {opts.since},
+		"limit": {strconv.Itoa(opts.limit)},
+	}
+
+	if opts.scenario != "" {
+		filter["scenario"] = []string{opts.scenario}
+	}
+
+	result, err := db.QueryAlertWithFilter(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve alerts: %w", err)
+	}
+
+	alerts := make([]*models.Alert, 0, len(result))
+
+	for _, alert := range result {
+		alerts = append(alerts, v1.FormatOneAlert(alert))
+	}
+
+	return alerts, nil
+}
+
+func (cli *cliProfiles) newProfiles(profilesCfg []*csconfig.ProfileCfg, db *database.Client) ([]*csprofiles.Runtime, error) {
+	profiles, err := csprofiles.NewProfile(profilesCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if db != nil {
+		for _, profile := range profiles {
+			profile.DecisionHistory = db
+		}
+	}
+
+	return profiles, nil
+}
+
+func (cli *cliProfiles) test(ctx context.Context, opts testOpts) error {
+	cfg := cli.cfg()
+
+	var db *database.Client
+
+	if opts.alertsFile == "" {
+		var err error
+
+		db, err = require.DBClient(ctx, cfg.DbConfig)
+		if err != nil {
+			return err
+		}
+	}
+
+	current, err := cli.newProfiles(cfg.API.Server.Profiles, db)
+	if err != nil {
+		return fmt.Errorf("while compiling current profiles: %w", err)
+	}
+
+	var candidate []*csprofiles.Runtime
+
+	if opts.profilesFile != "" {
+		candidateCfg, err := csconfig.LoadProfilesFile(opts.profilesFile)
+		if err != nil {
+			return fmt.Errorf("while loading candidate profiles: %w", err)
+		}
+
+		if len(candidateCfg) == 0 {
+			return fmt.Errorf("no profile found in %s", opts.profilesFile)
+		}
+
+		candidate, err = cli.newProfiles(candidateCfg, db)
+		if err != nil {
+			return fmt.Errorf("while compiling candidate profiles: %w", err)
+		}
+	}
+
+	alerts, err := cli.loadAlerts(ctx, db, opts)
+	if err != nil {
+		return err
+	}
+
+	results := make([]testResult, 0, len(alerts))
+	changed := 0
+
+	for _, alert := range alerts {
+		if alert.Source == nil || alert.Source.Value == nil || alert.Scenario == nil {
+			continue
+		}
+
+		res := testResult{
+			AlertID:  alert.ID,
+			Scenario: *alert.Scenario,
+			Source:   alert.GetScope() + ":" + alert.GetValue(),
+			Current:  csprofiles.Simulate(ctx, current, alert),
+		}
+
+		if candidate != nil {
+			res.Candidate = csprofiles.Simulate(ctx, candidate, alert)
+			res.Changed = res.Current.Changed(res.Candidate)
+		}
+
+		if res.Changed {
+			changed++
+		}
+
+		if opts.diffOnly && !res.Changed {
+			continue
+		}
+
+		results = append(results, res)
+	}
+
+	switch cfg.Cscli.Output {
+	case "human":
+		profilesTestTable(color.Output, cfg.Cscli.Color, results, candidate != nil)
+
+		if candidate != nil {
+			fmt.Printf("%d alert(s) tested, %d with a different outcome\n", len(alerts), changed)
+		}
+	case "json":
+		x, err := json.MarshalIndent(results, "", " ")
+		if err != nil {
+			return fmt.Errorf("failed to serialize results: %w", err)
+		}
+
+		fmt.Println(string(x))
+	case "raw":
+		csvwriter := csv.NewWriter(os.Stdout)
+
+		if err := csvwriter.Write([]string{"alert_id", "scenario", "source", "current", "candidate", "changed"}); err != nil {
+			return err
+		}
+
+		for _, res := range results {
+			candidateSummary := ""
+			if res.Candidate != nil {
+				candidateSummary = res.Candidate.Summary()
+			}
+
+			row := []string{
+				strconv.FormatInt(res.AlertID, 10),
+				res.Scenario,
+				res.Source,
+				res.Current.Summary(),
+				candidateSummary,
+				strconv.FormatBool(res.Changed),
+			}
+
+			if err := csvwriter.Write(row); err != nil {
+				return err
+			}
+		}
+
+		csvwriter.Flush()
+	default:
+		return errors.New("unknown output format")
+	}
+
+	return nil
+}
+
+func (cli *cliProfiles) newTestCmd() *cobra.Command {
+	opts := testOpts{}
+
+	cmd := &cobra.Command{
+		Use:   "test [options]",
+		Short: "Replay alerts through the profiles, without applying anything",
+		Long: `Replay alerts from the database or from a JSON file through the profiles,
+and report which profiles matched, the resulting decisions and the notifications.
+
+With --profiles, the alerts are also evaluated against a candidate profiles file
+and the outcomes are compared with the currently loaded profiles.
+
+Decisions previously generated by the profiles are ignored. Escalation steps are computed
+from the current content of the database, which includes the decisions of the replayed alerts.`,
+		Example: `cscli profiles test
+cscli profiles test --since 72h --scenario crowdsecurity/ssh-bf
+cscli profiles test --profiles /tmp/profiles.yaml --diff
+cscli alerts list -o json > alerts.json && cscli profiles test --file alerts.json --profiles /tmp/profiles.yaml`,
+		Args:              args.NoArgs,
+		DisableAutoGenTag: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return cli.test(cmd.Context(), opts)
+		},
+	}
+
+	flags := cmd.Flags()
+	flags.SortFlags = false
+	flags.StringVarP(&opts.profilesFile, "profiles", "p", "", "Candidate profiles file, compared to the current profiles")
+	flags.StringVarP(&opts.alertsFile, "file", "f", "", "Read the alerts from a JSON file instead of the database")
+	flags.StringVar(&opts.since, "since", "24h", "Replay the alerts newer than this duration")
+	flags.IntVarP(&opts.limit, "limit", "l", 100, "Maximum number of alerts to replay")
+	flags.StringVarP(&opts.scenario, "scenario", "s", "", "Only replay the alerts of this scenario")
+	flags.BoolVar(&opts.diffOnly, "diff", false, "Only show the alerts with a different outcome")
+
+	return cmd
+}
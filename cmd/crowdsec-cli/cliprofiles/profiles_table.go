@@ -0,0 +1,43 @@
+package cliprofiles
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/crowdsecurity/crowdsec/cmd/crowdsec-cli/cstable"
+	"github.com/crowdsecurity/crowdsec/pkg/emoji"
+)
+
+func profilesTestTable(out io.Writer, wantColor string, results []testResult, withCandidate bool) {
+	t := cstable.New(out, wantColor)
+	t.SetRowLines(false)
+
+	header := []string{"Alert ID", "Scenario", "Source", "Current"}
+	if withCandidate {
+		header = append(header, "Candidate", "Changed")
+	}
+
+	t.SetHeaders(header...)
+
+	for _, res := range results {
+		row := []string{
+			strconv.FormatInt(res.AlertID, 10),
+			res.Scenario,
+			res.Source,
+			res.Current.Summary(),
+		}
+
+		if withCandidate {
+			changed := ""
+			if res.Changed {
+				changed = emoji.Warning
+			}
+
+			row = append(row, res.Candidate.Summary(), changed)
+		}
+
+		t.AddRow(row...)
+	}
+
+	t.Render()
+}
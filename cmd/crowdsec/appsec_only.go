@@ -0,0 +1,242 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdsecurity/go-cs-lib/trace"
+
+	"github.com/crowdsecurity/crowdsec/pkg/acquisition"
+	"github.com/crowdsecurity/crowdsec/pkg/acquisition/configuration"
+	"github.com/crowdsecurity/crowdsec/pkg/apiclient"
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/cwhub"
+	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
+	leaky "github.com/crowdsecurity/crowdsec/pkg/leakybucket"
+	"github.com/crowdsecurity/crowdsec/pkg/parser"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+/*
+In appsec-only mode, crowdsec only runs the appsec datasources, so that the WAF can be scaled
+independently of the log processors: there are no parsers nor scenarios.
+- the alerts of the appsec rules are sent to LAPI, like any other agent
+- the log events are sent to a remote agent if the datasource has a "forward" section, dropped otherwise
+The allowlists are fetched from LAPI, so all the instances share them.
+*/
+
+// initAppsecOnly prepares the appsec-only service
+func initAppsecOnly(ctx context.Context, cConfig *csconfig.Config, hub *cwhub.Hub, testMode bool) ([]acquisition.DataSource, error) {
+	if err := exprhelpers.GeoIPInit(hub.GetDataDir()); err != nil {
+		// GeoIP databases are not mandatory, do not make crowdsec fail if they are not present
+		log.Warnf("unable to initialize GeoIP: %s", err)
+	}
+
+	// can be nerfed by a build flag
+	if err := LoadAppsecRules(hub); err != nil {
+		return nil, err
+	}
+
+	if !testMode {
+		err := apiclient.InitLAPIClient(
+			ctx, cConfig.API.Client.Credentials.URL, cConfig.API.Client.Credentials.PapiURL,
+			cConfig.API.Client.Credentials.Login, cConfig.API.Client.Credentials.Password,
+			hub.GetInstalledListForAPI())
+		if err != nil {
+			return nil, fmt.Errorf("while initializing LAPIClient: %w", err)
+		}
+	}
+
+	datasources, err := LoadAcquisition(cConfig)
+	if err != nil {
+		return nil, fmt.Errorf("while loading acquisition config: %w", err)
+	}
+
+	for _, ds := range datasources {
+		if ds.GetName() != "appsec" {
+			return nil, fmt.Errorf("datasource %s can't run in appsec-only mode, only appsec datasources are allowed", ds.GetName())
+		}
+	}
+
+	return datasources, nil
+}
+
+// runAppsecEvents sends the alerts to the outputs, there is no scenario for the log events
+func runAppsecEvents(input chan types.Event, output chan types.Event) error {
+	for {
+		select {
+		case <-parsersTomb.Dying():
+			log.Infof("Killing appsec event routines")
+			return nil
+		case event := <-input:
+			if !event.Process {
+				continue
+			}
+
+			if event.Type == types.APPSEC {
+				output <- event
+				continue
+			}
+
+			log.Tracef("no scenario in appsec-only mode, discarding log event")
+		}
+	}
+}
+
+// runAppsecOnly starts the appsec-only service
+func runAppsecOnly(ctx context.Context, cConfig *csconfig.Config, hub *cwhub.Hub, datasources []acquisition.DataSource) error {
+	inputLineChan = make(chan types.Event)
+	outputEventChan = make(chan types.Event)
+	buckets = leaky.NewBuckets()
+
+	parsersTomb.Go(func() error {
+		defer trace.CatchPanic("crowdsec/runAppsecEvents")
+		return runAppsecEvents(inputLineChan, outputEventChan)
+	})
+
+	apiClient, err := apiclient.GetLAPIClient()
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Starting HeartBeat service")
+	apiClient.HeartBeat.StartHeartBeat(context.Background(), &outputsTomb)
+
+	for range cConfig.Crowdsec.OutputRoutinesCount {
+		outputsTomb.Go(func() error {
+			defer trace.CatchPanic("crowdsec/runOutput")
+
+			// no post-overflow in appsec-only mode
+			return runOutput(inputLineChan, outputEventChan, buckets, parser.UnixParserCtx{}, nil, apiClient)
+		})
+	}
+
+	mp := NewMetricsProvider(
+		apiClient,
+		lpMetricsDefaultInterval,
+		log.WithField("service", "lpmetrics"),
+		[]string{},
+		datasources,
+		hub,
+	)
+
+	lpMetricsTomb.Go(func() error {
+		return mp.Run(context.Background(), &lpMetricsTomb)
+	})
+
+	if cConfig.Prometheus != nil && cConfig.Prometheus.Enabled {
+		aggregated := false
+		if cConfig.Prometheus.Level == configuration.CFG_METRICS_AGGREGATE {
+			aggregated = true
+		}
+
+		if err := acquisition.GetMetrics(datasources, aggregated); err != nil {
+			return fmt.Errorf("while fetching prometheus metrics for datasources: %w", err)
+		}
+	}
+
+	log.Info("Starting appsec-only processing")
+
+	if err := acquisition.StartAcquisition(ctx, datasources, inputLineChan, &acquisTomb); err != nil {
+		return fmt.Errorf("starting acquisition error: %w", err)
+	}
+
+	return nil
+}
+
+// serveAppsecOnly wraps the appsec-only service
+func serveAppsecOnly(ctx context.Context, cConfig *csconfig.Config, hub *cwhub.Hub, datasources []acquisition.DataSource, agentReady chan bool) {
+	crowdsecTomb.Go(func() error {
+		defer trace.CatchPanic("crowdsec/serveAppsecOnly")
+
+		go func() {
+			defer trace.CatchPanic("crowdsec/runAppsecOnly")
+			log.Debugf("running appsec-only agent after %s ms", time.Since(crowdsecT0))
+			agentReady <- true
+
+			if err := runAppsecOnly(ctx, cConfig, hub, datasources); err != nil {
+				log.Fatalf("unable to start appsec-only routines: %s", err)
+			}
+		}()
+
+		waitOnTomb()
+		log.Debugf("Shutting down appsec-only routines")
+
+		if err := ShutdownAppsecOnlyRoutines(); err != nil {
+			return fmt.Errorf("unable to shutdown appsec-only routines: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func ShutdownAppsecOnlyRoutines() error {
+	var reterr error
+
+	acquisTomb.Kill(nil)
+	log.Debugf("waiting for acquisition to finish")
+	drainChan(inputLineChan)
+
+	if err := acquisTomb.Wait(); err != nil {
+		log.Warningf("Acquisition returned error : %s", err)
+		reterr = err
+	}
+
+	parsersTomb.Kill(nil)
+
+	if err := parsersTomb.Wait(); err != nil {
+		log.Warningf("Appsec event routines returned error : %s", err)
+		reterr = err
+	}
+
+	outputsTomb.Kill(nil)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- outputsTomb.Wait()
+	}()
+
+	// wait for outputs to finish, max 3 seconds
+	select {
+	case err := <-done:
+		if err != nil {
+			log.Warningf("Outputs returned error : %s", err)
+			reterr = err
+		}
+	case <-time.After(3 * time.Second):
+		log.Warningf("Outputs didn't finish in time, some alerts may have not been sent")
+	}
+
+	lpMetricsTomb.Kill(nil)
+
+	if err := lpMetricsTomb.Wait(); err != nil {
+		log.Warningf("Metrics returned error : %s", err)
+		reterr = err
+	}
+
+	crowdsecTomb.Kill(nil)
+
+	exprhelpers.GeoIPClose()
+
+	return reterr
+}
+
+func checkAppsecOnlyFlags(cConfig *csconfig.Config) error {
+	if !flags.AppsecOnly {
+		return nil
+	}
+
+	if cConfig.DisableAgent {
+		return errors.New("-appsec-only requires the crowdsec agent to be enabled")
+	}
+
+	if flags.OneShotDSN != "" {
+		return errors.New("-appsec-only can't be used with -dsn")
+	}
+
+	return nil
+}
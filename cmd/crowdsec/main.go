@@ -74,6 +74,7 @@ type Flags struct {
 	DisableAPI     bool
 	WinSvc         string
 	DisableCAPI    bool
+	AppsecOnly     bool
 	Transform      string
 	OrderEvent     bool
 	CPUProfile     string
@@ -175,6 +176,7 @@ func (f *Flags) Parse() {
 	flag.BoolVar(&f.DisableAgent, "no-cs", false, "disable crowdsec agent")
 	flag.BoolVar(&f.DisableAPI, "no-api", false, "disable local API")
 	flag.BoolVar(&f.DisableCAPI, "no-capi", false, "disable communication with Central API")
+	flag.BoolVar(&f.AppsecOnly, "appsec-only", false, "only run the appsec datasources, without parsers and scenarios")
 	flag.BoolVar(&f.OrderEvent, "order-event", false, "enforce event ordering with significant performance cost")
 
 	if runtime.GOOS == "windows" {
@@ -285,6 +287,10 @@ func LoadConfig(configFile string, disableAgent bool, disableAPI bool, quiet boo
 		return nil, errors.New("you must run at least the API Server or crowdsec")
 	}
 
+	if err := checkAppsecOnlyFlags(cConfig); err != nil {
+		return nil, err
+	}
+
 	if flags.OneShotDSN != "" && flags.SingleFileType == "" {
 		return nil, errors.New("-dsn requires a -type argument")
 	}
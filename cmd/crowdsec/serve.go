@@ -94,24 +94,37 @@ func reloadHandler(sig os.Signal) (*csconfig.Config, error) {
 			return nil, err
 		}
 
-		csParsers, datasources, err := initCrowdsec(cConfig, hub, false)
-		if err != nil {
-			return nil, fmt.Errorf("unable to init crowdsec: %w", err)
-		}
+		if flags.AppsecOnly {
+			// the shutdown kills crowdsecTomb, which cancels the appsec-only service
+			appsecCtx := crowdsecTomb.Context(ctx)
 
-		// restore bucket state
-		if tmpFile != "" {
-			log.Warningf("we are now using %s as a state file", tmpFile)
-			cConfig.Crowdsec.BucketStateFile = tmpFile
-		}
+			datasources, err := initAppsecOnly(appsecCtx, cConfig, hub, false)
+			if err != nil {
+				return nil, fmt.Errorf("unable to init appsec-only mode: %w", err)
+			}
 
-		// reload the simulation state
-		if err := cConfig.LoadSimulation(); err != nil {
-			log.Errorf("reload error (simulation) : %s", err)
-		}
+			agentReady := make(chan bool, 1)
+			serveAppsecOnly(appsecCtx, cConfig, hub, datasources, agentReady)
+		} else {
+			csParsers, datasources, err := initCrowdsec(cConfig, hub, false)
+			if err != nil {
+				return nil, fmt.Errorf("unable to init crowdsec: %w", err)
+			}
+
+			// restore bucket state
+			if tmpFile != "" {
+				log.Warningf("we are now using %s as a state file", tmpFile)
+				cConfig.Crowdsec.BucketStateFile = tmpFile
+			}
 
-		agentReady := make(chan bool, 1)
-		serveCrowdsec(csParsers, cConfig, hub, datasources, agentReady)
+			// reload the simulation state
+			if err := cConfig.LoadSimulation(); err != nil {
+				log.Errorf("reload error (simulation) : %s", err)
+			}
+
+			agentReady := make(chan bool, 1)
+			serveCrowdsec(csParsers, cConfig, hub, datasources, agentReady)
+		}
 	}
 
 	log.Printf("Reload is finished")
@@ -396,16 +409,32 @@ func Serve(cConfig *csconfig.Config, agentReady chan bool) error {
 			return err
 		}
 
-		csParsers, datasources, err := initCrowdsec(cConfig, hub, flags.TestMode)
-		if err != nil {
-			return fmt.Errorf("crowdsec init: %w", err)
-		}
+		if flags.AppsecOnly {
+			// the shutdown kills crowdsecTomb, which cancels the appsec-only service
+			appsecCtx := crowdsecTomb.Context(ctx)
 
-		// if it's just linting, we're done
-		if !flags.TestMode {
-			serveCrowdsec(csParsers, cConfig, hub, datasources, agentReady)
+			datasources, err := initAppsecOnly(appsecCtx, cConfig, hub, flags.TestMode)
+			if err != nil {
+				return fmt.Errorf("appsec-only init: %w", err)
+			}
+
+			if !flags.TestMode {
+				serveAppsecOnly(appsecCtx, cConfig, hub, datasources, agentReady)
+			} else {
+				agentReady <- true
+			}
 		} else {
-			agentReady <- true
+			csParsers, datasources, err := initCrowdsec(cConfig, hub, flags.TestMode)
+			if err != nil {
+				return fmt.Errorf("crowdsec init: %w", err)
+			}
+
+			// if it's just linting, we're done
+			if !flags.TestMode {
+				serveCrowdsec(csParsers, cConfig, hub, datasources, agentReady)
+			} else {
+				agentReady <- true
+			}
 		}
 	} else {
 		agentReady <- true
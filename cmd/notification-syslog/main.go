@@ -0,0 +1,155 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csplugin"
+	"github.com/crowdsecurity/crowdsec/pkg/csplugin/cards"
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+type PluginConfig struct {
+	Name                string            `yaml:"name"`
+	LogLevel            *string           `yaml:"log_level"`
+	Network             string            `yaml:"network"`
+	Address             string            `yaml:"address"`
+	Framing             string            `yaml:"framing"`
+	DialTimeout         time.Duration     `yaml:"dial_timeout"`
+	SkipTLSVerification bool              `yaml:"skip_tls_verification"`
+	CAPath              string            `yaml:"ca_cert_path"`
+	CertPath            string            `yaml:"cert_path"`
+	KeyPath             string            `yaml:"key_path"`
+	Facility            string            `yaml:"facility"`
+	Severity            string            `yaml:"severity"`
+	Hostname            string            `yaml:"hostname"`
+	AppName             string            `yaml:"app_name"`
+	MsgID               string            `yaml:"msg_id"`
+	Payload             string            `yaml:"payload"`
+	CEF                 CEFConfig         `yaml:"cef"`
+	FieldMapping        map[string]string `yaml:"field_mapping"`
+
+	facility int
+	severity int
+}
+
+type SyslogPlugin struct {
+	protobufs.UnimplementedNotifierServer
+	PluginConfigByName map[string]*PluginConfig
+	senderByName       map[string]*sender
+	mu                 sync.Mutex // protects the maps, a configuration can be reloaded while notifying
+}
+
+var logger hclog.Logger = hclog.New(&hclog.LoggerOptions{
+	Name:       "syslog-plugin",
+	Level:      hclog.LevelFromString("INFO"),
+	Output:     os.Stderr,
+	JSONFormat: true,
+})
+
+func (s *SyslogPlugin) Notify(ctx context.Context, notification *protobufs.Notification) (*protobufs.Empty, error) {
+	s.mu.Lock()
+	cfg, ok := s.PluginConfigByName[notification.Name]
+	snd := s.senderByName[notification.Name]
+	s.mu.Unlock()
+
+	if !ok {
+		return nil, fmt.Errorf("invalid plugin config name %s", notification.Name)
+	}
+
+	if cfg.LogLevel != nil && *cfg.LogLevel != "" {
+		logger.SetLevel(hclog.LevelFromString(*cfg.LogLevel))
+	}
+
+	logger.Info(fmt.Sprintf("received signal for %s config", notification.Name))
+
+	// a format that doesn't output JSON alerts is sent as a single message
+	alerts, err := cards.ParseAlerts(notification.Text)
+	if err != nil {
+		logger.Debug(fmt.Sprintf("notification is not a list of alerts, sending it as is: %s", err))
+	}
+
+	payloads, err := cfg.payloads(alerts, notification.Text)
+	if err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	now := time.Now()
+
+	messages := make([][]byte, 0, len(payloads))
+	for _, p := range payloads {
+		messages = append(messages, cfg.frame(p, now))
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = now.Add(cfg.DialTimeout)
+	}
+
+	logger.Debug(fmt.Sprintf("sending %d message(s) to %s://%s", len(messages), cfg.Network, cfg.Address))
+
+	if err := snd.send(messages, deadline); err != nil {
+		logger.Error(err.Error())
+		return nil, err
+	}
+
+	return &protobufs.Empty{}, nil
+}
+
+func (s *SyslogPlugin) Configure(ctx context.Context, config *protobufs.Config) (*protobufs.Empty, error) {
+	d := PluginConfig{}
+
+	if err := yaml.Unmarshal(config.Config, &d); err != nil {
+		return nil, err
+	}
+
+	if err := d.validate(); err != nil {
+		return nil, fmt.Errorf("notification %s: %w", d.Name, err)
+	}
+
+	s.mu.Lock()
+	old := s.senderByName[d.Name]
+	s.PluginConfigByName[d.Name] = &d
+	s.senderByName[d.Name] = &sender{cfg: &d}
+	s.mu.Unlock()
+
+	if old != nil {
+		old.shutdown()
+	}
+
+	logger.Debug(fmt.Sprintf("Syslog plugin '%s' use %s://%s", d.Name, d.Network, d.Address))
+
+	return &protobufs.Empty{}, nil
+}
+
+func main() {
+	handshake := plugin.HandshakeConfig{
+		ProtocolVersion:  1,
+		MagicCookieKey:   "CROWDSEC_PLUGIN_KEY",
+		MagicCookieValue: os.Getenv("CROWDSEC_PLUGIN_KEY"),
+	}
+
+	sp := &SyslogPlugin{
+		PluginConfigByName: make(map[string]*PluginConfig),
+		senderByName:       make(map[string]*sender),
+	}
+
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: handshake,
+		Plugins: map[string]plugin.Plugin{
+			"syslog": &csplugin.NotifierPlugin{
+				Impl: sp,
+			},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+		Logger:     logger,
+	})
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+func testAlert() *models.Alert {
+	return &models.Alert{
+		Scenario:    ptr.Of("crowdsecurity/http-probing"),
+		Message:     ptr.Of("Ip 1.2.3.4 performed 'crowdsecurity/http-probing' (11 events)"),
+		MachineID:   "web|1",
+		EventsCount: ptr.Of(int32(11)),
+		Source: &models.Source{
+			Scope: ptr.Of("Ip"),
+			Value: ptr.Of("1.2.3.4"),
+			Cn:    "FR",
+		},
+		Decisions: []*models.Decision{
+			{Type: ptr.Of("ban"), Value: ptr.Of("1.2.3.4"), Duration: ptr.Of("4h")},
+		},
+	}
+}
+
+func testConfig(t *testing.T, cfg PluginConfig) *PluginConfig {
+	if cfg.Address == "" {
+		cfg.Address = "127.0.0.1:514"
+	}
+
+	cfg.Hostname = "host"
+	cfg.CEF.Version = "1.6"
+
+	require.NoError(t, cfg.validate())
+
+	return &cfg
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		cfg         PluginConfig
+		expectedErr string
+	}{
+		{PluginConfig{Address: "localhost:514", Network: "sctp"}, "invalid network 'sctp'"},
+		{PluginConfig{}, "address is required"},
+		{PluginConfig{Address: "localhost:514", Payload: "leef"}, "invalid payload 'leef'"},
+		{PluginConfig{Address: "localhost:514", Framing: "nul"}, "invalid framing 'nul'"},
+		{PluginConfig{Address: "localhost:514", Facility: "local9"}, "invalid facility: unknown value 'local9'"},
+		{PluginConfig{Address: "localhost:514", Severity: "panic"}, "invalid severity: unknown value 'panic'"},
+		{PluginConfig{Address: "localhost:514", FieldMapping: map[string]string{"ip": "src"}}, "unknown field 'ip' in field_mapping"},
+		{PluginConfig{Address: "localhost:514"}, ""},
+	}
+
+	for _, tc := range tests {
+		err := tc.cfg.validate()
+		cstest.RequireErrorContains(t, err, tc.expectedErr)
+	}
+}
+
+func TestFormatCEF(t *testing.T) {
+	cfg := testConfig(t, PluginConfig{})
+
+	payloads, err := cfg.payloads([]*models.Alert{testAlert()}, "")
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+
+	assert.Equal(t, "CEF:0|CrowdSec|CrowdSec|1.6|crowdsecurity/http-probing|"+
+		"Ip 1.2.3.4 performed 'crowdsecurity/http-probing' (11 events)|8|"+
+		"src=1.2.3.4 cs1=Ip cs1Label=source_scope cs2=crowdsecurity/http-probing cs2Label=scenario "+
+		"cs3=ban 1.2.3.4 4h cs3Label=decisions cs4=FR cs4Label=country dvchost=web|1 cnt=11 "+
+		"msg=Ip 1.2.3.4 performed 'crowdsecurity/http-probing' (11 events)", payloads[0])
+
+	cfg = testConfig(t, PluginConfig{FieldMapping: map[string]string{"source_ip": "c6a2", "message": ""}})
+
+	payloads, err = cfg.payloads([]*models.Alert{testAlert()}, "")
+	require.NoError(t, err)
+	assert.Contains(t, payloads[0], "c6a2=1.2.3.4 ")
+	assert.NotContains(t, payloads[0], "msg=")
+}
+
+func TestFormatJSON(t *testing.T) {
+	cfg := testConfig(t, PluginConfig{Payload: payloadJSON, FieldMapping: map[string]string{"source_ip": "src_ip"}})
+
+	payloads, err := cfg.payloads([]*models.Alert{testAlert()}, "")
+	require.NoError(t, err)
+	require.Len(t, payloads, 1)
+
+	var obj map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(payloads[0]), &obj))
+	assert.Equal(t, "1.2.3.4", obj["src_ip"])
+	assert.Equal(t, "crowdsecurity/http-probing", obj["scenario"])
+	assert.Equal(t, "ban 1.2.3.4 4h", obj["decisions"])
+	assert.InDelta(t, 8, obj["severity"], 0)
+
+	payloads, err = cfg.payloads(nil, "plain text\n")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"plain text"}, payloads)
+}
+
+func TestFrame(t *testing.T) {
+	now := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	pid := strconv.Itoa(os.Getpid())
+
+	cfg := testConfig(t, PluginConfig{})
+	assert.Equal(t, "<84>1 2024-01-01T10:00:00Z host crowdsec "+pid+" alert - hello", string(cfg.frame("hello", now)))
+
+	cfg = testConfig(t, PluginConfig{Network: "tcp", Facility: "local0", Severity: "info"})
+	line := "<134>1 2024-01-01T10:00:00Z host crowdsec " + pid + " alert - hello"
+	assert.Equal(t, strconv.Itoa(len(line))+" "+line, string(cfg.frame("hello", now)))
+
+	cfg = testConfig(t, PluginConfig{Network: "tcp", Framing: framingNewline})
+	assert.True(t, strings.HasSuffix(string(cfg.frame("hello\nworld", now)), " hello world\n"))
+}
+
+// TestNotifyTCP checks that the plugin reconnects after the server drops the connection
+func TestNotifyTCP(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan string, 10)
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			// read one message per connection, then drop it
+			go func() {
+				defer conn.Close()
+
+				line, err := bufio.NewReader(conn).ReadString('\n')
+				if err == nil {
+					received <- line
+				}
+			}()
+		}
+	}()
+
+	sp := &SyslogPlugin{
+		PluginConfigByName: make(map[string]*PluginConfig),
+		senderByName:       make(map[string]*sender),
+	}
+
+	ctx := context.Background()
+
+	config := "name: siem\nnetwork: tcp\nframing: newline\npayload: json\naddress: " + ln.Addr().String()
+	_, err = sp.Configure(ctx, &protobufs.Config{Config: []byte(config)})
+	require.NoError(t, err)
+
+	alerts, err := json.Marshal([]*models.Alert{testAlert()})
+	require.NoError(t, err)
+
+	for range 3 {
+		_, err = sp.Notify(ctx, &protobufs.Notification{Name: "siem", Text: string(alerts)})
+		require.NoError(t, err)
+
+		select {
+		case line := <-received:
+			assert.Contains(t, line, `"source_ip":"1.2.3.4"`)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timeout waiting for the syslog message")
+		}
+
+		// give the server time to close the connection
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	_, err = sp.Notify(ctx, &protobufs.Notification{Name: "unknown", Text: "x"})
+	cstest.RequireErrorContains(t, err, "invalid plugin config name unknown")
+}
+
+func TestConfigureWhileNotifying(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+				}
+			}()
+		}
+	}()
+
+	sp := &SyslogPlugin{
+		PluginConfigByName: make(map[string]*PluginConfig),
+		senderByName:       make(map[string]*sender),
+	}
+
+	ctx := context.Background()
+
+	config := &protobufs.Config{Config: []byte("name: siem\nnetwork: tcp\nframing: newline\naddress: " + ln.Addr().String())}
+	_, err = sp.Configure(ctx, config)
+	require.NoError(t, err)
+
+	alerts, err := json.Marshal([]*models.Alert{testAlert()})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		for range 20 {
+			_, err := sp.Notify(ctx, &protobufs.Notification{Name: "siem", Text: string(alerts)})
+			assert.NoError(t, err)
+		}
+	}()
+
+	// the old senders are closed while the notifications use them
+	for range 20 {
+		_, err = sp.Configure(ctx, config)
+		require.NoError(t, err)
+	}
+
+	<-done
+}
@@ -0,0 +1,483 @@
+package main
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/crowdsecurity/go-cs-lib/version"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+const (
+	payloadCEF  = "cef"
+	payloadJSON = "json"
+
+	framingOctetCounting = "octet_counting"
+	framingNewline       = "newline"
+
+	// the NILVALUE of RFC5424
+	nilValue = "-"
+
+	defaultDialTimeout = 5 * time.Second
+)
+
+var facilities = map[string]int{
+	"kern": 0, "user": 1, "mail": 2, "daemon": 3, "auth": 4, "syslog": 5, "lpr": 6, "news": 7,
+	"uucp": 8, "cron": 9, "authpriv": 10, "ftp": 11,
+	"local0": 16, "local1": 17, "local2": 18, "local3": 19, "local4": 20, "local5": 21, "local6": 22, "local7": 23,
+}
+
+var severities = map[string]int{
+	"emerg": 0, "alert": 1, "crit": 2, "err": 3, "warning": 4, "notice": 5, "info": 6, "debug": 7,
+}
+
+// the alert fields that can be renamed in the payload, with their default CEF and JSON keys
+var defaultFieldMapping = map[string][2]string{
+	"source_ip":    {"src", "source_ip"},
+	"source_scope": {"cs1", "source_scope"},
+	"scenario":     {"cs2", "scenario"},
+	"decisions":    {"cs3", "decisions"},
+	"country":      {"cs4", "country"},
+	"as":           {"cs5", "as"},
+	"machine":      {"dvchost", "machine"},
+	"events_count": {"cnt", "events_count"},
+	"start":        {"start", "start"},
+	"message":      {"msg", "message"},
+	"severity":     {"", "severity"}, // in the header of the CEF payloads
+}
+
+// CEFConfig sets the header of the CEF payloads
+type CEFConfig struct {
+	Vendor  string `yaml:"vendor"`
+	Product string `yaml:"product"`
+	Version string `yaml:"version"`
+}
+
+// record is one alert, flattened to the fields that are sent
+type record struct {
+	severity  int // CEF severity, 0-10
+	signature string
+	name      string
+	fields    [][2]string // ordered (field, value)
+}
+
+// the CEF severities of models.Alert.GetSeverity
+var cefSeverities = map[string]int{
+	"low":    3,
+	"medium": 6,
+	"high":   8,
+}
+
+func newRecord(alert *models.Alert) record {
+	r := record{
+		severity:  cefSeverities[alert.GetSeverity()],
+		signature: alert.GetScenario(),
+		name:      alert.GetScenario(),
+	}
+
+	if alert.Message != nil && *alert.Message != "" {
+		r.name = *alert.Message
+	}
+
+	add := func(field string, value string) {
+		if value != "" {
+			r.fields = append(r.fields, [2]string{field, value})
+		}
+	}
+
+	source := alert.Source
+	if source == nil {
+		source = &models.Source{}
+	}
+
+	decisions := make([]string, 0, len(alert.Decisions))
+
+	for _, decision := range alert.Decisions {
+		if decision.Type == nil || decision.Value == nil {
+			continue
+		}
+
+		d := *decision.Type + " " + *decision.Value
+		if decision.Duration != nil {
+			d += " " + *decision.Duration
+		}
+
+		decisions = append(decisions, d)
+	}
+
+	add("source_ip", source.GetValue())
+	add("source_scope", source.GetScope())
+	add("scenario", alert.GetScenario())
+	add("decisions", strings.Join(decisions, ", "))
+	add("country", source.Cn)
+	add("as", strings.TrimSpace(source.GetAsNumberName()))
+	add("machine", alert.MachineID)
+	add("events_count", strconv.Itoa(int(alert.GetEventsCount())))
+
+	if alert.StartAt != nil {
+		add("start", *alert.StartAt)
+	}
+
+	if alert.Message != nil {
+		add("message", *alert.Message)
+	}
+
+	return r
+}
+
+var (
+	cefHeaderEscaper    = strings.NewReplacer(`\`, `\\`, `|`, `\|`, "\n", " ", "\r", " ")
+	cefExtensionEscaper = strings.NewReplacer(`\`, `\\`, `=`, `\=`, "\n", `\n`, "\r", `\r`)
+)
+
+// fieldKey returns the name of a field in the payload
+func (c *PluginConfig) fieldKey(field string) string {
+	if key, ok := c.FieldMapping[field]; ok {
+		return key
+	}
+
+	if c.Payload == payloadCEF {
+		return defaultFieldMapping[field][0]
+	}
+
+	return defaultFieldMapping[field][1]
+}
+
+func (c *PluginConfig) formatCEF(r record) string {
+	ext := make([]string, 0, 2*len(r.fields))
+
+	for _, f := range r.fields {
+		key := c.fieldKey(f[0])
+		if key == "" {
+			continue
+		}
+
+		ext = append(ext, key+"="+cefExtensionEscaper.Replace(f[1]))
+
+		// custom string fields are described by a label
+		if strings.HasPrefix(key, "cs") && len(key) == 3 {
+			ext = append(ext, key+"Label="+f[0])
+		}
+	}
+
+	return fmt.Sprintf("CEF:0|%s|%s|%s|%s|%s|%d|%s",
+		cefHeaderEscaper.Replace(c.CEF.Vendor),
+		cefHeaderEscaper.Replace(c.CEF.Product),
+		cefHeaderEscaper.Replace(c.CEF.Version),
+		cefHeaderEscaper.Replace(r.signature),
+		cefHeaderEscaper.Replace(r.name),
+		r.severity,
+		strings.Join(ext, " "))
+}
+
+func (c *PluginConfig) formatJSON(r record) (string, error) {
+	obj := make(map[string]any, len(r.fields)+1)
+
+	for _, f := range r.fields {
+		if key := c.fieldKey(f[0]); key != "" {
+			obj[key] = f[1]
+		}
+	}
+
+	if key := c.fieldKey("severity"); key != "" {
+		obj[key] = r.severity
+	}
+
+	b, err := json.Marshal(obj)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// payloads turns the notification into syslog messages: one per alert if the format produces
+// JSON alerts, or the text as is.
+func (c *PluginConfig) payloads(alerts []*models.Alert, text string) ([]string, error) {
+	if alerts == nil {
+		return []string{strings.TrimSpace(text)}, nil
+	}
+
+	ret := make([]string, 0, len(alerts))
+
+	for _, alert := range alerts {
+		r := newRecord(alert)
+
+		if c.Payload == payloadCEF {
+			ret = append(ret, c.formatCEF(r))
+			continue
+		}
+
+		msg, err := c.formatJSON(r)
+		if err != nil {
+			return nil, err
+		}
+
+		ret = append(ret, msg)
+	}
+
+	return ret, nil
+}
+
+// frame builds an RFC5424 message, with the transport framing
+func (c *PluginConfig) frame(msg string, now time.Time) []byte {
+	line := fmt.Sprintf("<%d>1 %s %s %s %d %s - %s",
+		c.facility*8+c.severity,
+		now.UTC().Format(time.RFC3339Nano),
+		c.Hostname,
+		c.AppName,
+		os.Getpid(),
+		c.MsgID,
+		msg)
+
+	switch {
+	case c.Network == "udp":
+		return []byte(line)
+	case c.Framing == framingNewline:
+		return []byte(strings.ReplaceAll(line, "\n", " ") + "\n")
+	default:
+		return []byte(strconv.Itoa(len(line)) + " " + line)
+	}
+}
+
+func (c *PluginConfig) validate() error {
+	var err error
+
+	switch c.Network {
+	case "":
+		c.Network = "udp"
+	case "udp", "tcp", "tls":
+	default:
+		return fmt.Errorf("invalid network '%s': must be udp, tcp or tls", c.Network)
+	}
+
+	if c.Address == "" {
+		return errors.New("address is required")
+	}
+
+	switch c.Payload {
+	case "":
+		c.Payload = payloadCEF
+	case payloadCEF, payloadJSON:
+	default:
+		return fmt.Errorf("invalid payload '%s': must be %s or %s", c.Payload, payloadCEF, payloadJSON)
+	}
+
+	switch c.Framing {
+	case "":
+		c.Framing = framingOctetCounting
+	case framingOctetCounting, framingNewline:
+	default:
+		return fmt.Errorf("invalid framing '%s': must be %s or %s", c.Framing, framingOctetCounting, framingNewline)
+	}
+
+	if c.Facility == "" {
+		c.Facility = "authpriv"
+	}
+
+	if c.facility, err = lookup(facilities, c.Facility); err != nil {
+		return fmt.Errorf("invalid facility: %w", err)
+	}
+
+	if c.Severity == "" {
+		c.Severity = "warning"
+	}
+
+	if c.severity, err = lookup(severities, c.Severity); err != nil {
+		return fmt.Errorf("invalid severity: %w", err)
+	}
+
+	for field := range c.FieldMapping {
+		if _, ok := defaultFieldMapping[field]; !ok {
+			return fmt.Errorf("unknown field '%s' in field_mapping", field)
+		}
+	}
+
+	if c.Hostname == "" {
+		if c.Hostname, err = os.Hostname(); err != nil || c.Hostname == "" {
+			c.Hostname = nilValue
+		}
+	}
+
+	if c.AppName == "" {
+		c.AppName = "crowdsec"
+	}
+
+	if c.MsgID == "" {
+		c.MsgID = "alert"
+	}
+
+	if c.CEF.Vendor == "" {
+		c.CEF.Vendor = "CrowdSec"
+	}
+
+	if c.CEF.Product == "" {
+		c.CEF.Product = "CrowdSec"
+	}
+
+	if c.CEF.Version == "" {
+		c.CEF.Version = strings.TrimPrefix(version.Version, "v")
+	}
+
+	if c.DialTimeout == 0 {
+		c.DialTimeout = defaultDialTimeout
+	}
+
+	return nil
+}
+
+func lookup(names map[string]int, name string) (int, error) {
+	if n, ok := names[strings.ToLower(name)]; ok {
+		return n, nil
+	}
+
+	return 0, fmt.Errorf("unknown value '%s'", name)
+}
+
+// sender keeps the connection to the syslog server, and reconnects when it's broken
+type sender struct {
+	cfg  *PluginConfig
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (c *PluginConfig) tlsConfig() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: c.SkipTLSVerification,
+	}
+
+	if c.CAPath != "" {
+		pem, err := os.ReadFile(c.CAPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load CA certificate '%s': %w", c.CAPath, err)
+		}
+
+		tlsConfig.RootCAs = x509.NewCertPool()
+		tlsConfig.RootCAs.AppendCertsFromPEM(pem)
+	}
+
+	if c.CertPath != "" && c.KeyPath != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to load client certificate '%s' and key '%s': %w", c.CertPath, c.KeyPath, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+func (s *sender) dial() error {
+	dialer := &net.Dialer{Timeout: s.cfg.DialTimeout}
+
+	if s.cfg.Network != "tls" {
+		conn, err := dialer.Dial(s.cfg.Network, s.cfg.Address)
+		if err != nil {
+			return err
+		}
+
+		s.conn = conn
+
+		return nil
+	}
+
+	tlsConfig, err := s.cfg.tlsConfig()
+	if err != nil {
+		return err
+	}
+
+	conn, err := tls.DialWithDialer(dialer, "tcp", s.cfg.Address, tlsConfig)
+	if err != nil {
+		return err
+	}
+
+	s.conn = conn
+
+	return nil
+}
+
+func (s *sender) close() {
+	if s.conn != nil {
+		s.conn.Close()
+		s.conn = nil
+	}
+}
+
+// shutdown closes the connection once the messages being sent are written
+func (s *sender) shutdown() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.close()
+}
+
+// alive checks if the server has closed a stream connection. Syslog servers don't send
+// anything, so a read only returns when the connection is gone.
+func (s *sender) alive() bool {
+	if s.cfg.Network == "udp" {
+		return true
+	}
+
+	if err := s.conn.SetReadDeadline(time.Now().Add(time.Millisecond)); err != nil {
+		return false
+	}
+
+	var buf [1]byte
+
+	_, err := s.conn.Read(buf[:])
+
+	var netErr net.Error
+
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// send writes the messages, and reconnects once if the connection was closed by the server
+func (s *sender) send(messages [][]byte, deadline time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn != nil && !s.alive() {
+		logger.Debug(fmt.Sprintf("connection to %s was closed, reconnecting", s.cfg.Address))
+		s.close()
+	}
+
+	for _, msg := range messages {
+		var err error
+
+		for attempt := range 2 {
+			if s.conn == nil {
+				if err = s.dial(); err != nil {
+					return fmt.Errorf("while connecting to %s: %w", s.cfg.Address, err)
+				}
+			}
+
+			if err = s.conn.SetWriteDeadline(deadline); err == nil {
+				_, err = s.conn.Write(msg)
+			}
+
+			if err == nil {
+				break
+			}
+
+			logger.Warn(fmt.Sprintf("write to %s failed (attempt %d): %s", s.cfg.Address, attempt+1, err))
+			s.close()
+		}
+
+		if err != nil {
+			return fmt.Errorf("while sending to %s: %w", s.cfg.Address, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	plugin "github.com/hashicorp/go-plugin"
+	"gopkg.in/yaml.v3"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csplugin"
+	"github.com/crowdsecurity/crowdsec/pkg/csplugin/cards"
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+type PluginConfig struct {
+	Name          string  `yaml:"name"`
+	Webhook       string  `yaml:"webhook"`
+	LogLevel      *string `yaml:"log_level"`
+	cards.Options `yaml:",inline"`
+}
+
+type Notify struct {
+	protobufs.UnimplementedNotifierServer
+	ConfigByName map[string]PluginConfig
+	client       *http.Client
+}
+
+var logger hclog.Logger = hclog.New(&hclog.LoggerOptions{
+	Name:       "teams-plugin",
+	Level:      hclog.LevelFromString("INFO"),
+	Output:     os.Stderr,
+	JSONFormat: true,
+})
+
+func (n *Notify) Notify(ctx context.Context, notification *protobufs.Notification) (*protobufs.Empty, error) {
+	if _, ok := n.ConfigByName[notification.Name]; !ok {
+		return nil, fmt.Errorf("invalid plugin config name %s", notification.Name)
+	}
+
+	cfg := n.ConfigByName[notification.Name]
+
+	if cfg.LogLevel != nil && *cfg.LogLevel != "" {
+		logger.SetLevel(hclog.LevelFromString(*cfg.LogLevel))
+	}
+
+	logger.Info(fmt.Sprintf("found notify signal for %s config", notification.Name))
+
+	msg := cards.NewMessage(notification.Text, cfg.Options)
+
+	logger.Debug(fmt.Sprintf("posting %d card(s) to %s webhook", len(msg.Cards), cfg.Name))
+
+	err := cards.PostJSON(ctx, n.client, cfg.Webhook, msg.Teams())
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return &protobufs.Empty{}, err
+}
+
+func (n *Notify) Configure(ctx context.Context, config *protobufs.Config) (*protobufs.Empty, error) {
+	d := PluginConfig{}
+
+	if err := yaml.Unmarshal(config.Config, &d); err != nil {
+		return nil, err
+	}
+
+	if d.Webhook == "" {
+		return nil, fmt.Errorf("webhook is required for %s", d.Name)
+	}
+
+	d.SetDefaults()
+
+	n.ConfigByName[d.Name] = d
+	logger.Debug(fmt.Sprintf("Teams plugin '%s' use URL '%s'", d.Name, d.Webhook))
+
+	return &protobufs.Empty{}, nil
+}
+
+func main() {
+	handshake := plugin.HandshakeConfig{
+		ProtocolVersion:  1,
+		MagicCookieKey:   "CROWDSEC_PLUGIN_KEY",
+		MagicCookieValue: os.Getenv("CROWDSEC_PLUGIN_KEY"),
+	}
+
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: handshake,
+		Plugins: map[string]plugin.Plugin{
+			"teams": &csplugin.NotifierPlugin{
+				Impl: &Notify{ConfigByName: make(map[string]PluginConfig), client: &http.Client{}},
+			},
+		},
+		GRPCServer: plugin.DefaultGRPCServer,
+		Logger:     logger,
+	})
+}
@@ -49,6 +49,9 @@ type AppsecSourceConfig struct {
 	AppsecConfigs                     []string       `yaml:"appsec_configs"`
 	AppsecConfigPath                  string         `yaml:"appsec_config_path"`
 	AuthCacheDuration                 *time.Duration `yaml:"auth_cache_duration"`
+	EventsPath                        string         `yaml:"events_path"`    // accept the events forwarded by standalone appsec components
+	EventsAPIKey                      string         `yaml:"events_api_key"` // the key of the forwarding components, the bouncer keys are not accepted
+	Forward                           *ForwardConfig `yaml:"forward"`        // send the log events to a remote agent instead of the local scenarios
 	configuration.DataSourceCommonCfg `yaml:",inline"`
 }
 
@@ -68,6 +71,7 @@ type AppsecSource struct {
 	AppsecRunners         []AppsecRunner // one for each go-routine
 	appsecAllowlistClient *allowlists.AppsecAllowlist
 	lapiCACertPool        *x509.CertPool
+	forwarder             *forwarder
 }
 
 // Struct to handle cache of authentication
@@ -120,6 +124,28 @@ func (w *AppsecSource) UnmarshalConfig(yamlConfig []byte) error {
 		w.config.Path = "/" + w.config.Path
 	}
 
+	if w.config.EventsPath != "" && w.config.EventsPath[0] != '/' {
+		w.config.EventsPath = "/" + w.config.EventsPath
+	}
+
+	if w.config.EventsPath == w.config.Path {
+		return errors.New("events_path must be different from path")
+	}
+
+	if w.config.EventsPath != "" && w.config.EventsAPIKey == "" {
+		return errors.New("events_api_key must be set to accept forwarded events")
+	}
+
+	if w.config.Forward != nil {
+		if w.config.Forward.URL == "" {
+			return errors.New("forward.url must be set")
+		}
+
+		if w.config.Forward.APIKey == "" {
+			return errors.New("forward.api_key must be set")
+		}
+	}
+
 	if w.config.Mode == "" {
 		w.config.Mode = configuration.TAIL_MODE
 	}
@@ -155,11 +181,11 @@ func (w *AppsecSource) UnmarshalConfig(yamlConfig []byte) error {
 }
 
 func (w *AppsecSource) GetMetrics() []prometheus.Collector {
-	return []prometheus.Collector{AppsecReqCounter, AppsecBlockCounter, AppsecRuleHits, AppsecOutbandParsingHistogram, AppsecInbandParsingHistogram, AppsecGlobalParsingHistogram}
+	return []prometheus.Collector{AppsecReqCounter, AppsecBlockCounter, AppsecRuleHits, AppsecOutbandParsingHistogram, AppsecInbandParsingHistogram, AppsecGlobalParsingHistogram, AppsecForwardDropped}
 }
 
 func (w *AppsecSource) GetAggregMetrics() []prometheus.Collector {
-	return []prometheus.Collector{AppsecReqCounter, AppsecBlockCounter, AppsecRuleHits, AppsecOutbandParsingHistogram, AppsecInbandParsingHistogram, AppsecGlobalParsingHistogram}
+	return []prometheus.Collector{AppsecReqCounter, AppsecBlockCounter, AppsecRuleHits, AppsecOutbandParsingHistogram, AppsecInbandParsingHistogram, AppsecGlobalParsingHistogram, AppsecForwardDropped}
 }
 
 func loadCertPool(caCertPath string, logger log.FieldLogger) (*x509.CertPool, error) {
@@ -279,6 +305,18 @@ func (w *AppsecSource) Configure(yamlConfig []byte, logger *log.Entry, metricsLe
 	// We don´t use the wrapper provided by coraza because we want to fully control what happens when a rule match to send the information in crowdsec
 	w.mux.HandleFunc(w.config.Path, w.appsecHandler)
 
+	if w.config.EventsPath != "" {
+		w.logger.Infof("Accepting forwarded events on %s", w.config.EventsPath)
+		w.mux.HandleFunc(w.config.EventsPath, w.eventsHandler)
+	}
+
+	if w.config.Forward != nil {
+		w.forwarder, err = newForwarder(w.config.Forward, w.logger.WithField("component", "forwarder"))
+		if err != nil {
+			return fmt.Errorf("unable to configure event forwarding: %w", err)
+		}
+	}
+
 	csConfig := csconfig.GetConfig()
 
 	caCertPath := ""
@@ -423,11 +461,43 @@ func (w *AppsecSource) StreamingAcquisition(ctx context.Context, out chan types.
 
 	w.appsecAllowlistClient.StartRefresh(ctx, t)
 
+	if w.AppsecRuntime.Learner != nil {
+		t.Go(func() error {
+			defer trace.CatchPanic("crowdsec/acquis/appsec/learning")
+			w.flushLearning(t)
+
+			return nil
+		})
+	}
+
+	if w.AppsecRuntime.Capture != nil {
+		t.Go(func() error {
+			<-t.Dying()
+
+			if err := w.AppsecRuntime.CloseCapture(); err != nil {
+				w.logger.Errorf("unable to close capture file: %s", err)
+			}
+
+			return nil
+		})
+	}
+
+	runnerOut := out
+
+	if w.forwarder != nil {
+		runnerOut = make(chan types.Event)
+
+		t.Go(func() error {
+			defer trace.CatchPanic("crowdsec/acquis/appsec/forwarder")
+			return w.forwarder.Run(runnerOut, out, t)
+		})
+	}
+
 	t.Go(func() error {
 		defer trace.CatchPanic("crowdsec/acquis/appsec/live")
 
 		for _, runner := range w.AppsecRunners {
-			runner.outChan = out
+			runner.outChan = runnerOut
 
 			t.Go(func() error {
 				defer trace.CatchPanic("crowdsec/acquis/appsec/live/runner")
@@ -441,6 +511,29 @@ func (w *AppsecSource) StreamingAcquisition(ctx context.Context, out chan types.
 	return nil
 }
 
+// flushLearning periodically writes the matches recorded in learning mode, until the datasource is stopped
+func (w *AppsecSource) flushLearning(t *tomb.Tomb) {
+	learner := w.AppsecRuntime.Learner
+	ticker := time.NewTicker(w.AppsecRuntime.Config.Learning.GetFlushInterval())
+
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if err := learner.Flush(); err != nil {
+				w.logger.Errorf("unable to write learning data: %s", err)
+			}
+		case <-t.Dying():
+			if err := learner.Flush(); err != nil {
+				w.logger.Errorf("unable to write learning data: %s", err)
+			}
+
+			return
+		}
+	}
+}
+
 func (w *AppsecSource) CanRun() error {
 	return nil
 }
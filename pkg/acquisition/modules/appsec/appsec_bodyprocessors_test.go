@@ -0,0 +1,317 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecBodyProcessors(t *testing.T) {
+	request := func(contentType string, body string) appsec.ParsedRequest {
+		return appsec.ParsedRequest{
+			ClientIP:   "1.2.3.4",
+			RemoteAddr: "127.0.0.1",
+			Method:     "POST",
+			URI:        "/graphql",
+			Headers:    http.Header{"Content-Type": []string{contentType}},
+			Body:       []byte(body),
+		}
+	}
+
+	forceGraphQL := `SecRule REQUEST_FILENAME "@endsWith /graphql" "id:100,phase:1,pass,nolog,ctl:requestBodyProcessor=GRAPHQL"`
+	forceJSON := `SecRule REQUEST_FILENAME "@endsWith /graphql" "id:100,phase:1,pass,nolog,ctl:requestBodyProcessor=JSON_EXT"`
+	forceXML := `SecRule REQUEST_FILENAME "@endsWith /graphql" "id:100,phase:1,pass,nolog,ctl:requestBodyProcessor=XML_EXT"`
+	forceMultipart := `SecRule REQUEST_FILENAME "@endsWith /graphql" "id:100,phase:1,pass,nolog,ctl:requestBodyProcessor=MULTIPART_EXT"`
+	bodyError := `SecRule REQBODY_ERROR "@eq 1" "id:200,phase:2,deny,log,msg:'body error',logdata:'%{REQBODY_ERROR_MSG}'"`
+
+	matched := func(msg string) func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+		return func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+			require.Len(t, events, 2)
+			require.Equal(t, types.LOG, events[1].Type)
+			require.Len(t, events[1].Appsec.MatchedRules, 1)
+			require.Equal(t, msg, events[1].Appsec.MatchedRules[0]["msg"])
+			require.Len(t, responses, 1)
+			require.True(t, responses[0].InBandInterrupt)
+		}
+	}
+
+	bodyErrorWith := func(reason string) func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+		return func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+			matched("body error")(events, responses, appsecResponse, statusCode)
+			require.Contains(t, events[1].Appsec.MatchedRules[0]["logdata"], reason)
+		}
+	}
+
+	notMatched := func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+		require.Empty(t, events)
+		require.Len(t, responses, 1)
+		require.False(t, responses[0].InBandInterrupt)
+	}
+
+	tests := []appsecRuleTest{
+		{
+			name:             "graphql depth",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule TX:graphql_depth "@gt 3" "id:1,phase:2,deny,log,msg:'too deep'"`,
+			},
+			input_request:  request("application/json", `{"query": "{ user { friends { friends { friends { name } } } } }"}`),
+			output_asserts: matched("too deep"),
+		},
+		{
+			name:             "graphql depth under the limit",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule TX:graphql_depth "@gt 3" "id:1,phase:2,deny,log,msg:'too deep'"`,
+			},
+			input_request:  request("application/json", `{"query": "{ user { name } }"}`),
+			output_asserts: notMatched,
+		},
+		{
+			name:             "graphql arguments",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule ARGS_POST:graphql.args.user.id "@detectSQLi" "id:1,phase:2,deny,log,msg:'sqli'"`,
+			},
+			input_request:  request("application/json", `{"query": "{ user(id: \"1' OR '1'='1\") { name } }"}`),
+			output_asserts: matched("sqli"),
+		},
+		{
+			name:             "graphql variables",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule ARGS:graphql.variables.filter.name "@contains <script>" "id:1,phase:2,deny,log,msg:'xss'"`,
+			},
+			input_request: request("application/json",
+				`{"query": "query Search($filter: Filter) { search(filter: $filter) { id } }", "operationName": "Search", "variables": {"filter": {"name": "<script>"}}}`),
+			output_asserts: matched("xss"),
+		},
+		{
+			name:             "graphql operation and fields",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule ARGS_POST:graphql.operation_type "@streq mutation" "id:1,phase:2,deny,log,msg:'delete',chain"`,
+				`SecRule ARGS_POST:graphql.fields "@streq deleteUser" ""`,
+			},
+			input_request:  request("application/graphql", `mutation { deleteUser(id: 1) { id } }`),
+			output_asserts: matched("delete"),
+		},
+		{
+			name:             "graphql batch",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule TX:graphql_operations "@gt 2" "id:1,phase:2,deny,log,msg:'batch'"`,
+			},
+			input_request:  request("application/json", `[{"query": "{ a }"}, {"query": "{ b }"}, {"query": "{ c }"}]`),
+			output_asserts: matched("batch"),
+		},
+		{
+			name:             "graphql batch too large",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{GraphQLMaxBatch: ptr.Of(2)},
+			inband_native_rules: []string{
+				forceGraphQL,
+				bodyError,
+			},
+			input_request:  request("application/json", `[{"query": "{ a }"}, {"query": "{ b }"}, {"query": "{ c }"}]`),
+			output_asserts: bodyErrorWith("GraphQL batch has more than 2 requests"),
+		},
+		{
+			name:             "graphql request nested too deep",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{JSONMaxDepth: ptr.Of(5)},
+			inband_native_rules: []string{
+				forceGraphQL,
+				bodyError,
+			},
+			input_request:  request("application/json", `[{"query": "{ a }", "variables": {"a": [[[["]]]]"]]]]}}]`),
+			output_asserts: bodyErrorWith("GraphQL request is nested deeper than 5 levels"),
+		},
+		{
+			name:             "invalid graphql query",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				bodyError,
+			},
+			input_request:  request("application/json", `{"query": "{ user( { name } }"}`),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "graphqlDepth operator on the json processor",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceJSON,
+				`SecRule ARGS_POST:json.query "@graphqlDepth 2" "id:1,phase:2,deny,log,msg:'too deep'"`,
+			},
+			input_request:  request("application/json", `{"query": "{ user { posts { title } } }"}`),
+			output_asserts: matched("too deep"),
+		},
+		{
+			name:             "graphqlComplexity operator",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceGraphQL,
+				`SecRule ARGS_POST:graphql.query "@graphqlComplexity 3" "id:1,phase:2,deny,log,msg:'too complex'"`,
+			},
+			input_request:  request("application/json", `{"query": "{ a: user { name } b: user { name } }"}`),
+			output_asserts: matched("too complex"),
+		},
+		{
+			name:             "json values",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceJSON,
+				`SecRule ARGS_POST:json.items.1.name "@streq bar" "id:1,phase:2,deny,log,msg:'json',chain"`,
+				`SecRule ARGS_POST:json.items "@eq 2" ""`,
+			},
+			input_request:  request("application/json", `{"items": [{"name": "foo"}, {"name": "bar", "price": 1.50, "sold": null}]}`),
+			output_asserts: matched("json"),
+		},
+		{
+			name:             "json max depth",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{JSONMaxDepth: ptr.Of(3)},
+			inband_native_rules: []string{
+				forceJSON,
+				bodyError,
+			},
+			input_request:  request("application/json", `{"a": {"b": {"c": {"d": 1}}}}`),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "json max args",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{JSONMaxArgs: ptr.Of(10)},
+			inband_native_rules: []string{
+				forceJSON,
+				bodyError,
+			},
+			input_request:  request("application/json", "["+strings.Repeat("1,", 20)+"1]"),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "the json processor of coraza is kept",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{JSONMaxDepth: ptr.Of(3)},
+			inband_native_rules: []string{
+				`SecRule REQUEST_FILENAME "@endsWith /graphql" "id:100,phase:1,pass,nolog,ctl:requestBodyProcessor=JSON"`,
+				bodyError,
+			},
+			input_request:  request("application/json", `{"a": {"b": {"c": {"d": 1}}}}`),
+			output_asserts: notMatched,
+		},
+		{
+			name:             "trailing json document",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceJSON,
+				bodyError,
+			},
+			input_request:  request("application/json", `{"a": 1} {"b": "<script>"}`),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "xml content",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceXML,
+				`SecRule XML:/* "@contains <script>" "id:1,phase:2,deny,log,msg:'xss'"`,
+			},
+			input_request:  request("application/xml", `<a><b id="1">&lt;script&gt;</b></a>`),
+			output_asserts: matched("xss"),
+		},
+		{
+			name:             "xml max elements",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{XMLMaxElements: ptr.Of(10)},
+			inband_native_rules: []string{
+				forceXML,
+				bodyError,
+			},
+			input_request:  request("application/xml", "<a>"+strings.Repeat("<b/>", 20)+"</a>"),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "xml max depth",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{XMLMaxDepth: ptr.Of(10)},
+			inband_native_rules: []string{
+				forceXML,
+				bodyError,
+			},
+			input_request:  request("application/xml", strings.Repeat("<a>", 20)+strings.Repeat("</a>", 20)),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "multipart max parts",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{MultipartMaxParts: ptr.Of(2)},
+			inband_native_rules: []string{
+				forceMultipart,
+				`SecRule MULTIPART_STRICT_ERROR "@eq 1" "id:1,phase:2,deny,log,msg:'multipart error'"`,
+			},
+			input_request: request("multipart/form-data; boundary=boundary", strings.Repeat(`--boundary
+Content-Disposition: form-data; name="foo"
+
+bar
+`, 3)+"--boundary--\n"),
+			output_asserts: matched("multipart error"),
+		},
+		{
+			name:             "multipart max part size",
+			expected_load_ok: true,
+			body_limits:      appsec.BodyLimits{MultipartMaxPartSize: ptr.Of(10)},
+			inband_native_rules: []string{
+				forceMultipart,
+				bodyError,
+			},
+			input_request: request("multipart/form-data; boundary=boundary", `--boundary
+Content-Disposition: form-data; name="foo"; filename="foo.txt"
+
+`+strings.Repeat("a", 20)+`
+--boundary--
+`),
+			output_asserts: matched("body error"),
+		},
+		{
+			name:             "multipart files",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				forceMultipart,
+				`SecRule FILES_SIZES:foo.txt "@eq 4" "id:1,phase:2,deny,log,msg:'multipart',chain"`,
+				`SecRule ARGS_POST:name "@streq bar" ""`,
+			},
+			input_request: request("multipart/form-data; boundary=boundary", `--boundary
+Content-Disposition: form-data; name="file"; filename="foo.txt"
+
+toto
+--boundary
+Content-Disposition: form-data; name="name"
+
+bar
+--boundary--
+`),
+			output_asserts: matched("multipart"),
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
@@ -0,0 +1,141 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecBotDetection(t *testing.T) {
+	signatures := filepath.Join(t.TempDir(), "bot-signatures.yaml")
+
+	err := os.WriteFile(signatures, []byte(`
+signatures:
+  - name: python-requests
+    class: automation
+    score: 70
+    user_agent: "^python-requests/"
+  - name: scanner
+    class: bad_bot
+    score: 100
+    ja4: ["t13d1516h2_8daaf6152771_*"]
+crawlers:
+  - name: bingbot
+    user_agent: "bingbot"
+    ranges: ["157.55.39.0/24"]
+`), 0o644)
+	require.NoError(t, err)
+
+	data := []*types.DataSource{{DestPath: signatures, Type: appsec.BotSignaturesDataType}}
+	botDetection := appsec.BotDetectionConfig{Enabled: true}
+
+	request := func(ip string, userAgent string, ja4 string) appsec.ParsedRequest {
+		u, err := url.Parse("/")
+		require.NoError(t, err)
+
+		headers := http.Header{"Accept": []string{"*/*"}}
+		if userAgent != "" {
+			headers.Set("User-Agent", userAgent)
+		}
+
+		return appsec.ParsedRequest{
+			ClientIP:    ip,
+			RemoteAddr:  "127.0.0.1",
+			Method:      "GET",
+			URI:         "/",
+			URL:         u,
+			Headers:     headers,
+			JA4:         ja4,
+			HTTPRequest: &http.Request{Method: "GET", URL: u, Header: headers, RemoteAddr: ip, Host: "example.com"},
+		}
+	}
+
+	blockBots := []string{
+		`SecRule TX:bot_class "@streq bad_bot" "id:2001,phase:1,deny,log,msg:'bad bot'"`,
+		`SecRule TX:bot_class "@streq fake_crawler" "id:2002,phase:1,deny,log,msg:'fake crawler'"`,
+	}
+
+	tests := []appsecRuleTest{
+		{
+			name:                "forwarded JA4 matching a signature",
+			expected_load_ok:    true,
+			data:                data,
+			bot_detection:       botDetection,
+			inband_native_rules: blockBots,
+			input_request:       request("1.2.3.4", "Mozilla/5.0", "t13d1516h2_8daaf6152771_02713d6af862"),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "bad bot", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, "bad_bot", events[1].Parsed["bot_class"])
+				require.Equal(t, "100", events[1].Parsed["bot_score"])
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:                "crawler outside of its ranges",
+			expected_load_ok:    true,
+			data:                data,
+			bot_detection:       botDetection,
+			inband_native_rules: blockBots,
+			input_request:       request("1.2.3.4", "Mozilla/5.0 (compatible; bingbot/2.0)", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "fake crawler", events[1].Appsec.MatchedRules[0]["msg"])
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:                "verified crawler",
+			expected_load_ok:    true,
+			data:                data,
+			bot_detection:       botDetection,
+			inband_native_rules: blockBots,
+			input_request:       request("157.55.39.12", "Mozilla/5.0 (compatible; bingbot/2.0)", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "hooks use the classification",
+			expected_load_ok: true,
+			data:             data,
+			bot_detection:    botDetection,
+			inband_native_rules: []string{
+				`SecRule REQUEST_URI "@streq /" "id:2003,phase:1,deny,log,msg:'root'"`,
+			},
+			on_match: []appsec.Hook{
+				{Filter: `bot_class == "automation" && bot_score >= 50`, Apply: []string{`SetRemediation("captcha")`}},
+			},
+			input_request: request("1.2.3.4", "python-requests/2.32", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, appsec.CaptchaRemediation, responses[0].Action)
+			},
+		},
+		{
+			name:                "bot detection disabled",
+			expected_load_ok:    true,
+			data:                data,
+			inband_native_rules: blockBots,
+			input_request:       request("1.2.3.4", "Mozilla/5.0", "t13d1516h2_8daaf6152771_02713d6af862"),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
@@ -0,0 +1,166 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/challenge"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecChallenge(t *testing.T) {
+	challengeCfg := appsec.ChallengeConfig{Secret: "test-secret", Difficulty: 4}
+
+	// what the browser sends back once the challenge page is solved
+	solved := func(ip string, userAgent string) string {
+		issuer, err := challenge.NewIssuer([]byte(challengeCfg.Secret), challengeCfg.Difficulty, time.Hour)
+		require.NoError(t, err)
+
+		token := issuer.Issue(ip, userAgent, time.Now())
+
+		for nonce := 0; ; nonce++ {
+			if challenge.LeadingZeroBits(challenge.Hash(token, strconv.Itoa(nonce))) >= challengeCfg.Difficulty {
+				return token + "." + strconv.Itoa(nonce)
+			}
+		}
+	}
+
+	request := func(ip string, cookie string) appsec.ParsedRequest {
+		u, err := url.Parse("/admin")
+		require.NoError(t, err)
+
+		headers := http.Header{"User-Agent": []string{"Mozilla/5.0"}}
+		if cookie != "" {
+			headers.Set("Cookie", "crowdsec_appsec_challenge="+cookie)
+		}
+
+		return appsec.ParsedRequest{
+			ClientIP:    ip,
+			RemoteAddr:  "127.0.0.1",
+			Method:      "GET",
+			URI:         "/admin",
+			URL:         u,
+			Headers:     headers,
+			HTTPRequest: &http.Request{Method: "GET", URL: u, Header: headers, RemoteAddr: ip, Host: "example.com"},
+		}
+	}
+
+	adminRule := []string{`SecRule REQUEST_URI "@beginsWith /admin" "id:3001,phase:1,deny,log,msg:'admin'"`}
+
+	tests := []appsecRuleTest{
+		{
+			name:                "challenge page",
+			expected_load_ok:    true,
+			challenge:           challengeCfg,
+			DefaultRemediation:  appsec.ChallengeRemediation,
+			inband_native_rules: adminRule,
+			input_request:       request("1.2.3.4", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.True(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.ChallengeRemediation, appsecResponse.Action)
+				require.Equal(t, http.StatusForbidden, appsecResponse.HTTPStatus)
+				require.Contains(t, appsecResponse.UserBody, "Checking your browser")
+				require.Equal(t, "text/html; charset=utf-8", appsecResponse.UserHeaders["Content-Type"])
+				require.Equal(t, "no-store", appsecResponse.UserHeaders["Cache-Control"])
+			},
+		},
+		{
+			name:                "solved challenge",
+			expected_load_ok:    true,
+			challenge:           challengeCfg,
+			DefaultRemediation:  appsec.ChallengeRemediation,
+			inband_native_rules: adminRule,
+			input_request:       request("1.2.3.4", solved("1.2.3.4", "Mozilla/5.0")),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.AllowRemediation, appsecResponse.Action)
+				require.Empty(t, appsecResponse.UserBody)
+			},
+		},
+		{
+			name:                "challenge solved by another client",
+			expected_load_ok:    true,
+			challenge:           challengeCfg,
+			DefaultRemediation:  appsec.ChallengeRemediation,
+			inband_native_rules: adminRule,
+			input_request:       request("1.2.3.4", solved("5.6.7.8", "Mozilla/5.0")),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, appsec.ChallengeRemediation, appsecResponse.Action)
+				require.NotEmpty(t, appsecResponse.UserBody)
+			},
+		},
+		{
+			name:                "challenge set by a hook",
+			expected_load_ok:    true,
+			inband_native_rules: adminRule,
+			on_match: []appsec.Hook{
+				{Filter: "IsInBand", Apply: []string{`SetRemediation("challenge")`}},
+			},
+			input_request: request("1.2.3.4", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Equal(t, appsec.ChallengeRemediation, appsecResponse.Action)
+				require.Contains(t, appsecResponse.UserBody, "crowdsec_appsec_challenge")
+			},
+		},
+		{
+			name:                "the cookie skips a challenge set by a hook",
+			expected_load_ok:    true,
+			challenge:           challengeCfg,
+			inband_native_rules: adminRule,
+			on_match: []appsec.Hook{
+				{Filter: "IsInBand", Apply: []string{`SetRemediation("challenge")`}},
+			},
+			input_request: request("1.2.3.4", solved("1.2.3.4", "Mozilla/5.0")),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.AllowRemediation, appsecResponse.Action)
+				require.Empty(t, appsecResponse.UserBody)
+			},
+		},
+		{
+			name:                "the cookie does not skip a challenge changed to a ban by a hook",
+			expected_load_ok:    true,
+			challenge:           challengeCfg,
+			DefaultRemediation:  appsec.ChallengeRemediation,
+			inband_native_rules: adminRule,
+			on_match: []appsec.Hook{
+				{Filter: "IsInBand", Apply: []string{`SetRemediation("ban")`}},
+			},
+			input_request: request("1.2.3.4", solved("1.2.3.4", "Mozilla/5.0")),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.True(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.BanRemediation, appsecResponse.Action)
+				require.Empty(t, appsecResponse.UserBody)
+			},
+		},
+		{
+			name:                "the cookie is ignored for other remediations",
+			expected_load_ok:    true,
+			challenge:           challengeCfg,
+			inband_native_rules: adminRule,
+			input_request:       request("1.2.3.4", solved("1.2.3.4", "Mozilla/5.0")),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, appsec.BanRemediation, appsecResponse.Action)
+				require.Empty(t, appsecResponse.UserBody)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+package appsecacquisition
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/identity"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecIdentity(t *testing.T) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+
+	jwks := filepath.Join(t.TempDir(), "jwks.json")
+	err = os.WriteFile(jwks, []byte(`{"keys": [{"kty": "OKP", "crv": "Ed25519", "x": "`+base64.RawURLEncoding.EncodeToString(public)+`"}]}`), 0o644)
+	require.NoError(t, err)
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodEdDSA, jwt.MapClaims{"sub": "alice"}).SignedString(private)
+	require.NoError(t, err)
+
+	// the user comes from a verified JWT, or from a header set by a trusted proxy
+	identities := []identity.Config{
+		{Name: "user", Header: "Authorization", JWT: &identity.JWTConfig{Claim: "sub", JWKSFile: jwks}},
+		{Name: "user", Header: "X-Forwarded-User"},
+		{Name: "session", Cookie: "session_id", Hash: true},
+	}
+
+	request := func(ip string, headers http.Header) appsec.ParsedRequest {
+		u, err := url.Parse("/account")
+		require.NoError(t, err)
+
+		return appsec.ParsedRequest{
+			ClientIP:    ip,
+			RemoteAddr:  "127.0.0.1",
+			Method:      "GET",
+			URI:         "/account",
+			URL:         u,
+			Headers:     headers,
+			HTTPRequest: &http.Request{Method: "GET", URL: u, Header: headers, RemoteAddr: ip, Host: "example.com"},
+		}
+	}
+
+	blockMallory := []string{
+		`SecRule TX:identity_user "@streq mallory" "id:3001,phase:1,deny,log,msg:'blocked user'"`,
+	}
+
+	tests := []appsecRuleTest{
+		{
+			name:                "identities in the rules and events",
+			expected_load_ok:    true,
+			identity:            identities,
+			inband_native_rules: blockMallory,
+			input_request: request("1.2.3.4", http.Header{
+				"X-Forwarded-User": []string{"mallory"},
+				"Cookie":           []string{"session_id=s3cr3t"},
+			}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "blocked user", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, "mallory", events[1].Parsed["identity_user"])
+				require.Len(t, events[1].Parsed["identity_session"], 32)
+				require.NotContains(t, events[1].Parsed["identity_session"], "s3cr3t")
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:                "verified JWT",
+			expected_load_ok:    true,
+			identity:            identities,
+			inband_native_rules: []string{`SecRule TX:identity_user "@streq alice" "id:3002,phase:1,deny,log,msg:'alice'"`},
+			input_request: request("1.2.3.4", http.Header{
+				"Authorization":    []string{"Bearer " + token},
+				"X-Forwarded-User": []string{"mallory"},
+			}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "alice", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, "alice", events[1].Parsed["identity_user"])
+			},
+		},
+		{
+			name:                "forged JWT",
+			expected_load_ok:    true,
+			identity:            identities,
+			inband_native_rules: blockMallory,
+			input_request: request("1.2.3.4", http.Header{
+				"Authorization":    []string{"Bearer " + token[:len(token)-4] + "AAAA"},
+				"X-Forwarded-User": []string{"mallory"},
+			}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				// the next extractor of the identity is used
+				require.Len(t, events, 2)
+				require.Equal(t, "mallory", events[1].Parsed["identity_user"])
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "rate limit per user",
+			expected_load_ok: true,
+			identity:         identities,
+			rate_limits:      []appsec.RateLimit{{Name: "per-user", Key: "identity.user", Capacity: 1, LeakSpeed: "1m"}},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", http.Header{"X-Forwarded-User": []string{"bob"}}),
+				// no identity, not limited
+				request("5.6.7.8", http.Header{}),
+				request("5.6.7.8", http.Header{}),
+			},
+			input_request: request("9.9.9.9", http.Header{"X-Forwarded-User": []string{"bob"}}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "ratelimit:per-user", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, "bob", events[1].Parsed["identity_user"])
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "rate limit without key",
+			expected_load_ok: true,
+			identity:         identities,
+			rate_limits: []appsec.RateLimit{
+				{Name: "per-user", Key: "identity.user", Capacity: 1, LeakSpeed: "1m"},
+				{Name: "per-api-key", Key: `req.Header["X-Api-Key"]`, Capacity: 1, LeakSpeed: "1m"},
+				{Name: "per-tenant", Key: `identity.tenant == "" ? nil : identity.tenant`, Capacity: 1, LeakSpeed: "1m"},
+			},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", http.Header{}),
+				request("5.6.7.8", http.Header{}),
+			},
+			// the requests without a key don't share a bucket
+			input_request: request("9.9.9.9", http.Header{}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:                "hooks use the identities",
+			expected_load_ok:    true,
+			identity:            identities,
+			inband_native_rules: []string{`SecRule REQUEST_URI "@streq /account" "id:3003,phase:1,deny,log,msg:'account'"`},
+			on_match: []appsec.Hook{
+				{Filter: `identity.user == "bob"`, Apply: []string{`SetRemediation("captcha")`}},
+			},
+			input_request: request("1.2.3.4", http.Header{"X-Forwarded-User": []string{"bob"}}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, appsec.CaptchaRemediation, responses[0].Action)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/learning"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecLearning(t *testing.T) {
+	dir := t.TempDir()
+
+	request := appsec.ParsedRequest{
+		ClientIP:   "1.2.3.4",
+		RemoteAddr: "127.0.0.1",
+		Method:     "GET",
+		URI:        "/users/42/posts?q=<script>",
+		Args:       map[string][]string{"q": {"<script>"}},
+	}
+
+	u, err := url.Parse("/caf%C3%A9/42?q=<script>")
+	require.NoError(t, err)
+
+	escaped := appsec.ParsedRequest{
+		ClientIP:    "1.2.3.4",
+		RemoteAddr:  "127.0.0.1",
+		Method:      "GET",
+		URI:         u.String(),
+		URL:         u,
+		Args:        u.Query(),
+		HTTPRequest: &http.Request{Method: "GET", URL: u, RequestURI: u.String()},
+	}
+
+	xss := `SecRule ARGS_GET "@contains <script>" "id:1,phase:1,deny,log,msg:'xss'"`
+
+	// the learning period of ended.json is over
+	ended := filepath.Join(dir, "ended.json")
+	require.NoError(t, os.WriteFile(ended, []byte(`{"started_at": "2020-01-01T00:00:00Z"}`), 0o600))
+
+	tests := []appsecRuleTest{
+		{
+			name:                "matches are recorded without blocking",
+			expected_load_ok:    true,
+			learning:            appsec.LearningConfig{Enabled: true, Output: filepath.Join(dir, "learning.json")},
+			inband_native_rules: []string{xss},
+			previous_requests:   []appsec.ParsedRequest{request},
+			input_request:       request,
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+
+				data, err := learning.Load(filepath.Join(dir, "learning.json"))
+				require.NoError(t, err)
+
+				assert.Equal(t, map[string]int{"/users/*/posts": 2}, data.Requests)
+				require.Len(t, data.Matches, 1)
+				assert.Equal(t, 1, data.Matches[0].RuleID)
+				assert.Equal(t, "xss", data.Matches[0].Message)
+				assert.Equal(t, "inband", data.Matches[0].Band)
+				assert.Equal(t, "ARGS_GET", data.Matches[0].Zone)
+				assert.Equal(t, "q", data.Matches[0].Variable)
+				assert.Equal(t, 2, data.Matches[0].Hits)
+				assert.Equal(t, []string{"1.2.3.4"}, data.Matches[0].Clients)
+			},
+		},
+		{
+			name:                "the raw uri is recorded",
+			expected_load_ok:    true,
+			learning:            appsec.LearningConfig{Enabled: true, Output: filepath.Join(dir, "raw.json")},
+			inband_native_rules: []string{xss},
+			input_request:       escaped,
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+
+				data, err := learning.Load(filepath.Join(dir, "raw.json"))
+				require.NoError(t, err)
+
+				// the suggested exclusion matches the request as seen by the hooks
+				assert.Equal(t, map[string]int{"/caf%C3%A9/*": 1}, data.Requests)
+				require.Len(t, data.Matches, 1)
+				assert.Regexp(t, learning.URIRegexp(data.Matches[0].URI), escaped.HTTPRequest.RequestURI)
+			},
+		},
+		{
+			name:             "requests are blocked once the learning period is over",
+			expected_load_ok: true,
+			learning:         appsec.LearningConfig{Enabled: true, Output: ended, Duration: 24 * time.Hour},
+			inband_native_rules: []string{
+				xss,
+			},
+			input_request: request,
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.True(t, responses[0].InBandInterrupt)
+
+				data, err := learning.Load(ended)
+				require.NoError(t, err)
+				assert.Empty(t, data.Matches)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
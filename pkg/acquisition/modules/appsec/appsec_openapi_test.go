@@ -0,0 +1,171 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/openapi"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+const usersSpec = `
+openapi: 3.0.3
+info:
+  title: users
+  version: "1.0"
+paths:
+  /users:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              required: [name]
+              properties:
+                name:
+                  type: string
+      responses:
+        "201":
+          description: created
+  /users/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+`
+
+func TestAppsecOpenAPI(t *testing.T) {
+	specPath := filepath.Join(t.TempDir(), "users.yaml")
+	require.NoError(t, os.WriteFile(specPath, []byte(usersSpec), 0o600))
+
+	schemas := []openapi.Config{{Name: "users", Spec: specPath, Hosts: []string{"api.example.com"}, PathPrefix: "/v1"}}
+
+	request := func(method string, host string, uri string, body string) appsec.ParsedRequest {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+
+		return appsec.ParsedRequest{
+			ClientIP:   "1.2.3.4",
+			RemoteAddr: "127.0.0.1",
+			Method:     method,
+			Host:       host,
+			URI:        uri,
+			URL:        u,
+			Args:       u.Query(),
+			Headers:    http.Header{"Content-Type": []string{"application/json"}},
+			Body:       []byte(body),
+		}
+	}
+
+	tests := []appsecRuleTest{
+		{
+			name:             "conforming request",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			input_request:    request("POST", "api.example.com", "/v1/users", `{"name":"bob"}`),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "body not conforming",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			input_request:    request("POST", "api.example.com", "/v1/users", `{"name":"bob","admin":true}`),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, types.APPSEC, events[0].Type)
+				require.Equal(t, types.LOG, events[1].Type)
+				require.True(t, events[1].Appsec.HasInBandMatches)
+				require.Len(t, events[1].Appsec.MatchedRules, 1)
+				require.Equal(t, "openapi:users", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Contains(t, events[1].Appsec.MatchedRules[0]["logdata"], `property "admin" is unsupported`)
+
+				require.Len(t, responses, 1)
+				require.True(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.BanRemediation, appsecResponse.Action)
+				require.Equal(t, http.StatusForbidden, statusCode)
+			},
+		},
+		{
+			name:             "undefined path",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			input_request:    request("GET", "api.example.com", "/v1/admin", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Contains(t, events[1].Appsec.MatchedRules[0]["logdata"], "path /admin is not defined")
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "bad path parameter",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			input_request:    request("GET", "api.example.com", "/v1/users/1%20or%201=1", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Contains(t, events[1].Appsec.MatchedRules[0]["logdata"], `parameter "id" in path`)
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "encoded prefix is enforced",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			input_request:    request("GET", "api.example.com", "/%761/admin", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Contains(t, events[1].Appsec.MatchedRules[0]["logdata"], "path /admin is not defined")
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "other host is not enforced",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			input_request:    request("GET", "www.example.com", "/v1/admin", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "violation remediation set by name",
+			expected_load_ok: true,
+			openapi_schemas:  schemas,
+			pre_eval: []appsec.Hook{
+				{Apply: []string{"SetRemediationByName('openapi:users', 'captcha')"}},
+			},
+			input_request: request("DELETE", "api.example.com", "/v1/users/1", ""),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Contains(t, events[1].Appsec.MatchedRules[0]["logdata"], "method DELETE is not allowed on /users/{id}")
+				require.Equal(t, appsec.CaptchaRemediation, responses[0].Action)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
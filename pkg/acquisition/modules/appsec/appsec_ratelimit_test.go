@@ -0,0 +1,154 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecRateLimit(t *testing.T) {
+	request := func(ip string, uri string, headers http.Header) appsec.ParsedRequest {
+		u, err := url.Parse(uri)
+		require.NoError(t, err)
+
+		httpRequest := &http.Request{Method: "GET", URL: u, Header: headers, RemoteAddr: ip, Host: "example.com"}
+
+		return appsec.ParsedRequest{
+			ClientIP:    ip,
+			RemoteAddr:  "127.0.0.1",
+			Method:      "GET",
+			URI:         uri,
+			URL:         u,
+			Headers:     headers,
+			HTTPRequest: httpRequest,
+		}
+	}
+
+	tests := []appsecRuleTest{
+		{
+			name:             "under the limit",
+			expected_load_ok: true,
+			rate_limits:      []appsec.RateLimit{{Name: "per-ip", Capacity: 2, LeakSpeed: "1m"}},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", "/", nil),
+			},
+			input_request: request("1.2.3.4", "/", nil),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "over the limit",
+			expected_load_ok: true,
+			rate_limits:      []appsec.RateLimit{{Name: "per-ip", Capacity: 2, LeakSpeed: "1m", Remediation: appsec.CaptchaRemediation}},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", "/", nil),
+				request("1.2.3.4", "/", nil),
+			},
+			input_request: request("1.2.3.4", "/", nil),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, types.APPSEC, events[0].Type)
+				require.Equal(t, types.LOG, events[1].Type)
+				require.True(t, events[1].Appsec.HasInBandMatches)
+				require.Equal(t, "ratelimit:per-ip", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, "1.2.3.4", events[1].Appsec.MatchedRules[0]["logdata"])
+
+				require.Len(t, responses, 1)
+				require.True(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.CaptchaRemediation, responses[0].Action)
+				require.Equal(t, http.StatusForbidden, statusCode)
+			},
+		},
+		{
+			name:             "other clients are not limited",
+			expected_load_ok: true,
+			rate_limits:      []appsec.RateLimit{{Name: "per-ip", Capacity: 1, LeakSpeed: "1m"}},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", "/", nil),
+			},
+			input_request: request("5.6.7.8", "/", nil),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "filter",
+			expected_load_ok: true,
+			rate_limits: []appsec.RateLimit{
+				{Name: "login", Filter: "req.URL.Path == '/login'", Capacity: 1, LeakSpeed: "1m"},
+			},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", "/", nil),
+				request("1.2.3.4", "/", nil),
+				request("1.2.3.4", "/login", nil),
+			},
+			input_request: request("1.2.3.4", "/login", nil),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "ratelimit:login", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, appsec.BanRemediation, responses[0].Action)
+			},
+		},
+		{
+			name:             "key from a header",
+			expected_load_ok: true,
+			rate_limits: []appsec.RateLimit{
+				{Name: "per-api-key", Key: "req.Header.Get('X-Api-Key')", Capacity: 1, LeakSpeed: "1m"},
+			},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", "/", http.Header{"X-Api-Key": []string{"abcd"}}),
+				// requests without a key are not limited
+				request("1.2.3.4", "/", nil),
+			},
+			input_request: request("5.6.7.8", "/", http.Header{"X-Api-Key": []string{"abcd"}}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "abcd", events[1].Appsec.MatchedRules[0]["logdata"])
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "key from the JA4H fingerprint",
+			expected_load_ok: true,
+			rate_limits: []appsec.RateLimit{
+				{Name: "per-ja4h", Key: "JA4H(req)", Capacity: 1, LeakSpeed: "1m"},
+			},
+			previous_requests: []appsec.ParsedRequest{
+				request("1.2.3.4", "/", http.Header{"User-Agent": []string{"curl/8.0"}}),
+			},
+			input_request: request("5.6.7.8", "/", http.Header{"User-Agent": []string{"curl/8.0"}}),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "ratelimit:per-ja4h", events[1].Appsec.MatchedRules[0]["msg"])
+			},
+		},
+		{
+			name:             "allowlisted clients are not blocked",
+			expected_load_ok: true,
+			rate_limits:      []appsec.RateLimit{{Name: "per-ip", Capacity: 1, LeakSpeed: "1m"}},
+			previous_requests: []appsec.ParsedRequest{
+				request("5.4.3.2", "/", nil),
+			},
+			input_request: request("5.4.3.2", "/", nil),
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
@@ -0,0 +1,188 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/appsec_rule"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestAppsecResponsePhase(t *testing.T) {
+	stackTraceRule := appsec_rule.CustomRule{
+		Name:  "stack-trace-leak",
+		Zones: []string{"RESPONSE_BODY"},
+		Match: appsec_rule.Match{Type: "contains", Value: "Traceback (most recent call last)"},
+	}
+
+	tests := []appsecRuleTest{
+		{
+			name:             "inband response body match",
+			expected_load_ok: true,
+			inband_rules:     []appsec_rule.CustomRule{stackTraceRule},
+			input_request: appsec.ParsedRequest{
+				ClientIP:        "1.2.3.4",
+				RemoteAddr:      "127.0.0.1",
+				Method:          "GET",
+				URI:             "/api/users",
+				IsResponse:      true,
+				ResponseCode:    http.StatusInternalServerError,
+				ResponseHeaders: http.Header{"Content-Type": []string{"text/html; charset=utf-8"}},
+				ResponseBody:    []byte("<pre>Traceback (most recent call last):\n  File \"app.py\", line 42</pre>"),
+			},
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, types.APPSEC, events[0].Type)
+				require.Equal(t, types.LOG, events[1].Type)
+				require.True(t, events[1].Appsec.HasInBandMatches)
+				require.Len(t, events[1].Appsec.MatchedRules, 1)
+				require.Equal(t, "stack-trace-leak", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Equal(t, []string{"RESPONSE_BODY"}, events[1].Appsec.MatchedRules[0]["matched_zones"])
+				require.Equal(t, "500", events[1].Parsed["http_status"])
+
+				require.Len(t, responses, 1)
+				require.True(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.BanRemediation, appsecResponse.Action)
+				require.Equal(t, http.StatusForbidden, statusCode)
+			},
+		},
+		{
+			name:             "response body with a content type not inspected",
+			expected_load_ok: true,
+			inband_rules:     []appsec_rule.CustomRule{stackTraceRule},
+			input_request: appsec.ParsedRequest{
+				ClientIP:        "1.2.3.4",
+				RemoteAddr:      "127.0.0.1",
+				Method:          "GET",
+				URI:             "/logo.png",
+				IsResponse:      true,
+				ResponseCode:    http.StatusOK,
+				ResponseHeaders: http.Header{"Content-Type": []string{"image/png"}},
+				ResponseBody:    []byte("Traceback (most recent call last)"),
+			},
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "response rules are not evaluated for requests",
+			expected_load_ok: true,
+			inband_rules: []appsec_rule.CustomRule{
+				{
+					Name:  "no-server-errors",
+					Zones: []string{"RESPONSE_STATUS"},
+					Match: appsec_rule.Match{Type: "regex", Value: "^5"},
+				},
+				{
+					Name:  "no-request-body",
+					Zones: []string{"RAW_BODY"},
+					Match: appsec_rule.Match{Type: "contains", Value: "Traceback"},
+				},
+			},
+			input_request: appsec.ParsedRequest{
+				ClientIP:   "1.2.3.4",
+				RemoteAddr: "127.0.0.1",
+				Method:     "POST",
+				URI:        "/api/users",
+				Body:       []byte("hello"),
+			},
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "request rules are not evaluated for responses",
+			expected_load_ok: true,
+			inband_rules: []appsec_rule.CustomRule{
+				{
+					Name:  "api-uri",
+					Zones: []string{"URI"},
+					Match: appsec_rule.Match{Type: "startsWith", Value: "/api/"},
+				},
+			},
+			input_request: appsec.ParsedRequest{
+				ClientIP:        "1.2.3.4",
+				RemoteAddr:      "127.0.0.1",
+				Method:          "GET",
+				URI:             "/api/users",
+				IsResponse:      true,
+				ResponseCode:    http.StatusOK,
+				ResponseHeaders: http.Header{"Content-Type": []string{"application/json"}},
+				ResponseBody:    []byte(`{"users": []}`),
+			},
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Empty(t, events)
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+			},
+		},
+		{
+			name:             "outofband response header match",
+			expected_load_ok: true,
+			outofband_rules: []appsec_rule.CustomRule{
+				{
+					Name:      "server-version-leak",
+					Zones:     []string{"RESPONSE_HEADERS"},
+					Variables: []string{"x-powered-by"},
+					Match:     appsec_rule.Match{Type: "regex", Value: "php/[0-9]"},
+					Transform: []string{"lowercase"},
+				},
+			},
+			input_request: appsec.ParsedRequest{
+				ClientIP:        "1.2.3.4",
+				RemoteAddr:      "127.0.0.1",
+				Method:          "GET",
+				URI:             "/index.php",
+				IsResponse:      true,
+				ResponseCode:    http.StatusOK,
+				ResponseHeaders: http.Header{"X-Powered-By": []string{"PHP/5.4.1"}},
+			},
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 1)
+				require.Equal(t, types.LOG, events[0].Type)
+				require.True(t, events[0].Appsec.HasOutBandMatches)
+				require.Equal(t, "server-version-leak", events[0].Appsec.MatchedRules[0]["msg"])
+
+				require.Len(t, responses, 1)
+				require.False(t, responses[0].InBandInterrupt)
+				require.Equal(t, appsec.AllowRemediation, appsecResponse.Action)
+			},
+		},
+		{
+			name:             "native response body rule",
+			expected_load_ok: true,
+			inband_native_rules: []string{
+				`SecRule RESPONSE_BODY "@rx 4[0-9]{15}" "id:4100,phase:4,deny,log,msg:'credit card leak'"`,
+			},
+			input_request: appsec.ParsedRequest{
+				ClientIP:        "1.2.3.4",
+				RemoteAddr:      "127.0.0.1",
+				Method:          "GET",
+				URI:             "/api/orders/42",
+				IsResponse:      true,
+				ResponseCode:    http.StatusOK,
+				ResponseHeaders: http.Header{"Content-Type": []string{"application/json"}},
+				ResponseBody:    []byte(`{"card": "4111111111111111"}`),
+			},
+			output_asserts: func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int) {
+				require.Len(t, events, 2)
+				require.Equal(t, "credit card leak", events[1].Appsec.MatchedRules[0]["msg"])
+				require.Len(t, responses, 1)
+				require.True(t, responses[0].InBandInterrupt)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loadAppSecEngine(test, t)
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
@@ -14,8 +15,8 @@ import (
 	"github.com/corazawaf/coraza/v3"
 	corazatypes "github.com/corazawaf/coraza/v3/types"
 
-	// load body processors via init()
-	_ "github.com/crowdsecurity/crowdsec/pkg/acquisition/modules/appsec/bodyprocessors"
+	// also loads the body processors via init()
+	"github.com/crowdsecurity/crowdsec/pkg/acquisition/modules/appsec/bodyprocessors"
 	"github.com/crowdsecurity/crowdsec/pkg/appsec"
 	"github.com/crowdsecurity/crowdsec/pkg/appsec/allowlists"
 	"github.com/crowdsecurity/crowdsec/pkg/types"
@@ -32,6 +33,7 @@ type AppsecRunner struct {
 	Labels                 map[string]string
 	logger                 *log.Entry
 	appsecAllowlistsClient *allowlists.AppsecAllowlist
+	txObserver             func(request *appsec.ParsedRequest) // called after the evaluation of each band, by the replay
 }
 
 func (r *AppsecRunner) MergeDedupRules(collections []appsec.AppsecCollection, logger *log.Entry) string {
@@ -60,6 +62,21 @@ func (r *AppsecRunner) MergeDedupRules(collections []appsec.AppsecCollection, lo
 	return strings.Join(rulesArr, "\n")
 }
 
+// withResponseOptions configures the inspection of the responses forwarded by the remediation component
+func withResponseOptions(cfg coraza.WAFConfig, opts appsec.AppsecSubEngineOpts) coraza.WAFConfig {
+	if opts.DisableResponseBodyInspection {
+		return cfg
+	}
+
+	cfg = cfg.WithResponseBodyAccess().WithResponseBodyMimeTypes(opts.GetResponseBodyMimeTypes())
+
+	if opts.ResponseBodyLimit != nil {
+		cfg = cfg.WithResponseBodyLimit(*opts.ResponseBodyLimit)
+	}
+
+	return cfg
+}
+
 func (r *AppsecRunner) Init(datadir string) error {
 	var err error
 	fs := os.DirFS(datadir)
@@ -81,6 +98,7 @@ func (r *AppsecRunner) Init(datadir string) error {
 	if r.AppsecRuntime.Config.InbandOptions.RequestBodyInMemoryLimit != nil {
 		inbandCfg = inbandCfg.WithRequestBodyInMemoryLimit(*r.AppsecRuntime.Config.InbandOptions.RequestBodyInMemoryLimit)
 	}
+	inbandCfg = withResponseOptions(inbandCfg, r.AppsecRuntime.Config.InbandOptions)
 	r.AppsecInbandEngine, err = coraza.NewWAF(inbandCfg)
 	if err != nil {
 		return fmt.Errorf("unable to initialize inband engine : %w", err)
@@ -96,6 +114,7 @@ func (r *AppsecRunner) Init(datadir string) error {
 	if r.AppsecRuntime.Config.OutOfBandOptions.RequestBodyInMemoryLimit != nil {
 		outbandCfg = outbandCfg.WithRequestBodyInMemoryLimit(*r.AppsecRuntime.Config.OutOfBandOptions.RequestBodyInMemoryLimit)
 	}
+	outbandCfg = withResponseOptions(outbandCfg, r.AppsecRuntime.Config.OutOfBandOptions)
 	r.AppsecOutbandEngine, err = coraza.NewWAF(outbandCfg)
 	if err != nil {
 		return fmt.Errorf("unable to initialize outband engine : %w", err)
@@ -159,6 +178,9 @@ func (r *AppsecRunner) processRequest(tx appsec.ExtendedTransaction, request *ap
 
 	request.Tx.ProcessConnection(request.ClientIP, 0, "", 0)
 
+	r.AppsecRuntime.SetBotVariables(request)
+	r.AppsecRuntime.SetIdentityVariables(request)
+
 	for k, v := range request.Args {
 		for _, vv := range v {
 			request.Tx.AddGetRequestArgument(k, vv)
@@ -167,6 +189,20 @@ func (r *AppsecRunner) processRequest(tx appsec.ExtendedTransaction, request *ap
 
 	request.Tx.ProcessURI(request.URI, request.Method, request.Proto)
 
+	r.AppsecRuntime.StartLearning(request)
+
+	if request.IsResponse {
+		return r.processResponse(request)
+	}
+
+	if request.IsInBand {
+		r.AppsecRuntime.CheckRateLimits(request)
+		r.AppsecRuntime.ValidateOpenAPI(request)
+		setBodyLimits(request, r.AppsecRuntime.Config.InbandOptions.BodyLimits)
+	} else {
+		setBodyLimits(request, r.AppsecRuntime.Config.OutOfBandOptions.BodyLimits)
+	}
+
 	for k, vr := range request.Headers {
 		for _, v := range vr {
 			request.Tx.AddRequestHeader(k, v)
@@ -213,6 +249,65 @@ func (r *AppsecRunner) processRequest(tx appsec.ExtendedTransaction, request *ap
 	return nil
 }
 
+// setBodyLimits passes the configured limits to the body processors, through the transaction variables they read
+func setBodyLimits(request *appsec.ParsedRequest, limits appsec.BodyLimits) {
+	variables := map[string]*int{
+		bodyprocessors.JSONMaxDepthVariable:         limits.JSONMaxDepth,
+		bodyprocessors.JSONMaxArgsVariable:          limits.JSONMaxArgs,
+		bodyprocessors.XMLMaxDepthVariable:          limits.XMLMaxDepth,
+		bodyprocessors.XMLMaxElementsVariable:       limits.XMLMaxElements,
+		bodyprocessors.MultipartMaxPartsVariable:    limits.MultipartMaxParts,
+		bodyprocessors.MultipartMaxPartSizeVariable: limits.MultipartMaxPartSize,
+		bodyprocessors.GraphQLMaxBatchVariable:      limits.GraphQLMaxBatch,
+	}
+
+	for variable, limit := range variables {
+		if limit != nil {
+			request.Tx.Variables().TX().Set(variable, []string{strconv.Itoa(*limit)})
+		}
+	}
+}
+
+// processResponse evaluates the response phases (3 and 4). The request phases are skipped,
+// they were evaluated when the remediation component forwarded the request itself.
+func (r *AppsecRunner) processResponse(request *appsec.ParsedRequest) error {
+	for k, vr := range request.ResponseHeaders {
+		for _, v := range vr {
+			request.Tx.AddResponseHeader(k, v)
+		}
+	}
+
+	in := request.Tx.ProcessResponseHeaders(request.ResponseCode, request.Proto)
+	if in != nil {
+		r.logger.Infof("rules matched for response headers : %s", in.Action)
+		return nil
+	}
+
+	if len(request.ResponseBody) > 0 {
+		in, _, err := request.Tx.WriteResponseBody(request.ResponseBody)
+		if err != nil {
+			r.logger.Errorf("unable to write response body : %s", err)
+			return err
+		}
+
+		if in != nil {
+			return nil
+		}
+	}
+
+	in, err := request.Tx.ProcessResponseBody()
+	if err != nil {
+		r.logger.Errorf("unable to process response body : %s", err)
+		return err
+	}
+
+	if in != nil {
+		r.logger.Debugf("rules matched for response body : %d", in.RuleID)
+	}
+
+	return nil
+}
+
 func (r *AppsecRunner) ProcessInBandRules(request *appsec.ParsedRequest) error {
 	tx := appsec.NewExtendedTransaction(r.AppsecInbandEngine, request.UUID)
 	r.AppsecRuntime.InBandTx = tx
@@ -275,6 +370,9 @@ func (r *AppsecRunner) handleInBandInterrupt(request *appsec.ParsedRequest) {
 			return
 		}
 
+		// a solved challenge lets the request through, without event nor alert
+		r.AppsecRuntime.ProcessChallenge(request)
+
 		// Should the in band match trigger an overflow ?
 		if r.AppsecRuntime.Response.SendAlert {
 			appsecOvlfw, err := AppsecEventGeneration(evt, request.HTTPRequest)
@@ -290,7 +388,6 @@ func (r *AppsecRunner) handleInBandInterrupt(request *appsec.ParsedRequest) {
 		if r.AppsecRuntime.Response.SendEvent {
 			r.outChan <- evt
 		}
-
 	}
 }
 
@@ -338,12 +435,25 @@ func (r *AppsecRunner) handleOutBandInterrupt(request *appsec.ParsedRequest) {
 	}
 }
 
+// afterBand is called once the rules of a band are evaluated, before the transaction is closed
+func (r *AppsecRunner) afterBand(request *appsec.ParsedRequest) {
+	r.AppsecRuntime.RecordLearning(request)
+
+	if r.txObserver != nil {
+		r.txObserver(request)
+	}
+}
+
 func (r *AppsecRunner) handleRequest(request *appsec.ParsedRequest) {
 	r.AppsecRuntime.Logger = r.AppsecRuntime.Logger.WithField("request_uuid", request.UUID)
 	logger := r.logger.WithField("request_uuid", request.UUID)
 	logger.Debug("Request received in runner")
 	r.AppsecRuntime.ClearResponse()
 
+	// the classification and the identities are used by the hooks and rules of both bands
+	r.AppsecRuntime.ClassifyBot(request)
+	r.AppsecRuntime.ExtractIdentity(request)
+
 	request.IsInBand = true
 	request.IsOutBand = false
 
@@ -366,6 +476,8 @@ func (r *AppsecRunner) handleRequest(request *appsec.ParsedRequest) {
 	inBandParsingElapsed := time.Since(startInBandParsing)
 	AppsecInbandParsingHistogram.With(prometheus.Labels{"source": request.RemoteAddrNormalized, "appsec_engine": request.AppsecEngine}).Observe(inBandParsingElapsed.Seconds())
 
+	r.afterBand(request)
+
 	if request.Tx.IsInterrupted() {
 		r.handleInBandInterrupt(request)
 	}
@@ -378,6 +490,8 @@ func (r *AppsecRunner) handleRequest(request *appsec.ParsedRequest) {
 	// send back the result to the HTTP handler for the InBand part
 	request.ResponseChannel <- r.AppsecRuntime.Response
 
+	r.AppsecRuntime.CaptureRequest(request, r.AppsecRuntime.Response)
+
 	//Now let's process the out of band rules
 
 	request.IsInBand = false
@@ -404,6 +518,7 @@ func (r *AppsecRunner) handleRequest(request *appsec.ParsedRequest) {
 		// time spent to process out of band rules
 		outOfBandParsingElapsed := time.Since(startOutOfBandParsing)
 		AppsecOutbandParsingHistogram.With(prometheus.Labels{"source": request.RemoteAddrNormalized, "appsec_engine": request.AppsecEngine}).Observe(outOfBandParsingElapsed.Seconds())
+		r.afterBand(request)
 		if request.Tx.IsInterrupted() {
 			r.handleOutBandInterrupt(request)
 		}
@@ -17,6 +17,8 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/appsec"
 	"github.com/crowdsecurity/crowdsec/pkg/appsec/allowlists"
 	"github.com/crowdsecurity/crowdsec/pkg/appsec/appsec_rule"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/identity"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/openapi"
 	"github.com/crowdsecurity/crowdsec/pkg/types"
 )
 
@@ -36,6 +38,15 @@ type appsecRuleTest struct {
 	UserPassedHTTPCode     int
 	DefaultRemediation     string
 	DefaultPassAction      string
+	openapi_schemas        []openapi.Config
+	rate_limits            []appsec.RateLimit
+	body_limits            appsec.BodyLimits
+	learning               appsec.LearningConfig
+	bot_detection          appsec.BotDetectionConfig
+	challenge              appsec.ChallengeConfig
+	identity               []identity.Config
+	data                   []*types.DataSource
+	previous_requests      []appsec.ParsedRequest // processed before input_request, must not generate events
 	input_request          appsec.ParsedRequest
 	afterload_asserts      func(runner AppsecRunner)
 	output_asserts         func(events []types.Event, responses []appsec.AppsecTempResponse, appsecResponse appsec.BodyResponse, statusCode int)
@@ -103,12 +114,20 @@ func loadAppSecEngine(test appsecRuleTest, t *testing.T) {
 		UserPassedHTTPCode:     test.UserPassedHTTPCode,
 		DefaultRemediation:     test.DefaultRemediation,
 		DefaultPassAction:      test.DefaultPassAction,
+		OpenAPISchemas:         test.openapi_schemas,
+		RateLimits:             test.rate_limits,
+		InbandOptions:          appsec.AppsecSubEngineOpts{BodyLimits: test.body_limits},
+		Learning:               test.learning,
+		BotDetection:           test.bot_detection,
+		Challenge:              test.challenge,
+		Identity:               test.identity,
+		Data:                   test.data,
 	}
 	AppsecRuntime, err := appsecCfg.Build()
 	if err != nil {
 		t.Fatalf("unable to build appsec runtime : %s", err)
 	}
-	AppsecRuntime.InBandRules = []appsec.AppsecCollection{{Rules: inbandRules, NativeRules: nativeInbandRules}}
+	AppsecRuntime.InBandRules = append(AppsecRuntime.InBandRules, appsec.AppsecCollection{Rules: inbandRules, NativeRules: nativeInbandRules})
 	AppsecRuntime.OutOfBandRules = []appsec.AppsecCollection{{Rules: outofbandRules, NativeRules: nativeOutofbandRules}}
 	appsecRunnerUUID := uuid.New().String()
 	//we copy AppsecRutime for each runner
@@ -197,9 +216,21 @@ func loadAppSecEngine(test appsecRuleTest, t *testing.T) {
 		}
 	}()
 
+	for _, previous := range test.previous_requests {
+		previous.ResponseChannel = make(chan appsec.AppsecTempResponse)
+		go func() {
+			<-previous.ResponseChannel
+		}()
+		runner.handleRequest(&previous)
+	}
+
 	runner.handleRequest(&input)
 	time.Sleep(50 * time.Millisecond)
 
+	if AppsecRuntime.Learner != nil {
+		require.NoError(t, AppsecRuntime.Learner.Flush())
+	}
+
 	http_status, appsecResponse := AppsecRuntime.GenerateResponse(OutputResponses[0], logger)
 	log.Infof("events : %s", spew.Sdump(OutputEvents))
 	log.Infof("responses : %s", spew.Sdump(OutputResponses))
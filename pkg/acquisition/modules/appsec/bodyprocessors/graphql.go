@@ -0,0 +1,221 @@
+package bodyprocessors
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/corazawaf/coraza/v3/experimental/plugins"
+	"github.com/corazawaf/coraza/v3/experimental/plugins/plugintypes"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/graphql"
+)
+
+// graphqlBodyProcessor parses the GraphQL requests, sent either as JSON (a single request or a batch)
+// or as application/graphql, and exposes their analysis:
+//   - ARGS_POST:graphql.query, graphql.operation_name, graphql.operation_type
+//   - ARGS_POST:graphql.fields, the paths of the selected fields (user.posts.title)
+//   - ARGS_POST:graphql.args.<field path>.<argument>, the values of the arguments
+//   - ARGS_POST:graphql.variables.<path>, the variables, flattened like the json processor does
+//   - TX:graphql_operation_name, graphql_operation_type, graphql_operations (the size of the batch),
+//     graphql_depth (the deepest request), graphql_complexity and graphql_aliases (summed over the batch)
+//
+// The raw body is kept in REQUEST_BODY, and an invalid query is reported as a body processing error.
+// The JSON requests are limited in nesting (like json_ext) and in batch size before they are decoded.
+type graphqlBodyProcessor struct{}
+
+type graphqlRequest struct {
+	Query         string          `json:"query"`
+	OperationName string          `json:"operationName"`
+	Variables     json.RawMessage `json:"variables"`
+}
+
+func (*graphqlBodyProcessor) ProcessRequest(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	buf := new(strings.Builder)
+	if _, err := io.Copy(buf, reader); err != nil {
+		return err
+	}
+
+	b := buf.String()
+
+	v.RequestBody().(setterInterface).Set(b)
+	v.RequestBodyLength().(setterInterface).Set(strconv.Itoa(len(b)))
+
+	requests, err := readGraphQLRequests(b, options.Mime,
+		limit(v, JSONMaxDepthVariable, DefaultJSONMaxDepth),
+		limit(v, GraphQLMaxBatchVariable, DefaultGraphQLMaxBatch))
+	if err != nil {
+		return err
+	}
+
+	var (
+		names      []string
+		types      []string
+		depth      int
+		complexity int
+		aliases    int
+	)
+
+	args := v.ArgsPost()
+
+	for _, req := range requests {
+		if len(req.Variables) > 0 {
+			if err := readJSON(bytes.NewReader(req.Variables), args, v, "graphql.variables"); err != nil {
+				return err
+			}
+		}
+
+		// persisted queries are only referenced by their hash
+		if req.Query == "" {
+			continue
+		}
+
+		args.Add("graphql.query", req.Query)
+
+		query, err := graphql.Parse(req.Query, req.OperationName)
+		if err != nil {
+			return fmt.Errorf("invalid GraphQL query: %w", err)
+		}
+
+		if query.OperationName != "" {
+			names = append(names, query.OperationName)
+			args.Add("graphql.operation_name", query.OperationName)
+		}
+
+		types = append(types, query.OperationType)
+		args.Add("graphql.operation_type", query.OperationType)
+
+		for _, field := range query.Fields {
+			args.Add("graphql.fields", field)
+		}
+
+		for _, arg := range query.Arguments {
+			args.Add("graphql.args."+arg.Path, arg.Value)
+		}
+
+		depth = max(depth, query.Depth)
+		complexity += query.Complexity
+		aliases += query.Aliases
+	}
+
+	tx := v.TX()
+	tx.Set("graphql_operation_name", names)
+	tx.Set("graphql_operation_type", types)
+	tx.Set("graphql_operations", []string{strconv.Itoa(len(requests))})
+	tx.Set("graphql_depth", []string{strconv.Itoa(depth)})
+	tx.Set("graphql_complexity", []string{strconv.Itoa(complexity)})
+	tx.Set("graphql_aliases", []string{strconv.Itoa(aliases)})
+
+	return nil
+}
+
+func (*graphqlBodyProcessor) ProcessResponse(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	return nil
+}
+
+func readGraphQLRequests(body string, mimeType string, maxDepth int, maxBatch int) ([]graphqlRequest, error) {
+	if mediaType, _, err := mime.ParseMediaType(mimeType); err == nil && mediaType == "application/graphql" {
+		return []graphqlRequest{{Query: body}}, nil
+	}
+
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return nil, nil
+	}
+
+	// the decoder has no limit on the nesting, check it first
+	if exceeds(jsonDepth(body), maxDepth) {
+		return nil, fmt.Errorf("GraphQL request is nested deeper than %d levels", maxDepth)
+	}
+
+	if body[0] == '[' {
+		return readGraphQLBatch(body, maxBatch)
+	}
+
+	var req graphqlRequest
+	if err := json.Unmarshal([]byte(body), &req); err != nil {
+		return nil, fmt.Errorf("invalid GraphQL request: %w", err)
+	}
+
+	return []graphqlRequest{req}, nil
+}
+
+// readGraphQLBatch decodes the requests of a batch one by one, and stops as soon as there are too many
+func readGraphQLBatch(body string, maxBatch int) ([]graphqlRequest, error) {
+	dec := json.NewDecoder(strings.NewReader(body))
+
+	// opening bracket
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid GraphQL batch: %w", err)
+	}
+
+	var batch []graphqlRequest
+
+	for dec.More() {
+		if exceeds(len(batch)+1, maxBatch) {
+			return nil, fmt.Errorf("GraphQL batch has more than %d requests", maxBatch)
+		}
+
+		var req graphqlRequest
+		if err := dec.Decode(&req); err != nil {
+			return nil, fmt.Errorf("invalid GraphQL batch: %w", err)
+		}
+
+		batch = append(batch, req)
+	}
+
+	// closing bracket
+	if _, err := dec.Token(); err != nil {
+		return nil, fmt.Errorf("invalid GraphQL batch: %w", err)
+	}
+
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid GraphQL batch: unexpected data after the batch")
+	}
+
+	return batch, nil
+}
+
+// jsonDepth returns the deepest nesting of objects and arrays of a JSON document, without decoding it
+func jsonDepth(body string) int {
+	depth, deepest := 0, 0
+	inString, escaped := false, false
+
+	for i := range len(body) {
+		c := body[i]
+
+		switch {
+		case escaped:
+			escaped = false
+		case inString:
+			if c == '\\' {
+				escaped = true
+			} else if c == '"' {
+				inString = false
+			}
+		case c == '"':
+			inString = true
+		case c == '{' || c == '[':
+			depth++
+			deepest = max(deepest, depth)
+		case c == '}' || c == ']':
+			depth--
+		}
+	}
+
+	return deepest
+}
+
+var _ plugintypes.BodyProcessor = &graphqlBodyProcessor{}
+
+//nolint:gochecknoinits //Coraza recommends to use init() for registering plugins
+func init() {
+	plugins.RegisterBodyProcessor("graphql", func() plugintypes.BodyProcessor {
+		return &graphqlBodyProcessor{}
+	})
+}
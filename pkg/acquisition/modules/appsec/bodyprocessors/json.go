@@ -0,0 +1,173 @@
+package bodyprocessors
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/corazawaf/coraza/v3/collection"
+	"github.com/corazawaf/coraza/v3/experimental/plugins"
+	"github.com/corazawaf/coraza/v3/experimental/plugins/plugintypes"
+)
+
+// jsonBodyProcessor is a stricter version of the json processor of Coraza, registered as json_ext
+// (ctl:requestBodyProcessor=JSON_EXT). It has the same variables (json.a.b, json.items.0, and the
+// length of the arrays under their key), but the body is decoded as a stream, with limits on the
+// nesting and the number of values, and invalid documents are reported as a body processing error.
+type jsonBodyProcessor struct{}
+
+type jsonReader struct {
+	dec      *json.Decoder
+	col      collection.Map
+	maxDepth int
+	maxArgs  int
+	args     int
+}
+
+func (*jsonBodyProcessor) ProcessRequest(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	return readJSON(reader, v.ArgsPost(), v, "json")
+}
+
+func (*jsonBodyProcessor) ProcessResponse(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	return readJSON(reader, v.ResponseArgs(), v, "json")
+}
+
+// readJSON stores the values of the document in col, with their path from key
+func readJSON(reader io.Reader, col collection.Map, v plugintypes.TransactionVariables, key string) error {
+	dec := json.NewDecoder(reader)
+	dec.UseNumber()
+
+	r := &jsonReader{
+		dec:      dec,
+		col:      col,
+		maxDepth: limit(v, JSONMaxDepthVariable, DefaultJSONMaxDepth),
+		maxArgs:  limit(v, JSONMaxArgsVariable, DefaultJSONMaxArgs),
+	}
+
+	tok, err := dec.Token()
+	if errors.Is(err, io.EOF) {
+		// empty body
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	if err := r.readToken(tok, []byte(key), 0); err != nil {
+		return err
+	}
+
+	// don't let a second document hide behind the first one
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid JSON: unexpected data after the document")
+	}
+
+	return nil
+}
+
+func (r *jsonReader) set(key []byte, value string) error {
+	r.args++
+	if exceeds(r.args, r.maxArgs) {
+		return fmt.Errorf("JSON body has more than %d values", r.maxArgs)
+	}
+
+	r.col.SetIndex(string(key), 0, value)
+
+	return nil
+}
+
+func (r *jsonReader) readValue(key []byte, depth int) error {
+	tok, err := r.dec.Token()
+	if err != nil {
+		return fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	return r.readToken(tok, key, depth)
+}
+
+func (r *jsonReader) readToken(tok json.Token, key []byte, depth int) error {
+	switch t := tok.(type) {
+	case json.Delim:
+		depth++
+		if exceeds(depth, r.maxDepth) {
+			return fmt.Errorf("JSON body is nested deeper than %d levels", r.maxDepth)
+		}
+
+		if t == '{' {
+			return r.readObject(key, depth)
+		}
+
+		return r.readArray(key, depth)
+	case string:
+		return r.set(key, t)
+	case json.Number:
+		return r.set(key, t.String())
+	case bool:
+		return r.set(key, strconv.FormatBool(t))
+	case nil:
+		return r.set(key, "")
+	}
+
+	return fmt.Errorf("invalid JSON: unexpected token %v", tok)
+}
+
+func (r *jsonReader) readObject(key []byte, depth int) error {
+	for r.dec.More() {
+		tok, err := r.dec.Token()
+		if err != nil {
+			return fmt.Errorf("invalid JSON: %w", err)
+		}
+
+		name, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("invalid JSON: unexpected key %v", tok)
+		}
+
+		// the key buffer is shared by the children, and truncated back once they are read
+		child := append(append(key, '.'), name...)
+		if err := r.readValue(child, depth); err != nil {
+			return err
+		}
+	}
+
+	// closing brace
+	_, err := r.dec.Token()
+
+	return err
+}
+
+func (r *jsonReader) readArray(key []byte, depth int) error {
+	length := 0
+
+	for r.dec.More() {
+		child := strconv.AppendInt(append(key, '.'), int64(length), 10)
+		if err := r.readValue(child, depth); err != nil {
+			return err
+		}
+
+		length++
+	}
+
+	// closing bracket
+	if _, err := r.dec.Token(); err != nil {
+		return err
+	}
+
+	if length > 0 {
+		return r.set(key, strconv.Itoa(length))
+	}
+
+	return nil
+}
+
+var _ plugintypes.BodyProcessor = &jsonBodyProcessor{}
+
+//nolint:gochecknoinits //Coraza recommends to use init() for registering plugins
+func init() {
+	plugins.RegisterBodyProcessor("json_ext", func() plugintypes.BodyProcessor {
+		return &jsonBodyProcessor{}
+	})
+}
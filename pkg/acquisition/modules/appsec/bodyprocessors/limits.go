@@ -0,0 +1,51 @@
+package bodyprocessors
+
+import (
+	"strconv"
+
+	"github.com/corazawaf/coraza/v3/experimental/plugins/plugintypes"
+)
+
+// The limits of the json_ext, xml_ext, multipart_ext and graphql processors are read from transaction
+// variables, so that they can be set by the appsec-config for each transaction, or overridden by
+// the rules with setvar. A limit of 0 disables the check.
+const (
+	JSONMaxDepthVariable         = "crowdsec_json_max_depth"
+	JSONMaxArgsVariable          = "crowdsec_json_max_args"
+	XMLMaxDepthVariable          = "crowdsec_xml_max_depth"
+	XMLMaxElementsVariable       = "crowdsec_xml_max_elements"
+	MultipartMaxPartsVariable    = "crowdsec_multipart_max_parts"
+	MultipartMaxPartSizeVariable = "crowdsec_multipart_max_part_size"
+	GraphQLMaxBatchVariable      = "crowdsec_graphql_max_batch"
+)
+
+const (
+	DefaultJSONMaxDepth      = 64
+	DefaultJSONMaxArgs       = 10000
+	DefaultXMLMaxDepth       = 64
+	DefaultXMLMaxElements    = 10000
+	DefaultMultipartMaxParts = 1000
+	DefaultGraphQLMaxBatch   = 100
+	// the size of the parts is only bounded by the request body limit by default
+	DefaultMultipartMaxPartSize = 0
+)
+
+// limit returns the value of the limit variable of the transaction, or its default value if unset or invalid
+func limit(v plugintypes.TransactionVariables, variable string, defaultValue int) int {
+	values := v.TX().Get(variable)
+	if len(values) == 0 {
+		return defaultValue
+	}
+
+	ret, err := strconv.Atoi(values[0])
+	if err != nil || ret < 0 {
+		return defaultValue
+	}
+
+	return ret
+}
+
+// exceeds tells if a count is over a limit, 0 meaning no limit
+func exceeds(count int, limit int) bool {
+	return limit > 0 && count > limit
+}
@@ -0,0 +1,136 @@
+package bodyprocessors
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"mime/multipart"
+	"strconv"
+	"strings"
+
+	"github.com/corazawaf/coraza/v3/experimental/plugins"
+	"github.com/corazawaf/coraza/v3/experimental/plugins/plugintypes"
+)
+
+// multipartBodyProcessor is a stricter version of the multipart processor of Coraza, registered as
+// multipart_ext (ctl:requestBodyProcessor=MULTIPART_EXT). It has the same variables, but the uploaded
+// files are never written to disk (FILES_TMPNAMES stays empty), and the number and size of the parts are limited.
+type multipartBodyProcessor struct{}
+
+func (*multipartBodyProcessor) ProcessRequest(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	err := readMultipart(reader, v, options.Mime)
+	if err != nil {
+		v.MultipartStrictError().(setterInterface).Set("1")
+	}
+
+	return err
+}
+
+func (*multipartBodyProcessor) ProcessResponse(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	return nil
+}
+
+func readMultipart(reader io.Reader, v plugintypes.TransactionVariables, mimeType string) error {
+	mediaType, params, err := mime.ParseMediaType(mimeType)
+	if err != nil {
+		return err
+	}
+
+	if !strings.HasPrefix(mediaType, "multipart/") {
+		return errors.New("not a multipart body")
+	}
+
+	maxParts := limit(v, MultipartMaxPartsVariable, DefaultMultipartMaxParts)
+	maxPartSize := limit(v, MultipartMaxPartSizeVariable, DefaultMultipartMaxPartSize)
+
+	mr := multipart.NewReader(reader, params["boundary"])
+	totalSize := int64(0)
+	parts := 0
+
+	for {
+		p, err := mr.NextPart()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		parts++
+		if exceeds(parts, maxParts) {
+			return fmt.Errorf("multipart body has more than %d parts", maxParts)
+		}
+
+		partName := p.FormName()
+
+		for key, values := range p.Header {
+			for _, value := range values {
+				v.MultipartPartHeaders().Add(partName, fmt.Sprintf("%s: %s", key, value))
+			}
+		}
+
+		// read one byte over the limit to detect the parts exceeding it
+		var part io.Reader = p
+		if maxPartSize > 0 {
+			part = io.LimitReader(p, int64(maxPartSize)+1)
+		}
+
+		filename := originFileName(p)
+
+		var (
+			data []byte
+			size int64
+		)
+
+		if filename != "" {
+			// the content of the files is only measured
+			size, err = io.Copy(io.Discard, part)
+		} else {
+			data, err = io.ReadAll(part)
+			size = int64(len(data))
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if exceeds(int(size), maxPartSize) {
+			return fmt.Errorf("multipart part %s is larger than %d bytes", partName, maxPartSize)
+		}
+
+		if filename != "" {
+			v.Files().Add("", filename)
+			v.FilesSizes().SetIndex(filename, 0, strconv.FormatInt(size, 10))
+			v.FilesNames().Add("", partName)
+		} else {
+			v.ArgsPost().Add(partName, string(data))
+		}
+
+		totalSize += size
+		v.FilesCombinedSize().(setterInterface).Set(strconv.FormatInt(totalSize, 10))
+	}
+
+	return nil
+}
+
+// originFileName returns the filename parameter of the Content-Disposition header of the part,
+// unlike (*multipart.Part).FileName it is not reduced to its base name: the path is part of the payload.
+func originFileName(p *multipart.Part) string {
+	_, params, err := mime.ParseMediaType(p.Header.Get("Content-Disposition"))
+	if err != nil {
+		return ""
+	}
+
+	return params["filename"]
+}
+
+var _ plugintypes.BodyProcessor = &multipartBodyProcessor{}
+
+//nolint:gochecknoinits //Coraza recommends to use init() for registering plugins
+func init() {
+	plugins.RegisterBodyProcessor("multipart_ext", func() plugintypes.BodyProcessor {
+		return &multipartBodyProcessor{}
+	})
+}
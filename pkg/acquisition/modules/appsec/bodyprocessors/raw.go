@@ -29,6 +29,15 @@ func (*rawBodyProcessor) ProcessRequest(reader io.Reader, v plugintypes.Transact
 }
 
 func (*rawBodyProcessor) ProcessResponse(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	buf := new(strings.Builder)
+	if _, err := io.Copy(buf, reader); err != nil {
+		return err
+	}
+
+	b := buf.String()
+
+	v.ResponseBody().(setterInterface).Set(b)
+	v.ResponseContentLength().(setterInterface).Set(strconv.Itoa(len(b)))
 	return nil
 }
 
@@ -0,0 +1,96 @@
+package bodyprocessors
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/corazawaf/coraza/v3/experimental/plugins"
+	"github.com/corazawaf/coraza/v3/experimental/plugins/plugintypes"
+)
+
+// xmlBodyProcessor is a stricter version of the xml processor of Coraza, registered as xml_ext
+// (ctl:requestBodyProcessor=XML_EXT). It has the same variables (XML://@* for the values of the
+// attributes, XML:/* for the text content), and limits on the nesting and the number of elements.
+type xmlBodyProcessor struct{}
+
+func (*xmlBodyProcessor) ProcessRequest(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	attrs, contents, err := readXML(reader,
+		limit(v, XMLMaxDepthVariable, DefaultXMLMaxDepth),
+		limit(v, XMLMaxElementsVariable, DefaultXMLMaxElements))
+	if err != nil {
+		return err
+	}
+
+	col := v.RequestXML()
+	col.Set("//@*", attrs)
+	col.Set("/*", contents)
+
+	return nil
+}
+
+func (*xmlBodyProcessor) ProcessResponse(reader io.Reader, v plugintypes.TransactionVariables, options plugintypes.BodyProcessorOptions) error {
+	return nil
+}
+
+func readXML(reader io.Reader, maxDepth int, maxElements int) ([]string, []string, error) {
+	var (
+		attrs    []string
+		contents []string
+		depth    int
+		elements int
+	)
+
+	dec := xml.NewDecoder(reader)
+	dec.Strict = false
+	dec.AutoClose = xml.HTMLAutoClose
+	dec.Entity = xml.HTMLEntity
+
+	for {
+		token, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch tok := token.(type) {
+		case xml.StartElement:
+			depth++
+			if exceeds(depth, maxDepth) {
+				return nil, nil, fmt.Errorf("XML body is nested deeper than %d levels", maxDepth)
+			}
+
+			elements++
+			if exceeds(elements, maxElements) {
+				return nil, nil, fmt.Errorf("XML body has more than %d elements", maxElements)
+			}
+
+			for _, attr := range tok.Attr {
+				attrs = append(attrs, attr.Value)
+			}
+		case xml.EndElement:
+			// the decoder is not strict, don't trust the end tags to be balanced
+			depth = max(depth-1, 0)
+		case xml.CharData:
+			if c := strings.TrimSpace(string(tok)); c != "" {
+				contents = append(contents, c)
+			}
+		}
+	}
+
+	return attrs, contents, nil
+}
+
+var _ plugintypes.BodyProcessor = &xmlBodyProcessor{}
+
+//nolint:gochecknoinits //Coraza recommends to use init() for registering plugins
+func init() {
+	plugins.RegisterBodyProcessor("xml_ext", func() plugintypes.BodyProcessor {
+		return &xmlBodyProcessor{}
+	})
+}
@@ -0,0 +1,267 @@
+package appsecacquisition
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/tomb.v2"
+
+	"github.com/crowdsecurity/go-cs-lib/trace"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+const (
+	defaultForwardInterval = 1 * time.Second
+	forwardTimeout         = 10 * time.Second
+	maxForwardBatch        = 500       // events per request
+	forwardQueueSize       = 1000      // events waiting for the flusher, the runners never wait on the remote agent
+	maxForwardBacklog      = 10000     // events kept while the remote agent can't be reached
+	maxForwardBodySize     = 128 << 20 // accepted by the events endpoint
+)
+
+// ForwardConfig is used by standalone appsec components (crowdsec -appsec-only), which have no
+// scenario: the log events are sent to the events_path of the appsec datasource of a remote agent.
+// The alerts are still sent to LAPI.
+type ForwardConfig struct {
+	URL                string        `yaml:"url"`
+	APIKey             string        `yaml:"api_key"` // the events_api_key of the remote agent
+	CACertPath         string        `yaml:"ca_cert_path"`
+	InsecureSkipVerify bool          `yaml:"insecure_skip_verify"`
+	FlushInterval      time.Duration `yaml:"flush_interval"`
+}
+
+type forwarder struct {
+	url      string
+	apiKey   string
+	interval time.Duration
+	client   *http.Client
+	logger   *log.Entry
+	queue    chan types.Event
+	pending  []types.Event
+}
+
+func newForwarder(cfg *ForwardConfig, logger *log.Entry) (*forwarder, error) {
+	caCertPool, err := loadCertPool(cfg.CACertPath, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	interval := cfg.FlushInterval
+	if interval <= 0 {
+		interval = defaultForwardInterval
+	}
+
+	return &forwarder{
+		url:      cfg.URL,
+		apiKey:   cfg.APIKey,
+		interval: interval,
+		client: &http.Client{
+			Timeout: forwardTimeout,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{
+					RootCAs:            caCertPool,
+					InsecureSkipVerify: cfg.InsecureSkipVerify, //nolint:gosec // explicitly set by the user
+				},
+			},
+		},
+		logger: logger,
+		queue:  make(chan types.Event, forwardQueueSize),
+	}, nil
+}
+
+// Run queues the log events of the runners for the remote agent, the alerts are passed to out.
+// The events are sent by another goroutine: when the remote agent is slow and the queue is full,
+// they are dropped instead of blocking the runners.
+func (f *forwarder) Run(in chan types.Event, out chan types.Event, t *tomb.Tomb) error {
+	t.Go(func() error {
+		defer trace.CatchPanic("crowdsec/acquis/appsec/forwarder/flush")
+		return f.flushLoop(t)
+	})
+
+	for {
+		select {
+		case evt := <-in:
+			if evt.Type != types.LOG {
+				out <- evt
+				continue
+			}
+
+			f.enqueue(evt)
+		case <-t.Dying():
+			return nil
+		}
+	}
+}
+
+func (f *forwarder) enqueue(evt types.Event) {
+	select {
+	case f.queue <- evt:
+	default:
+		AppsecForwardDropped.Inc()
+		f.logger.Debugf("forwarding queue is full, dropping event")
+	}
+}
+
+// flushLoop batches the queued events to the remote agent
+func (f *forwarder) flushLoop(t *tomb.Tomb) error {
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case evt := <-f.queue:
+			f.pending = append(f.pending, evt)
+
+			if len(f.pending) >= maxForwardBatch {
+				f.flush()
+			}
+		case <-ticker.C:
+			f.flush()
+		case <-t.Dying():
+			for len(f.queue) > 0 {
+				f.pending = append(f.pending, <-f.queue)
+			}
+
+			f.flush()
+
+			return nil
+		}
+	}
+}
+
+func (f *forwarder) flush() {
+	for len(f.pending) > 0 {
+		batch := f.pending[:min(len(f.pending), maxForwardBatch)]
+
+		if err := f.send(batch); err != nil {
+			f.logger.Errorf("unable to forward %d events: %s", len(f.pending), err)
+
+			if dropped := len(f.pending) - maxForwardBacklog; dropped > 0 {
+				f.logger.Warningf("dropping %d events", dropped)
+				AppsecForwardDropped.Add(float64(dropped))
+				f.pending = f.pending[dropped:]
+			}
+
+			return
+		}
+
+		f.pending = f.pending[len(batch):]
+	}
+}
+
+func (f *forwarder) send(events []types.Event) error {
+	body, err := json.Marshal(events)
+	if err != nil {
+		return fmt.Errorf("unable to serialize events: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), forwardTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(appsec.APIKeyHeaderName, f.apiKey)
+
+	resp, err := f.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	f.logger.Debugf("forwarded %d events", len(events))
+
+	return nil
+}
+
+// eventsHandler accepts the log events forwarded by standalone appsec components, they go to
+// the scenarios as if they had been generated by this datasource
+func (w *AppsecSource) eventsHandler(rw http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		rw.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	// the bouncer keys are not enough: the events feed the scenarios of this agent
+	apiKey := r.Header.Get(appsec.APIKeyHeaderName)
+	if apiKey == "" || subtle.ConstantTimeCompare([]byte(apiKey), []byte(w.config.EventsAPIKey)) != 1 {
+		w.logger.Errorf("Unauthorized forwarded events from '%s'", r.RemoteAddr)
+		rw.WriteHeader(http.StatusUnauthorized)
+
+		return
+	}
+
+	var events []types.Event
+
+	if err := json.NewDecoder(http.MaxBytesReader(rw, r.Body, maxForwardBodySize)).Decode(&events); err != nil {
+		w.logger.Errorf("invalid forwarded events from '%s': %s", r.RemoteAddr, err)
+		rw.WriteHeader(http.StatusBadRequest)
+
+		return
+	}
+
+	w.logger.Debugf("received %d forwarded events from '%s'", len(events), r.RemoteAddr)
+
+	for _, evt := range events {
+		// the alerts are sent to LAPI by the standalone component
+		if evt.Type != types.LOG {
+			continue
+		}
+
+		restoreMatchedRules(evt.Appsec.MatchedRules)
+
+		evt.Process = true
+		evt.ExpectMode = types.LIVE
+		evt.Line.Labels = w.config.Labels
+
+		w.outChan <- evt
+	}
+
+	rw.WriteHeader(http.StatusOK)
+}
+
+// restoreMatchedRules gives back their types to the fields of the rules that went through json,
+// the helpers of the scenarios rely on them
+func restoreMatchedRules(rules types.MatchedRules) {
+	for _, rule := range rules {
+		for _, field := range []string{"id", "file_line", "accuracy"} {
+			if v, ok := rule[field].(float64); ok {
+				rule[field] = int(v)
+			}
+		}
+
+		for _, field := range []string{"tags", "matched_zones"} {
+			values, _ := rule[field].([]any)
+
+			strs := make([]string, 0, len(values))
+
+			for _, v := range values {
+				if s, ok := v.(string); ok {
+					strs = append(strs, s)
+				}
+			}
+
+			rule[field] = strs
+		}
+	}
+}
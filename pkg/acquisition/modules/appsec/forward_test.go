@@ -0,0 +1,152 @@
+package appsecacquisition
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/tomb.v2"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// newEventsReceiver returns the datasource of a remote agent accepting the forwarded events with a known API key
+func newEventsReceiver(t *testing.T) (*AppsecSource, *httptest.Server) {
+	w := &AppsecSource{
+		config: AppsecSourceConfig{
+			AuthCacheDuration: &DefaultAuthCacheDuration,
+			EventsAPIKey:      "valid-key",
+		},
+		logger:    log.WithField("test", t.Name()),
+		AuthCache: NewAuthCache(),
+		outChan:   make(chan types.Event, 10),
+	}
+
+	w.config.Labels = map[string]string{"type": "appsec"}
+	// a valid bouncer key
+	w.AuthCache.Set("bouncer-key", time.Now().Add(time.Hour))
+
+	server := httptest.NewServer(http.HandlerFunc(w.eventsHandler))
+	t.Cleanup(server.Close)
+
+	return w, server
+}
+
+func TestForwardEvents(t *testing.T) {
+	receiver, server := newEventsReceiver(t)
+
+	f, err := newForwarder(&ForwardConfig{URL: server.URL, APIKey: "valid-key"}, log.WithField("test", t.Name()))
+	require.NoError(t, err)
+
+	logEvent := types.MakeEvent(false, types.LOG, true)
+	logEvent.Parsed["source_ip"] = "1.2.3.4"
+	logEvent.Line = types.Line{Module: "appsec", Src: "appsec", Raw: "dummy-appsec-data", Labels: map[string]string{"type": "other"}}
+	logEvent.Appsec.MatchedRules = types.MatchedRules{{
+		"id":            1001,
+		"name":          "rule-1001",
+		"tags":          []string{"attack-sqli"},
+		"matched_zones": []string{"ARGS.q"},
+	}}
+
+	alert := types.MakeEvent(false, types.APPSEC, true)
+
+	in := make(chan types.Event)
+	out := make(chan types.Event, 1)
+
+	var tb tomb.Tomb
+
+	tb.Go(func() error {
+		return f.Run(in, out, &tb)
+	})
+
+	in <- logEvent
+	in <- alert
+
+	// the alerts stay local, they go to LAPI
+	require.Equal(t, types.APPSEC, (<-out).Type)
+
+	tb.Kill(nil)
+	require.NoError(t, tb.Wait())
+
+	require.Len(t, receiver.outChan, 1)
+
+	evt := <-receiver.outChan
+	assert.Equal(t, types.LOG, evt.Type)
+	assert.True(t, evt.Process)
+	assert.Equal(t, "1.2.3.4", evt.Parsed["source_ip"])
+	assert.Equal(t, "appsec", evt.Line.Module)
+	assert.Equal(t, map[string]string{"type": "appsec"}, evt.Line.Labels)
+	assert.Equal(t, []int{1001}, evt.Appsec.MatchedRules.GetRuleIDs())
+	assert.Equal(t, "rule-1001", evt.Appsec.MatchedRules.GetName())
+	assert.Len(t, evt.Appsec.MatchedRules.ByTag("attack-sqli"), 1)
+	assert.Equal(t, []string{"ARGS.q"}, evt.Appsec.MatchedRules.GetMatchedZones())
+}
+
+func TestForwardEventsRejected(t *testing.T) {
+	receiver, server := newEventsReceiver(t)
+
+	f, err := newForwarder(&ForwardConfig{URL: server.URL, APIKey: "valid-key"}, log.WithField("test", t.Name()))
+	require.NoError(t, err)
+
+	// no api key
+	resp, err := http.Post(server.URL, "application/json", strings.NewReader("[]"))
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	// the bouncer keys are not accepted
+	req, err := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("[]"))
+	require.NoError(t, err)
+	req.Header.Set(appsec.APIKeyHeaderName, "bouncer-key")
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err = http.NewRequest(http.MethodPost, server.URL, strings.NewReader("not json"))
+	require.NoError(t, err)
+	req.Header.Set(appsec.APIKeyHeaderName, "valid-key")
+
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	// the events are kept until they are accepted
+	server.Config.Handler = http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusUnauthorized)
+	})
+
+	f.pending = []types.Event{types.MakeEvent(false, types.LOG, true)}
+	f.flush()
+	require.Len(t, f.pending, 1)
+
+	server.Config.Handler = http.HandlerFunc(receiver.eventsHandler)
+
+	f.flush()
+	require.Empty(t, f.pending)
+	require.Len(t, receiver.outChan, 1)
+}
+
+func TestForwardQueueFull(t *testing.T) {
+	f, err := newForwarder(&ForwardConfig{URL: "http://127.0.0.1:1", APIKey: "valid-key"}, log.WithField("test", t.Name()))
+	require.NoError(t, err)
+
+	dropped := testutil.ToFloat64(AppsecForwardDropped)
+
+	// nothing reads the queue: the events are dropped instead of blocking the runners
+	for range forwardQueueSize + 10 {
+		f.enqueue(types.MakeEvent(false, types.LOG, true))
+	}
+
+	assert.Len(t, f.queue, forwardQueueSize)
+	assert.InDelta(t, dropped+10, testutil.ToFloat64(AppsecForwardDropped), 0)
+}
@@ -0,0 +1,57 @@
+package appsecacquisition
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/corazawaf/coraza/v3/experimental/plugins"
+	"github.com/corazawaf/coraza/v3/experimental/plugins/plugintypes"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/graphql"
+)
+
+// graphqlLimit matches the GraphQL queries exceeding a limit, e.g. "@graphqlDepth 10".
+// It can be used on ARGS_POST:graphql.query, or on ARGS_POST:json.query without the graphql body processor.
+// Values that are not valid queries don't match, the graphql body processor reports them as body errors.
+type graphqlLimit struct {
+	limit  int
+	metric func(*graphql.Query) int
+}
+
+var _ plugintypes.Operator = (*graphqlLimit)(nil)
+
+func newGraphQLLimit(metric func(*graphql.Query) int) plugintypes.OperatorFactory {
+	return func(options plugintypes.OperatorOptions) (plugintypes.Operator, error) {
+		limit, err := strconv.Atoi(strings.TrimSpace(options.Arguments))
+		if err != nil {
+			return nil, fmt.Errorf("invalid limit %q: %w", options.Arguments, err)
+		}
+
+		return &graphqlLimit{limit: limit, metric: metric}, nil
+	}
+}
+
+func (o *graphqlLimit) Evaluate(tx plugintypes.TransactionState, value string) bool {
+	query, err := graphql.Parse(value, "")
+	if err != nil {
+		return false
+	}
+
+	n := o.metric(query)
+	if n <= o.limit {
+		return false
+	}
+
+	if tx.Capturing() {
+		tx.CaptureField(0, strconv.Itoa(n))
+	}
+
+	return true
+}
+
+//nolint:gochecknoinits //Coraza recommends to use init() for registering plugins
+func init() {
+	plugins.RegisterOperator("graphqlDepth", newGraphQLLimit(func(q *graphql.Query) int { return q.Depth }))
+	plugins.RegisterOperator("graphqlComplexity", newGraphQLLimit(func(q *graphql.Query) int { return q.Complexity }))
+}
@@ -52,3 +52,10 @@ var AppsecRuleHits = prometheus.NewCounterVec(
 	},
 	[]string{"rule_name", "type", "appsec_engine", "source"},
 )
+
+var AppsecForwardDropped = prometheus.NewCounter(
+	prometheus.CounterOpts{
+		Name: "cs_appsec_forward_dropped_total",
+		Help: "Log events dropped because the forwarding buffer was full.",
+	},
+)
@@ -0,0 +1,157 @@
+package appsecacquisition
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/allowlists"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// ReplayedRule is a rule matched by a replayed request
+type ReplayedRule struct {
+	ID         int
+	Message    string
+	Band       string
+	Disruptive bool
+	Targets    []string // ZONE:variable
+}
+
+// ReplayResult explains how a captured request is evaluated
+type ReplayResult struct {
+	Captured           appsec.CapturedRequest
+	Rules              []ReplayedRule
+	Hooks              []string                  // stage: filter
+	Response           appsec.AppsecTempResponse // returned in band
+	OutOfBandInterrupt bool
+	Err                error
+}
+
+// Replay evaluates captured requests with an appsec configuration, like the datasource does,
+// but without sending events and with the learning mode and the capture disabled
+func Replay(appsecCfg *appsec.AppsecConfig, captured []appsec.CapturedRequest, dataDir string) ([]ReplayResult, error) {
+	logger := appsecCfg.Logger
+
+	appsecCfg.Learning.Enabled = false
+	appsecCfg.Capture.Enabled = false
+
+	runtime, err := appsecCfg.Build()
+	if err != nil {
+		return nil, fmt.Errorf("unable to build appsec runtime: %w", err)
+	}
+
+	if err := runtime.ProcessOnLoadRules(); err != nil {
+		return nil, fmt.Errorf("unable to process on_load rules: %w", err)
+	}
+
+	outChan := make(chan types.Event)
+	defer close(outChan)
+
+	// the events are what the appsec component would send to crowdsec, they are not needed here
+	go func() {
+		for range outChan {
+		}
+	}()
+
+	wrt := *runtime
+	runner := AppsecRunner{
+		UUID:                   uuid.New().String(),
+		logger:                 logger.WithField("runner_uuid", "replay"),
+		AppsecRuntime:          &wrt,
+		Labels:                 map[string]string{},
+		outChan:                outChan,
+		appsecAllowlistsClient: allowlists.NewAppsecAllowlist(logger),
+	}
+
+	if err := runner.Init(dataDir); err != nil {
+		return nil, fmt.Errorf("unable to initialize runner: %w", err)
+	}
+
+	var current *ReplayResult
+
+	wrt.HookTracer = func(stage string, hook *appsec.Hook) {
+		if current != nil {
+			current.Hooks = append(current.Hooks, stage+": "+cmp.Or(hook.Filter, "(no filter)"))
+		}
+	}
+
+	runner.txObserver = func(request *appsec.ParsedRequest) {
+		current.Rules = append(current.Rules, matchedRules(request)...)
+	}
+
+	results := make([]ReplayResult, 0, len(captured))
+
+	for _, c := range captured {
+		results = append(results, ReplayResult{Captured: c})
+		current = &results[len(results)-1]
+
+		httpReq, err := c.HTTPRequest()
+		if err != nil {
+			current.Err = err
+			continue
+		}
+
+		request, err := appsec.NewParsedRequestFromRequest(httpReq, logger)
+		if err != nil {
+			current.Err = err
+			continue
+		}
+
+		// sent by the runner before the out of band evaluation
+		request.ResponseChannel = make(chan appsec.AppsecTempResponse, 1)
+
+		runner.handleRequest(&request)
+
+		select {
+		case current.Response = <-request.ResponseChannel:
+			current.OutOfBandInterrupt = wrt.Response.OutOfBandInterrupt
+		default:
+			current.Err = errors.New("unable to evaluate the request, see the logs")
+		}
+	}
+
+	return results, nil
+}
+
+func matchedRules(request *appsec.ParsedRequest) []ReplayedRule {
+	band := "outofband"
+	if request.IsInBand {
+		band = "inband"
+	}
+
+	var ret []ReplayedRule
+
+	for _, rule := range request.Tx.Tx.MatchedRules() {
+		// the internal rules of the CRS
+		if rule.Message() == "" {
+			continue
+		}
+
+		matched := ReplayedRule{
+			ID:         rule.Rule().ID(),
+			Message:    rule.Message(),
+			Band:       band,
+			Disruptive: rule.Disruptive(),
+		}
+
+		for _, data := range rule.MatchedDatas() {
+			target := data.Variable().Name()
+			if data.Key() != "" {
+				target += ":" + data.Key()
+			}
+
+			if !slices.Contains(matched.Targets, target) {
+				matched.Targets = append(matched.Targets, target)
+			}
+		}
+
+		ret = append(ret, matched)
+	}
+
+	return ret
+}
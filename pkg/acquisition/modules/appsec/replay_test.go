@@ -0,0 +1,65 @@
+package appsecacquisition
+
+import (
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+)
+
+func TestReplay(t *testing.T) {
+	cfg := appsec.AppsecConfig{
+		Logger:     log.WithField("test", "replay"),
+		RateLimits: []appsec.RateLimit{{Name: "per-ip", Capacity: 1, LeakSpeed: "1h", Remediation: appsec.CaptchaRemediation}},
+		PreEval: []appsec.Hook{
+			{Filter: `req.RequestURI startsWith "/admin"`, Apply: []string{`SetRemediationByName("ratelimit:per-ip", "ban")`}},
+		},
+		// the capture is not used when replaying
+		Capture: appsec.CaptureConfig{Enabled: true, Path: "/nonexistent/capture.jsonl"},
+	}
+
+	request := func(uri string) appsec.CapturedRequest {
+		return appsec.CapturedRequest{
+			Reason: appsec.CaptureSampled,
+			Request: &appsec.ParsedRequest{
+				ClientIP:   "1.2.3.4",
+				URI:        uri,
+				Method:     "GET",
+				ClientHost: "example.com",
+				Proto:      "HTTP/1.1",
+			},
+		}
+	}
+
+	results, err := Replay(&cfg, []appsec.CapturedRequest{
+		request("/"),
+		request("/"),
+		request("/admin"),
+		{Request: &appsec.ParsedRequest{}},
+	}, t.TempDir())
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	require.NoError(t, results[0].Err)
+	assert.False(t, results[0].Response.InBandInterrupt)
+	assert.Equal(t, appsec.AllowRemediation, results[0].Response.Action)
+	assert.Empty(t, results[0].Rules)
+	assert.Empty(t, results[0].Hooks)
+
+	require.NoError(t, results[1].Err)
+	assert.True(t, results[1].Response.InBandInterrupt)
+	assert.Equal(t, appsec.CaptchaRemediation, results[1].Response.Action)
+	require.Len(t, results[1].Rules, 1)
+	assert.Equal(t, "ratelimit:per-ip", results[1].Rules[0].Message)
+	assert.Equal(t, "inband", results[1].Rules[0].Band)
+	assert.True(t, results[1].Rules[0].Disruptive)
+
+	require.NoError(t, results[2].Err)
+	assert.Equal(t, appsec.BanRemediation, results[2].Response.Action)
+	assert.Equal(t, []string{`pre_eval: req.RequestURI startsWith "/admin"`}, results[2].Hooks)
+
+	require.ErrorContains(t, results[3].Err, "missing 'X-Crowdsec-Appsec-Ip' header")
+}
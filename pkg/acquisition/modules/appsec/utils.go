@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/oschwald/geoip2-golang"
@@ -128,10 +129,19 @@ func EventFromRequest(r *appsec.ParsedRequest, labels map[string]string) (types.
 		"source":              "crowdsec-appsec",
 		"remediation_cmpt_ip": r.RemoteAddrNormalized,
 		// TBD:
-		// http_status
 		// user_agent
 
 	}
+	if r.IsResponse {
+		evt.Parsed["http_status"] = strconv.Itoa(r.ResponseCode)
+	}
+	if r.Bot.Class != "" {
+		evt.Parsed["bot_score"] = strconv.Itoa(r.Bot.Score)
+		evt.Parsed["bot_class"] = r.Bot.Class
+	}
+	for name, value := range r.Identity {
+		evt.Parsed[appsec.IdentityVariablePrefix+name] = value
+	}
 	evt.Line = types.Line{
 		Time: time.Now(),
 		// should we add some info like listen addr/port/path ?
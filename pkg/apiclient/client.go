@@ -46,6 +46,7 @@ type ApiClient struct {
 	Signal         *SignalService
 	HeartBeat      *HeartBeatService
 	UsageMetrics   *UsageMetricsService
+	Federation     *FederationService
 }
 
 func (a *ApiClient) GetClient() *http.Client {
@@ -189,6 +190,7 @@ func NewClient(config *Config) (*ApiClient, error) {
 	c.DecisionDelete = (*DecisionDeleteService)(&c.common)
 	c.HeartBeat = (*HeartBeatService)(&c.common)
 	c.UsageMetrics = (*UsageMetricsService)(&c.common)
+	c.Federation = (*FederationService)(&c.common)
 
 	return c, nil
 }
@@ -232,6 +234,7 @@ func NewDefaultClient(url *url.URL, prefix string, userAgent string, client *htt
 	c.DecisionDelete = (*DecisionDeleteService)(&c.common)
 	c.HeartBeat = (*HeartBeatService)(&c.common)
 	c.UsageMetrics = (*UsageMetricsService)(&c.common)
+	c.Federation = (*FederationService)(&c.common)
 
 	return c, nil
 }
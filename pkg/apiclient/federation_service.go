@@ -0,0 +1,29 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+// FederationService is used by a LAPI to pull the decisions published by a peer LAPI
+type FederationService service
+
+func (s *FederationService) GetDecisions(ctx context.Context) ([]*models.Decision, *Response, error) {
+	u := s.client.URLPrefix + "/federation/decisions"
+
+	req, err := s.client.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	decisions := []*models.Decision{}
+
+	resp, err := s.client.Do(ctx, req, &decisions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return decisions, resp, nil
+}
@@ -16,10 +16,13 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
 	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
 	"github.com/crowdsecurity/crowdsec/pkg/csplugin"
 	"github.com/crowdsecurity/crowdsec/pkg/database"
 	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
 )
 
 const (
@@ -229,6 +232,64 @@ func TestCreateAlertChannels(t *testing.T) {
 	apiServer.Close()
 }
 
+func TestCreateAlertAggregation(t *testing.T) {
+	ctx := t.Context()
+
+	config := LoadTestConfig(t)
+
+	for _, profile := range config.API.Server.Profiles {
+		if profile.Name == "default_ip_remediation" {
+			profile.Aggregation = &csconfig.AggregationCfg{
+				Duration:         ptr.Of("24h"),
+				ExpireIndividual: true,
+				IPv4:             []csconfig.AggregationThreshold{{Prefix: 24, Threshold: 3}},
+			}
+		}
+	}
+
+	apiServer, err := NewServer(ctx, config.API.Server)
+	require.NoError(t, err)
+	require.NoError(t, apiServer.InitController())
+
+	router, err := apiServer.Router()
+	require.NoError(t, err)
+
+	apiKey, dbClient := CreateTestBouncer(t, ctx, config.API.Server.DbConfig)
+
+	lapi := LAPI{
+		router:     router,
+		loginResp:  LoginToTestAPI(t, ctx, router, config),
+		bouncerKey: apiKey,
+		DBConfig:   config.API.Server.DbConfig,
+		DBClient:   dbClient,
+	}
+
+	// a manual decision in the same network, that is not aggregated
+	w := lapi.InsertAlertFromFile(t, ctx, "./tests/alert_aggregation_manual.json")
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	// 4 alerts, but only 3 distinct addresses in 10.1.2.0/24
+	w = lapi.InsertAlertFromFile(t, ctx, "./tests/alert_aggregation.json")
+	require.Equal(t, http.StatusCreated, w.Code)
+
+	w = lapi.RecordResponse(t, ctx, http.MethodGet, "/v1/decisions/stream?startup=true", emptyBody, apiKeyAuthType)
+	decisions, code := readDecisionsStreamResp(t, w)
+	require.Equal(t, http.StatusOK, code)
+
+	require.Len(t, decisions["new"], 2)
+	assert.Equal(t, "10.1.2.9", *decisions["new"][0].Value)
+	assert.Equal(t, types.CscliOrigin, *decisions["new"][0].Origin)
+	assert.Equal(t, "10.1.2.0/24", *decisions["new"][1].Value)
+	assert.Equal(t, "Range", *decisions["new"][1].Scope)
+	// the 4 individual decisions have been expired
+	assert.Len(t, decisions["deleted"], 4)
+
+	w = lapi.RecordResponse(t, ctx, http.MethodGet, "/v1/alerts?range=10.1.2.0/24&contains=false&scope=Range", emptyBody, passwordAuthType)
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"message":"3 Ip decisions (ban) aggregated into 10.1.2.0/24 by profile default_ip_remediation"`)
+	assert.Contains(t, w.Body.String(), `{"key":"aggregated_values","value":"[\"10.1.2.3\",\"10.1.2.4\",\"10.1.2.5\"]"}`)
+}
+
 func TestAlertListFilters(t *testing.T) {
 	ctx := t.Context()
 	lapi := SetupLAPITest(t, ctx)
@@ -25,26 +25,31 @@ import (
 	v1 "github.com/crowdsecurity/crowdsec/pkg/apiserver/middlewares/v1"
 	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
 	"github.com/crowdsecurity/crowdsec/pkg/csplugin"
+	"github.com/crowdsecurity/crowdsec/pkg/cticlient"
+	"github.com/crowdsecurity/crowdsec/pkg/ctienrich"
 	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/feeds"
 	"github.com/crowdsecurity/crowdsec/pkg/types"
 )
 
 const keyLength = 32
 
 type APIServer struct {
-	URL            string
-	UnixSocket     string
-	TLS            *csconfig.TLSCfg
-	dbClient       *database.Client
-	logFile        string
-	controller     *controllers.Controller
-	flushScheduler *gocron.Scheduler
-	router         *gin.Engine
-	httpServer     *http.Server
-	apic           *apic
-	papi           *Papi
-	httpServerTomb tomb.Tomb
-	consoleConfig  *csconfig.ConsoleConfig
+	URL                  string
+	UnixSocket           string
+	TLS                  *csconfig.TLSCfg
+	dbClient             *database.Client
+	logFile              string
+	controller           *controllers.Controller
+	flushScheduler       *gocron.Scheduler
+	router               *gin.Engine
+	httpServer           *http.Server
+	apic                 *apic
+	papi                 *Papi
+	feeds                *feeds.Manager
+	httpServerTomb       tomb.Tomb
+	consoleConfig        *csconfig.ConsoleConfig
+	durableNotifications bool
 }
 
 func isBrokenConnection(maybeError any) bool {
@@ -161,6 +166,25 @@ func newGinLogger(config *csconfig.LocalApiServerCfg) (*log.Logger, string, erro
 	return clog, logFile, nil
 }
 
+// newCTIEnricher returns nil if the enrichment of the alerts is not enabled
+func newCTIEnricher(config *csconfig.CTIEnrichmentCfg, dbClient *database.Client, clog *log.Logger) *ctienrich.Enricher {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+
+	logger := clog.WithField("module", "cti-enrichment")
+
+	client := cticlient.NewCrowdsecCTIClient(
+		cticlient.WithAPIKey(config.Key),
+		cticlient.WithHTTPClient(&http.Client{Timeout: config.Timeout}),
+		cticlient.WithLogger(logger),
+	)
+
+	log.Infof("CTI enrichment of alerts enabled, cache TTL %s", config.CacheTTL)
+
+	return ctienrich.New(client, dbClient, config.CacheTTL, config.Synchronous, logger)
+}
+
 // NewServer creates a LAPI server.
 // It sets up a gin router, a database client, and a controller.
 func NewServer(ctx context.Context, config *csconfig.LocalApiServerCfg) (*APIServer, error) {
@@ -237,6 +261,11 @@ func NewServer(ctx context.Context, config *csconfig.LocalApiServerCfg) (*APISer
 		ConsoleConfig:                 config.ConsoleConfig,
 		DisableRemoteLapiRegistration: config.DisableRemoteLapiRegistration,
 		AutoRegisterCfg:               config.AutoRegister,
+		CTIEnricher:                   newCTIEnricher(config.CTIEnrichment, dbClient, clog),
+	}
+
+	if config.Federation != nil {
+		controller.FederationPublish = config.Federation.Publish
 	}
 
 	var (
@@ -282,19 +311,34 @@ func NewServer(ctx context.Context, config *csconfig.LocalApiServerCfg) (*APISer
 
 	controller.TrustedIPs = trustedIPs
 
+	var (
+		feedManager *feeds.Manager
+		peers       []*csconfig.FederationPeerCfg
+	)
+
+	if config.Federation != nil {
+		peers = config.Federation.Peers
+	}
+
+	if len(config.Feeds)+len(peers) > 0 {
+		feedManager = feeds.NewManager(config.Feeds, peers, dbClient)
+	}
+
 	return &APIServer{
-		URL:            config.ListenURI,
-		UnixSocket:     config.ListenSocket,
-		TLS:            config.TLS,
-		logFile:        logFile,
-		dbClient:       dbClient,
-		controller:     controller,
-		flushScheduler: flushScheduler,
-		router:         router,
-		apic:           apiClient,
-		papi:           papiClient,
-		httpServerTomb: tomb.Tomb{},
-		consoleConfig:  config.ConsoleConfig,
+		URL:                  config.ListenURI,
+		UnixSocket:           config.ListenSocket,
+		TLS:                  config.TLS,
+		logFile:              logFile,
+		dbClient:             dbClient,
+		controller:           controller,
+		flushScheduler:       flushScheduler,
+		router:               router,
+		apic:                 apiClient,
+		papi:                 papiClient,
+		feeds:                feedManager,
+		httpServerTomb:       tomb.Tomb{},
+		consoleConfig:        config.ConsoleConfig,
+		durableNotifications: config.DurableNotifications,
 	}, nil
 }
 
@@ -393,6 +437,14 @@ func (s *APIServer) Run(apiReady chan bool) error {
 		s.initAPIC(ctx)
 	}
 
+	if s.feeds != nil {
+		s.feeds.Start(ctx)
+	}
+
+	if s.controller.CTIEnricher != nil {
+		s.controller.CTIEnricher.Start(ctx)
+	}
+
 	s.httpServerTomb.Go(func() error {
 		return s.listenAndServeLAPI(apiReady)
 	})
@@ -511,6 +563,14 @@ func (s *APIServer) Close() {
 		s.papi.Shutdown() // papi also uses the dbClient
 	}
 
+	if s.feeds != nil {
+		s.feeds.Shutdown()
+	}
+
+	if s.controller.CTIEnricher != nil {
+		s.controller.CTIEnricher.Shutdown()
+	}
+
 	s.dbClient.Ent.Close()
 
 	if s.flushScheduler != nil {
@@ -547,6 +607,10 @@ func (s *APIServer) Shutdown() error {
 
 func (s *APIServer) AttachPluginBroker(broker *csplugin.PluginBroker) {
 	s.controller.PluginChannel = broker.PluginChannel
+
+	if s.durableNotifications && s.dbClient != nil {
+		broker.SetQueue(s.dbClient)
+	}
 }
 
 func (s *APIServer) InitController() error {
@@ -586,5 +650,17 @@ func (s *APIServer) InitController() error {
 		return fmt.Errorf("while creating TLS auth for bouncers: %w", err)
 	}
 
+	if len(s.TLS.AllowedPeersOU) > 0 {
+		s.controller.HandlerV1.Middlewares.Peer.TlsAuth, err = v1.NewTLSAuth(s.TLS.AllowedPeersOU, s.TLS.CRLPath,
+			cacheExpiration,
+			log.WithFields(log.Fields{
+				"component": "tls-auth",
+				"type":      "peer",
+			}))
+		if err != nil {
+			return fmt.Errorf("while creating TLS auth for federation peers: %w", err)
+		}
+	}
+
 	return nil
 }
@@ -12,6 +12,7 @@ import (
 	v1 "github.com/crowdsecurity/crowdsec/pkg/apiserver/controllers/v1"
 	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
 	"github.com/crowdsecurity/crowdsec/pkg/csplugin"
+	"github.com/crowdsecurity/crowdsec/pkg/ctienrich"
 	"github.com/crowdsecurity/crowdsec/pkg/database"
 	"github.com/crowdsecurity/crowdsec/pkg/models"
 )
@@ -29,6 +30,8 @@ type Controller struct {
 	HandlerV1                     *v1.Controller
 	AutoRegisterCfg               *csconfig.LocalAPIAutoRegisterCfg
 	DisableRemoteLapiRegistration bool
+	CTIEnricher                   *ctienrich.Enricher
+	FederationPublish             *csconfig.FederationPublishCfg
 }
 
 func (c *Controller) Init() error {
@@ -88,6 +91,8 @@ func (c *Controller) NewV1() error {
 		ConsoleConfig:      *c.ConsoleConfig,
 		TrustedIPs:         c.TrustedIPs,
 		AutoRegisterCfg:    c.AutoRegisterCfg,
+		CTIEnricher:        c.CTIEnricher,
+		FederationPublish:  c.FederationPublish,
 	}
 
 	c.HandlerV1, err = v1.New(&v1Config)
@@ -136,6 +141,15 @@ func (c *Controller) NewV1() error {
 		apiKeyAuth.HEAD("/decisions", c.HandlerV1.GetDecision)
 		apiKeyAuth.GET("/decisions/stream", c.HandlerV1.StreamDecision)
 		apiKeyAuth.HEAD("/decisions/stream", c.HandlerV1.StreamDecision)
+		apiKeyAuth.GET("/decisions/export", c.HandlerV1.ExportDecisions)
+	}
+
+	if c.FederationPublish != nil && c.FederationPublish.Enabled {
+		peerAuth := groupV1.Group("/federation")
+		peerAuth.Use(c.HandlerV1.Middlewares.Peer.MiddlewareFunc())
+		{
+			peerAuth.GET("/decisions", c.HandlerV1.FederationDecisions)
+		}
 	}
 
 	eitherAuth := groupV1.Group("")
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csprofiles"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// pendingAggregation is an alert whose decisions may be collapsed by the aggregation settings of a profile,
+// once it has been saved
+type pendingAggregation struct {
+	alert   *models.Alert
+	profile *csprofiles.Runtime
+}
+
+func (c *Controller) aggregateDecisions(ctx context.Context, machineID string, pending []pendingAggregation) {
+	for _, p := range pending {
+		for _, decision := range p.alert.Decisions {
+			if decision.Scope == nil || *decision.Scope != types.Ip || decision.Value == nil || decision.Type == nil {
+				continue
+			}
+
+			if decision.Simulated != nil && *decision.Simulated {
+				continue
+			}
+
+			for _, target := range p.profile.Aggregation.Targets(*decision.Value) {
+				if err := c.aggregateNetwork(ctx, machineID, p, decision, target); err != nil {
+					p.profile.Logger.Errorf("while aggregating decisions in %s: %s", target.Network, err)
+				}
+			}
+		}
+	}
+}
+
+// aggregateNetwork creates a Range decision for the target network if it contains enough distinct Ip decisions
+func (c *Controller) aggregateNetwork(ctx context.Context, machineID string, p pendingAggregation, decision *models.Decision, target csprofiles.AggregationTarget) error {
+	cidr := target.Network.String()
+
+	covering, err := c.DBClient.QueryDecisionWithFilter(ctx, map[string][]string{
+		"range":  {cidr},
+		"scopes": {types.Range},
+		"type":   {*decision.Type},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(covering) > 0 {
+		p.profile.Logger.Debugf("%s is already covered by a range decision", cidr)
+		return nil
+	}
+
+	since := time.Now().UTC().Add(-p.profile.Aggregation.Window)
+
+	// only the decisions of the same origin are aggregated: the ones from CAPI, cscli or the lists must not be expired
+	origin := types.CrowdSecOrigin
+	if decision.Origin != nil {
+		origin = *decision.Origin
+	}
+
+	members, err := c.DBClient.QueryActiveDecisionsInRangeSince(ctx, types.Ip, *decision.Type, origin, cidr, since)
+	if err != nil {
+		return err
+	}
+
+	values := []string{}
+	ids := make([]int, 0, len(members))
+	seen := map[string]struct{}{}
+
+	for _, member := range members {
+		ids = append(ids, member.ID)
+
+		if _, ok := seen[member.Value]; ok {
+			continue
+		}
+
+		seen[member.Value] = struct{}{}
+		values = append(values, member.Value)
+	}
+
+	if len(values) < target.Threshold {
+		return nil
+	}
+
+	aggAlert, err := p.profile.NewAggregatedAlert(p.alert, decision, target.Network, values, ids)
+	if err != nil {
+		return fmt.Errorf("while building aggregated alert: %w", err)
+	}
+
+	aggAlert.MachineID = machineID
+	aggAlert.UUID = uuid.NewString()
+
+	for _, d := range aggAlert.Decisions {
+		d.UUID = uuid.NewString()
+	}
+
+	if _, err := c.DBClient.CreateAlert(ctx, machineID, []*models.Alert{aggAlert}); err != nil {
+		return fmt.Errorf("while saving aggregated alert: %w", err)
+	}
+
+	p.profile.Logger.Infof("%d Ip decisions aggregated into %s", len(values), cidr)
+
+	if !p.profile.Aggregation.ExpireIndividual {
+		return nil
+	}
+
+	if _, err := c.DBClient.ExpireDecisions(ctx, members); err != nil {
+		return fmt.Errorf("while expiring aggregated decisions: %w", err)
+	}
+
+	if c.DecisionDeleteChan != nil {
+		c.DecisionDeleteChan <- FormatDecisions(members)
+	}
+
+	return nil
+}
@@ -83,14 +83,15 @@ func FormatOneAlert(alert *ent.Alert) *models.Alert {
 	for _, decisionItem := range alert.Edges.Decisions {
 		duration := decisionItem.Until.Sub(time.Now().UTC()).Round(time.Second).String()
 		outputAlert.Decisions = append(outputAlert.Decisions, &models.Decision{
-			Duration:  &duration, // transform into time.Time ?
-			Scenario:  &decisionItem.Scenario,
-			Type:      &decisionItem.Type,
-			Scope:     &decisionItem.Scope,
-			Value:     &decisionItem.Value,
-			Origin:    &decisionItem.Origin,
-			Simulated: outputAlert.Simulated,
-			ID:        int64(decisionItem.ID),
+			Duration:       &duration, // transform into time.Time ?
+			Scenario:       &decisionItem.Scenario,
+			Type:           &decisionItem.Type,
+			Scope:          &decisionItem.Scope,
+			Value:          &decisionItem.Value,
+			Origin:         &decisionItem.Origin,
+			Simulated:      outputAlert.Simulated,
+			ID:             int64(decisionItem.ID),
+			EscalationStep: int64(decisionItem.EscalationStep),
 		})
 	}
 
@@ -164,6 +165,7 @@ func (c *Controller) CreateAlert(gctx *gin.Context) {
 
 	stopFlush := false
 	alertsToSave := make([]*models.Alert, 0)
+	aggregations := make([]pendingAggregation, 0)
 
 	for _, alert := range input {
 		// normalize scope for alert.Source and decisions
@@ -186,6 +188,10 @@ func (c *Controller) CreateAlert(gctx *gin.Context) {
 		// generate uuid here for alert
 		alert.UUID = uuid.NewString()
 
+		// the reputation of the source can be used by the profiles and notifications,
+		// it is only set by the local API
+		c.CTIEnricher.Enrich(ctx, alert)
+
 		// if coming from cscli, alert already has decisions
 		if len(alert.Decisions) != 0 {
 			// alert already has a decision (cscli decisions add etc.), generate uuid here
@@ -194,7 +200,7 @@ func (c *Controller) CreateAlert(gctx *gin.Context) {
 			}
 
 			for pIdx, profile := range c.Profiles {
-				_, matched, err := profile.EvaluateProfile(alert)
+				_, matched, err := profile.EvaluateProfile(ctx, alert)
 				if err != nil {
 					profile.Logger.Warningf("error while evaluating profile %s : %v", profile.Cfg.Name, err)
 
@@ -223,7 +229,7 @@ func (c *Controller) CreateAlert(gctx *gin.Context) {
 		}
 
 		for pIdx, profile := range c.Profiles {
-			profileDecisions, matched, err := profile.EvaluateProfile(alert)
+			profileDecisions, matched, err := profile.EvaluateProfile(ctx, alert)
 			forceBreak := false
 
 			if err != nil {
@@ -260,6 +266,10 @@ func (c *Controller) CreateAlert(gctx *gin.Context) {
 			profileAlert := *alert
 			c.sendAlertToPluginChannel(&profileAlert, uint(pIdx))
 
+			if profile.Aggregation != nil {
+				aggregations = append(aggregations, pendingAggregation{alert: alert, profile: profile})
+			}
+
 			if profile.Cfg.OnSuccess == "break" || forceBreak {
 				break
 			}
@@ -280,6 +290,13 @@ func (c *Controller) CreateAlert(gctx *gin.Context) {
 		return
 	}
 
+	c.aggregateDecisions(ctx, machineID, aggregations)
+
+	// the sources missing from the cache are looked up in the background
+	if c.CTIEnricher != nil {
+		c.CTIEnricher.Queue(alertsToSave)
+	}
+
 	if c.AlertsAddChan != nil {
 		select {
 		case c.AlertsAddChan <- alertsToSave:
@@ -331,6 +348,16 @@ func (c *Controller) FindAlertByID(gctx *gin.Context) {
 
 	data := FormatOneAlert(result)
 
+	// the CTI information is not stored with the alert, but cached by source IP
+	if data.Source != nil && data.Source.GetScope() == types.Ip && data.Source.GetValue() != "" {
+		cti, err := c.DBClient.GetIPReputation(ctx, data.Source.GetValue(), time.Time{})
+		if err != nil {
+			log.Warningf("while reading the reputation of %s: %s", data.Source.GetValue(), err)
+		}
+
+		data.Cti = cti
+	}
+
 	if gctx.Request.Method == http.MethodHead {
 		gctx.String(http.StatusOK, "")
 		return
@@ -8,6 +8,7 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
 	"github.com/crowdsecurity/crowdsec/pkg/csplugin"
 	"github.com/crowdsecurity/crowdsec/pkg/csprofiles"
+	"github.com/crowdsecurity/crowdsec/pkg/ctienrich"
 	"github.com/crowdsecurity/crowdsec/pkg/database"
 	"github.com/crowdsecurity/crowdsec/pkg/models"
 )
@@ -21,10 +22,12 @@ type Controller struct {
 	AlertsAddChan      chan []*models.Alert
 	DecisionDeleteChan chan []*models.Decision
 
-	PluginChannel   chan csplugin.ProfileAlert
-	ConsoleConfig   csconfig.ConsoleConfig
-	TrustedIPs      []net.IPNet
-	AutoRegisterCfg *csconfig.LocalAPIAutoRegisterCfg
+	PluginChannel     chan csplugin.ProfileAlert
+	ConsoleConfig     csconfig.ConsoleConfig
+	TrustedIPs        []net.IPNet
+	AutoRegisterCfg   *csconfig.LocalAPIAutoRegisterCfg
+	CTIEnricher       *ctienrich.Enricher
+	FederationPublish *csconfig.FederationPublishCfg
 }
 
 type ControllerV1Config struct {
@@ -34,10 +37,12 @@ type ControllerV1Config struct {
 	AlertsAddChan      chan []*models.Alert
 	DecisionDeleteChan chan []*models.Decision
 
-	PluginChannel   chan csplugin.ProfileAlert
-	ConsoleConfig   csconfig.ConsoleConfig
-	TrustedIPs      []net.IPNet
-	AutoRegisterCfg *csconfig.LocalAPIAutoRegisterCfg
+	PluginChannel     chan csplugin.ProfileAlert
+	ConsoleConfig     csconfig.ConsoleConfig
+	TrustedIPs        []net.IPNet
+	AutoRegisterCfg   *csconfig.LocalAPIAutoRegisterCfg
+	CTIEnricher       *ctienrich.Enricher
+	FederationPublish *csconfig.FederationPublishCfg
 }
 
 func New(cfg *ControllerV1Config) (*Controller, error) {
@@ -48,6 +53,12 @@ func New(cfg *ControllerV1Config) (*Controller, error) {
 		return &Controller{}, fmt.Errorf("failed to compile profiles: %w", err)
 	}
 
+	if cfg.DbClient != nil {
+		for _, profile := range profiles {
+			profile.DecisionHistory = cfg.DbClient
+		}
+	}
+
 	v1 := &Controller{
 		DBClient:           cfg.DbClient,
 		APIKeyHeader:       middlewares.APIKeyHeader,
@@ -58,6 +69,8 @@ func New(cfg *ControllerV1Config) (*Controller, error) {
 		ConsoleConfig:      cfg.ConsoleConfig,
 		TrustedIPs:         cfg.TrustedIPs,
 		AutoRegisterCfg:    cfg.AutoRegisterCfg,
+		CTIEnricher:        cfg.CTIEnricher,
+		FederationPublish:  cfg.FederationPublish,
 	}
 
 	v1.Middlewares, err = middlewares.NewMiddlewares(cfg.DbClient)
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"net/http"
@@ -10,7 +11,9 @@ import (
 	"github.com/gin-gonic/gin"
 	log "github.com/sirupsen/logrus"
 
+	"github.com/crowdsecurity/crowdsec/pkg/database"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/decisionexport"
 	"github.com/crowdsecurity/crowdsec/pkg/fflag"
 	"github.com/crowdsecurity/crowdsec/pkg/models"
 )
@@ -22,14 +25,15 @@ func FormatDecisions(decisions []*ent.Decision) []*models.Decision {
 	for _, dbDecision := range decisions {
 		duration := dbDecision.Until.Sub(time.Now().UTC()).Round(time.Second).String()
 		decision := models.Decision{
-			ID:       int64(dbDecision.ID),
-			Duration: &duration,
-			Scenario: &dbDecision.Scenario,
-			Scope:    &dbDecision.Scope,
-			Value:    &dbDecision.Value,
-			Type:     &dbDecision.Type,
-			Origin:   &dbDecision.Origin,
-			UUID:     dbDecision.UUID,
+			ID:             int64(dbDecision.ID),
+			Duration:       &duration,
+			Scenario:       &dbDecision.Scenario,
+			Scope:          &dbDecision.Scope,
+			Value:          &dbDecision.Value,
+			Type:           &dbDecision.Type,
+			Origin:         &dbDecision.Origin,
+			UUID:           dbDecision.UUID,
+			EscalationStep: int64(dbDecision.EscalationStep),
 		}
 		results = append(results, &decision)
 	}
@@ -37,6 +41,24 @@ func FormatDecisions(decisions []*ent.Decision) []*models.Decision {
 	return results
 }
 
+// maxDecisions returns the maximum number of decisions the bouncer may receive in a full list (startup pull,
+// /v1/decisions, export), 0 meaning no limit. The deltas are never capped: LastPull moves past all of them.
+func maxDecisions(bouncerInfo *ent.Bouncer) int {
+	if bouncerInfo.Filters == nil {
+		return 0
+	}
+
+	return bouncerInfo.Filters.MaxDecisions
+}
+
+func limitDecisions(decisions []*ent.Decision, limit int) []*ent.Decision {
+	if limit > 0 && len(decisions) > limit {
+		return decisions[:limit]
+	}
+
+	return decisions
+}
+
 func (c *Controller) GetDecision(gctx *gin.Context) {
 	var (
 		results []*models.Decision
@@ -52,14 +74,17 @@ func (c *Controller) GetDecision(gctx *gin.Context) {
 		return
 	}
 
-	data, err = c.DBClient.QueryDecisionWithFilter(ctx, gctx.Request.URL.Query())
+	filters := gctx.Request.URL.Query()
+	database.ApplyBouncerFilters(filters, bouncerInfo.Filters)
+
+	data, err = c.DBClient.QueryDecisionWithFilter(ctx, filters)
 	if err != nil {
 		c.HandleDBErrors(gctx, err)
 
 		return
 	}
 
-	results = FormatDecisions(data)
+	results = FormatDecisions(limitDecisions(data, maxDecisions(bouncerInfo)))
 	/*let's follow a naive logic : when a bouncer queries /decisions, if the answer is empty, we assume there is no decision for this ip/user/...,
 	but if it's non-empty, it means that there is one or more decisions for this target*/
 	if len(results) > 0 {
@@ -83,6 +108,69 @@ func (c *Controller) GetDecision(gctx *gin.Context) {
 	gctx.JSON(http.StatusOK, results)
 }
 
+// ExportDecisions serves the active decisions in a format that can be loaded directly by a firewall or proxy.
+// It accepts the same filters as the stream endpoint.
+func (c *Controller) ExportDecisions(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+
+	bouncerInfo, err := getBouncerFromContext(gctx)
+	if err != nil {
+		gctx.JSON(http.StatusUnauthorized, gin.H{"message": "not allowed"})
+
+		return
+	}
+
+	filters := gctx.Request.URL.Query()
+
+	format := filters.Get("format")
+	if format == "" {
+		format = decisionexport.FormatFromAccept(gctx.GetHeader("Accept"))
+	}
+
+	contentType, err := decisionexport.ContentType(format)
+	if err != nil {
+		gctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+
+		return
+	}
+
+	opts := decisionexport.Options{
+		SetName: filters.Get("set_name"),
+	}
+
+	delete(filters, "format")
+	delete(filters, "set_name")
+
+	if _, ok := filters["scopes"]; !ok {
+		filters["scopes"] = []string{"ip,range"}
+	}
+
+	database.ApplyBouncerFilters(filters, bouncerInfo.Filters)
+
+	data, err := c.DBClient.QueryAllDecisionsWithFilters(ctx, filters)
+	if err != nil {
+		c.HandleDBErrors(gctx, err)
+
+		return
+	}
+
+	buf := bytes.Buffer{}
+
+	if err := decisionexport.Write(&buf, format, limitDecisions(data, maxDecisions(bouncerInfo)), opts); err != nil {
+		gctx.JSON(http.StatusInternalServerError, gin.H{"message": err.Error()})
+
+		return
+	}
+
+	if bouncerInfo.LastPull == nil || time.Now().UTC().Sub(*bouncerInfo.LastPull) >= time.Minute {
+		if err := c.DBClient.UpdateBouncerLastPull(ctx, time.Now().UTC(), bouncerInfo.ID); err != nil {
+			log.Errorf("failed to update bouncer last pull: %v", err)
+		}
+	}
+
+	gctx.Data(http.StatusOK, contentType, buf.Bytes())
+}
+
 func (c *Controller) DeleteDecisionById(gctx *gin.Context) {
 	decisionIDStr := gctx.Param("decision_id")
 
@@ -140,11 +228,12 @@ func (c *Controller) DeleteDecisions(gctx *gin.Context) {
 	gctx.JSON(http.StatusOK, deleteDecisionResp)
 }
 
-func writeStartupDecisions(gctx *gin.Context, filters map[string][]string, dbFunc func(context.Context, map[string][]string) ([]*ent.Decision, error)) error {
+func writeStartupDecisions(gctx *gin.Context, filters map[string][]string, maxDecisions int, dbFunc func(context.Context, map[string][]string) ([]*ent.Decision, error)) error {
 	// respBuffer := bytes.NewBuffer([]byte{})
 	limit := 30000 // FIXME : make it configurable
 	needComma := false
 	lastId := 0
+	sent := 0
 
 	ctx := gctx.Request.Context()
 
@@ -166,6 +255,12 @@ func writeStartupDecisions(gctx *gin.Context, filters map[string][]string, dbFun
 			lastId = data[len(data)-1].ID
 
 			results := FormatDecisions(data)
+			if maxDecisions > 0 && sent+len(results) > maxDecisions {
+				results = results[:maxDecisions-sent]
+			}
+
+			sent += len(results)
+
 			for _, decision := range results {
 				decisionJSON, _ := json.Marshal(decision)
 
@@ -189,7 +284,7 @@ func writeStartupDecisions(gctx *gin.Context, filters map[string][]string, dbFun
 
 		log.Debugf("startup: %d decisions returned (limit: %d, lastid: %d)", len(data), limit, lastId)
 
-		if len(data) < limit {
+		if len(data) < limit || (maxDecisions > 0 && sent >= maxDecisions) {
 			gctx.Writer.Flush()
 
 			break
@@ -269,7 +364,7 @@ func (c *Controller) StreamDecisionChunked(gctx *gin.Context, bouncerInfo *ent.B
 	// if the blocker just started, return all decisions
 	if val, ok := gctx.Request.URL.Query()["startup"]; ok && val[0] == "true" {
 		// Active decisions
-		err := writeStartupDecisions(gctx, filters, c.DBClient.QueryAllDecisionsWithFilters)
+		err := writeStartupDecisions(gctx, filters, maxDecisions(bouncerInfo), c.DBClient.QueryAllDecisionsWithFilters)
 		if err != nil {
 			log.Errorf("failed sending new decisions for startup: %v", err)
 			gctx.Writer.WriteString(`], "deleted": []}`)
@@ -280,7 +375,7 @@ func (c *Controller) StreamDecisionChunked(gctx *gin.Context, bouncerInfo *ent.B
 
 		gctx.Writer.WriteString(`], "deleted": [`)
 		// Expired decisions
-		err = writeStartupDecisions(gctx, filters, c.DBClient.QueryExpiredDecisionsWithFilters)
+		err = writeStartupDecisions(gctx, filters, 0, c.DBClient.QueryExpiredDecisionsWithFilters)
 		if err != nil {
 			log.Errorf("failed sending expired decisions for startup: %v", err)
 			gctx.Writer.WriteString(`]}`)
@@ -341,7 +436,7 @@ func (c *Controller) StreamDecisionNonChunked(gctx *gin.Context, bouncerInfo *en
 				return err
 			}
 			// data = KeepLongestDecision(data)
-			ret["new"] = FormatDecisions(data)
+			ret["new"] = FormatDecisions(limitDecisions(data, maxDecisions(bouncerInfo)))
 
 			// getting expired decisions
 			data, err = c.DBClient.QueryExpiredDecisionsWithFilters(ctx, filters)
@@ -416,6 +511,8 @@ func (c *Controller) StreamDecision(gctx *gin.Context) {
 		filters["scopes"] = []string{"ip,range"}
 	}
 
+	database.ApplyBouncerFilters(filters, bouncerInfo.Filters)
+
 	if fflag.ChunkedDecisionsStream.IsEnabled() {
 		err = c.StreamDecisionChunked(gctx, bouncerInfo, streamStartTime, filters)
 	} else {
@@ -0,0 +1,55 @@
+package v1
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+
+	middlewares "github.com/crowdsecurity/crowdsec/pkg/apiserver/middlewares/v1"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// FederationDecisions serves to a peer LAPI the active decisions selected by federation.publish
+func (c *Controller) FederationDecisions(gctx *gin.Context) {
+	ctx := gctx.Request.Context()
+	publish := c.FederationPublish
+
+	filter := map[string][]string{}
+
+	if len(publish.Scopes) > 0 {
+		filter["scopes"] = []string{strings.Join(publish.Scopes, ",")}
+	}
+
+	if len(publish.Origins) > 0 {
+		filter["origins"] = []string{strings.Join(publish.Origins, ",")}
+	}
+
+	if len(publish.ScenariosContaining) > 0 {
+		filter["scenarios_containing"] = []string{strings.Join(publish.ScenariosContaining, ",")}
+	}
+
+	data, err := c.DBClient.QueryDecisionWithFilter(ctx, filter)
+	if err != nil {
+		c.HandleDBErrors(gctx, err)
+		return
+	}
+
+	// the decisions received from the peers are not published again, to avoid loops
+	data = slices.DeleteFunc(data, func(d *ent.Decision) bool {
+		return d.Origin == types.FederationOrigin
+	})
+
+	log.WithField("peer", gctx.GetString(middlewares.PeerContextKey)).Debugf("publishing %d decisions", len(data))
+
+	results := FormatDecisions(data)
+	if results == nil {
+		results = []*models.Decision{}
+	}
+
+	gctx.JSON(http.StatusOK, results)
+}
@@ -2,8 +2,12 @@ package apiserver
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database"
 )
 
 const (
@@ -319,6 +323,111 @@ func TestStreamStartDecisionDedup(t *testing.T) {
 	assert.Empty(t, decisions["new"])
 }
 
+func TestStreamDecisionBouncerFilters(t *testing.T) {
+	ctx := t.Context()
+	lapi := SetupLAPITest(t, ctx)
+
+	// 3 decisions on 127.0.0.1 from origins test1/http_bf, test2/ssh_bf and test3/ddos
+	lapi.InsertAlertFromFile(t, ctx, "./tests/alert_stream_fixture.json")
+
+	bouncer, err := lapi.DBClient.SelectBouncerByName(ctx, "test")
+	require.NoError(t, err)
+
+	setFilters := func(specs ...string) {
+		filters, err := database.ParseBouncerFilters(specs)
+		require.NoError(t, err)
+		require.NoError(t, lapi.DBClient.UpdateBouncerFilters(ctx, filters, bouncer.ID))
+	}
+
+	streamOrigins := func(url string) []string {
+		w := lapi.RecordResponse(t, ctx, "GET", url, emptyBody, APIKEY)
+		decisions, code := readDecisionsStreamResp(t, w)
+		require.Equal(t, 200, code)
+
+		origins := []string{}
+		for _, d := range decisions["new"] {
+			origins = append(origins, *d.Origin)
+		}
+
+		return origins
+	}
+
+	setFilters("origins=test1,test2")
+	assert.Equal(t, []string{"test1", "test2"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	// the bouncer can restrict further, but not escape the policy
+	assert.Equal(t, []string{"test2"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false&origins=test2,test3"))
+	assert.Equal(t, []string{"test1", "test2"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false&bouncer_origins=test3"))
+
+	setFilters("scenarios=crowdsecurity/*_bf")
+	assert.Equal(t, []string{"test1", "test2"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	setFilters("scenarios=crowdsecurity/ddos,crowdsecurity/http*")
+	assert.Equal(t, []string{"test1", "test3"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	// the pieces of the pattern are matched in order
+	setFilters("scenarios=*bf*ssh*")
+	assert.Empty(t, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	setFilters("scenarios=*ssh*bf")
+	assert.Equal(t, []string{"test2"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	// wildcards of LIKE are matched literally
+	setFilters("scenarios=crowdsecurity/%*")
+	assert.Empty(t, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	setFilters("exclude_countries=france")
+	assert.Empty(t, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	setFilters("scopes=range")
+	assert.Empty(t, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	setFilters("max_decisions=2")
+	assert.Equal(t, []string{"test1", "test2"}, streamOrigins("/v1/decisions/stream?startup=true&dedup=false"))
+
+	// the deltas are not capped, LastPull moves past all of them
+	require.NoError(t, lapi.DBClient.UpdateBouncerLastPull(ctx, time.Now().UTC().Add(-time.Hour), bouncer.ID))
+	assert.Equal(t, []string{"test1", "test2", "test3"}, streamOrigins("/v1/decisions/stream?dedup=false"))
+
+	// the policy applies to /v1/decisions as well
+	setFilters("origins=test3")
+	w := lapi.RecordResponse(t, ctx, "GET", "/v1/decisions?ip=127.0.0.1", emptyBody, APIKEY)
+	decisions, code := readDecisionsGetResp(t, w)
+	require.Equal(t, 200, code)
+	require.Len(t, decisions, 1)
+	assert.Equal(t, "test3", *decisions[0].Origin)
+
+	setFilters("max_decisions=2")
+	w = lapi.RecordResponse(t, ctx, "GET", "/v1/decisions?ip=127.0.0.1", emptyBody, APIKEY)
+	decisions, code = readDecisionsGetResp(t, w)
+	require.Equal(t, 200, code)
+	assert.Len(t, decisions, 2)
+}
+
+func TestExportDecisions(t *testing.T) {
+	ctx := t.Context()
+	lapi := SetupLAPITest(t, ctx)
+
+	lapi.InsertAlertFromFile(t, ctx, "./tests/alert_minibulk.json")
+
+	w := lapi.RecordResponse(t, ctx, "GET", "/v1/decisions/export?format=plaintext", emptyBody, APIKEY)
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "text/plain; charset=utf-8", w.Header().Get("Content-Type"))
+	assert.Equal(t, "91.121.79.179\n91.121.79.178\n", w.Body.String())
+
+	w = lapi.RecordResponse(t, ctx, "GET", "/v1/decisions/export?format=haproxy&origins=cscli", emptyBody, APIKEY)
+	assert.Equal(t, 200, w.Code)
+	assert.Empty(t, w.Body.String())
+
+	w = lapi.RecordResponse(t, ctx, "GET", "/v1/decisions/export?format=ipset&set_name=cs", emptyBody, APIKEY)
+	assert.Equal(t, 200, w.Code)
+	assert.Contains(t, w.Body.String(), "create cs hash:net family inet timeout 0 -exist\n")
+	assert.Contains(t, w.Body.String(), "add cs 91.121.79.178 timeout ")
+
+	w = lapi.RecordResponse(t, ctx, "GET", "/v1/decisions/export?format=pf", emptyBody, APIKEY)
+	assert.Equal(t, 400, w.Code)
+}
+
 type DecisionCheck struct {
 	ID       int64
 	Origin   string
@@ -5,6 +5,7 @@ import "github.com/crowdsecurity/crowdsec/pkg/database"
 type Middlewares struct {
 	APIKey *APIKey
 	JWT    *JWT
+	Peer   *PeerAuth
 }
 
 func NewMiddlewares(dbClient *database.Client) (*Middlewares, error) {
@@ -18,6 +19,7 @@ func NewMiddlewares(dbClient *database.Client) (*Middlewares, error) {
 	}
 
 	ret.APIKey = NewAPIKey(dbClient)
+	ret.Peer = &PeerAuth{}
 
 	return ret, nil
 }
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	log "github.com/sirupsen/logrus"
+)
+
+const PeerContextKey = "peer"
+
+// PeerAuth authenticates the federation peers, by the OU of their TLS client certificate
+type PeerAuth struct {
+	TlsAuth *TLSAuth
+}
+
+func (p *PeerAuth) MiddlewareFunc() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		logger := log.WithField("ip", c.ClientIP())
+
+		if p.TlsAuth == nil {
+			logger.Warn("a federation peer tried to connect, but TLS auth is not configured for peers")
+			c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden"})
+			c.Abort()
+
+			return
+		}
+
+		peer, err := p.TlsAuth.ValidateCert(c)
+		if err != nil {
+			logger.Warnf("federation peer authentication failed: %s", err)
+			c.JSON(http.StatusForbidden, gin.H{"message": "access forbidden"})
+			c.Abort()
+
+			return
+		}
+
+		c.Set(PeerContextKey, peer)
+	}
+}
@@ -1,6 +1,7 @@
 package appsec
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"os"
@@ -11,6 +12,11 @@ import (
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/yaml.v2"
 
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/bot"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/challenge"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/identity"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/learning"
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/openapi"
 	"github.com/crowdsecurity/crowdsec/pkg/cwhub"
 	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
 	"github.com/crowdsecurity/crowdsec/pkg/types"
@@ -33,9 +39,10 @@ const (
 )
 
 const (
-	BanRemediation     = "ban"
-	CaptchaRemediation = "captcha"
-	AllowRemediation   = "allow"
+	BanRemediation       = "ban"
+	CaptchaRemediation   = "captcha"
+	AllowRemediation     = "allow"
+	ChallengeRemediation = "challenge" // served by the appsec component, see ChallengeConfig
 )
 
 func (h *Hook) Build(hookStage int) error {
@@ -77,16 +84,81 @@ func (h *Hook) Build(hookStage int) error {
 type AppsecTempResponse struct {
 	InBandInterrupt         bool
 	OutOfBandInterrupt      bool
-	Action                  string // allow, deny, captcha, log
-	UserHTTPResponseCode    int    // The response code to send to the user
-	BouncerHTTPResponseCode int    // The response code to send to the remediation component
-	SendEvent               bool   // do we send an internal event on rule match
-	SendAlert               bool   // do we send an alert on rule match
+	Action                  string            // allow, deny, captcha, log
+	UserHTTPResponseCode    int               // The response code to send to the user
+	BouncerHTTPResponseCode int               // The response code to send to the remediation component
+	SendEvent               bool              // do we send an internal event on rule match
+	SendAlert               bool              // do we send an alert on rule match
+	UserBody                []byte            // The body to send to the user, for the remediations served by the appsec component
+	UserHeaders             map[string]string // The headers to send with the user body
 }
 
 type AppsecSubEngineOpts struct {
 	DisableBodyInspection    bool `yaml:"disable_body_inspection"`
 	RequestBodyInMemoryLimit *int `yaml:"request_body_in_memory_limit"`
+
+	// response phase, used when the remediation component forwards the responses
+	DisableResponseBodyInspection bool     `yaml:"disable_response_body_inspection"`
+	ResponseBodyMimeTypes         []string `yaml:"response_body_mime_types"`
+	ResponseBodyLimit             *int     `yaml:"response_body_limit"`
+
+	BodyLimits BodyLimits `yaml:"body_limits"`
+}
+
+// BodyLimits bounds the work of the json_ext, xml_ext, multipart_ext and graphql body processors on
+// the requests, the bodies exceeding them are reported as body processing errors (REQBODY_ERROR).
+// Unset limits keep the defaults of the processors, a limit of 0 disables the check.
+type BodyLimits struct {
+	JSONMaxDepth         *int `yaml:"json_max_depth"`
+	JSONMaxArgs          *int `yaml:"json_max_args"`
+	XMLMaxDepth          *int `yaml:"xml_max_depth"`
+	XMLMaxElements       *int `yaml:"xml_max_elements"`
+	MultipartMaxParts    *int `yaml:"multipart_max_parts"`
+	MultipartMaxPartSize *int `yaml:"multipart_max_part_size"`
+	GraphQLMaxBatch      *int `yaml:"graphql_max_batch"`
+}
+
+func (l *BodyLimits) merge(other BodyLimits) {
+	if other.JSONMaxDepth != nil {
+		l.JSONMaxDepth = other.JSONMaxDepth
+	}
+
+	if other.JSONMaxArgs != nil {
+		l.JSONMaxArgs = other.JSONMaxArgs
+	}
+
+	if other.XMLMaxDepth != nil {
+		l.XMLMaxDepth = other.XMLMaxDepth
+	}
+
+	if other.XMLMaxElements != nil {
+		l.XMLMaxElements = other.XMLMaxElements
+	}
+
+	if other.MultipartMaxParts != nil {
+		l.MultipartMaxParts = other.MultipartMaxParts
+	}
+
+	if other.MultipartMaxPartSize != nil {
+		l.MultipartMaxPartSize = other.MultipartMaxPartSize
+	}
+
+	if other.GraphQLMaxBatch != nil {
+		l.GraphQLMaxBatch = other.GraphQLMaxBatch
+	}
+}
+
+// DefaultResponseBodyMimeTypes are the content types of the response bodies inspected
+// when response_body_mime_types is not set. Binary content is never inspected.
+var DefaultResponseBodyMimeTypes = []string{"text/plain", "text/html", "text/xml", "application/json", "application/xml"}
+
+// GetResponseBodyMimeTypes returns the content types of the response bodies to inspect
+func (o *AppsecSubEngineOpts) GetResponseBodyMimeTypes() []string {
+	if len(o.ResponseBodyMimeTypes) == 0 {
+		return DefaultResponseBodyMimeTypes
+	}
+
+	return o.ResponseBodyMimeTypes
 }
 
 // runtime version of AppsecConfig
@@ -104,6 +176,14 @@ type AppsecRuntimeConfig struct {
 	CompiledPostEval          []Hook
 	CompiledOnMatch           []Hook
 	CompiledVariablesTracking []*regexp.Regexp
+	OpenAPISchemas            []openAPIEnforcer
+	RateLimiters              []*rateLimiter                 // shared by the runners
+	Learner                   *learning.Recorder             // shared by the runners, nil if learning is disabled
+	Capture                   *captureSink                   // shared by the runners, nil if capture is disabled
+	BotClassifier             *bot.Classifier                // shared by the runners, nil if bot detection is disabled
+	Challenge                 *challenge.Issuer              // shared by the runners
+	IdentityExtractors        []*identity.Extractor          // shared by the runners
+	HookTracer                func(stage string, hook *Hook) // called with the hooks applied, to explain the requests
 	Config                    *AppsecConfig
 	// CorazaLogger              debuglog.Logger
 
@@ -141,6 +221,14 @@ type AppsecConfig struct {
 	VariablesTracking []string            `yaml:"variables_tracking"`
 	InbandOptions     AppsecSubEngineOpts `yaml:"inband_options"`
 	OutOfBandOptions  AppsecSubEngineOpts `yaml:"outofband_options"`
+	OpenAPISchemas    []openapi.Config    `yaml:"openapi_schemas"`
+	RateLimits        []RateLimit         `yaml:"rate_limits"`
+	Learning          LearningConfig      `yaml:"learning"`
+	Capture           CaptureConfig       `yaml:"capture"`
+	BotDetection      BotDetectionConfig  `yaml:"bot_detection"`
+	Challenge         ChallengeConfig     `yaml:"challenge"`
+	Identity          []identity.Config   `yaml:"identity"`
+	Data              []*types.DataSource `yaml:"data"`
 
 	LogLevel *log.Level `yaml:"log_level"`
 	Logger   *log.Entry `yaml:"-"`
@@ -215,6 +303,38 @@ func (wc *AppsecConfig) LoadByPath(file string) error {
 		wc.VariablesTracking = append(wc.VariablesTracking, tmp.VariablesTracking...)
 	}
 
+	if tmp.OpenAPISchemas != nil {
+		wc.OpenAPISchemas = append(wc.OpenAPISchemas, tmp.OpenAPISchemas...)
+	}
+
+	if tmp.RateLimits != nil {
+		wc.RateLimits = append(wc.RateLimits, tmp.RateLimits...)
+	}
+
+	if tmp.Learning.Enabled {
+		wc.Learning = tmp.Learning
+	}
+
+	if tmp.Capture.Enabled {
+		wc.Capture = tmp.Capture
+	}
+
+	if tmp.BotDetection.Enabled {
+		wc.BotDetection = tmp.BotDetection
+	}
+
+	if tmp.Challenge != (ChallengeConfig{}) {
+		wc.Challenge = tmp.Challenge
+	}
+
+	if tmp.Identity != nil {
+		wc.Identity = append(wc.Identity, tmp.Identity...)
+	}
+
+	if tmp.Data != nil {
+		wc.Data = append(wc.Data, tmp.Data...)
+	}
+
 	// override other options
 	wc.LogLevel = tmp.LogLevel
 
@@ -241,6 +361,33 @@ func (wc *AppsecConfig) LoadByPath(file string) error {
 		wc.OutOfBandOptions.RequestBodyInMemoryLimit = tmp.OutOfBandOptions.RequestBodyInMemoryLimit
 	}
 
+	if tmp.InbandOptions.DisableResponseBodyInspection {
+		wc.InbandOptions.DisableResponseBodyInspection = true
+	}
+
+	if tmp.InbandOptions.ResponseBodyMimeTypes != nil {
+		wc.InbandOptions.ResponseBodyMimeTypes = tmp.InbandOptions.ResponseBodyMimeTypes
+	}
+
+	if tmp.InbandOptions.ResponseBodyLimit != nil {
+		wc.InbandOptions.ResponseBodyLimit = tmp.InbandOptions.ResponseBodyLimit
+	}
+
+	if tmp.OutOfBandOptions.DisableResponseBodyInspection {
+		wc.OutOfBandOptions.DisableResponseBodyInspection = true
+	}
+
+	if tmp.OutOfBandOptions.ResponseBodyMimeTypes != nil {
+		wc.OutOfBandOptions.ResponseBodyMimeTypes = tmp.OutOfBandOptions.ResponseBodyMimeTypes
+	}
+
+	if tmp.OutOfBandOptions.ResponseBodyLimit != nil {
+		wc.OutOfBandOptions.ResponseBodyLimit = tmp.OutOfBandOptions.ResponseBodyLimit
+	}
+
+	wc.InbandOptions.BodyLimits.merge(tmp.InbandOptions.BodyLimits)
+	wc.OutOfBandOptions.BodyLimits.merge(tmp.OutOfBandOptions.BodyLimits)
+
 	return nil
 }
 
@@ -262,6 +409,10 @@ func (wc *AppsecConfig) Load(configName string) error {
 }
 
 func (wc *AppsecConfig) GetDataDir() string {
+	if hub == nil {
+		return ""
+	}
+
 	return hub.GetDataDir()
 }
 
@@ -294,10 +445,10 @@ func (wc *AppsecConfig) Build() (*AppsecRuntimeConfig, error) {
 
 	// set the defaults
 	switch wc.DefaultRemediation {
-	case BanRemediation, CaptchaRemediation, AllowRemediation:
+	case BanRemediation, CaptchaRemediation, AllowRemediation, ChallengeRemediation:
 		// those are the officially supported remediation(s)
 	default:
-		wc.Logger.Warningf("default '%s' remediation of %s is none of [%s,%s,%s] ensure bouncer compatbility!", wc.DefaultRemediation, wc.Name, BanRemediation, CaptchaRemediation, AllowRemediation)
+		wc.Logger.Warningf("default '%s' remediation of %s is none of [%s,%s,%s,%s] ensure bouncer compatbility!", wc.DefaultRemediation, wc.Name, BanRemediation, CaptchaRemediation, AllowRemediation, ChallengeRemediation)
 	}
 
 	ret.Name = wc.Name
@@ -330,8 +481,62 @@ func (wc *AppsecConfig) Build() (*AppsecRuntimeConfig, error) {
 		ret.InBandRules = append(ret.InBandRules, collections...)
 	}
 
+	if len(wc.OpenAPISchemas) > 0 {
+		collection, err := ret.loadOpenAPISchemas(context.TODO(), wc)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.InBandRules = append(ret.InBandRules, collection)
+	}
+
+	if len(wc.RateLimits) > 0 {
+		collection, err := ret.loadRateLimits(wc)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.InBandRules = append(ret.InBandRules, collection)
+	}
+
+	if wc.Learning.Enabled {
+		collection, err := ret.loadLearning(wc)
+		if err != nil {
+			return nil, err
+		}
+
+		// the transactions must be switched to detection only before any other rule is evaluated.
+		// The inband rules always get it, as the requests are counted when they are evaluated.
+		ret.InBandRules = append([]AppsecCollection{collection}, ret.InBandRules...)
+
+		if len(ret.OutOfBandRules) > 0 {
+			ret.OutOfBandRules = append([]AppsecCollection{collection}, ret.OutOfBandRules...)
+		}
+	}
+
 	wc.Logger.Infof("Loaded %d inband rules", len(ret.InBandRules))
 
+	signatures, err := ret.loadData(wc)
+	if err != nil {
+		return nil, err
+	}
+
+	ret.loadBotDetection(wc, signatures)
+
+	if err := ret.loadChallenge(wc); err != nil {
+		return nil, err
+	}
+
+	if err := ret.loadIdentity(wc); err != nil {
+		return nil, err
+	}
+
+	if wc.Capture.Enabled {
+		if err := ret.loadCapture(wc); err != nil {
+			return nil, err
+		}
+	}
+
 	// load hooks
 	for _, hook := range wc.OnLoad {
 		if hook.OnSuccess != "" && hook.OnSuccess != "continue" && hook.OnSuccess != "break" {
@@ -398,6 +603,12 @@ func (wc *AppsecConfig) Build() (*AppsecRuntimeConfig, error) {
 	return ret, nil
 }
 
+func (w *AppsecRuntimeConfig) traceHook(stage string, hook *Hook) {
+	if w.HookTracer != nil {
+		w.HookTracer(stage, hook)
+	}
+}
+
 func (w *AppsecRuntimeConfig) ProcessOnLoadRules() error {
 	has_match := false
 
@@ -422,6 +633,8 @@ func (w *AppsecRuntimeConfig) ProcessOnLoadRules() error {
 			has_match = true
 		}
 
+		w.traceHook("on_load", &rule)
+
 		for _, applyExpr := range rule.ApplyExpr {
 			o, err := exprhelpers.Run(applyExpr, GetOnLoadEnv(w), w.Logger, w.Logger.Level >= log.DebugLevel)
 			if err != nil {
@@ -469,6 +682,8 @@ func (w *AppsecRuntimeConfig) ProcessOnMatchRules(request *ParsedRequest, evt ty
 			has_match = true
 		}
 
+		w.traceHook("on_match", &rule)
+
 		for _, applyExpr := range rule.ApplyExpr {
 			o, err := exprhelpers.Run(applyExpr, GetOnMatchEnv(w, request, evt), w.Logger, w.Logger.Level >= log.DebugLevel)
 			if err != nil {
@@ -516,6 +731,8 @@ func (w *AppsecRuntimeConfig) ProcessPreEvalRules(request *ParsedRequest) error
 			has_match = true
 		}
 		// here means there is no filter or the filter matched
+		w.traceHook("pre_eval", &rule)
+
 		for _, applyExpr := range rule.ApplyExpr {
 			o, err := exprhelpers.Run(applyExpr, GetPreEvalEnv(w, request), w.Logger, w.Logger.Level >= log.DebugLevel)
 			if err != nil {
@@ -563,6 +780,8 @@ func (w *AppsecRuntimeConfig) ProcessPostEvalRules(request *ParsedRequest) error
 			has_match = true
 		}
 		// here means there is no filter or the filter matched
+		w.traceHook("post_eval", &rule)
+
 		for _, applyExpr := range rule.ApplyExpr {
 			o, err := exprhelpers.Run(applyExpr, GetPostEvalEnv(w, request), w.Logger, w.Logger.Level >= log.DebugLevel)
 			if err != nil {
@@ -723,14 +942,20 @@ func (w *AppsecRuntimeConfig) SetHTTPCode(code int) error {
 }
 
 type BodyResponse struct {
-	Action     string `json:"action"`
-	HTTPStatus int    `json:"http_status"`
+	Action      string            `json:"action"`
+	HTTPStatus  int               `json:"http_status"`
+	UserBody    string            `json:"user_body,omitempty"`    // to send to the user instead of handling the action, if set
+	UserHeaders map[string]string `json:"user_headers,omitempty"` // to send with the user body
 }
 
 func (w *AppsecRuntimeConfig) GenerateResponse(response AppsecTempResponse, logger *log.Entry) (int, BodyResponse) {
 	var bouncerStatusCode int
 
-	resp := BodyResponse{Action: response.Action}
+	resp := BodyResponse{
+		Action:      response.Action,
+		UserBody:    string(response.UserBody),
+		UserHeaders: response.UserHeaders,
+	}
 	if response.Action == AllowRemediation {
 		resp.HTTPStatus = w.Config.UserPassedHTTPCode
 		bouncerStatusCode = w.Config.BouncerPassedHTTPCode
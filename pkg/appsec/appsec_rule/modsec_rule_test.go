@@ -155,6 +155,43 @@ SecRule ARGS_GET:bar "@rx [^a-zA-Z]" "id:271441587,phase:2,deny,log,msg:'Basic O
 SecRule ARGS_GET:bar "@rx [^a-zA-Z]" "id:1519945803,phase:2,deny,log,msg:'OR AND mix',tag:'crowdsec-OR AND mix',t:lowercase"
 SecRule ARGS_GET:foo "@rx [^a-zA-Z]" "id:1519945803,phase:2,deny,log,msg:'OR AND mix',tag:'crowdsec-OR AND mix',t:lowercase"`,
 		},
+		{
+			name: "Response header rule",
+			rule: CustomRule{
+				Zones:     []string{"RESPONSE_HEADERS"},
+				Variables: []string{"x-powered-by"},
+				Match:     Match{Type: "contains", Value: "php/5"},
+				Transform: []string{"lowercase"},
+			},
+			expected: `SecRule RESPONSE_HEADERS:x-powered-by "@contains php/5" "id:1877301197,phase:3,deny,log,msg:'Response header rule',tag:'crowdsec-Response header rule',t:lowercase"`,
+		},
+		{
+			name: "Response body rule with request zone",
+			rule: CustomRule{
+				And: []CustomRule{
+					{
+						Zones: []string{"URI"},
+						Match: Match{Type: "startsWith", Value: "/api/"},
+					},
+					{
+						Zones: []string{"RESPONSE_BODY"},
+						Match: Match{Type: "regex", Value: "Traceback \\(most recent call last\\)"},
+					},
+				},
+			},
+			expected: `SecRule REQUEST_FILENAME "@beginsWith /api/" "id:3624938871,phase:4,deny,log,msg:'Response body rule with request zone',tag:'crowdsec-Response body rule with request zone',chain"
+SecRule RESPONSE_BODY "@rx Traceback \(most recent call last\)" "id:3043224837,phase:4,deny,log,msg:'Response body rule with request zone',tag:'crowdsec-Response body rule with request zone'"`,
+		},
+		{
+			name: "GraphQL depth",
+			rule: CustomRule{
+				Zones:     []string{"BODY_ARGS"},
+				Variables: []string{"graphql.query"},
+				Match:     Match{Type: "graphqlDepth", Value: "10"},
+				BodyType:  "graphql",
+			},
+			expected: `SecRule ARGS_POST:graphql.query "@graphqlDepth 10" "id:3534308300,phase:2,deny,log,msg:'GraphQL depth',tag:'crowdsec-GraphQL depth',ctl:requestBodyProcessor=GRAPHQL"`,
+		},
 	}
 
 	for _, tt := range tests {
@@ -8,7 +8,8 @@ import (
 )
 
 type ModsecurityRule struct {
-	ids []uint32
+	ids   []uint32
+	phase int
 }
 
 var zonesMap = map[string]string{
@@ -29,6 +30,22 @@ var zonesMap = map[string]string{
 	"URI_FULL":         "REQUEST_URI",
 	"RAW_BODY":         "REQUEST_BODY",
 	"FILENAMES":        "FILES",
+
+	"RESPONSE_STATUS":        "RESPONSE_STATUS",
+	"RESPONSE_HEADERS":       "RESPONSE_HEADERS",
+	"RESPONSE_HEADERS_NAMES": "RESPONSE_HEADERS_NAMES",
+	"RESPONSE_CONTENT_TYPE":  "RESPONSE_CONTENT_TYPE",
+	"RESPONSE_BODY":          "RESPONSE_BODY",
+}
+
+// zones only available once the response is known: rules using them are evaluated
+// in the response headers (3) or response body (4) phase instead of the request body phase (2)
+var zonesPhase = map[string]int{
+	"RESPONSE_STATUS":        3,
+	"RESPONSE_HEADERS":       3,
+	"RESPONSE_HEADERS_NAMES": 3,
+	"RESPONSE_CONTENT_TYPE":  3,
+	"RESPONSE_BODY":          4,
 }
 
 var transformMap = map[string]string{
@@ -58,6 +75,9 @@ var matchMap = map[string]string{
 	"gte":             "@ge",
 	"lte":             "@le",
 	"eq":              "@eq",
+	// the values are parsed as GraphQL queries, matching when their depth or complexity exceeds the match value
+	"graphqlDepth":      "@graphqlDepth",
+	"graphqlComplexity": "@graphqlComplexity",
 }
 
 var bodyTypeMatch = map[string]string{
@@ -65,9 +85,13 @@ var bodyTypeMatch = map[string]string{
 	"xml":        "XML",
 	"multipart":  "MULTIPART",
 	"urlencoded": "URLENCODED",
+	"graphql":    "GRAPHQL",
 }
 
 func (m *ModsecurityRule) Build(rule *CustomRule, appsecRuleName string) (string, []uint32, error) {
+	// all the rules of a chain must be evaluated in the same phase
+	m.phase = rulePhase(rule)
+
 	rules, err := m.buildRules(rule, appsecRuleName, false, 0, 0)
 	if err != nil {
 		return "", nil, err
@@ -77,6 +101,25 @@ func (m *ModsecurityRule) Build(rule *CustomRule, appsecRuleName string) (string
 	return strings.Join(rules, "\n"), m.ids, nil
 }
 
+// rulePhase returns the latest phase required by the zones of the rule and its sub-rules
+func rulePhase(rule *CustomRule) int {
+	phase := 2
+
+	for _, zone := range rule.Zones {
+		phase = max(phase, zonesPhase[zone])
+	}
+
+	for _, sub := range rule.And {
+		phase = max(phase, rulePhase(&sub))
+	}
+
+	for _, sub := range rule.Or {
+		phase = max(phase, rulePhase(&sub))
+	}
+
+	return phase
+}
+
 func (m *ModsecurityRule) generateRuleID(rule *CustomRule, appsecRuleName string, depth int) uint32 {
 	h := fnv.New32a()
 	h.Write([]byte(appsecRuleName))
@@ -176,8 +219,7 @@ func (m *ModsecurityRule) buildRules(rule *CustomRule, appsecRuleName string, an
 		r.WriteString(fmt.Sprintf(`"%s%s %s"`, prefix, match, rule.Match.Value))
 	}
 
-	//Should phase:2 be configurable?
-	r.WriteString(fmt.Sprintf(` "id:%d,phase:2,deny,log,msg:'%s',tag:'crowdsec-%s'`, m.generateRuleID(rule, appsecRuleName, depth), appsecRuleName, appsecRuleName))
+	r.WriteString(fmt.Sprintf(` "id:%d,phase:%d,deny,log,msg:'%s',tag:'crowdsec-%s'`, m.generateRuleID(rule, appsecRuleName, depth), m.phase, appsecRuleName, appsecRuleName))
 
 	if rule.Transform != nil {
 		for _, transform := range rule.Transform {
@@ -2,6 +2,7 @@ package appsec
 
 import (
 	"fmt"
+	"hash/fnv"
 	"os"
 	"path/filepath"
 	"strings"
@@ -48,6 +49,29 @@ type RulesDetails struct {
 // Is using the id is a good idea ? might be too specific to coraza and not easily reusable
 var AppsecRulesDetails = make(map[int]RulesDetails)
 
+// txRule returns a rule matching when the TX variable is set, so that the checks done outside of coraza
+// (openapi schemas, rate limits) are reported, remediated and hooked like any other rule.
+// The id is a hash of the name: an error is returned if it's already used by another rule.
+func txRule(name string) (string, string, error) {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+	id := h.Sum32()
+
+	if details, ok := AppsecRulesDetails[int(id)]; ok && details.Name != name {
+		return "", "", fmt.Errorf("rule id %d of %s is already used by %s, rename it", id, name, details.Name)
+	}
+
+	variable := fmt.Sprintf("crowdsec_%d", id)
+	rule := fmt.Sprintf(`SecRule TX:%s "@rx ." "id:%d,phase:1,deny,log,msg:'%s',tag:'crowdsec-%s',logdata:'%%{MATCHED_VAR}'"`, variable, id, name, name)
+
+	AppsecRulesDetails[int(id)] = RulesDetails{
+		LogLevel: log.InfoLevel,
+		Name:     name,
+	}
+
+	return variable, rule, nil
+}
+
 func LoadCollection(pattern string, logger *log.Entry) ([]AppsecCollection, error) {
 	ret := make([]AppsecCollection, 0)
 
@@ -0,0 +1,113 @@
+package appsec
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/bot"
+	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
+)
+
+// BotSignaturesDataType is the type of the data files of an appsec-config holding bot signatures
+const BotSignaturesDataType = "bot_signatures"
+
+// the transaction variables set with the classification, for the rules: TX:bot_score and TX:bot_class
+const (
+	BotScoreVariable = "bot_score"
+	BotClassVariable = "bot_class"
+)
+
+// BotDetectionConfig classifies the clients with the signatures of the data files of type bot_signatures.
+// The classification is available as bot_score and bot_class in the hooks, and as TX variables in the rules.
+type BotDetectionConfig struct {
+	Enabled        bool          `yaml:"enabled"`
+	VerifyCrawlers bool          `yaml:"verify_crawlers"` // verify the crawlers with their reverse DNS, in band: disabled by default
+	DNSTimeout     time.Duration `yaml:"dns_timeout"`
+	CacheSize      int           `yaml:"cache_size"` // verified crawler IPs kept in memory
+	CacheTTL       time.Duration `yaml:"cache_ttl"`
+}
+
+// loadData loads the data files of the configuration, like the other hub items do.
+// The bot signatures are returned, the other files are made available to the expr helpers.
+func (w *AppsecRuntimeConfig) loadData(wc *AppsecConfig) ([]*bot.File, error) {
+	var signatures []*bot.File
+
+	dataDir := wc.GetDataDir()
+
+	for _, data := range wc.Data {
+		if data.Type != BotSignaturesDataType {
+			if err := exprhelpers.FileInit(dataDir, data.DestPath, data.Type); err != nil {
+				return nil, fmt.Errorf("unable to load data file %s: %w", data.DestPath, err)
+			}
+
+			continue
+		}
+
+		f, err := bot.LoadFile(filepath.Join(dataDir, data.DestPath))
+		if err != nil {
+			return nil, fmt.Errorf("unable to load bot signatures: %w", err)
+		}
+
+		signatures = append(signatures, f)
+	}
+
+	return signatures, nil
+}
+
+func (w *AppsecRuntimeConfig) loadBotDetection(wc *AppsecConfig, signatures []*bot.File) {
+	cfg := wc.BotDetection
+
+	if !cfg.Enabled {
+		if len(signatures) > 0 {
+			w.Logger.Debugf("bot signatures loaded but bot detection is disabled")
+		}
+
+		return
+	}
+
+	opts := bot.Options{
+		DNSTimeout: cfg.DNSTimeout,
+		CacheSize:  cfg.CacheSize,
+		CacheTTL:   cfg.CacheTTL,
+	}
+
+	if cfg.VerifyCrawlers {
+		opts.Resolver = net.DefaultResolver
+	}
+
+	w.BotClassifier = bot.NewClassifier(signatures, opts)
+
+	nbSignatures, nbCrawlers := w.BotClassifier.Signatures()
+	w.Logger.Infof("bot detection enabled with %d signatures and %d crawlers", nbSignatures, nbCrawlers)
+}
+
+// ClassifyBot scores the client of the request, before the hooks and rules are evaluated
+func (w *AppsecRuntimeConfig) ClassifyBot(request *ParsedRequest) {
+	if w.BotClassifier == nil || request.IsResponse || request.HTTPRequest == nil {
+		return
+	}
+
+	request.Bot = w.BotClassifier.Classify(&bot.Request{
+		IP:          request.ClientIP,
+		HTTPRequest: request.HTTPRequest,
+		JA4:         request.JA4,
+		JA3:         request.JA3,
+		HeaderOrder: request.HeaderOrder,
+	})
+
+	w.Logger.Debugf("request %s classified as %s (score %d, signature %q)", request.UUID, request.Bot.Class, request.Bot.Score, request.Bot.Name)
+}
+
+// SetBotVariables exposes the classification of the request to the rules of the transaction
+func (w *AppsecRuntimeConfig) SetBotVariables(request *ParsedRequest) {
+	if request.Bot.Class == "" {
+		return
+	}
+
+	tx := request.Tx.Variables().TX()
+	tx.Set(BotScoreVariable, []string{strconv.Itoa(request.Bot.Score)})
+	tx.Set(BotClassVariable, []string{request.Bot.Class})
+}
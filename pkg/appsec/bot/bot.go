@@ -0,0 +1,288 @@
+// Package bot classifies the clients of the appsec component from the fingerprints of their requests
+// (JA4H, header order, JA4/JA3 forwarded by the remediation component), the verification of the
+// crawlers they claim to be, and the shape of their requests.
+package bot
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/bluele/gcache"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/ja4h"
+)
+
+const (
+	ClassVerifiedCrawler = "verified_crawler" // the crawler was verified, score 0
+	ClassFakeCrawler     = "fake_crawler"     // the client claims to be a crawler but is not, score 100
+	ClassSuspicious      = "suspicious"       // no signature matched, but the request looks automated
+	ClassUnknown         = "unknown"
+)
+
+// SuspiciousScore is the score from which the requests not matching any signature are suspicious
+const SuspiciousScore = 50
+
+// scores of the requests not matching any signature, added up
+const (
+	noUserAgentScore            = 40
+	noAcceptScore               = 20
+	browserWithoutLanguageScore = 30
+	oldProtocolScore            = 20
+)
+
+const (
+	defaultDNSTimeout = time.Second
+	defaultCacheSize  = 10000
+	defaultCacheTTL   = time.Hour
+	// the failed lookups are cached too, so that a client can't slow down all its requests with a crawler user agent
+	failedLookupTTL = 5 * time.Minute
+)
+
+// the outcome of the verification of a crawler IP, kept in the cache
+type verification int
+
+const (
+	verified verification = iota
+	notVerified
+	lookupFailed
+)
+
+// Resolver is implemented by net.Resolver
+type Resolver interface {
+	LookupAddr(ctx context.Context, addr string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+}
+
+type Options struct {
+	Resolver   Resolver      // verifies the crawlers with their reverse DNS, only their IP ranges are used if nil
+	DNSTimeout time.Duration // for each lookup
+	CacheSize  int           // verified IPs and failed lookups kept in memory
+	CacheTTL   time.Duration
+}
+
+// Request is what the classification uses from a request
+type Request struct {
+	IP          string
+	HTTPRequest *http.Request // the original request, for its headers and JA4H
+	JA4         string
+	JA3         string
+	HeaderOrder []string
+}
+
+// Result is the classification of a request
+type Result struct {
+	Score int    // between 0 (legitimate) and 100 (bot)
+	Class string // the class of the signature matched, or one of the Class* constants
+	Name  string // the signature or crawler matched
+}
+
+// Classifier is safe for concurrent use
+type Classifier struct {
+	signatures []Signature
+	crawlers   []Crawler
+	needJA4H   bool
+	opts       Options
+	verified   gcache.Cache
+}
+
+func NewClassifier(files []*File, opts Options) *Classifier {
+	if opts.DNSTimeout <= 0 {
+		opts.DNSTimeout = defaultDNSTimeout
+	}
+
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = defaultCacheSize
+	}
+
+	if opts.CacheTTL <= 0 {
+		opts.CacheTTL = defaultCacheTTL
+	}
+
+	c := &Classifier{
+		opts:     opts,
+		verified: gcache.New(opts.CacheSize).LRU().Expiration(opts.CacheTTL).Build(),
+	}
+
+	for _, f := range files {
+		c.signatures = append(c.signatures, f.Signatures...)
+		c.crawlers = append(c.crawlers, f.Crawlers...)
+	}
+
+	c.needJA4H = slices.ContainsFunc(c.signatures, func(s Signature) bool {
+		return len(s.JA4H) > 0
+	})
+
+	return c
+}
+
+// Signatures returns the number of signatures and crawlers loaded
+func (c *Classifier) Signatures() (int, int) {
+	return len(c.signatures), len(c.crawlers)
+}
+
+// Classify scores a request: the crawlers it claims to be are verified first, then the signatures
+// are matched (the highest score wins), and the shape of the request is scored when none matches
+func (c *Classifier) Classify(req *Request) Result {
+	userAgent := req.HTTPRequest.UserAgent()
+
+	for i := range c.crawlers {
+		crawler := &c.crawlers[i]
+
+		if !crawler.userAgent.MatchString(userAgent) {
+			continue
+		}
+
+		verified, ok := c.verify(crawler, req.IP)
+		if !ok {
+			// the DNS did not answer, the request is classified like any other
+			break
+		}
+
+		if verified {
+			return Result{Score: 0, Class: ClassVerifiedCrawler, Name: crawler.Name}
+		}
+
+		return Result{Score: 100, Class: ClassFakeCrawler, Name: crawler.Name}
+	}
+
+	var fingerprint string
+	if c.needJA4H {
+		fingerprint = ja4h.JA4H(req.HTTPRequest)
+	}
+
+	var best *Signature
+
+	for i := range c.signatures {
+		s := &c.signatures[i]
+		if (best == nil || s.Score > best.Score) && s.match(req, fingerprint) {
+			best = s
+		}
+	}
+
+	if best != nil {
+		return Result{Score: best.Score, Class: best.Class, Name: best.Name}
+	}
+
+	score := shapeScore(req.HTTPRequest)
+
+	class := ClassUnknown
+	if score >= SuspiciousScore {
+		class = ClassSuspicious
+	}
+
+	return Result{Score: score, Class: class}
+}
+
+// shapeScore scores the headers browsers always send, and the outdated protocols
+func shapeScore(req *http.Request) int {
+	score := 0
+
+	userAgent := req.UserAgent()
+
+	if userAgent == "" {
+		score += noUserAgentScore
+	}
+
+	if req.Header.Get("Accept") == "" {
+		score += noAcceptScore
+	}
+
+	if strings.HasPrefix(userAgent, "Mozilla/") && req.Header.Get("Accept-Language") == "" {
+		score += browserWithoutLanguageScore
+	}
+
+	if req.ProtoMajor == 1 && req.ProtoMinor == 0 {
+		score += oldProtocolScore
+	}
+
+	return min(score, 100)
+}
+
+// verify checks the IP of a client claiming to be a crawler. It's not ok if the DNS did not answer.
+func (c *Classifier) verify(crawler *Crawler, addr string) (bool, bool) {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false, true
+	}
+
+	if crawler.inRanges(ip) {
+		return true, true
+	}
+
+	if len(crawler.Domains) == 0 {
+		return false, true
+	}
+
+	if c.opts.Resolver == nil {
+		return false, false
+	}
+
+	key := crawler.Name + "/" + ip.String()
+
+	if cached, err := c.verified.Get(key); err == nil {
+		result, _ := cached.(verification)
+		return result == verified, result != lookupFailed
+	}
+
+	ok, err := c.verifyDNS(crawler, ip)
+	if err != nil {
+		_ = c.verified.SetWithExpire(key, lookupFailed, failedLookupTTL)
+		return false, false
+	}
+
+	result := notVerified
+	if ok {
+		result = verified
+	}
+
+	_ = c.verified.Set(key, result)
+
+	return ok, true
+}
+
+// verifyDNS checks that the reverse DNS of the IP is in the domains of the crawler, and resolves to the IP
+func (c *Classifier) verifyDNS(crawler *Crawler, ip net.IP) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.opts.DNSTimeout)
+	defer cancel()
+
+	names, err := c.opts.Resolver.LookupAddr(ctx, ip.String())
+	if isNotFound(err) {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	for _, name := range names {
+		if !crawler.matchDomain(name) {
+			continue
+		}
+
+		addrs, err := c.opts.Resolver.LookupIPAddr(ctx, name)
+		if isNotFound(err) {
+			continue
+		}
+
+		if err != nil {
+			return false, err
+		}
+
+		if slices.ContainsFunc(addrs, func(a net.IPAddr) bool { return a.IP.Equal(ip) }) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,294 @@
+package bot
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+const testSignatures = `
+signatures:
+  - name: python-requests
+    class: automation
+    score: 70
+    user_agent: "^python-requests/"
+  - name: headless-chrome
+    class: bad_bot
+    score: 90
+    user_agent: "HeadlessChrome"
+  - name: scanner
+    class: bad_bot
+    score: 100
+    ja4: ["t13d1516h2_8daaf6152771_*"]
+  - name: curl
+    class: automation
+    score: 60
+    header_order: ["Host, User-Agent, Accept"]
+crawlers:
+  - name: googlebot
+    user_agent: "Googlebot"
+    domains: [googlebot.com, .google.com.]
+  - name: bingbot
+    user_agent: "bingbot"
+    ranges: ["157.55.39.0/24"]
+`
+
+type fakeResolver struct {
+	ptr     map[string][]string
+	hosts   map[string][]string
+	err     error
+	lookups int
+}
+
+func (r *fakeResolver) LookupAddr(_ context.Context, addr string) ([]string, error) {
+	r.lookups++
+
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	names, ok := r.ptr[addr]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: addr, IsNotFound: true}
+	}
+
+	return names, nil
+}
+
+func (r *fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	var ret []net.IPAddr
+
+	for _, ip := range r.hosts[host] {
+		ret = append(ret, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+
+	if len(ret) == 0 {
+		return nil, &net.DNSError{Err: "no such host", Name: host, IsNotFound: true}
+	}
+
+	return ret, nil
+}
+
+func loadTestFile(t *testing.T, content string) *File {
+	path := filepath.Join(t.TempDir(), "signatures.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+
+	f, err := LoadFile(path)
+	require.NoError(t, err)
+
+	return f
+}
+
+func newRequest(ip string, headers map[string]string) *Request {
+	req := &http.Request{Header: http.Header{}, ProtoMajor: 1, ProtoMinor: 1}
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	return &Request{IP: ip, HTTPRequest: req}
+}
+
+func TestClassify(t *testing.T) {
+	resolver := &fakeResolver{
+		ptr: map[string][]string{
+			"66.249.66.1": {"crawl-66-249-66-1.googlebot.com."},
+			"1.2.3.4":     {"crawl.googlebot.com.evil.example."},
+			"5.6.7.8":     {"spoofed.googlebot.com."},
+		},
+		hosts: map[string][]string{
+			"crawl-66-249-66-1.googlebot.com.": {"66.249.66.1"},
+			"spoofed.googlebot.com.":           {"66.249.66.2"},
+		},
+	}
+
+	c := NewClassifier([]*File{loadTestFile(t, testSignatures)}, Options{Resolver: resolver})
+
+	browser := map[string]string{
+		"User-Agent":      "Mozilla/5.0 (X11; Linux x86_64) Firefox/130.0",
+		"Accept":          "text/html",
+		"Accept-Language": "en-US,en;q=0.5",
+	}
+
+	tests := []struct {
+		name     string
+		req      *Request
+		expected Result
+	}{
+		{
+			name:     "browser",
+			req:      newRequest("1.2.3.4", browser),
+			expected: Result{Score: 0, Class: ClassUnknown},
+		},
+		{
+			name:     "signature on the user agent",
+			req:      newRequest("1.2.3.4", map[string]string{"User-Agent": "python-requests/2.32", "Accept": "*/*"}),
+			expected: Result{Score: 70, Class: "automation", Name: "python-requests"},
+		},
+		{
+			name: "signature on the JA4 prefix",
+			req: func() *Request {
+				r := newRequest("1.2.3.4", browser)
+				r.JA4 = "t13d1516h2_8daaf6152771_02713d6af862"
+				return r
+			}(),
+			expected: Result{Score: 100, Class: "bad_bot", Name: "scanner"},
+		},
+		{
+			name: "signature on the header order",
+			req: func() *Request {
+				r := newRequest("1.2.3.4", map[string]string{"User-Agent": "curl/8.0", "Accept": "*/*"})
+				r.HeaderOrder = ParseHeaderOrder("host,user-agent,accept")
+				return r
+			}(),
+			expected: Result{Score: 60, Class: "automation", Name: "curl"},
+		},
+		{
+			name: "the highest score wins",
+			req: func() *Request {
+				r := newRequest("1.2.3.4", map[string]string{"User-Agent": "python-requests/2.32", "Accept": "*/*"})
+				r.JA4 = "t13d1516h2_8daaf6152771_02713d6af862"
+				return r
+			}(),
+			expected: Result{Score: 100, Class: "bad_bot", Name: "scanner"},
+		},
+		{
+			name:     "no user agent, no accept",
+			req:      newRequest("1.2.3.4", nil),
+			expected: Result{Score: 60, Class: ClassSuspicious},
+		},
+		{
+			name:     "browser without language",
+			req:      newRequest("1.2.3.4", map[string]string{"User-Agent": "Mozilla/5.0", "Accept": "text/html"}),
+			expected: Result{Score: 30, Class: ClassUnknown},
+		},
+		{
+			name:     "crawler verified by reverse DNS",
+			req:      newRequest("66.249.66.1", map[string]string{"User-Agent": "Mozilla/5.0 (compatible; Googlebot/2.1)"}),
+			expected: Result{Score: 0, Class: ClassVerifiedCrawler, Name: "googlebot"},
+		},
+		{
+			name:     "crawler with a reverse DNS outside its domains",
+			req:      newRequest("1.2.3.4", map[string]string{"User-Agent": "Googlebot/2.1"}),
+			expected: Result{Score: 100, Class: ClassFakeCrawler, Name: "googlebot"},
+		},
+		{
+			name:     "crawler with a reverse DNS not resolving to the IP",
+			req:      newRequest("5.6.7.8", map[string]string{"User-Agent": "Googlebot/2.1"}),
+			expected: Result{Score: 100, Class: ClassFakeCrawler, Name: "googlebot"},
+		},
+		{
+			name:     "crawler without reverse DNS",
+			req:      newRequest("9.9.9.9", map[string]string{"User-Agent": "Googlebot/2.1"}),
+			expected: Result{Score: 100, Class: ClassFakeCrawler, Name: "googlebot"},
+		},
+		{
+			name:     "crawler verified by IP range",
+			req:      newRequest("157.55.39.12", map[string]string{"User-Agent": "Mozilla/5.0 (compatible; bingbot/2.0)"}),
+			expected: Result{Score: 0, Class: ClassVerifiedCrawler, Name: "bingbot"},
+		},
+		{
+			name:     "crawler outside its IP ranges",
+			req:      newRequest("1.2.3.4", map[string]string{"User-Agent": "bingbot/2.0"}),
+			expected: Result{Score: 100, Class: ClassFakeCrawler, Name: "bingbot"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, c.Classify(tc.req))
+		})
+	}
+
+	// the verifications are cached
+	lookups := resolver.lookups
+	c.Classify(newRequest("66.249.66.1", map[string]string{"User-Agent": "Googlebot/2.1"}))
+	assert.Equal(t, lookups, resolver.lookups)
+}
+
+func TestClassifyLookupFailed(t *testing.T) {
+	resolver := &fakeResolver{err: &net.DNSError{Err: "i/o timeout", Name: "66.249.66.1", IsTimeout: true}}
+	c := NewClassifier([]*File{loadTestFile(t, testSignatures)}, Options{Resolver: resolver})
+
+	// the crawler can't be verified, the request is classified like any other
+	for range 3 {
+		res := c.Classify(newRequest("66.249.66.1", map[string]string{"User-Agent": "Googlebot/2.1", "Accept": "*/*"}))
+		assert.Equal(t, Result{Score: 0, Class: ClassUnknown}, res)
+	}
+
+	// and the failed lookup is not retried for each request
+	assert.Equal(t, 1, resolver.lookups)
+}
+
+func TestClassifyWithoutResolver(t *testing.T) {
+	c := NewClassifier([]*File{loadTestFile(t, testSignatures)}, Options{})
+
+	// the crawler can't be verified, the request is classified like any other
+	res := c.Classify(newRequest("66.249.66.1", map[string]string{"User-Agent": "Googlebot/2.1", "Accept": "*/*"}))
+	assert.Equal(t, Result{Score: 0, Class: ClassUnknown}, res)
+
+	// the ranges are still checked
+	res = c.Classify(newRequest("1.2.3.4", map[string]string{"User-Agent": "bingbot/2.0"}))
+	assert.Equal(t, Result{Score: 100, Class: ClassFakeCrawler, Name: "bingbot"}, res)
+}
+
+func TestLoadFile(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		expectedErr string
+	}{
+		{
+			name:        "unknown field",
+			content:     "signatures:\n  - name: foo\n    class: bar\n    ja5: [x]\n",
+			expectedErr: "field ja5 not found",
+		},
+		{
+			name:        "missing class",
+			content:     "signatures:\n  - name: foo\n    ja4: [x]\n",
+			expectedErr: "signature foo: missing class",
+		},
+		{
+			name:        "no criteria",
+			content:     "signatures:\n  - name: foo\n    class: bar\n",
+			expectedErr: "signature foo: no criteria",
+		},
+		{
+			name:        "invalid score",
+			content:     "signatures:\n  - name: foo\n    class: bar\n    score: 200\n    ja4: [x]\n",
+			expectedErr: "signature foo: score must be between 0 and 100",
+		},
+		{
+			name:        "invalid regexp",
+			content:     "signatures:\n  - name: foo\n    class: bar\n    user_agent: \"(\"\n",
+			expectedErr: "signature foo: invalid user_agent",
+		},
+		{
+			name:        "crawler without verification",
+			content:     "crawlers:\n  - name: foo\n    user_agent: foo\n",
+			expectedErr: "crawler foo: domains or ranges are required to verify it",
+		},
+		{
+			name:        "invalid range",
+			content:     "crawlers:\n  - name: foo\n    user_agent: foo\n    ranges: [1.2.3.4]\n",
+			expectedErr: "crawler foo: invalid range",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "signatures.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tc.content), 0o644))
+
+			_, err := LoadFile(path)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
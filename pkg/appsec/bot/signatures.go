@@ -0,0 +1,208 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Signature identifies a bot, or a family of bots, by the fingerprints of its requests.
+// All the criteria set must match, any of the values of a criterion can match.
+// The fingerprints ending with * match by prefix, to match only the first sections of a JA4H or a JA4.
+type Signature struct {
+	Name        string   `yaml:"name"`
+	Class       string   `yaml:"class"`
+	Score       int      `yaml:"score"` // between 0 (legitimate) and 100 (bot)
+	JA4H        []string `yaml:"ja4h"`
+	JA4         []string `yaml:"ja4"`
+	JA3         []string `yaml:"ja3"`
+	HeaderOrder []string `yaml:"header_order"` // comma separated header names, in the order sent by the client
+	UserAgent   string   `yaml:"user_agent"`   // regexp
+
+	userAgent *regexp.Regexp
+}
+
+// Crawler is a known crawler, verified with its IP ranges or the reverse DNS of its IPs
+type Crawler struct {
+	Name      string   `yaml:"name"`
+	UserAgent string   `yaml:"user_agent"` // regexp matching the user agents claiming to be the crawler
+	Domains   []string `yaml:"domains"`    // the reverse DNS of its IPs ends with one of them, and resolves to the IP
+	Ranges    []string `yaml:"ranges"`     // CIDR
+
+	userAgent *regexp.Regexp
+	ranges    []*net.IPNet
+}
+
+// File is the content of a signature data file
+type File struct {
+	Signatures []Signature `yaml:"signatures"`
+	Crawlers   []Crawler   `yaml:"crawlers"`
+}
+
+// LoadFile reads and compiles a signature data file
+func LoadFile(path string) (*File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+
+	if err := yaml.UnmarshalStrict(content, &f); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	if err := f.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return &f, nil
+}
+
+func (f *File) compile() error {
+	for i := range f.Signatures {
+		if err := f.Signatures[i].compile(); err != nil {
+			return err
+		}
+	}
+
+	for i := range f.Crawlers {
+		if err := f.Crawlers[i].compile(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Signature) compile() error {
+	if s.Name == "" {
+		return errors.New("signature without name")
+	}
+
+	if s.Class == "" {
+		return fmt.Errorf("signature %s: missing class", s.Name)
+	}
+
+	if s.Score < 0 || s.Score > 100 {
+		return fmt.Errorf("signature %s: score must be between 0 and 100", s.Name)
+	}
+
+	if len(s.JA4H) == 0 && len(s.JA4) == 0 && len(s.JA3) == 0 && len(s.HeaderOrder) == 0 && s.UserAgent == "" {
+		return fmt.Errorf("signature %s: no criteria", s.Name)
+	}
+
+	for i, order := range s.HeaderOrder {
+		s.HeaderOrder[i] = strings.Join(ParseHeaderOrder(order), ",")
+	}
+
+	if s.UserAgent != "" {
+		re, err := regexp.Compile(s.UserAgent)
+		if err != nil {
+			return fmt.Errorf("signature %s: invalid user_agent: %w", s.Name, err)
+		}
+
+		s.userAgent = re
+	}
+
+	return nil
+}
+
+func (c *Crawler) compile() error {
+	if c.Name == "" {
+		return errors.New("crawler without name")
+	}
+
+	if c.UserAgent == "" {
+		return fmt.Errorf("crawler %s: missing user_agent", c.Name)
+	}
+
+	if len(c.Domains) == 0 && len(c.Ranges) == 0 {
+		return fmt.Errorf("crawler %s: domains or ranges are required to verify it", c.Name)
+	}
+
+	re, err := regexp.Compile(c.UserAgent)
+	if err != nil {
+		return fmt.Errorf("crawler %s: invalid user_agent: %w", c.Name, err)
+	}
+
+	c.userAgent = re
+
+	for i, domain := range c.Domains {
+		c.Domains[i] = strings.ToLower(strings.Trim(domain, "."))
+	}
+
+	for _, r := range c.Ranges {
+		_, ipNet, err := net.ParseCIDR(r)
+		if err != nil {
+			return fmt.Errorf("crawler %s: invalid range: %w", c.Name, err)
+		}
+
+		c.ranges = append(c.ranges, ipNet)
+	}
+
+	return nil
+}
+
+// ParseHeaderOrder normalizes the header names forwarded by the remediation component
+func ParseHeaderOrder(value string) []string {
+	var ret []string
+
+	for _, name := range strings.Split(value, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" {
+			ret = append(ret, name)
+		}
+	}
+
+	return ret
+}
+
+func matchFingerprint(patterns []string, value string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+
+	if value == "" {
+		return false
+	}
+
+	return slices.ContainsFunc(patterns, func(p string) bool {
+		if prefix, ok := strings.CutSuffix(p, "*"); ok {
+			return strings.HasPrefix(value, prefix)
+		}
+
+		return p == value
+	})
+}
+
+func (s *Signature) match(req *Request, ja4h string) bool {
+	if s.userAgent != nil && !s.userAgent.MatchString(req.HTTPRequest.UserAgent()) {
+		return false
+	}
+
+	return matchFingerprint(s.JA4H, ja4h) &&
+		matchFingerprint(s.JA4, req.JA4) &&
+		matchFingerprint(s.JA3, req.JA3) &&
+		matchFingerprint(s.HeaderOrder, strings.Join(req.HeaderOrder, ","))
+}
+
+func (c *Crawler) inRanges(ip net.IP) bool {
+	return slices.ContainsFunc(c.ranges, func(r *net.IPNet) bool {
+		return r.Contains(ip)
+	})
+}
+
+func (c *Crawler) matchDomain(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	return slices.ContainsFunc(c.Domains, func(domain string) bool {
+		return host == domain || strings.HasSuffix(host, "."+domain)
+	})
+}
@@ -0,0 +1,326 @@
+package appsec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+const (
+	CaptureBlocked = "blocked"
+	CaptureSampled = "sampled"
+)
+
+const (
+	defaultCaptureMaxSize  = 100 // MB
+	defaultCaptureMaxFiles = 5
+)
+
+// CaptureConfig stores the requests blocked in band, and a sample of the others, so they can be replayed
+// against a local configuration with cscli appsec replay
+type CaptureConfig struct {
+	Enabled    bool          `yaml:"enabled"`
+	Path       string        `yaml:"path"`        // <data_dir>/appsec_capture_<name>.jsonl if empty
+	MaxSize    int           `yaml:"max_size"`    // in MB, before the file is rotated
+	MaxFiles   int           `yaml:"max_files"`   // rotated files to keep
+	Compress   bool          `yaml:"compress"`    // gzip the rotated files
+	Blocked    *bool         `yaml:"blocked"`     // capture the requests blocked in band, true by default
+	SampleRate float64       `yaml:"sample_rate"` // fraction of the other requests to capture, between 0 and 1
+	Redact     CaptureRedact `yaml:"redact"`
+}
+
+// CaptureRedact removes the sensitive parts of the captured requests, like DumpRequest() does
+type CaptureRedact struct {
+	HeadersNames   []string `yaml:"headers_names"` // cookie and authorization if not set
+	HeadersContent []string `yaml:"headers_content"`
+	ArgsNames      []string `yaml:"args_names"`
+	ArgsContent    []string `yaml:"args_content"`
+	DropBody       bool     `yaml:"drop_body"`
+}
+
+// CapturedRequest is a line of a capture file
+type CapturedRequest struct {
+	Time    time.Time      `json:"time"`
+	Reason  string         `json:"reason"`           // blocked or sampled
+	Action  string         `json:"action,omitempty"` // the remediation returned in band
+	Request *ParsedRequest `json:"request"`
+}
+
+type captureSink struct {
+	cfg CaptureConfig
+	mu  sync.Mutex
+	out *lumberjack.Logger
+}
+
+// CaptureOutput returns the default capture file for an appsec datasource
+func CaptureOutput(dataDir string, name string) string {
+	if name == "" {
+		name = "default"
+	}
+
+	return filepath.Join(dataDir, "appsec_capture_"+unsafeFilenameChars.ReplaceAllString(name, "_")+".jsonl")
+}
+
+func (cc *CaptureConfig) Validate() error {
+	if cc.SampleRate < 0 || cc.SampleRate > 1 {
+		return fmt.Errorf("invalid capture sample_rate %v: must be between 0 and 1", cc.SampleRate)
+	}
+
+	if cc.MaxSize < 0 || cc.MaxFiles < 0 {
+		return errors.New("invalid capture max_size or max_files: must be positive")
+	}
+
+	return nil
+}
+
+func (w *AppsecRuntimeConfig) loadCapture(wc *AppsecConfig) error {
+	cfg := wc.Capture
+
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.Path == "" {
+		cfg.Path = CaptureOutput(wc.GetDataDir(), wc.Name)
+	}
+
+	if cfg.MaxSize == 0 {
+		cfg.MaxSize = defaultCaptureMaxSize
+	}
+
+	if cfg.MaxFiles == 0 {
+		cfg.MaxFiles = defaultCaptureMaxFiles
+	}
+
+	if cfg.Redact.HeadersNames == nil {
+		cfg.Redact.HeadersNames = []string{"cookie", "authorization"}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(cfg.Path), 0o755); err != nil {
+		return fmt.Errorf("unable to create directory for %s: %w", cfg.Path, err)
+	}
+
+	w.Capture = &captureSink{
+		cfg: cfg,
+		out: &lumberjack.Logger{
+			Filename:   cfg.Path,
+			MaxSize:    cfg.MaxSize,
+			MaxBackups: cfg.MaxFiles,
+			Compress:   cfg.Compress,
+		},
+	}
+
+	w.Logger.Infof("capturing requests in %s", cfg.Path)
+
+	return nil
+}
+
+func (s *captureSink) wants(blocked bool) (string, bool) {
+	if blocked && (s.cfg.Blocked == nil || *s.cfg.Blocked) {
+		return CaptureBlocked, true
+	}
+
+	if s.cfg.SampleRate > 0 && rand.Float64() < s.cfg.SampleRate { //nolint:gosec // no need for a secure random to sample
+		return CaptureSampled, true
+	}
+
+	return "", false
+}
+
+// redact returns the request to store: the redacted headers, args and body of DumpRequest(),
+// with what identifies the request, and a query string without the redacted args
+func (s *captureSink) redact(request *ParsedRequest) *ParsedRequest {
+	filter := request.DumpRequest().NoFilters()
+	filter.HeadersNameFilters = s.cfg.Redact.HeadersNames
+	filter.HeadersContentFilters = s.cfg.Redact.HeadersContent
+	filter.ArgsNameFilters = s.cfg.Redact.ArgsNames
+	filter.ArgsContentFilters = s.cfg.Redact.ArgsContent
+	filter.BodyDrop = s.cfg.Redact.DropBody
+
+	filtered := filter.GetFilteredRequest()
+
+	// nothing is redacted
+	if filtered == request {
+		return request
+	}
+
+	ret := &ParsedRequest{
+		RemoteAddr:       request.RemoteAddr,
+		Host:             request.Host,
+		ClientIP:         request.ClientIP,
+		URI:              request.URI,
+		ClientHost:       request.ClientHost,
+		Method:           request.Method,
+		Proto:            request.Proto,
+		TransferEncoding: request.TransferEncoding,
+		UUID:             request.UUID,
+		JA4:              request.JA4,
+		JA3:              request.JA3,
+		HeaderOrder:      request.HeaderOrder,
+		Headers:          filtered.Headers,
+		Body:             filtered.Body,
+		Args:             filtered.Args,
+	}
+
+	if len(s.cfg.Redact.ArgsNames) > 0 || len(s.cfg.Redact.ArgsContent) > 0 {
+		if path, query, ok := strings.Cut(request.URI, "?"); ok && query != "" {
+			ret.URI = path
+			if len(ret.Args) > 0 {
+				ret.URI += "?" + ret.Args.Encode()
+			}
+		}
+	}
+
+	return ret
+}
+
+// CloseCapture closes the capture file, if any
+func (w *AppsecRuntimeConfig) CloseCapture() error {
+	if w.Capture == nil {
+		return nil
+	}
+
+	w.Capture.mu.Lock()
+	defer w.Capture.mu.Unlock()
+
+	return w.Capture.out.Close()
+}
+
+// CaptureRequest stores the request if it was blocked in band, or if it's sampled
+func (w *AppsecRuntimeConfig) CaptureRequest(request *ParsedRequest, response AppsecTempResponse) {
+	if w.Capture == nil || request.IsResponse {
+		return
+	}
+
+	reason, ok := w.Capture.wants(response.InBandInterrupt)
+	if !ok {
+		return
+	}
+
+	captured := CapturedRequest{
+		Time:    time.Now().UTC(),
+		Reason:  reason,
+		Request: w.Capture.redact(request),
+	}
+
+	if response.InBandInterrupt {
+		captured.Action = response.Action
+	}
+
+	line, err := json.Marshal(captured)
+	if err != nil {
+		w.Logger.Errorf("unable to marshal captured request: %s", err)
+		return
+	}
+
+	w.Capture.mu.Lock()
+	defer w.Capture.mu.Unlock()
+
+	if _, err := w.Capture.out.Write(append(line, '\n')); err != nil {
+		w.Logger.Errorf("unable to write captured request: %s", err)
+	}
+}
+
+// ReadCapture reads the requests of a capture file
+func ReadCapture(path string) ([]CapturedRequest, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer fd.Close()
+
+	var ret []CapturedRequest
+
+	scanner := bufio.NewScanner(fd)
+	// the bodies can be large
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var captured CapturedRequest
+		if err := json.Unmarshal(scanner.Bytes(), &captured); err != nil {
+			return nil, fmt.Errorf("%s:%d: %w", path, line, err)
+		}
+
+		if captured.Request == nil {
+			return nil, fmt.Errorf("%s:%d: missing request", path, line)
+		}
+
+		ret = append(ret, captured)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("unable to read %s: %w", path, err)
+	}
+
+	return ret, nil
+}
+
+// HTTPRequest rebuilds the request the remediation component sent to the appsec component,
+// to parse it again with NewParsedRequestFromRequest
+func (c *CapturedRequest) HTTPRequest() (*http.Request, error) {
+	req := c.Request
+
+	httpReq, err := http.NewRequest(http.MethodPost, "http://appsec/", bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, err
+	}
+
+	for name, values := range req.Headers {
+		for _, v := range values {
+			httpReq.Header.Add(name, v)
+		}
+	}
+
+	httpReq.Header.Set(IPHeaderName, req.ClientIP)
+	httpReq.Header.Set(URIHeaderName, req.URI)
+	httpReq.Header.Set(VerbHeaderName, req.Method)
+	httpReq.Header.Set(HostHeaderName, req.ClientHost)
+
+	if ua := req.Headers.Get("User-Agent"); ua != "" {
+		httpReq.Header.Set(UserAgentHeaderName, ua)
+	}
+
+	if req.JA4 != "" {
+		httpReq.Header.Set(JA4HeaderName, req.JA4)
+	}
+
+	if req.JA3 != "" {
+		httpReq.Header.Set(JA3HeaderName, req.JA3)
+	}
+
+	if len(req.HeaderOrder) > 0 {
+		httpReq.Header.Set(HeaderOrderHeaderName, strings.Join(req.HeaderOrder, ","))
+	}
+
+	// HTTP/1.1 is forwarded as 11, HTTP/2 as 20
+	if version, ok := strings.CutPrefix(req.Proto, "HTTP/"); ok {
+		version = strings.ReplaceAll(version, ".", "")
+		if len(version) == 1 {
+			version += "0"
+		}
+
+		httpReq.Header.Set(HTTPVersionHeaderName, version)
+	}
+
+	httpReq.RemoteAddr = req.RemoteAddr
+	if httpReq.RemoteAddr == "" {
+		httpReq.RemoteAddr = "127.0.0.1:0"
+	}
+
+	return httpReq, nil
+}
@@ -0,0 +1,118 @@
+package appsec
+
+import (
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+)
+
+func TestCaptureRequest(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "capture.jsonl")
+
+	wc := AppsecConfig{
+		Logger: log.NewEntry(log.StandardLogger()),
+		Capture: CaptureConfig{
+			Enabled: true,
+			Path:    path,
+			Redact:  CaptureRedact{ArgsNames: []string{"^password$"}},
+		},
+	}
+
+	runtime, err := wc.Build()
+	require.NoError(t, err)
+
+	defer runtime.CloseCapture()
+
+	forwarded, err := http.NewRequest(http.MethodPost, "http://appsec/", strings.NewReader("password=hunter2"))
+	require.NoError(t, err)
+
+	forwarded.Header.Set(IPHeaderName, "1.2.3.4")
+	forwarded.Header.Set(URIHeaderName, "/login?user=admin&password=hunter2")
+	forwarded.Header.Set(VerbHeaderName, "POST")
+	forwarded.Header.Set(HostHeaderName, "example.com")
+	forwarded.Header.Set(HTTPVersionHeaderName, "20")
+	forwarded.Header.Set(UserAgentHeaderName, "curl/8.0")
+	forwarded.Header.Set("Cookie", "session=secret")
+	forwarded.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	forwarded.RemoteAddr = "127.0.0.1:4242"
+
+	request, err := NewParsedRequestFromRequest(forwarded, log.NewEntry(log.StandardLogger()))
+	require.NoError(t, err)
+
+	// not blocked and not sampled
+	runtime.CaptureRequest(&request, AppsecTempResponse{})
+
+	runtime.CaptureRequest(&request, AppsecTempResponse{InBandInterrupt: true, Action: BanRemediation})
+
+	captured, err := ReadCapture(path)
+	require.NoError(t, err)
+	require.Len(t, captured, 1)
+
+	assert.Equal(t, CaptureBlocked, captured[0].Reason)
+	assert.Equal(t, BanRemediation, captured[0].Action)
+
+	req := captured[0].Request
+	assert.Equal(t, "1.2.3.4", req.ClientIP)
+	assert.Equal(t, "/login?user=admin", req.URI)
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "HTTP/2", req.Proto)
+	assert.Equal(t, "password=hunter2", string(req.Body))
+	assert.Empty(t, req.Headers.Get("Cookie"))
+	assert.Equal(t, "curl/8.0", req.Headers.Get("User-Agent"))
+	assert.Equal(t, []string{"admin"}, req.Args["user"])
+	assert.NotContains(t, req.Args, "password")
+
+	// the captured request is forwarded again like the remediation component did
+	replayed, err := captured[0].HTTPRequest()
+	require.NoError(t, err)
+
+	parsed, err := NewParsedRequestFromRequest(replayed, log.NewEntry(log.StandardLogger()))
+	require.NoError(t, err)
+
+	assert.Equal(t, request.ClientIP, parsed.ClientIP)
+	assert.Equal(t, "/login?user=admin", parsed.URI)
+	assert.Equal(t, request.Method, parsed.Method)
+	assert.Equal(t, request.ClientHost, parsed.ClientHost)
+	assert.Equal(t, request.Proto, parsed.Proto)
+	assert.Equal(t, request.Body, parsed.Body)
+	assert.Equal(t, "curl/8.0", parsed.HTTPRequest.UserAgent())
+	assert.Equal(t, "/login", parsed.URL.Path)
+}
+
+func TestCaptureConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "capture.jsonl")
+
+	wc := AppsecConfig{
+		Logger:  log.NewEntry(log.StandardLogger()),
+		Capture: CaptureConfig{Enabled: true, Path: path, SampleRate: 2},
+	}
+
+	_, err := wc.Build()
+	require.ErrorContains(t, err, "invalid capture sample_rate 2")
+
+	// all the requests are sampled, none of the blocked ones are captured
+	wc.Capture = CaptureConfig{Enabled: true, Path: path, SampleRate: 1, Blocked: ptr.Of(false)}
+
+	runtime, err := wc.Build()
+	require.NoError(t, err)
+
+	defer runtime.CloseCapture()
+
+	request := &ParsedRequest{ClientIP: "1.2.3.4", URI: "/", Method: "GET"}
+
+	runtime.CaptureRequest(request, AppsecTempResponse{InBandInterrupt: true})
+	runtime.CaptureRequest(request, AppsecTempResponse{})
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, strings.Count(string(content), `"reason":"sampled"`))
+}
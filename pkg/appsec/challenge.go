@@ -0,0 +1,95 @@
+package appsec
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/challenge"
+)
+
+const (
+	defaultChallengeDifficulty = 16
+	defaultChallengeValidity   = time.Hour
+	defaultChallengeCookie     = "crowdsec_appsec_challenge"
+)
+
+// ChallengeConfig is used by the challenge remediation: instead of leaving the captcha to the
+// remediation component, the appsec component returns a proof-of-work page setting a signed cookie,
+// and lets the requests carrying a valid cookie through.
+type ChallengeConfig struct {
+	Secret     string        `yaml:"secret"`      // signs the cookies, random if empty: the cookies are then invalidated on restart
+	Difficulty int           `yaml:"difficulty"`  // leading zero bits of the proof of work, each one doubles the work
+	Validity   time.Duration `yaml:"validity"`    // of the cookie once the challenge is solved
+	CookieName string        `yaml:"cookie_name"` // crowdsec_appsec_challenge if empty
+}
+
+func (w *AppsecRuntimeConfig) loadChallenge(wc *AppsecConfig) error {
+	cfg := &wc.Challenge
+
+	if cfg.Difficulty == 0 {
+		cfg.Difficulty = defaultChallengeDifficulty
+	}
+
+	if cfg.Validity == 0 {
+		cfg.Validity = defaultChallengeValidity
+	}
+
+	if cfg.CookieName == "" {
+		cfg.CookieName = defaultChallengeCookie
+	}
+
+	secret := []byte(cfg.Secret)
+	if len(secret) == 0 {
+		w.Logger.Debugf("no challenge secret, the challenge cookies will be invalidated on restart")
+
+		secret = challenge.RandomSecret()
+	}
+
+	issuer, err := challenge.NewIssuer(secret, cfg.Difficulty, cfg.Validity)
+	if err != nil {
+		return fmt.Errorf("invalid challenge configuration: %w", err)
+	}
+
+	w.Challenge = issuer
+
+	return nil
+}
+
+// ProcessChallenge replaces the challenge remediation of the response with the challenge page, or lets
+// the request through without event nor alert if its client already solved the challenge.
+// The other remediations, such as bans, are left untouched whatever the cookie.
+func (w *AppsecRuntimeConfig) ProcessChallenge(request *ParsedRequest) {
+	if w.Response.Action != ChallengeRemediation || w.Challenge == nil || request.HTTPRequest == nil {
+		return
+	}
+
+	cookieName := w.Config.Challenge.CookieName
+	userAgent := request.HTTPRequest.UserAgent()
+
+	if cookie, err := request.HTTPRequest.Cookie(cookieName); err == nil {
+		err = w.Challenge.Verify(cookie.Value, request.ClientIP, userAgent, time.Now())
+		if err == nil {
+			w.Logger.Debugf("%s solved the challenge, letting request %s through", request.ClientIP, request.UUID)
+			w.ClearResponse()
+			w.Response.SendEvent = false
+			w.Response.SendAlert = false
+
+			return
+		}
+
+		w.Logger.Debugf("invalid challenge cookie from %s: %s", request.ClientIP, err)
+	}
+
+	body, err := w.Challenge.Page(w.Challenge.Issue(request.ClientIP, userAgent, time.Now()), cookieName)
+	if err != nil {
+		// the remediation component gets the challenge action without a page, and handles it like a captcha
+		w.Logger.Errorf("unable to render challenge page: %s", err)
+		return
+	}
+
+	w.Response.UserBody = body
+	w.Response.UserHeaders = map[string]string{
+		"Content-Type":  "text/html; charset=utf-8",
+		"Cache-Control": "no-store",
+	}
+}
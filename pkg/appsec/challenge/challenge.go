@@ -0,0 +1,162 @@
+// Package challenge implements a proof-of-work challenge served by the appsec component.
+//
+// The challenge page gets a token signed for the client (IP and user agent) with an expiration date.
+// Its script looks for a nonce such that sha256(token.nonce) starts with enough zero bits, and stores
+// token.nonce in a cookie. The cookie is then verified on the next requests without any state:
+// the signature, the expiration date and the proof of work are checked.
+package challenge
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/bits"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxDifficulty keeps the challenge solvable by a browser
+const MaxDifficulty = 32
+
+// maxTokenLength is well above the length of the tokens issued
+const maxTokenLength = 256
+
+type Issuer struct {
+	secret     []byte
+	difficulty int
+	validity   time.Duration
+}
+
+func NewIssuer(secret []byte, difficulty int, validity time.Duration) (*Issuer, error) {
+	if len(secret) == 0 {
+		return nil, errors.New("empty secret")
+	}
+
+	if difficulty < 1 || difficulty > MaxDifficulty {
+		return nil, fmt.Errorf("difficulty must be between 1 and %d", MaxDifficulty)
+	}
+
+	if validity <= 0 {
+		return nil, errors.New("validity must be positive")
+	}
+
+	return &Issuer{
+		secret:     secret,
+		difficulty: difficulty,
+		validity:   validity,
+	}, nil
+}
+
+// RandomSecret is used when no secret is configured, the cookies are then only valid until a restart
+func RandomSecret() []byte {
+	secret := make([]byte, 32)
+	_, _ = rand.Read(secret)
+
+	return secret
+}
+
+func (i *Issuer) Difficulty() int {
+	return i.difficulty
+}
+
+func (i *Issuer) Validity() time.Duration {
+	return i.validity
+}
+
+func (i *Issuer) sign(payload string, ip string, userAgent string) string {
+	mac := hmac.New(sha256.New, i.secret)
+	mac.Write([]byte(payload))
+	mac.Write([]byte{0})
+	mac.Write([]byte(ip))
+	mac.Write([]byte{0})
+	mac.Write([]byte(userAgent))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// Issue returns a token for the client: expiration.seed.difficulty.signature
+func (i *Issuer) Issue(ip string, userAgent string, now time.Time) string {
+	seed := make([]byte, 16)
+	_, _ = rand.Read(seed)
+
+	payload := strconv.FormatInt(now.Add(i.validity).Unix(), 10) + "." + hex.EncodeToString(seed) + "." + strconv.Itoa(i.difficulty)
+
+	return payload + "." + i.sign(payload, ip, userAgent)
+}
+
+// Verify checks a solved token (token.nonce) sent back by the client
+func (i *Issuer) Verify(value string, ip string, userAgent string, now time.Time) error {
+	if len(value) > maxTokenLength {
+		return errors.New("malformed token")
+	}
+
+	token, nonce, ok := cutLast(value, ".")
+	if !ok || nonce == "" {
+		return errors.New("malformed token")
+	}
+
+	parts := strings.Split(token, ".")
+	if len(parts) != 4 {
+		return errors.New("malformed token")
+	}
+
+	payload := strings.Join(parts[:3], ".")
+
+	if !hmac.Equal([]byte(parts[3]), []byte(i.sign(payload, ip, userAgent))) {
+		return errors.New("invalid signature")
+	}
+
+	expiration, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return errors.New("malformed token")
+	}
+
+	if now.Unix() > expiration {
+		return errors.New("expired token")
+	}
+
+	// the difficulty may have been raised since the token was issued
+	difficulty, err := strconv.Atoi(parts[2])
+	if err != nil || difficulty < i.difficulty {
+		return errors.New("token difficulty too low")
+	}
+
+	if LeadingZeroBits(Hash(token, nonce)) < difficulty {
+		return errors.New("invalid proof of work")
+	}
+
+	return nil
+}
+
+// Hash is what the client computes for each nonce
+func Hash(token string, nonce string) [sha256.Size]byte {
+	return sha256.Sum256([]byte(token + "." + nonce))
+}
+
+func LeadingZeroBits(hash [sha256.Size]byte) int {
+	ret := 0
+
+	for _, b := range hash {
+		if b != 0 {
+			return ret + bits.LeadingZeros8(b)
+		}
+
+		ret += 8
+	}
+
+	return ret
+}
+
+func cutLast(s string, sep string) (string, string, bool) {
+	idx := strings.LastIndex(s, sep)
+	if idx < 0 {
+		return s, "", false
+	}
+
+	return s[:idx], s[idx+len(sep):], true
+}
@@ -0,0 +1,112 @@
+package challenge
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+// solve does what the script of the challenge page does
+func solve(token string, difficulty int) string {
+	for nonce := 0; ; nonce++ {
+		if LeadingZeroBits(Hash(token, strconv.Itoa(nonce))) >= difficulty {
+			return token + "." + strconv.Itoa(nonce)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	now := time.Now()
+
+	issuer, err := NewIssuer([]byte("secret"), 8, time.Hour)
+	require.NoError(t, err)
+
+	token := issuer.Issue("1.2.3.4", "Mozilla/5.0", now)
+	solved := solve(token, 8)
+
+	require.NoError(t, issuer.Verify(solved, "1.2.3.4", "Mozilla/5.0", now.Add(time.Minute)))
+
+	// bound to the client
+	cstest.RequireErrorContains(t, issuer.Verify(solved, "5.6.7.8", "Mozilla/5.0", now), "invalid signature")
+	cstest.RequireErrorContains(t, issuer.Verify(solved, "1.2.3.4", "curl/8.0", now), "invalid signature")
+
+	cstest.RequireErrorContains(t, issuer.Verify(solved, "1.2.3.4", "Mozilla/5.0", now.Add(2*time.Hour)), "expired token")
+
+	// signed with another secret
+	other, err := NewIssuer([]byte("other"), 8, time.Hour)
+	require.NoError(t, err)
+	cstest.RequireErrorContains(t, other.Verify(solved, "1.2.3.4", "Mozilla/5.0", now), "invalid signature")
+
+	// the difficulty was raised
+	harder, err := NewIssuer([]byte("secret"), 12, time.Hour)
+	require.NoError(t, err)
+	cstest.RequireErrorContains(t, harder.Verify(solved, "1.2.3.4", "Mozilla/5.0", now), "token difficulty too low")
+
+	// the difficulty of the token can't be changed
+	parts := strings.Split(token, ".")
+	parts[2] = "1"
+	cstest.RequireErrorContains(t, issuer.Verify(solve(strings.Join(parts, "."), 1), "1.2.3.4", "Mozilla/5.0", now), "invalid signature")
+
+	for _, malformed := range []string{"", token, token + ".", "a.b.c", strings.Repeat("a", 300) + ".1"} {
+		cstest.RequireErrorContains(t, issuer.Verify(malformed, "1.2.3.4", "Mozilla/5.0", now), "malformed token")
+	}
+}
+
+func TestProofOfWork(t *testing.T) {
+	issuer, err := NewIssuer([]byte("secret"), 16, time.Hour)
+	require.NoError(t, err)
+
+	token := issuer.Issue("1.2.3.4", "", time.Now())
+
+	// find a nonce that does not solve the challenge
+	for nonce := 0; ; nonce++ {
+		if LeadingZeroBits(Hash(token, strconv.Itoa(nonce))) < 16 {
+			cstest.RequireErrorContains(t, issuer.Verify(token+"."+strconv.Itoa(nonce), "1.2.3.4", "", time.Now()), "invalid proof of work")
+			break
+		}
+	}
+
+	require.NoError(t, issuer.Verify(solve(token, 16), "1.2.3.4", "", time.Now()))
+}
+
+func TestLeadingZeroBits(t *testing.T) {
+	var hash [32]byte
+
+	assert.Equal(t, 256, LeadingZeroBits(hash))
+
+	hash[1] = 0x10
+	assert.Equal(t, 11, LeadingZeroBits(hash))
+
+	hash[0] = 0x80
+	assert.Equal(t, 0, LeadingZeroBits(hash))
+}
+
+func TestNewIssuer(t *testing.T) {
+	_, err := NewIssuer(nil, 16, time.Hour)
+	cstest.RequireErrorContains(t, err, "empty secret")
+
+	_, err = NewIssuer([]byte("secret"), 40, time.Hour)
+	cstest.RequireErrorContains(t, err, "difficulty must be between 1 and 32")
+
+	_, err = NewIssuer([]byte("secret"), 16, 0)
+	cstest.RequireErrorContains(t, err, "validity must be positive")
+}
+
+func TestPage(t *testing.T) {
+	issuer, err := NewIssuer([]byte("secret"), 16, time.Hour)
+	require.NoError(t, err)
+
+	token := issuer.Issue("1.2.3.4", "", time.Now())
+
+	page, err := issuer.Page(token, "my_cookie")
+	require.NoError(t, err)
+
+	assert.Contains(t, string(page), `var token = "`+token+`", difficulty =  16 ;`)
+	assert.Contains(t, string(page), `document.cookie = "my_cookie" + "=" + token + "." + nonce + "; path=/; max-age=" +  3600`)
+}
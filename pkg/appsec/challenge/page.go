@@ -0,0 +1,112 @@
+package challenge
+
+import (
+	"bytes"
+	"html/template"
+	"time"
+)
+
+// the script does not use crypto.subtle, which is not available on plain HTTP
+var page = template.Must(template.New("challenge").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="robots" content="noindex, nofollow">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Checking your browser</title>
+<style>body{font-family:sans-serif;text-align:center;margin-top:15%;color:#333}</style>
+</head>
+<body>
+<p id="status">Checking your browser before accessing the website...</p>
+<noscript><p>JavaScript is required to access this website.</p></noscript>
+<script>
+(function () {
+  var token = {{.Token}}, difficulty = {{.Difficulty}};
+  var K = [], H = [];
+
+  for (var n = 2, i = 0; i < 64; n++) {
+    var prime = true;
+    for (var d = 2; d * d <= n; d++) {
+      if (n % d === 0) { prime = false; break; }
+    }
+    if (!prime) continue;
+    if (i < 8) H[i] = (Math.pow(n, 1 / 2) * 4294967296) | 0;
+    K[i++] = (Math.pow(n, 1 / 3) * 4294967296) | 0;
+  }
+
+  function rotr(x, n) { return (x >>> n) | (x << (32 - n)); }
+
+  function sha256(s) {
+    var words = [], len = s.length, blocks = (((len + 8) >> 6) + 1) * 16, w = [], h = H.slice();
+    for (var i = 0; i < len; i++) words[i >> 2] |= s.charCodeAt(i) << (24 - (i % 4) * 8);
+    words[len >> 2] |= 0x80 << (24 - (len % 4) * 8);
+    words[blocks - 1] = len * 8;
+    for (var j = 0; j < blocks; j += 16) {
+      var a = h[0], b = h[1], c = h[2], d = h[3], e = h[4], f = h[5], g = h[6], k = h[7];
+      for (i = 0; i < 64; i++) {
+        if (i < 16) {
+          w[i] = words[j + i] | 0;
+        } else {
+          var x = w[i - 15], y = w[i - 2];
+          w[i] = (w[i - 16] + (rotr(x, 7) ^ rotr(x, 18) ^ (x >>> 3)) + w[i - 7] + (rotr(y, 17) ^ rotr(y, 19) ^ (y >>> 10))) | 0;
+        }
+        var t1 = (k + (rotr(e, 6) ^ rotr(e, 11) ^ rotr(e, 25)) + ((e & f) ^ (~e & g)) + K[i] + w[i]) | 0;
+        var t2 = ((rotr(a, 2) ^ rotr(a, 13) ^ rotr(a, 22)) + ((a & b) ^ (a & c) ^ (b & c))) | 0;
+        k = g; g = f; f = e; e = (d + t1) | 0; d = c; c = b; b = a; a = (t1 + t2) | 0;
+      }
+      h = [(h[0] + a) | 0, (h[1] + b) | 0, (h[2] + c) | 0, (h[3] + d) | 0, (h[4] + e) | 0, (h[5] + f) | 0, (h[6] + g) | 0, (h[7] + k) | 0];
+    }
+    return h;
+  }
+
+  function zeros(h) {
+    for (var i = 0, z = 0; i < h.length; i++) {
+      if (h[i] !== 0) return z + Math.clz32(h[i]);
+      z += 32;
+    }
+    return z;
+  }
+
+  var nonce = 0;
+
+  function work() {
+    for (var end = nonce + 20000; nonce < end; nonce++) {
+      if (zeros(sha256(token + "." + nonce)) >= difficulty) {
+        document.cookie = {{.Cookie}} + "=" + token + "." + nonce + "; path=/; max-age=" + {{.MaxAge}} + "; SameSite=Lax";
+        location.reload();
+        return;
+      }
+    }
+    setTimeout(work, 0);
+  }
+
+  work();
+})();
+</script>
+</body>
+</html>
+`))
+
+type pageData struct {
+	Token      string
+	Difficulty int
+	Cookie     string
+	MaxAge     int
+}
+
+// Page renders the challenge page for a token, the solved token is stored in the cookie
+func (i *Issuer) Page(token string, cookie string) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := page.Execute(&buf, pageData{
+		Token:      token,
+		Difficulty: i.difficulty,
+		Cookie:     cookie,
+		MaxAge:     int(i.validity / time.Second),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
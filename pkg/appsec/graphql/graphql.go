@@ -0,0 +1,234 @@
+// Package graphql analyzes GraphQL queries without a schema: operation, selected fields,
+// arguments, depth and complexity, for the appsec rules to match on.
+package graphql
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/parser"
+)
+
+const (
+	// MaxTokens bounds the size of the queries, and the nesting the parser has to deal with
+	MaxTokens = 15000
+	// maxCollected bounds the number of fields and arguments reported, fragments can make them grow exponentially
+	maxCollected = 1000
+	// maxVisited bounds the selections visited to collect them, fragments can spread other fragments without selecting fields
+	maxVisited = 10 * maxCollected
+	// MaxComplexity is the ceiling of the complexity and aliases, the fragments can make them overflow
+	MaxComplexity = math.MaxInt32
+)
+
+// Argument is a scalar value of an argument, lists and input objects are flattened
+type Argument struct {
+	Path  string // path of the field, name of the argument and path in the value, e.g. user.posts.filter.author
+	Value string // variables are reported as $name
+}
+
+// Query is the analysis of the operations of a query document
+type Query struct {
+	OperationName string
+	OperationType string // query, mutation or subscription
+	// deepest selection, e.g. 3 for { user { posts { title } } }
+	Depth int
+	// number of fields selected once the fragments are expanded, up to MaxComplexity
+	Complexity int
+	// number of aliased fields, aliases allow to batch expensive fields in a single query
+	Aliases   int
+	Fields    []string // paths of the selected fields
+	Arguments []Argument
+}
+
+type stats struct {
+	depth      int
+	complexity int
+	aliases    int
+}
+
+type analyzer struct {
+	fragments ast.FragmentDefinitionList
+	// stats of the fragments, computed once
+	stats map[string]stats
+	// fragments being expanded, to detect the cycles
+	expanding map[string]bool
+	visited   int
+	query     *Query
+}
+
+// Parse parses and analyzes a query. If the document holds several operations, only the one
+// named operationName is analyzed, or all of them if operationName is empty.
+func Parse(query string, operationName string) (*Query, error) {
+	doc, err := parser.ParseQueryWithTokenLimit(&ast.Source{Input: query}, MaxTokens)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(doc.Operations) == 0 {
+		return nil, errors.New("no operation in the query")
+	}
+
+	operations := doc.Operations
+
+	if operationName != "" {
+		op := doc.Operations.ForName(operationName)
+		if op == nil {
+			return nil, fmt.Errorf("unknown operation %s", operationName)
+		}
+
+		operations = ast.OperationList{op}
+	}
+
+	a := &analyzer{
+		fragments: doc.Fragments,
+		stats:     make(map[string]stats),
+		expanding: make(map[string]bool),
+		query: &Query{
+			OperationName: operations[0].Name,
+			OperationType: string(operations[0].Operation),
+		},
+	}
+
+	for _, op := range operations {
+		st, err := a.selectionStats(op.SelectionSet)
+		if err != nil {
+			return nil, err
+		}
+
+		a.query.Depth = max(a.query.Depth, st.depth)
+		a.query.Complexity = clampedAdd(a.query.Complexity, st.complexity)
+		a.query.Aliases = clampedAdd(a.query.Aliases, st.aliases)
+
+		a.collect(op.SelectionSet, "", 0)
+	}
+
+	return a.query, nil
+}
+
+// clampedAdd adds two counters, without going past MaxComplexity
+func clampedAdd(a, b int) int {
+	return min(a+b, MaxComplexity)
+}
+
+// selectionStats computes the depth and complexity of a selection set, expanding the fragments
+func (a *analyzer) selectionStats(set ast.SelectionSet) (stats, error) {
+	var ret stats
+
+	for _, sel := range set {
+		var (
+			st  stats
+			err error
+		)
+
+		switch s := sel.(type) {
+		case *ast.Field:
+			st, err = a.selectionStats(s.SelectionSet)
+			st.depth++
+			st.complexity = clampedAdd(st.complexity, 1)
+
+			if s.Alias != "" && s.Alias != s.Name {
+				st.aliases = clampedAdd(st.aliases, 1)
+			}
+		case *ast.InlineFragment:
+			st, err = a.selectionStats(s.SelectionSet)
+		case *ast.FragmentSpread:
+			st, err = a.fragmentStats(s.Name)
+		}
+
+		if err != nil {
+			return ret, err
+		}
+
+		ret.depth = max(ret.depth, st.depth)
+		ret.complexity = clampedAdd(ret.complexity, st.complexity)
+		ret.aliases = clampedAdd(ret.aliases, st.aliases)
+	}
+
+	return ret, nil
+}
+
+func (a *analyzer) fragmentStats(name string) (stats, error) {
+	if st, ok := a.stats[name]; ok {
+		return st, nil
+	}
+
+	if a.expanding[name] {
+		return stats{}, fmt.Errorf("fragment %s spreads itself", name)
+	}
+
+	fragment := a.fragments.ForName(name)
+	if fragment == nil {
+		return stats{}, fmt.Errorf("unknown fragment %s", name)
+	}
+
+	a.expanding[name] = true
+	st, err := a.selectionStats(fragment.SelectionSet)
+	delete(a.expanding, name)
+
+	if err != nil {
+		return stats{}, err
+	}
+
+	a.stats[name] = st
+
+	return st, nil
+}
+
+// collect reports the fields and arguments, until enough of them have been seen.
+// The fragments are known to be acyclic once the stats have been computed.
+func (a *analyzer) collect(set ast.SelectionSet, prefix string, depth int) {
+	for _, sel := range set {
+		a.visited++
+		if len(a.query.Fields) >= maxCollected || a.visited > maxVisited {
+			return
+		}
+
+		switch s := sel.(type) {
+		case *ast.Field:
+			path := s.Name
+			if prefix != "" {
+				path = prefix + "." + s.Name
+			}
+
+			a.query.Fields = append(a.query.Fields, path)
+
+			for _, arg := range s.Arguments {
+				a.collectValue(path+"."+arg.Name, arg.Value)
+			}
+
+			a.collect(s.SelectionSet, path, depth+1)
+		case *ast.InlineFragment:
+			a.collect(s.SelectionSet, prefix, depth)
+		case *ast.FragmentSpread:
+			if fragment := a.fragments.ForName(s.Name); fragment != nil {
+				a.collect(fragment.SelectionSet, prefix, depth)
+			}
+		}
+	}
+}
+
+func (a *analyzer) collectValue(path string, value *ast.Value) {
+	if value == nil || len(a.query.Arguments) >= maxCollected {
+		return
+	}
+
+	switch value.Kind {
+	case ast.ListValue:
+		for i, child := range value.Children {
+			a.collectValue(path+"."+strconv.Itoa(i), child.Value)
+		}
+	case ast.ObjectValue:
+		for _, child := range value.Children {
+			a.collectValue(path+"."+child.Name, child.Value)
+		}
+	case ast.Variable:
+		a.query.Arguments = append(a.query.Arguments, Argument{Path: path, Value: "$" + value.Raw})
+	case ast.NullValue:
+		a.query.Arguments = append(a.query.Arguments, Argument{Path: path, Value: ""})
+	default:
+		a.query.Arguments = append(a.query.Arguments, Argument{Path: path, Value: value.Raw})
+	}
+}
@@ -0,0 +1,215 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		expected      *Query
+		expectedErr   string
+	}{
+		{
+			name:  "shorthand query",
+			query: `{ user(id: 42) { name posts { title } } }`,
+			expected: &Query{
+				OperationType: "query",
+				Depth:         3,
+				Complexity:    4,
+				Fields:        []string{"user", "user.name", "user.posts", "user.posts.title"},
+				Arguments:     []Argument{{Path: "user.id", Value: "42"}},
+			},
+		},
+		{
+			name:  "named mutation with variables",
+			query: `mutation Login($user: String!) { login(user: $user, password: "hunter2") { token } }`,
+			expected: &Query{
+				OperationName: "Login",
+				OperationType: "mutation",
+				Depth:         2,
+				Complexity:    2,
+				Fields:        []string{"login", "login.token"},
+				Arguments: []Argument{
+					{Path: "login.user", Value: "$user"},
+					{Path: "login.password", Value: "hunter2"},
+				},
+			},
+		},
+		{
+			name:  "input objects and lists are flattened",
+			query: `{ search(filter: {author: "bob", tags: ["a", "b"], draft: null}) { id } }`,
+			expected: &Query{
+				OperationType: "query",
+				Depth:         2,
+				Complexity:    2,
+				Fields:        []string{"search", "search.id"},
+				Arguments: []Argument{
+					{Path: "search.filter.author", Value: "bob"},
+					{Path: "search.filter.tags.0", Value: "a"},
+					{Path: "search.filter.tags.1", Value: "b"},
+					{Path: "search.filter.draft", Value: ""},
+				},
+			},
+		},
+		{
+			name: "fragments are expanded",
+			query: `query { user { ...names friends { ...names } } }
+				fragment names on User { first last }`,
+			expected: &Query{
+				OperationType: "query",
+				Depth:         3,
+				Complexity:    6,
+				Fields:        []string{"user", "user.first", "user.last", "user.friends", "user.friends.first", "user.friends.last"},
+			},
+		},
+		{
+			name:  "inline fragments and aliases",
+			query: `{ a: node(id: 1) { ... on User { name } } b: node(id: 2) { id } }`,
+			expected: &Query{
+				OperationType: "query",
+				Depth:         2,
+				Complexity:    4,
+				Aliases:       2,
+				Fields:        []string{"node", "node.name", "node", "node.id"},
+				Arguments:     []Argument{{Path: "node.id", Value: "1"}, {Path: "node.id", Value: "2"}},
+			},
+		},
+		{
+			name:          "operation selected by name",
+			query:         `query A { a } query B { b { c } }`,
+			operationName: "B",
+			expected: &Query{
+				OperationName: "B",
+				OperationType: "query",
+				Depth:         2,
+				Complexity:    2,
+				Fields:        []string{"b", "b.c"},
+			},
+		},
+		{
+			name:  "all the operations without a name",
+			query: `query A { a } query B { b { c } }`,
+			expected: &Query{
+				OperationName: "A",
+				OperationType: "query",
+				Depth:         2,
+				Complexity:    3,
+				Fields:        []string{"a", "b", "b.c"},
+			},
+		},
+		{
+			name:          "unknown operation",
+			query:         `query A { a }`,
+			operationName: "B",
+			expectedErr:   "unknown operation B",
+		},
+		{
+			name:        "syntax error",
+			query:       `{ user(id: 42 { name } }`,
+			expectedErr: "Expected Name, found {",
+		},
+		{
+			name:        "no operation",
+			query:       `fragment names on User { first }`,
+			expectedErr: "no operation in the query",
+		},
+		{
+			name:        "unknown fragment",
+			query:       `{ user { ...names } }`,
+			expectedErr: "unknown fragment names",
+		},
+		{
+			name: "fragment cycle",
+			query: `{ user { ...a } }
+				fragment a on User { friends { ...b } }
+				fragment b on User { friends { ...a } }`,
+			expectedErr: "spreads itself",
+		},
+		{
+			name:        "too many tokens",
+			query:       "{ " + strings.Repeat("a ", MaxTokens) + "}",
+			expectedErr: "exceeded token limit",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			query, err := Parse(tc.query, tc.operationName)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if tc.expectedErr != "" {
+				return
+			}
+
+			assert.Equal(t, tc.expected, query)
+		})
+	}
+}
+
+func TestParseFragmentBomb(t *testing.T) {
+	// each fragment doubles the size of the query once expanded
+	var sb strings.Builder
+
+	sb.WriteString("{ ...f0 }\n")
+
+	for i := range 40 {
+		fmt.Fprintf(&sb, "fragment f%d on T { a: x { ...f%d } b: x { ...f%d } }\n", i, i+1, i+1)
+	}
+
+	sb.WriteString("fragment f40 on T { leaf }\n")
+
+	query, err := Parse(sb.String(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 41, query.Depth)
+	assert.Equal(t, MaxComplexity, query.Complexity)
+	assert.Equal(t, MaxComplexity, query.Aliases)
+	assert.Len(t, query.Fields, maxCollected)
+}
+
+func TestParseSpreadBomb(t *testing.T) {
+	// fragments only spreading fragments, the fields are far away
+	var sb strings.Builder
+
+	sb.WriteString("{ x { ...f0 } }\n")
+
+	for i := range 40 {
+		fmt.Fprintf(&sb, "fragment f%d on T { ...f%d ...f%d }\n", i, i+1, i+1)
+	}
+
+	sb.WriteString("fragment f40 on T { leaf }\n")
+
+	query, err := Parse(sb.String(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, query.Depth)
+	assert.Equal(t, MaxComplexity, query.Complexity)
+	assert.LessOrEqual(t, len(query.Fields), maxCollected)
+}
+
+func TestParseComplexityOverflow(t *testing.T) {
+	// 2^64 fields once expanded: the complexity must not wrap around
+	var sb strings.Builder
+
+	sb.WriteString("{ ...f64 }\n")
+	sb.WriteString("fragment f0 on T { leaf }\n")
+
+	for i := 1; i <= 64; i++ {
+		fmt.Fprintf(&sb, "fragment f%d on T { ...f%d ...f%d }\n", i, i-1, i-1)
+	}
+
+	query, err := Parse(sb.String(), "")
+	require.NoError(t, err)
+
+	assert.Equal(t, MaxComplexity, query.Complexity)
+}
@@ -0,0 +1,78 @@
+package appsec
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/identity"
+)
+
+// IdentityVariablePrefix prefixes the transaction variables of the identities, for the rules: TX:identity_user...
+// The events get them as parsed fields with the same names, so that the scenarios can group by session or user.
+const IdentityVariablePrefix = "identity_"
+
+func (w *AppsecRuntimeConfig) loadIdentity(wc *AppsecConfig) error {
+	for _, cfg := range wc.Identity {
+		if cfg.JWT != nil && cfg.JWT.JWKSFile != "" && !filepath.IsAbs(cfg.JWT.JWKSFile) {
+			jwtCfg := *cfg.JWT
+			jwtCfg.JWKSFile = filepath.Join(wc.GetDataDir(), jwtCfg.JWKSFile)
+			cfg.JWT = &jwtCfg
+		}
+
+		extractor, err := identity.NewExtractor(cfg)
+		if err != nil {
+			return fmt.Errorf("invalid identity configuration: %w", err)
+		}
+
+		w.IdentityExtractors = append(w.IdentityExtractors, extractor)
+	}
+
+	if len(w.IdentityExtractors) > 0 {
+		w.Logger.Infof("loaded %d identity extractors", len(w.IdentityExtractors))
+	}
+
+	return nil
+}
+
+// ExtractIdentity sets the identities of the request, before the hooks and rules are evaluated.
+// The identities that can't be trusted (invalid JWT...) are left out.
+func (w *AppsecRuntimeConfig) ExtractIdentity(request *ParsedRequest) {
+	if len(w.IdentityExtractors) == 0 || request.HTTPRequest == nil {
+		return
+	}
+
+	for _, extractor := range w.IdentityExtractors {
+		if _, ok := request.Identity[extractor.Name()]; ok {
+			continue
+		}
+
+		value, err := extractor.Extract(request.HTTPRequest)
+		if err != nil {
+			w.Logger.Debugf("ignoring %s identity of request %s: %s", extractor.Name(), request.UUID, err)
+			continue
+		}
+
+		if value == "" {
+			continue
+		}
+
+		if request.Identity == nil {
+			request.Identity = make(map[string]string)
+		}
+
+		request.Identity[extractor.Name()] = value
+	}
+}
+
+// SetIdentityVariables exposes the identities of the request to the rules of the transaction
+func (w *AppsecRuntimeConfig) SetIdentityVariables(request *ParsedRequest) {
+	if len(request.Identity) == 0 {
+		return
+	}
+
+	tx := request.Tx.Variables().TX()
+
+	for name, value := range request.Identity {
+		tx.Set(IdentityVariablePrefix+name, []string{value})
+	}
+}
@@ -0,0 +1,200 @@
+// Package identity extracts the identity of the client (session, user...) from the requests, so that
+// the hooks, rules and scenarios can track a session or a user instead of an IP.
+//
+// The value is taken from a cookie or a header. It can be a JWT, whose signature is verified with the
+// keys of a local JWKS file before one of its claims is used.
+package identity
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// hashLength is the length of the hashed identities, in hex characters
+const hashLength = 32
+
+// the algorithms of the public keys a JWKS can hold: HMAC and none are never accepted
+var defaultAlgorithms = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+type Config struct {
+	Name   string     `yaml:"name"`   // several extractors can set the same identity, the first one found is used
+	Cookie string     `yaml:"cookie"` // the value is read from this cookie...
+	Header string     `yaml:"header"` // ...or from this header
+	JWT    *JWTConfig `yaml:"jwt"`
+	Hash   bool       `yaml:"hash"` // keep a hash of the value, for session cookies that should not end up in the events
+}
+
+type JWTConfig struct {
+	Claim      string   `yaml:"claim"` // nested claims are separated by dots
+	JWKSFile   string   `yaml:"jwks_file"`
+	Issuer     string   `yaml:"issuer"`
+	Audience   string   `yaml:"audience"`
+	Algorithms []string `yaml:"algorithms"`
+}
+
+type Extractor struct {
+	name   string
+	cookie string
+	header string
+	hash   bool
+	jwt    *jwtVerifier
+}
+
+type jwtVerifier struct {
+	claim    []string
+	keys     *KeySet
+	issuer   string
+	audience string
+	parser   *jwt.Parser
+}
+
+func NewExtractor(cfg Config) (*Extractor, error) {
+	if cfg.Name == "" {
+		return nil, errors.New("identity without name")
+	}
+
+	if (cfg.Cookie == "") == (cfg.Header == "") {
+		return nil, fmt.Errorf("identity %s: exactly one of cookie or header must be set", cfg.Name)
+	}
+
+	ret := &Extractor{
+		name:   cfg.Name,
+		cookie: cfg.Cookie,
+		header: cfg.Header,
+		hash:   cfg.Hash,
+	}
+
+	if cfg.JWT == nil {
+		return ret, nil
+	}
+
+	if cfg.JWT.Claim == "" {
+		return nil, fmt.Errorf("identity %s: jwt.claim must be set", cfg.Name)
+	}
+
+	if cfg.JWT.JWKSFile == "" {
+		return nil, fmt.Errorf("identity %s: jwt.jwks_file must be set", cfg.Name)
+	}
+
+	keys, err := LoadJWKS(cfg.JWT.JWKSFile)
+	if err != nil {
+		return nil, fmt.Errorf("identity %s: %w", cfg.Name, err)
+	}
+
+	algorithms := cfg.JWT.Algorithms
+	if len(algorithms) == 0 {
+		algorithms = defaultAlgorithms
+	}
+
+	for _, alg := range algorithms {
+		if !strings.HasPrefix(alg, "RS") && !strings.HasPrefix(alg, "PS") && !strings.HasPrefix(alg, "ES") && alg != "EdDSA" {
+			return nil, fmt.Errorf("identity %s: unsupported algorithm %s", cfg.Name, alg)
+		}
+	}
+
+	ret.jwt = &jwtVerifier{
+		claim:    strings.Split(cfg.JWT.Claim, "."),
+		keys:     keys,
+		issuer:   cfg.JWT.Issuer,
+		audience: cfg.JWT.Audience,
+		parser:   jwt.NewParser(jwt.WithValidMethods(algorithms), jwt.WithJSONNumber()),
+	}
+
+	return ret, nil
+}
+
+func (e *Extractor) Name() string {
+	return e.name
+}
+
+// Extract returns the identity of the request, an empty string if it has none.
+// An error is returned when the value can't be trusted, like a JWT with an invalid signature.
+func (e *Extractor) Extract(r *http.Request) (string, error) {
+	var value string
+
+	if e.cookie != "" {
+		cookie, err := r.Cookie(e.cookie)
+		if err != nil {
+			return "", nil
+		}
+
+		value = cookie.Value
+	} else {
+		value = r.Header.Get(e.header)
+
+		if e.jwt != nil && len(value) > len("bearer ") && strings.EqualFold(value[:len("bearer ")], "bearer ") {
+			value = value[len("bearer "):]
+		}
+	}
+
+	if value == "" {
+		return "", nil
+	}
+
+	if e.jwt != nil {
+		var err error
+
+		value, err = e.jwt.claimOf(value)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if e.hash && value != "" {
+		sum := sha256.Sum256([]byte(value))
+		value = hex.EncodeToString(sum[:])[:hashLength]
+	}
+
+	return value, nil
+}
+
+func (v *jwtVerifier) claimOf(value string) (string, error) {
+	claims := jwt.MapClaims{}
+
+	_, err := v.parser.ParseWithClaims(value, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return v.keys.Key(kid, token.Method.Alg())
+	})
+	if err != nil {
+		return "", fmt.Errorf("invalid token: %w", err)
+	}
+
+	if v.issuer != "" && !claims.VerifyIssuer(v.issuer, true) {
+		return "", errors.New("invalid token: unexpected issuer")
+	}
+
+	if v.audience != "" && !claims.VerifyAudience(v.audience, true) {
+		return "", errors.New("invalid token: unexpected audience")
+	}
+
+	var claim any = map[string]any(claims)
+
+	for _, key := range v.claim {
+		m, ok := claim.(map[string]any)
+		if !ok {
+			return "", nil
+		}
+
+		claim = m[key]
+	}
+
+	switch c := claim.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return c, nil
+	case json.Number:
+		return c.String(), nil
+	case bool:
+		return fmt.Sprint(c), nil
+	default:
+		return "", fmt.Errorf("claim %s is not a scalar", strings.Join(v.claim, "."))
+	}
+}
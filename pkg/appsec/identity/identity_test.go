@@ -0,0 +1,288 @@
+package identity
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+func b64(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+// writeJWKS writes the public keys of a RSA key ("rsa") and an EC key ("ec") to a JWKS file
+func writeJWKS(t *testing.T, rsaKey *rsa.PrivateKey, ecKey *ecdsa.PrivateKey) string {
+	set := map[string]any{
+		"keys": []map[string]string{
+			{
+				"kty": "RSA",
+				"kid": "rsa",
+				"use": "sig",
+				"n":   b64(rsaKey.N.Bytes()),
+				"e":   b64(big.NewInt(int64(rsaKey.E)).Bytes()),
+			},
+			{
+				"kty": "EC",
+				"kid": "ec",
+				"crv": "P-256",
+				"x":   b64(ecKey.X.FillBytes(make([]byte, 32))),
+				"y":   b64(ecKey.Y.FillBytes(make([]byte, 32))),
+			},
+			{
+				// encryption keys are ignored
+				"kty": "RSA",
+				"kid": "enc",
+				"use": "enc",
+				"n":   "invalid",
+				"e":   "AQAB",
+			},
+		},
+	}
+
+	content, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, content, 0o644))
+
+	return path
+}
+
+func sign(t *testing.T, method jwt.SigningMethod, kid string, key any, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+
+	ret, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	return ret
+}
+
+func TestJWTExtractor(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	jwks := writeJWKS(t, rsaKey, ecKey)
+
+	extractor, err := NewExtractor(Config{
+		Name:   "user",
+		Header: "Authorization",
+		JWT: &JWTConfig{
+			Claim:    "sub",
+			JWKSFile: jwks,
+			Issuer:   "https://idp.example.com",
+			Audience: "shop",
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "user", extractor.Name())
+
+	claims := func(extra jwt.MapClaims) jwt.MapClaims {
+		ret := jwt.MapClaims{
+			"sub": "alice",
+			"iss": "https://idp.example.com",
+			"aud": "shop",
+			"exp": time.Now().Add(time.Hour).Unix(),
+		}
+
+		for k, v := range extra {
+			ret[k] = v
+		}
+
+		return ret
+	}
+
+	tests := []struct {
+		name        string
+		token       string
+		expected    string
+		expectedErr string
+	}{
+		{
+			name:     "RSA",
+			token:    "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(nil)),
+			expected: "alice",
+		},
+		{
+			name:     "EC, without bearer prefix",
+			token:    sign(t, jwt.SigningMethodES256, "ec", ecKey, claims(jwt.MapClaims{"sub": 1234})),
+			expected: "1234",
+		},
+		{
+			name:  "no claim",
+			token: "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"sub": nil})),
+		},
+		{
+			name:        "signed with another key",
+			token:       "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", otherKey, claims(nil)),
+			expectedErr: "invalid token: crypto/rsa: verification error",
+		},
+		{
+			name:        "unknown kid",
+			token:       "Bearer " + sign(t, jwt.SigningMethodRS256, "other", otherKey, claims(nil)),
+			expectedErr: `unknown key "other"`,
+		},
+		{
+			name:        "no kid, several keys",
+			token:       "Bearer " + sign(t, jwt.SigningMethodRS256, "", rsaKey, claims(nil)),
+			expectedErr: "token without kid",
+		},
+		{
+			name:        "HMAC with the public key",
+			token:       "Bearer " + sign(t, jwt.SigningMethodHS256, "rsa", rsaKey.N.Bytes(), claims(nil)),
+			expectedErr: "signing method HS256 is invalid",
+		},
+		{
+			name:        "expired",
+			token:       "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"exp": time.Now().Add(-time.Hour).Unix()})),
+			expectedErr: "Token is expired",
+		},
+		{
+			name:        "wrong issuer",
+			token:       "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"iss": "https://evil.example.com"})),
+			expectedErr: "unexpected issuer",
+		},
+		{
+			name:        "wrong audience",
+			token:       "Bearer " + sign(t, jwt.SigningMethodRS256, "rsa", rsaKey, claims(jwt.MapClaims{"aud": "admin"})),
+			expectedErr: "unexpected audience",
+		},
+		{
+			name:        "garbage",
+			token:       "Bearer not-a-token",
+			expectedErr: "invalid token",
+		},
+		{
+			name: "no header",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+			require.NoError(t, err)
+
+			if tc.token != "" {
+				r.Header.Set("Authorization", tc.token)
+			}
+
+			value, err := extractor.Extract(r)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+			assert.Equal(t, tc.expected, value)
+		})
+	}
+}
+
+func TestNestedClaim(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	extractor, err := NewExtractor(Config{
+		Name:   "tenant",
+		Cookie: "token",
+		JWT:    &JWTConfig{Claim: "org.id", JWKSFile: writeJWKS(t, rsaKey, ecKey)},
+	})
+	require.NoError(t, err)
+
+	r, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+	require.NoError(t, err)
+
+	r.AddCookie(&http.Cookie{Name: "token", Value: sign(t, jwt.SigningMethodES256, "ec", ecKey, jwt.MapClaims{"org": map[string]any{"id": "acme"}})})
+
+	value, err := extractor.Extract(r)
+	require.NoError(t, err)
+	assert.Equal(t, "acme", value)
+}
+
+func TestCookieAndHeaderExtractors(t *testing.T) {
+	session, err := NewExtractor(Config{Name: "session", Cookie: "PHPSESSID", Hash: true})
+	require.NoError(t, err)
+
+	user, err := NewExtractor(Config{Name: "user", Header: "X-User"})
+	require.NoError(t, err)
+
+	r, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+	require.NoError(t, err)
+
+	value, err := session.Extract(r)
+	require.NoError(t, err)
+	assert.Empty(t, value)
+
+	r.AddCookie(&http.Cookie{Name: "PHPSESSID", Value: "s3cr3t"})
+	r.Header.Set("X-User", "bob")
+
+	value, err = session.Extract(r)
+	require.NoError(t, err)
+	assert.Len(t, value, hashLength)
+	assert.NotContains(t, value, "s3cr3t")
+
+	// the same session always gets the same hash
+	again, err := session.Extract(r)
+	require.NoError(t, err)
+	assert.Equal(t, value, again)
+
+	value, err = user.Extract(r)
+	require.NoError(t, err)
+	assert.Equal(t, "bob", value)
+}
+
+func TestNewExtractor(t *testing.T) {
+	_, err := NewExtractor(Config{Cookie: "session"})
+	cstest.RequireErrorContains(t, err, "identity without name")
+
+	_, err = NewExtractor(Config{Name: "user"})
+	cstest.RequireErrorContains(t, err, "identity user: exactly one of cookie or header must be set")
+
+	_, err = NewExtractor(Config{Name: "user", Cookie: "session", Header: "X-User"})
+	cstest.RequireErrorContains(t, err, "identity user: exactly one of cookie or header must be set")
+
+	_, err = NewExtractor(Config{Name: "user", Header: "Authorization", JWT: &JWTConfig{JWKSFile: "jwks.json"}})
+	cstest.RequireErrorContains(t, err, "identity user: jwt.claim must be set")
+
+	_, err = NewExtractor(Config{Name: "user", Header: "Authorization", JWT: &JWTConfig{Claim: "sub"}})
+	cstest.RequireErrorContains(t, err, "identity user: jwt.jwks_file must be set")
+
+	_, err = NewExtractor(Config{Name: "user", Header: "Authorization", JWT: &JWTConfig{Claim: "sub", JWKSFile: filepath.Join(t.TempDir(), "missing.json")}})
+	cstest.RequireErrorContains(t, err, "missing.json: "+cstest.FileNotFoundMessage)
+
+	empty := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(empty, []byte(`{"keys": []}`), 0o644))
+
+	_, err = NewExtractor(Config{Name: "user", Header: "Authorization", JWT: &JWTConfig{Claim: "sub", JWKSFile: empty}})
+	cstest.RequireErrorContains(t, err, "no signature key in JWKS")
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	_, err = NewExtractor(Config{Name: "user", Header: "Authorization", JWT: &JWTConfig{Claim: "sub", JWKSFile: writeJWKS(t, rsaKey, ecKey), Algorithms: []string{"HS256"}}})
+	cstest.RequireErrorContains(t, err, "identity user: unsupported algorithm HS256")
+}
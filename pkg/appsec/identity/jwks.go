@@ -0,0 +1,182 @@
+package identity
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+)
+
+// jwk is a public key of a JSON Web Key Set (RFC 7517), only the signature keys are used
+type jwk struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+type publicKey struct {
+	kid string
+	alg string
+	key any
+}
+
+// KeySet holds the public keys verifying the signature of the tokens
+type KeySet struct {
+	keys []publicKey
+}
+
+// LoadJWKS reads a local JWKS file, as published by the identity provider
+func LoadJWKS(path string) (*KeySet, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var set struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	if err := json.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid JWKS %s: %w", path, err)
+	}
+
+	ret := &KeySet{}
+
+	for idx, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			return nil, fmt.Errorf("invalid key %d of JWKS %s: %w", idx, path, err)
+		}
+
+		ret.keys = append(ret.keys, publicKey{kid: k.Kid, alg: k.Alg, key: key})
+	}
+
+	if len(ret.keys) == 0 {
+		return nil, fmt.Errorf("no signature key in JWKS %s", path)
+	}
+
+	return ret, nil
+}
+
+// Key returns the key of a token: the one with its kid, or the only key of the set when the token has no kid
+func (s *KeySet) Key(kid string, alg string) (any, error) {
+	for _, k := range s.keys {
+		if kid != "" && k.kid != kid {
+			continue
+		}
+
+		if kid == "" && len(s.keys) > 1 {
+			break
+		}
+
+		if k.alg != "" && k.alg != alg {
+			return nil, fmt.Errorf("key %q is not used with %s", kid, alg)
+		}
+
+		return k.key, nil
+	}
+
+	if kid == "" {
+		return nil, errors.New("token without kid")
+	}
+
+	return nil, fmt.Errorf("unknown key %q", kid)
+}
+
+func decodeBase64(s string, field string) ([]byte, error) {
+	if s == "" {
+		return nil, fmt.Errorf("missing %s", field)
+	}
+
+	ret, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+
+	return ret, nil
+}
+
+func (k *jwk) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBase64(k.N, "n")
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBase64(k.E, "e")
+		if err != nil {
+			return nil, err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid e")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBase64(k.X, "x")
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBase64(k.Y, "y")
+		if err != nil {
+			return nil, err
+		}
+
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) { //nolint:staticcheck // the key is only used to verify signatures
+			return nil, errors.New("point not on curve")
+		}
+
+		return key, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBase64(k.X, "x")
+		if err != nil {
+			return nil, err
+		}
+
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid x")
+		}
+
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
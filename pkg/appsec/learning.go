@@ -0,0 +1,147 @@
+package appsec
+
+import (
+	"fmt"
+	"hash/fnv"
+	"path/filepath"
+	"regexp"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/learning"
+)
+
+// LearningVariable is set in the transactions evaluated in learning mode
+const LearningVariable = "crowdsec_learning"
+
+const defaultLearningFlushInterval = time.Minute
+
+// LearningConfig runs the rules without blocking, to record the matches of the legitimate traffic
+// before enforcing them. The exclusions are then suggested by cscli appsec-configs suggest-exclusions.
+type LearningConfig struct {
+	Enabled       bool           `yaml:"enabled"`
+	Duration      time.Duration  `yaml:"duration"`       // how long to learn, forever if 0
+	Output        string         `yaml:"output"`         // <data_dir>/appsec_learning_<name>.json if empty
+	FlushInterval *time.Duration `yaml:"flush_interval"` // how often the matches are written to output
+}
+
+func (lc *LearningConfig) GetFlushInterval() time.Duration {
+	if lc.FlushInterval == nil || *lc.FlushInterval <= 0 {
+		return defaultLearningFlushInterval
+	}
+
+	return *lc.FlushInterval
+}
+
+var unsafeFilenameChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// LearningOutput returns the default file of the learning data for an appsec datasource
+func LearningOutput(dataDir string, name string) string {
+	if name == "" {
+		name = "default"
+	}
+
+	return filepath.Join(dataDir, "appsec_learning_"+unsafeFilenameChars.ReplaceAllString(name, "_")+".json")
+}
+
+func learningRule(name string, phase int) string {
+	h := fnv.New32a()
+	h.Write([]byte(name))
+
+	return fmt.Sprintf(`SecRule TX:%s "@eq 1" "id:%d,phase:%d,pass,nolog,ctl:ruleEngine=DetectionOnly"`, LearningVariable, h.Sum32(), phase)
+}
+
+// loadLearning creates the recorder of the matches, and returns the collection of the rules
+// switching the transactions in learning mode to detection only, for the request and the response phases
+func (w *AppsecRuntimeConfig) loadLearning(wc *AppsecConfig) (AppsecCollection, error) {
+	collection := AppsecCollection{
+		collectionName: "learning",
+		NativeRules: []string{
+			learningRule("learning:request", 1),
+			learningRule("learning:response", 3),
+		},
+	}
+
+	output := wc.Learning.Output
+	if output == "" {
+		output = LearningOutput(wc.GetDataDir(), wc.Name)
+	}
+
+	recorder, err := learning.NewRecorder(output, wc.Learning.Duration)
+	if err != nil {
+		return collection, fmt.Errorf("unable to load learning data: %w", err)
+	}
+
+	if end := recorder.End(); end.IsZero() {
+		w.Logger.Warningf("learning mode enabled, the requests are not blocked, matches are recorded in %s", output)
+	} else if recorder.Active(time.Now()) {
+		w.Logger.Warningf("learning mode enabled, the requests are not blocked until %s, matches are recorded in %s", end.Format(time.RFC3339), output)
+	} else {
+		w.Logger.Infof("learning period ended at %s, run 'cscli appsec-configs suggest-exclusions %s' to review the exclusions", end.Format(time.RFC3339), output)
+	}
+
+	w.Learner = recorder
+
+	return collection, nil
+}
+
+// StartLearning flags the transaction as evaluated in learning mode, if the learning period is running,
+// and counts the request
+func (w *AppsecRuntimeConfig) StartLearning(request *ParsedRequest) {
+	if w.Learner == nil || !w.Learner.Active(time.Now()) {
+		return
+	}
+
+	request.Tx.Variables().TX().Set(LearningVariable, []string{"1"})
+
+	if request.IsInBand && !request.IsResponse {
+		w.Learner.CountRequest(requestPath(request))
+	}
+}
+
+// RecordLearning records the rules matched by a transaction evaluated in learning mode
+func (w *AppsecRuntimeConfig) RecordLearning(request *ParsedRequest) {
+	if w.Learner == nil || request.Tx.Tx == nil || len(request.Tx.Variables().TX().Get(LearningVariable)) == 0 {
+		return
+	}
+
+	band := "outofband"
+	if request.IsInBand {
+		band = "inband"
+	}
+
+	var matches []learning.Match
+
+	for _, rule := range request.Tx.Tx.MatchedRules() {
+		// the rules without a message are the CRS internals (anomaly scores, paranoia levels...)
+		if rule.Message() == "" {
+			continue
+		}
+
+		for _, data := range rule.MatchedDatas() {
+			// the matches on TX variables are the CRS anomaly scores and the checks done outside of coraza,
+			// the original rules are recorded instead
+			if data.Variable().Name() == "TX" {
+				continue
+			}
+
+			matches = append(matches, learning.Match{
+				RuleID:   rule.Rule().ID(),
+				Message:  rule.Message(),
+				Band:     band,
+				Zone:     data.Variable().Name(),
+				Variable: data.Key(),
+			})
+		}
+	}
+
+	w.Learner.Record(time.Now(), request.ClientIP, requestPath(request), matches)
+}
+
+// requestPath returns the raw URI of the request, the one the suggested exclusions match as req.RequestURI
+func requestPath(request *ParsedRequest) string {
+	if request.URI == "" && request.URL != nil {
+		return request.URL.RequestURI()
+	}
+
+	return request.URI
+}
@@ -0,0 +1,301 @@
+// Package learning records the rules matched by the AppSec component while it runs without blocking,
+// and suggests the exclusions for the matches that look like false positives.
+package learning
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+)
+
+const (
+	maxMatches       = 10000 // distinct (rule, zone, variable, uri) entries
+	maxClients       = 10000 // clients with stats
+	maxURIs          = 10000 // uri patterns with a request count
+	maxMatchClients  = 100   // distinct clients kept per match
+	maxClientURIs    = 100   // distinct uri patterns kept per client
+	maxVariableBytes = 256
+)
+
+// Match is a rule matched by a request
+type Match struct {
+	RuleID   int
+	Message  string
+	Band     string // inband or outofband
+	Zone     string // the collection of the variable: ARGS_GET, REQUEST_HEADERS...
+	Variable string // the key of the variable in the collection
+}
+
+type MatchStats struct {
+	RuleID    int       `json:"rule_id"`
+	Message   string    `json:"message"`
+	Band      string    `json:"band"`
+	Zone      string    `json:"zone"`
+	Variable  string    `json:"variable,omitempty"`
+	URI       string    `json:"uri"`
+	Hits      int       `json:"hits"`
+	Clients   []string  `json:"clients"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+type ClientStats struct {
+	IP        string    `json:"ip"`
+	Hits      int       `json:"hits"` // requests with at least one match
+	URIs      []string  `json:"uris"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+}
+
+// Data is what was learned, it's stored as JSON between the restarts
+type Data struct {
+	StartedAt time.Time      `json:"started_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	Requests  map[string]int `json:"requests"` // by uri pattern
+	Matches   []*MatchStats  `json:"matches"`
+	Clients   []*ClientStats `json:"clients"`
+}
+
+type matchKey struct {
+	ruleID   int
+	band     string
+	zone     string
+	variable string
+	uri      string
+}
+
+// Recorder aggregates the matches of the requests, it's shared by the runners
+type Recorder struct {
+	path     string
+	duration time.Duration // 0 to learn forever
+
+	mu      sync.Mutex
+	data    Data
+	matches map[matchKey]*MatchStats
+	clients map[string]*ClientStats
+	dirty   bool
+}
+
+// NewRecorder returns a recorder storing its data in path, and resuming from it if it exists,
+// so the learning period is not restarted with crowdsec
+func NewRecorder(path string, duration time.Duration) (*Recorder, error) {
+	r := &Recorder{
+		path:     path,
+		duration: duration,
+		matches:  make(map[matchKey]*MatchStats),
+		clients:  make(map[string]*ClientStats),
+	}
+
+	data, err := Load(path)
+
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		now := time.Now().UTC()
+		r.data = Data{StartedAt: now, UpdatedAt: now, Requests: make(map[string]int)}
+		r.dirty = true
+	case err != nil:
+		return nil, err
+	default:
+		r.data = *data
+
+		for _, m := range r.data.Matches {
+			r.matches[matchKey{ruleID: m.RuleID, band: m.Band, zone: m.Zone, variable: m.Variable, uri: m.URI}] = m
+		}
+
+		for _, c := range r.data.Clients {
+			r.clients[c.IP] = c
+		}
+	}
+
+	return r, nil
+}
+
+// Load reads the data written by a recorder
+func Load(path string) (*Data, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data := Data{}
+	if err := json.Unmarshal(content, &data); err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	if data.Requests == nil {
+		data.Requests = make(map[string]int)
+	}
+
+	return &data, nil
+}
+
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// End returns the end of the learning period, zero if there is none
+func (r *Recorder) End() time.Time {
+	if r.duration == 0 {
+		return time.Time{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.data.StartedAt.Add(r.duration)
+}
+
+// Active tells if the learning period is still running at now
+func (r *Recorder) Active(now time.Time) bool {
+	end := r.End()
+
+	return end.IsZero() || now.Before(end)
+}
+
+// CountRequest counts a request to uri, to compare the number of matches with the traffic
+func (r *Recorder) CountRequest(uri string) {
+	pattern := URIPattern(uri)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.data.Requests[pattern]; ok || len(r.data.Requests) < maxURIs {
+		r.data.Requests[pattern]++
+		r.dirty = true
+	}
+}
+
+// Record adds the matches of a request from client to uri
+func (r *Recorder) Record(now time.Time, client string, uri string, matches []Match) {
+	if len(matches) == 0 {
+		return
+	}
+
+	now = now.UTC()
+	pattern := URIPattern(uri)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.dirty = true
+
+	// a variable can be matched several times by the same rule, it's counted once per request
+	seen := make(map[matchKey]struct{}, len(matches))
+
+	for _, m := range matches {
+		key := matchKey{ruleID: m.RuleID, band: m.Band, zone: m.Zone, variable: truncate(m.Variable), uri: pattern}
+
+		if _, ok := seen[key]; ok {
+			continue
+		}
+
+		seen[key] = struct{}{}
+
+		stats, ok := r.matches[key]
+		if !ok {
+			if len(r.matches) >= maxMatches {
+				continue
+			}
+
+			stats = &MatchStats{
+				RuleID:    m.RuleID,
+				Message:   m.Message,
+				Band:      m.Band,
+				Zone:      m.Zone,
+				Variable:  key.variable,
+				URI:       pattern,
+				FirstSeen: now,
+			}
+			r.matches[key] = stats
+			r.data.Matches = append(r.data.Matches, stats)
+		}
+
+		stats.Hits++
+		stats.LastSeen = now
+
+		if len(stats.Clients) < maxMatchClients && !slices.Contains(stats.Clients, client) {
+			stats.Clients = append(stats.Clients, client)
+		}
+	}
+
+	c, ok := r.clients[client]
+	if !ok {
+		if len(r.clients) >= maxClients {
+			return
+		}
+
+		c = &ClientStats{IP: client, FirstSeen: now}
+		r.clients[client] = c
+		r.data.Clients = append(r.data.Clients, c)
+	}
+
+	c.Hits++
+	c.LastSeen = now
+
+	if len(c.URIs) < maxClientURIs && !slices.Contains(c.URIs, pattern) {
+		c.URIs = append(c.URIs, pattern)
+	}
+}
+
+// Flush writes the data to the file of the recorder, if it changed since the last flush
+func (r *Recorder) Flush() error {
+	err := r.flush()
+	if err != nil {
+		// retry with the next flush
+		r.mu.Lock()
+		r.dirty = true
+		r.mu.Unlock()
+	}
+
+	return err
+}
+
+func (r *Recorder) flush() error {
+	r.mu.Lock()
+
+	if !r.dirty {
+		r.mu.Unlock()
+		return nil
+	}
+
+	r.data.UpdatedAt = time.Now().UTC()
+	content, err := json.Marshal(r.data)
+	r.dirty = false
+
+	r.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("unable to marshal learning data: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return fmt.Errorf("unable to create directory for %s: %w", r.path, err)
+	}
+
+	// write then rename, to never leave a truncated file behind
+	tmp := r.path + ".tmp"
+
+	if err := os.WriteFile(tmp, content, 0o600); err != nil {
+		return fmt.Errorf("unable to write %s: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, r.path); err != nil {
+		return fmt.Errorf("unable to rename %s: %w", tmp, err)
+	}
+
+	return nil
+}
+
+func truncate(s string) string {
+	if len(s) <= maxVariableBytes {
+		return s
+	}
+
+	return s[:maxVariableBytes]
+}
@@ -0,0 +1,135 @@
+package learning
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestURIPattern(t *testing.T) {
+	tests := []struct {
+		uri      string
+		expected string
+	}{
+		{uri: "", expected: "/"},
+		{uri: "/", expected: "/"},
+		{uri: "/login?next=/admin", expected: "/login"},
+		{uri: "/users/42/posts", expected: "/users/*/posts"},
+		{uri: "/orders/3f2504e0-4f89-11d3-9a0c-0305e82c3301", expected: "/orders/*"},
+		{uri: "/files/d41d8cd98f00b204e9800998ecf8427e/", expected: "/files/*/"},
+		{uri: "/reset/eyJhbGciOiJIUzI1NiJ9abc123XYZ", expected: "/reset/*"},
+		{uri: "/static/app.3f2a9c1b.js", expected: "/static/app.3f2a9c1b.js"},
+		{uri: "/documentation/internationalization", expected: "/documentation/internationalization"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.uri, func(t *testing.T) {
+			assert.Equal(t, tc.expected, URIPattern(tc.uri))
+		})
+	}
+}
+
+func TestURIRegexp(t *testing.T) {
+	re := regexp.MustCompile(URIRegexp("/users/*/posts.json"))
+
+	assert.True(t, re.MatchString("/users/42/posts.json"))
+	assert.True(t, re.MatchString("/users/42/posts.json?page=2"))
+	assert.False(t, re.MatchString("/users/42/posts_json"))
+	assert.False(t, re.MatchString("/users/42/43/posts.json"))
+	assert.False(t, re.MatchString("/admin/users/42/posts.json"))
+
+	// the pattern of an URI always matches it
+	for _, uri := range []string{"/caf%C3%A9/42?q=1", "/files/a%2Fb", "/search?q=%3Cscript%3E"} {
+		assert.Regexp(t, URIRegexp(URIPattern(uri)), uri)
+	}
+}
+
+func TestRecorder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "learning.json")
+
+	r, err := NewRecorder(path, time.Hour)
+	require.NoError(t, err)
+
+	now := time.Now()
+	require.True(t, r.Active(now))
+	require.False(t, r.Active(now.Add(2*time.Hour)))
+
+	sqli := Match{RuleID: 942100, Message: "SQL Injection", Band: "inband", Zone: "ARGS_GET", Variable: "q"}
+
+	r.CountRequest("/search?q=1")
+	r.CountRequest("/search?q=select")
+	r.Record(now, "1.2.3.4", "/search?q=select", []Match{sqli, sqli})
+	r.Record(now, "5.6.7.8", "/search?q=union", []Match{sqli})
+	r.Record(now, "5.6.7.8", "/users/1?q=union", []Match{sqli})
+
+	require.NoError(t, r.Flush())
+
+	// the data and the learning period are kept when restarting
+	r2, err := NewRecorder(path, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, r.End(), r2.End())
+
+	r2.Record(now, "1.2.3.4", "/search", []Match{sqli})
+	require.NoError(t, r2.Flush())
+
+	data, err := Load(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]int{"/search": 2}, data.Requests)
+	require.Len(t, data.Matches, 2)
+	assert.Equal(t, "/search", data.Matches[0].URI)
+	assert.Equal(t, 3, data.Matches[0].Hits)
+	assert.Equal(t, []string{"1.2.3.4", "5.6.7.8"}, data.Matches[0].Clients)
+	assert.Equal(t, "/users/*", data.Matches[1].URI)
+
+	require.Len(t, data.Clients, 2)
+	assert.Equal(t, "5.6.7.8", data.Clients[1].IP)
+	assert.Equal(t, 2, data.Clients[1].Hits)
+	assert.Equal(t, []string{"/search", "/users/*"}, data.Clients[1].URIs)
+}
+
+func TestSuggest(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(7 * 24 * time.Hour)
+
+	data := &Data{
+		StartedAt: start,
+		UpdatedAt: end,
+		Requests:  map[string]int{"/search": 100, "/admin": 10},
+		Matches: []*MatchStats{
+			{RuleID: 1, Message: "sqli", Band: "inband", Zone: "ARGS_GET", Variable: "q", URI: "/search", Hits: 60, Clients: []string{"a", "b", "c"}},
+			{RuleID: 1, Message: "sqli", Band: "inband", Zone: "ARGS_GET", Variable: "sort", URI: "/search", Hits: 10, Clients: []string{"c", "d"}},
+			// a single client, an attack more than a false positive
+			{RuleID: 2, Message: "xss", Band: "inband", Zone: "ARGS_GET", Variable: "q", URI: "/search", Hits: 100, Clients: []string{"e"}},
+			// not often enough
+			{RuleID: 1, Message: "sqli", Band: "outofband", Zone: "REQUEST_HEADERS", Variable: "cookie", URI: "/admin", Hits: 2, Clients: []string{"a", "b", "c"}},
+		},
+		Clients: []*ClientStats{
+			{IP: "monitoring", Hits: 50, URIs: []string{"/search"}, FirstSeen: start, LastSeen: end},
+			{IP: "attacker", Hits: 100, URIs: []string{"/search"}, FirstSeen: end.Add(-time.Hour), LastSeen: end},
+			{IP: "user", Hits: 1, URIs: []string{"/search"}, FirstSeen: start, LastSeen: end},
+		},
+	}
+
+	suggestions := Suggest(data, SuggestOptions{MinHits: 10, MinClients: 3})
+
+	assert.Equal(t, []Exclusion{
+		{
+			RuleID:   1,
+			Message:  "sqli",
+			Band:     "inband",
+			URI:      "/search",
+			Regexp:   `^/search(\?.*)?$`,
+			Hits:     70,
+			Clients:  4,
+			Requests: 100,
+			Targets:  []string{"ARGS_GET:q", "ARGS_GET:sort"},
+		},
+	}, suggestions.Exclusions)
+
+	assert.Equal(t, []AllowlistEntry{{IP: "monitoring", Hits: 50, URIs: 1}}, suggestions.Allowlist)
+}
@@ -0,0 +1,183 @@
+package learning
+
+import (
+	"cmp"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+var (
+	uuidSegment  = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+	hexSegment   = regexp.MustCompile(`^[0-9a-fA-F]{16,}$`)
+	tokenSegment = regexp.MustCompile(`^[0-9A-Za-z_=-]{24,}$`)
+)
+
+// URIPattern groups the paths only differing by their identifiers: the query string is removed,
+// and the segments that are numbers, UUIDs, hashes or tokens are replaced by *.
+// The URI is the raw one, still escaped, as matched by the regexp of the pattern.
+func URIPattern(uri string) string {
+	path, _, _ := strings.Cut(uri, "?")
+	if path == "" {
+		return "/"
+	}
+
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		if isIdentifier(segment) {
+			segments[i] = "*"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+func isIdentifier(segment string) bool {
+	if segment == "" {
+		return false
+	}
+
+	if strings.Trim(segment, "0123456789") == "" {
+		return true
+	}
+
+	if uuidSegment.MatchString(segment) || hexSegment.MatchString(segment) {
+		return true
+	}
+
+	// long tokens mixing letters and digits, a long word is not a token
+	return tokenSegment.MatchString(segment) && strings.ContainsAny(segment, "0123456789")
+}
+
+// URIRegexp returns the regexp matching the request URIs (with their query string) of a pattern
+func URIRegexp(pattern string) string {
+	segments := strings.Split(pattern, "/")
+
+	for i, segment := range segments {
+		if segment == "*" {
+			segments[i] = `[^/?]+`
+			continue
+		}
+
+		segments[i] = regexp.QuoteMeta(segment)
+	}
+
+	return "^" + strings.Join(segments, "/") + `(\?.*)?$`
+}
+
+type SuggestOptions struct {
+	MinHits    int // matches of a rule on an uri
+	MinClients int // distinct clients triggering a rule on an uri
+}
+
+// Exclusion is a rule matched consistently on an uri pattern, that could be disabled for it
+type Exclusion struct {
+	RuleID   int
+	Message  string
+	Band     string
+	URI      string
+	Regexp   string
+	Hits     int
+	Clients  int
+	Requests int      // requests to the uri
+	Targets  []string // ZONE:variable
+}
+
+// AllowlistEntry is a client triggering the rules during all the learning period,
+// probably a scanner or a monitoring tool
+type AllowlistEntry struct {
+	IP   string
+	Hits int
+	URIs int
+}
+
+type Suggestions struct {
+	Exclusions []Exclusion
+	Allowlist  []AllowlistEntry
+}
+
+// Suggest returns the exclusions and the allowlist entries for the matches seen often enough
+func Suggest(data *Data, opts SuggestOptions) Suggestions {
+	type exclusionKey struct {
+		ruleID int
+		band   string
+		uri    string
+	}
+
+	exclusions := make(map[exclusionKey]*Exclusion)
+	clients := make(map[exclusionKey]map[string]struct{})
+
+	for _, m := range data.Matches {
+		key := exclusionKey{ruleID: m.RuleID, band: m.Band, uri: m.URI}
+
+		e, ok := exclusions[key]
+		if !ok {
+			e = &Exclusion{
+				RuleID:   m.RuleID,
+				Message:  m.Message,
+				Band:     m.Band,
+				URI:      m.URI,
+				Regexp:   URIRegexp(m.URI),
+				Requests: data.Requests[m.URI],
+			}
+			exclusions[key] = e
+			clients[key] = make(map[string]struct{})
+		}
+
+		e.Hits += m.Hits
+
+		target := m.Zone
+		if m.Variable != "" {
+			target += ":" + m.Variable
+		}
+
+		if !slices.Contains(e.Targets, target) {
+			e.Targets = append(e.Targets, target)
+		}
+
+		for _, c := range m.Clients {
+			clients[key][c] = struct{}{}
+		}
+	}
+
+	ret := Suggestions{}
+
+	for key, e := range exclusions {
+		e.Clients = len(clients[key])
+
+		if e.Hits < opts.MinHits || e.Clients < opts.MinClients {
+			continue
+		}
+
+		slices.Sort(e.Targets)
+		ret.Exclusions = append(ret.Exclusions, *e)
+	}
+
+	slices.SortFunc(ret.Exclusions, func(a, b Exclusion) int {
+		return cmp.Or(cmp.Compare(a.URI, b.URI), cmp.Compare(a.Band, b.Band), cmp.Compare(a.RuleID, b.RuleID))
+	})
+
+	// the clients seen during most of the period are not attacks starting and stopping
+	period := data.Period()
+
+	for _, c := range data.Clients {
+		if c.Hits < opts.MinHits || period <= 0 || c.LastSeen.Sub(c.FirstSeen) < period/2 {
+			continue
+		}
+
+		ret.Allowlist = append(ret.Allowlist, AllowlistEntry{IP: c.IP, Hits: c.Hits, URIs: len(c.URIs)})
+	}
+
+	slices.SortFunc(ret.Allowlist, func(a, b AllowlistEntry) int {
+		return cmp.Or(cmp.Compare(b.Hits, a.Hits), cmp.Compare(a.IP, b.IP))
+	})
+
+	return ret
+}
+
+// Period returns the duration of the learning covered by the data
+func (d *Data) Period() time.Duration {
+	return d.UpdatedAt.Sub(d.StartedAt)
+}
@@ -0,0 +1,103 @@
+package appsec
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/openapi"
+)
+
+// openAPIEnforcer flags the requests violating a schema in a transaction variable,
+// that is matched by a generated rule so that violations are handled like any other rule match.
+type openAPIEnforcer struct {
+	schema   *openapi.Schema
+	variable string
+}
+
+// OpenAPIRuleName is the name of the rule matching the violations of a schema,
+// it can be used with the *ByName helpers of the hooks
+func OpenAPIRuleName(schema string) string {
+	return "openapi:" + schema
+}
+
+// loadOpenAPISchemas loads the schemas of the configuration and returns the collection of the rules matching their violations
+func (w *AppsecRuntimeConfig) loadOpenAPISchemas(ctx context.Context, wc *AppsecConfig) (AppsecCollection, error) {
+	collection := AppsecCollection{collectionName: "openapi"}
+	names := make(map[string]bool, len(wc.OpenAPISchemas))
+
+	for _, cfg := range wc.OpenAPISchemas {
+		if err := cfg.Validate(); err != nil {
+			return collection, fmt.Errorf("invalid openapi schema: %w", err)
+		}
+
+		if names[cfg.Name] {
+			return collection, fmt.Errorf("duplicate openapi schema %s", cfg.Name)
+		}
+
+		names[cfg.Name] = true
+
+		specPath := cfg.Spec
+		if !filepath.IsAbs(specPath) {
+			specPath = filepath.Join(wc.GetDataDir(), specPath)
+		}
+
+		schema, err := openapi.Load(ctx, cfg, specPath)
+		if err != nil {
+			return collection, err
+		}
+
+		variable, rule, err := txRule(OpenAPIRuleName(cfg.Name))
+		if err != nil {
+			return collection, err
+		}
+
+		collection.NativeRules = append(collection.NativeRules, rule)
+
+		w.OpenAPISchemas = append(w.OpenAPISchemas, openAPIEnforcer{schema: schema, variable: variable})
+
+		wc.Logger.Infof("loaded openapi schema %s from %s", cfg.Name, specPath)
+	}
+
+	return collection, nil
+}
+
+// ValidateOpenAPI checks the request against the first schema matching its host and path,
+// and flags the violation in the transaction
+func (w *AppsecRuntimeConfig) ValidateOpenAPI(request *ParsedRequest) {
+	if request.URL == nil {
+		return
+	}
+
+	for _, enforcer := range w.OpenAPISchemas {
+		if !enforcer.schema.Matches(request.Host, openapi.EscapedPath(request.URL)) {
+			continue
+		}
+
+		ctx := context.TODO()
+		if request.HTTPRequest != nil {
+			ctx = request.HTTPRequest.Context()
+		}
+
+		// the validation consumes the body, don't use the original request
+		req := (&http.Request{
+			Method:        request.Method,
+			URL:           request.URL,
+			Proto:         request.Proto,
+			Header:        request.Headers,
+			Host:          request.Host,
+			Body:          io.NopCloser(bytes.NewReader(request.Body)),
+			ContentLength: int64(len(request.Body)),
+		}).WithContext(ctx)
+
+		if err := enforcer.schema.Validate(ctx, req); err != nil {
+			w.Logger.Debugf("request %s violates openapi schema %s: %s", request.UUID, enforcer.schema.Name, err)
+			request.Tx.Variables().TX().Set(enforcer.variable, []string{err.Error()})
+		}
+
+		return
+	}
+}
@@ -0,0 +1,420 @@
+// Package openapi enforces OpenAPI 3 specifications on the requests seen by the appsec component:
+// requests whose path, method, parameters, content type or body don't conform to the spec are rejected.
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+)
+
+// maximum length of the validation errors, they end up in the logs and the alerts
+const maxErrorLength = 256
+
+// maximum time to load a specification with remote references, the component doesn't start before
+const remoteRefsTimeout = 30 * time.Second
+
+var validName = regexp.MustCompile(`^[a-zA-Z0-9_-]+$`)
+
+// Config is an OpenAPI specification enforced on the requests of some hosts and path prefix
+type Config struct {
+	Name       string   `yaml:"name"`
+	Spec       string   `yaml:"spec"`                  // path of the specification, relative to the data directory
+	Hosts      []string `yaml:"hosts,omitempty"`       // empty means any host
+	PathPrefix string   `yaml:"path_prefix,omitempty"` // stripped before matching the paths of the specification
+	RemoteRefs bool     `yaml:"remote_refs,omitempty"` // fetch the $ref to http(s) URLs, only local files are read by default
+}
+
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("missing name")
+	}
+
+	if !validName.MatchString(c.Name) {
+		return fmt.Errorf("invalid name %q: only letters, digits, '_' and '-' are allowed", c.Name)
+	}
+
+	if c.Spec == "" {
+		return fmt.Errorf("%s: missing spec", c.Name)
+	}
+
+	return nil
+}
+
+type route struct {
+	path     string
+	item     *openapi3.PathItem
+	segments []segment
+}
+
+// routeKey indexes the templated paths, wildcard is set when the first segment has parameters
+type routeKey struct {
+	segments int
+	first    string
+	wildcard bool
+}
+
+func keyOf(parts []string) routeKey {
+	k := routeKey{segments: len(parts)}
+	if len(parts) > 1 {
+		k.first = parts[1]
+	}
+
+	return k
+}
+
+// segment is a part of a templated path, either a literal or a pattern with parameters
+type segment struct {
+	literal string
+	re      *regexp.Regexp
+	params  []string
+}
+
+// Schema is a loaded specification, safe for concurrent use
+type Schema struct {
+	Name   string
+	hosts  []string
+	prefix string
+	doc    *openapi3.T
+	// paths without parameters, by path
+	exact map[string]*route
+	// paths with parameters, by number of segments and first segment, in matching order
+	templated map[routeKey][]*route
+	options   *openapi3filter.Options
+}
+
+// readRemote fetches the remote references with the context of the loader, which has a deadline
+func readRemote(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+	if location.Scheme == "" || location.Host == "" {
+		return nil, openapi3.ErrURINotSupported
+	}
+
+	req, err := http.NewRequestWithContext(loader.Context, http.MethodGet, location.String(), http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("error loading %q: unexpected status code %d", location, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+// Load reads and validates the specification of the configuration.
+// The references to other files are followed, the ones to URLs only if RemoteRefs is set.
+func Load(ctx context.Context, cfg Config, specPath string) (*Schema, error) {
+	readers := []openapi3.ReadFromURIFunc{openapi3.ReadFromFile}
+
+	if cfg.RemoteRefs {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, remoteRefsTimeout)
+		defer cancel()
+
+		readers = append(readers, readRemote)
+	}
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = openapi3.ReadFromURIs(readers...)
+	loader.Context = ctx
+
+	doc, err := loader.LoadFromFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to load spec %s: %w", cfg.Name, specPath, err)
+	}
+
+	if err = doc.Validate(ctx); err != nil {
+		return nil, fmt.Errorf("%s: invalid spec %s: %w", cfg.Name, specPath, err)
+	}
+
+	return New(cfg, doc)
+}
+
+// New builds a schema from an already loaded specification
+func New(cfg Config, doc *openapi3.T) (*Schema, error) {
+	s := &Schema{
+		Name:      cfg.Name,
+		prefix:    normalizePrefix(cfg.PathPrefix),
+		doc:       doc,
+		exact:     make(map[string]*route),
+		templated: make(map[routeKey][]*route),
+		options: &openapi3filter.Options{
+			// authentication is the business of the application
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+			SkipSettingDefaults: true,
+		},
+	}
+
+	for _, host := range cfg.Hosts {
+		s.hosts = append(s.hosts, strings.ToLower(host))
+	}
+
+	if doc.Paths == nil {
+		return s, nil
+	}
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		r := &route{path: path, item: doc.Paths.Value(path)}
+
+		if !strings.Contains(path, "{") {
+			s.exact[path] = r
+			continue
+		}
+
+		parts := strings.Split(path, "/")
+		for _, part := range parts {
+			seg, err := newSegment(part)
+			if err != nil {
+				return nil, fmt.Errorf("%s: path %s: %w", cfg.Name, path, err)
+			}
+
+			r.segments = append(r.segments, seg)
+		}
+
+		key := keyOf(parts)
+		if len(r.segments) > 1 && r.segments[1].re != nil {
+			key = routeKey{segments: len(parts), wildcard: true}
+		}
+
+		s.templated[key] = append(s.templated[key], r)
+	}
+
+	return s, nil
+}
+
+var paramPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+func newSegment(part string) (segment, error) {
+	matches := paramPattern.FindAllStringSubmatchIndex(part, -1)
+	if len(matches) == 0 {
+		return segment{literal: part}, nil
+	}
+
+	var (
+		expr   strings.Builder
+		params []string
+		last   int
+	)
+
+	expr.WriteString("^")
+
+	for _, m := range matches {
+		expr.WriteString(regexp.QuoteMeta(part[last:m[0]]))
+		expr.WriteString("([^/]+?)")
+		params = append(params, part[m[2]:m[3]])
+		last = m[1]
+	}
+
+	expr.WriteString(regexp.QuoteMeta(part[last:]))
+	expr.WriteString("$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return segment{}, err
+	}
+
+	return segment{re: re, params: params}, nil
+}
+
+func normalizePrefix(prefix string) string {
+	prefix = strings.TrimRight(prefix, "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	return prefix
+}
+
+// EscapedPath returns the path of the URL with its segments escaped the same way, whatever the
+// encoding sent by the client: /%761 and /v1 are the same path, but an encoded slash stays in its segment.
+// The schemas are matched and validated against this path.
+func EscapedPath(u *url.URL) string {
+	parts := strings.Split(u.EscapedPath(), "/")
+
+	for i, part := range parts {
+		if unescaped, err := url.PathUnescape(part); err == nil {
+			parts[i] = url.PathEscape(unescaped)
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// Matches returns true if the requests to this host and path (see EscapedPath) must conform to the schema
+func (s *Schema) Matches(host string, path string) bool {
+	if len(s.hosts) > 0 {
+		if h, _, err := net.SplitHostPort(host); err == nil {
+			host = h
+		}
+
+		found := false
+
+		for _, h := range s.hosts {
+			if strings.EqualFold(h, host) {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return s.prefix == "" || path == s.prefix || strings.HasPrefix(path, s.prefix+"/")
+}
+
+func (s *Schema) findRoute(path string) (*route, map[string]string) {
+	if r, ok := s.exact[path]; ok {
+		return r, nil
+	}
+
+	parts := strings.Split(path, "/")
+
+	// the paths starting with a literal are more specific
+	key := keyOf(parts)
+	for _, r := range s.templated[key] {
+		if params, ok := r.match(parts); ok {
+			return r, params
+		}
+	}
+
+	for _, r := range s.templated[routeKey{segments: len(parts), wildcard: true}] {
+		if params, ok := r.match(parts); ok {
+			return r, params
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *route) match(parts []string) (map[string]string, bool) {
+	var params map[string]string
+
+	for i, seg := range r.segments {
+		if seg.re == nil {
+			if seg.literal != parts[i] {
+				return nil, false
+			}
+
+			continue
+		}
+
+		m := seg.re.FindStringSubmatch(parts[i])
+		if m == nil {
+			return nil, false
+		}
+
+		if params == nil {
+			params = make(map[string]string)
+		}
+
+		for j, name := range seg.params {
+			value, err := url.PathUnescape(m[j+1])
+			if err != nil {
+				return nil, false
+			}
+
+			params[name] = value
+		}
+	}
+
+	return params, true
+}
+
+// Validate checks that the request conforms to the schema. The body of the request is consumed.
+func (s *Schema) Validate(ctx context.Context, req *http.Request) error {
+	// match on the escaped path, so that an encoded slash stays in its path parameter
+	path := strings.TrimPrefix(EscapedPath(req.URL), s.prefix)
+	if path == "" {
+		path = "/"
+	}
+
+	r, params := s.findRoute(path)
+	if r == nil {
+		return fmt.Errorf("path %s is not defined", path)
+	}
+
+	op := r.item.GetOperation(req.Method)
+	if op == nil {
+		return fmt.Errorf("method %s is not allowed on %s", req.Method, r.path)
+	}
+
+	err := openapi3filter.ValidateRequest(ctx, &openapi3filter.RequestValidationInput{
+		Request:    req,
+		PathParams: params,
+		Route: &routers.Route{
+			Spec:      s.doc,
+			Path:      r.path,
+			PathItem:  r.item,
+			Method:    req.Method,
+			Operation: op,
+		},
+		Options: s.options,
+	})
+	if err != nil {
+		return shortError(err)
+	}
+
+	return nil
+}
+
+// shortError removes the details of the schemas from the validation errors
+func shortError(err error) error {
+	var reqErr *openapi3filter.RequestError
+
+	msg := err.Error()
+
+	if errors.As(err, &reqErr) {
+		switch {
+		case reqErr.Parameter != nil:
+			msg = fmt.Sprintf("parameter %q in %s: %s", reqErr.Parameter.Name, reqErr.Parameter.In, errorReason(reqErr))
+		case reqErr.RequestBody != nil:
+			msg = "request body: " + errorReason(reqErr)
+		}
+	}
+
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength-3] + "..."
+	}
+
+	return errors.New(msg)
+}
+
+func errorReason(reqErr *openapi3filter.RequestError) string {
+	var schemaErr *openapi3.SchemaError
+
+	if errors.As(reqErr.Err, &schemaErr) {
+		if field := schemaErr.JSONPointer(); len(field) > 0 {
+			return fmt.Sprintf("%s: %s", strings.Join(field, "."), schemaErr.Reason)
+		}
+
+		return schemaErr.Reason
+	}
+
+	if reqErr.Err != nil {
+		return reqErr.Err.Error()
+	}
+
+	return reqErr.Reason
+}
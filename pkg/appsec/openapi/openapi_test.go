@@ -0,0 +1,330 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+const petstore = `
+openapi: 3.0.3
+info:
+  title: petstore
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            maximum: 100
+      responses:
+        "200":
+          description: ok
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              additionalProperties: false
+              required: [name]
+              properties:
+                name:
+                  type: string
+                  maxLength: 32
+                age:
+                  type: integer
+      responses:
+        "201":
+          description: created
+  /pets/{petId}:
+    get:
+      parameters:
+        - name: petId
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+  /files/{name}.json:
+    get:
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: "^[a-z]+$"
+      responses:
+        "200":
+          description: ok
+`
+
+func writeSpec(t testing.TB, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "spec.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         Config
+		expectedErr string
+	}{
+		{name: "valid", cfg: Config{Name: "petstore", Spec: "petstore.yaml"}},
+		{name: "no name", cfg: Config{Spec: "petstore.yaml"}, expectedErr: "missing name"},
+		{name: "bad name", cfg: Config{Name: "pet store", Spec: "petstore.yaml"}, expectedErr: `invalid name "pet store"`},
+		{name: "no spec", cfg: Config{Name: "petstore"}, expectedErr: "petstore: missing spec"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.Validate()
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	ctx := t.Context()
+
+	_, err := Load(ctx, Config{Name: "missing"}, filepath.Join(t.TempDir(), "missing.yaml"))
+	cstest.RequireErrorContains(t, err, "missing: unable to load spec")
+
+	_, err = Load(ctx, Config{Name: "invalid"}, writeSpec(t, "openapi: 3.0.3\npaths: {}\n"))
+	cstest.RequireErrorContains(t, err, "invalid: invalid spec")
+
+	s, err := Load(ctx, Config{Name: "petstore"}, writeSpec(t, petstore))
+	require.NoError(t, err)
+	assert.Equal(t, "petstore", s.Name)
+}
+
+func TestLoadRefs(t *testing.T) {
+	ctx := t.Context()
+
+	pet := "type: object\nproperties:\n  name:\n    type: string\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, _ *http.Request) {
+		_, _ = rw.Write([]byte(pet))
+	}))
+	defer server.Close()
+
+	spec := func(ref string) string {
+		return `
+openapi: 3.0.3
+info:
+  title: refs
+  version: "1.0"
+paths:
+  /pets:
+    post:
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: "` + ref + `"
+      responses:
+        "201":
+          description: created
+`
+	}
+
+	// the local files are always read
+	specPath := writeSpec(t, spec("pet.yaml"))
+	require.NoError(t, os.WriteFile(filepath.Join(filepath.Dir(specPath), "pet.yaml"), []byte(pet), 0o600))
+
+	_, err := Load(ctx, Config{Name: "local"}, specPath)
+	require.NoError(t, err)
+
+	// the remote references must be enabled
+	_, err = Load(ctx, Config{Name: "remote"}, writeSpec(t, spec(server.URL+"/pet.yaml")))
+	cstest.RequireErrorContains(t, err, "unsupported URI")
+
+	_, err = Load(ctx, Config{Name: "remote", RemoteRefs: true}, writeSpec(t, spec(server.URL+"/pet.yaml")))
+	require.NoError(t, err)
+}
+
+func TestMatches(t *testing.T) {
+	s, err := New(Config{Name: "api", Hosts: []string{"API.example.com"}, PathPrefix: "api/v1/"}, &openapi3.T{})
+	require.NoError(t, err)
+
+	assert.True(t, s.Matches("api.example.com", "/api/v1/pets"))
+	assert.True(t, s.Matches("api.example.com:8080", "/api/v1"))
+	assert.False(t, s.Matches("api.example.com", "/api/v10/pets"))
+	assert.False(t, s.Matches("www.example.com", "/api/v1/pets"))
+
+	s, err = New(Config{Name: "any"}, &openapi3.T{})
+	require.NoError(t, err)
+
+	assert.True(t, s.Matches("www.example.com", "/whatever"))
+}
+
+func TestEscapedPath(t *testing.T) {
+	for raw, expected := range map[string]string{
+		"/api/v1/pets":       "/api/v1/pets",
+		"/api/%761/pets":     "/api/v1/pets",
+		"/api/v1/pets/a%2Fb": "/api/v1/pets/a%2Fb",
+		"/api/v1/pets/a b":   "/api/v1/pets/a%20b",
+	} {
+		u, err := url.Parse(raw)
+		require.NoError(t, err)
+		assert.Equal(t, expected, EscapedPath(u), raw)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	ctx := t.Context()
+
+	s, err := Load(ctx, Config{Name: "petstore", PathPrefix: "/api"}, writeSpec(t, petstore))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		method      string
+		uri         string
+		contentType string
+		body        string
+		expectedErr string
+	}{
+		{name: "valid get", method: http.MethodGet, uri: "/api/pets?limit=10"},
+		{name: "valid path param", method: http.MethodGet, uri: "/api/pets/42"},
+		{name: "valid partial path param", method: http.MethodGet, uri: "/api/files/report.json"},
+		{name: "valid post", method: http.MethodPost, uri: "/api/pets", contentType: "application/json", body: `{"name":"rex","age":3}`},
+		{name: "unknown path", method: http.MethodGet, uri: "/api/owners", expectedErr: "path /owners is not defined"},
+		{name: "unknown method", method: http.MethodDelete, uri: "/api/pets", expectedErr: "method DELETE is not allowed on /pets"},
+		{name: "bad query param", method: http.MethodGet, uri: "/api/pets?limit=1000", expectedErr: `parameter "limit" in query: number must be at most 100`},
+		{name: "bad path param", method: http.MethodGet, uri: "/api/pets/rex", expectedErr: `parameter "petId" in path`},
+		{name: "bad partial path param", method: http.MethodGet, uri: "/api/files/..%2Fetc.json", expectedErr: `parameter "name" in path`},
+		{name: "bad content type", method: http.MethodPost, uri: "/api/pets", contentType: "text/plain", body: "rex", expectedErr: "request body"},
+		{name: "missing body", method: http.MethodPost, uri: "/api/pets", contentType: "application/json", expectedErr: "request body"},
+		{name: "extra property", method: http.MethodPost, uri: "/api/pets", contentType: "application/json", body: `{"name":"rex","admin":true}`, expectedErr: `property "admin" is unsupported`},
+		{name: "bad property type", method: http.MethodPost, uri: "/api/pets", contentType: "application/json", body: `{"name":"rex","age":"three"}`, expectedErr: "request body: age: value must be an integer"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequestWithContext(ctx, tc.method, "http://example.com"+tc.uri, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			if tc.contentType != "" {
+				req.Header.Set("Content-Type", tc.contentType)
+			}
+
+			err = s.Validate(ctx, req)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if err != nil {
+				assert.LessOrEqual(t, len(err.Error()), maxErrorLength)
+			}
+		})
+	}
+}
+
+// largeSpec returns a specification with n resources, each with a collection and an item path
+func largeSpec(n int) string {
+	var sb strings.Builder
+
+	sb.WriteString("openapi: 3.0.3\ninfo:\n  title: large\n  version: \"1.0\"\npaths:\n")
+
+	for i := range n {
+		fmt.Fprintf(&sb, `  /resource%d:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [name]
+              properties:
+                name:
+                  type: string
+                count:
+                  type: integer
+      responses:
+        "201":
+          description: created
+  /resource%d/{id}:
+    get:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+`, i, i)
+	}
+
+	return sb.String()
+}
+
+func benchmarkValidate(b *testing.B, n int) {
+	ctx := b.Context()
+
+	s, err := Load(ctx, Config{Name: "large"}, writeSpec(b, largeSpec(n)))
+	require.NoError(b, err)
+
+	last := n - 1
+	body := `{"name":"foo","count":12}`
+
+	b.Run("templated", func(b *testing.B) {
+		for b.Loop() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://example.com/resource%d/42", last), http.NoBody)
+			if err := s.Validate(ctx, req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("body", func(b *testing.B) {
+		for b.Loop() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("http://example.com/resource%d", last), strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+
+			if err := s.Validate(ctx, req); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("unknown", func(b *testing.B) {
+		for b.Loop() {
+			req, _ := http.NewRequestWithContext(ctx, http.MethodGet, "http://example.com/unknown/42", http.NoBody)
+			if err := s.Validate(ctx, req); err == nil {
+				b.Fatal("expected an error")
+			}
+		}
+	})
+}
+
+func BenchmarkValidate100(b *testing.B) {
+	benchmarkValidate(b, 100)
+}
+
+func BenchmarkValidate1000(b *testing.B) {
+	benchmarkValidate(b, 1000)
+}
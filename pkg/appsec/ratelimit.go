@@ -0,0 +1,192 @@
+package appsec
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/ratelimit"
+	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
+)
+
+// RateLimit blocks in band the requests sharing a key (client IP, header, JA4H...) once they exceed
+// a token bucket: up to capacity requests in a burst, then one request every leakspeed.
+type RateLimit struct {
+	Name        string `yaml:"name"`
+	Filter      string `yaml:"filter"` // the requests counted by the limit, all of them if empty
+	Key         string `yaml:"key"`    // the key of the bucket of the request, the client IP if empty. The requests without a key are not limited
+	Capacity    int    `yaml:"capacity"`
+	LeakSpeed   string `yaml:"leakspeed"`
+	Remediation string `yaml:"remediation"` // the default remediation if empty
+	MaxKeys     int    `yaml:"max_keys"`    // number of buckets kept in memory
+}
+
+type rateLimiter struct {
+	name     string
+	filter   *vm.Program
+	key      *vm.Program
+	store    *ratelimit.Store
+	variable string
+}
+
+// RateLimitRuleName is the name of the rule matching the requests over a rate limit,
+// it can be used with the *ByName helpers of the hooks
+func RateLimitRuleName(limit string) string {
+	return "ratelimit:" + limit
+}
+
+func (rl *RateLimit) Validate() error {
+	if rl.Name == "" {
+		return errors.New("missing name")
+	}
+
+	if strings.ContainsAny(rl.Name, "'\"% ") {
+		return fmt.Errorf("invalid name %q: quotes, '%%' and spaces are not allowed", rl.Name)
+	}
+
+	if rl.Capacity <= 0 {
+		return fmt.Errorf("%s: capacity must be positive", rl.Name)
+	}
+
+	if rl.LeakSpeed == "" {
+		return fmt.Errorf("%s: missing leakspeed", rl.Name)
+	}
+
+	return nil
+}
+
+func (rl *RateLimit) build() (*rateLimiter, error) {
+	if err := rl.Validate(); err != nil {
+		return nil, err
+	}
+
+	leakspeed, err := time.ParseDuration(rl.LeakSpeed)
+	if err != nil {
+		return nil, fmt.Errorf("%s: invalid leakspeed %s: %w", rl.Name, rl.LeakSpeed, err)
+	}
+
+	if leakspeed <= 0 {
+		return nil, fmt.Errorf("%s: leakspeed must be positive", rl.Name)
+	}
+
+	ret := &rateLimiter{
+		name:  rl.Name,
+		store: ratelimit.New(rl.Capacity, leakspeed, rl.MaxKeys),
+	}
+
+	opts := exprhelpers.GetExprOptions(GetRateLimitEnv(&ParsedRequest{}))
+
+	if rl.Filter != "" {
+		ret.filter, err = expr.Compile(rl.Filter, append(opts, expr.AsBool())...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to compile filter %s: %w", rl.Name, rl.Filter, err)
+		}
+	}
+
+	if rl.Key != "" {
+		ret.key, err = expr.Compile(rl.Key, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("%s: unable to compile key %s: %w", rl.Name, rl.Key, err)
+		}
+	}
+
+	return ret, nil
+}
+
+// loadRateLimits builds the rate limits of the configuration and returns the collection of the rules matching the requests over them
+func (w *AppsecRuntimeConfig) loadRateLimits(wc *AppsecConfig) (AppsecCollection, error) {
+	collection := AppsecCollection{collectionName: "ratelimit"}
+	names := make(map[string]bool, len(wc.RateLimits))
+
+	for _, rl := range wc.RateLimits {
+		limiter, err := rl.build()
+		if err != nil {
+			return collection, fmt.Errorf("invalid rate limit: %w", err)
+		}
+
+		if names[rl.Name] {
+			return collection, fmt.Errorf("duplicate rate limit %s", rl.Name)
+		}
+
+		names[rl.Name] = true
+
+		name := RateLimitRuleName(rl.Name)
+
+		var rule string
+
+		limiter.variable, rule, err = txRule(name)
+		if err != nil {
+			return collection, err
+		}
+
+		collection.NativeRules = append(collection.NativeRules, rule)
+
+		if rl.Remediation != "" {
+			if err := w.SetActionByName(name, rl.Remediation); err != nil {
+				return collection, err
+			}
+		}
+
+		w.RateLimiters = append(w.RateLimiters, limiter)
+	}
+
+	return collection, nil
+}
+
+// CheckRateLimits counts the request in the buckets of the matching rate limits,
+// and flags the exceeded ones in the transaction
+func (w *AppsecRuntimeConfig) CheckRateLimits(request *ParsedRequest) {
+	now := time.Now()
+	env := GetRateLimitEnv(request)
+
+	for _, limiter := range w.RateLimiters {
+		if limiter.filter != nil {
+			output, err := exprhelpers.Run(limiter.filter, env, w.Logger, w.Logger.Level >= log.DebugLevel)
+			if err != nil {
+				w.Logger.Errorf("unable to run filter of rate limit %s: %s", limiter.name, err)
+				continue
+			}
+
+			if matched, ok := output.(bool); !ok || !matched {
+				continue
+			}
+		}
+
+		key := request.ClientIP
+
+		if limiter.key != nil {
+			output, err := exprhelpers.Run(limiter.key, env, w.Logger, w.Logger.Level >= log.DebugLevel)
+			if err != nil {
+				w.Logger.Errorf("unable to run key of rate limit %s: %s", limiter.name, err)
+				continue
+			}
+
+			// a missing value must not put all the requests in the same bucket
+			switch v := output.(type) {
+			case nil:
+				key = ""
+			case string:
+				key = v
+			case []string:
+				key = strings.Join(v, ",")
+			default:
+				key = fmt.Sprint(v)
+			}
+		}
+
+		if key == "" {
+			w.Logger.Debugf("request %s has no key for rate limit %s, not counted", request.UUID, limiter.name)
+			continue
+		}
+
+		if !limiter.store.Allow(key, now) {
+			w.Logger.Debugf("request %s exceeds rate limit %s for key %s", request.UUID, limiter.name, key)
+			request.Tx.Variables().TX().Set(limiter.variable, []string{key})
+		}
+	}
+}
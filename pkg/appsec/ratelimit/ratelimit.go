@@ -0,0 +1,88 @@
+// Package ratelimit implements the token buckets used by the appsec component to limit
+// the rate of the requests in band, without waiting for a decision from the local API.
+package ratelimit
+
+import (
+	"sync"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/time/rate"
+)
+
+// DefaultMaxKeys is the number of buckets kept in memory when no limit is configured
+const DefaultMaxKeys = 100000
+
+// Store holds the token buckets of a rate limit by key, it is safe for concurrent use.
+// A bucket holds up to capacity requests, and one request is added back every leakspeed.
+type Store struct {
+	mu      sync.Mutex
+	limit   rate.Limit
+	burst   int
+	maxKeys int
+	buckets map[string]*rate.Limiter
+	// full buckets are forgotten, they are identical to new ones
+	gcInterval time.Duration
+	lastGC     time.Time
+}
+
+func New(capacity int, leakspeed time.Duration, maxKeys int) *Store {
+	if maxKeys <= 0 {
+		maxKeys = DefaultMaxKeys
+	}
+
+	return &Store{
+		limit:      rate.Every(leakspeed),
+		burst:      capacity,
+		maxKeys:    maxKeys,
+		buckets:    make(map[string]*rate.Limiter),
+		gcInterval: leakspeed * time.Duration(capacity),
+	}
+}
+
+// Allow takes a token from the bucket of the key, and returns false if it was empty
+func (s *Store) Allow(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if now.Sub(s.lastGC) > s.gcInterval {
+		s.gc(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		if len(s.buckets) >= s.maxKeys {
+			s.gc(now)
+		}
+
+		if len(s.buckets) >= s.maxKeys {
+			// still too many clients: forget one at random, it's better than forgetting the new one
+			for k := range s.buckets {
+				delete(s.buckets, k)
+				break
+			}
+		}
+
+		bucket = rate.NewLimiter(s.limit, s.burst)
+		s.buckets[key] = bucket
+	}
+
+	return bucket.AllowN(now, 1)
+}
+
+// Len returns the number of buckets in memory
+func (s *Store) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return len(s.buckets)
+}
+
+func (s *Store) gc(now time.Time) {
+	for key, bucket := range s.buckets {
+		if bucket.GetTokensCountAt(now) >= float64(s.burst) {
+			delete(s.buckets, key)
+		}
+	}
+
+	s.lastGC = now
+}
@@ -0,0 +1,64 @@
+package ratelimit
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAllow(t *testing.T) {
+	s := New(3, time.Second, 0)
+	now := time.Now()
+
+	for range 3 {
+		assert.True(t, s.Allow("1.2.3.4", now))
+	}
+
+	assert.False(t, s.Allow("1.2.3.4", now))
+	// the keys have their own bucket
+	assert.True(t, s.Allow("5.6.7.8", now))
+
+	// one token is added back every second
+	assert.False(t, s.Allow("1.2.3.4", now.Add(500*time.Millisecond)))
+	assert.True(t, s.Allow("1.2.3.4", now.Add(1500*time.Millisecond)))
+	assert.False(t, s.Allow("1.2.3.4", now.Add(1500*time.Millisecond)))
+}
+
+func TestGC(t *testing.T) {
+	s := New(2, time.Second, 0)
+	now := time.Now()
+
+	assert.True(t, s.Allow("1.2.3.4", now))
+	assert.True(t, s.Allow("5.6.7.8", now))
+	assert.Equal(t, 2, s.Len())
+
+	// the buckets are full again after capacity*leakspeed, and forgotten
+	assert.True(t, s.Allow("9.9.9.9", now.Add(3*time.Second)))
+	assert.Equal(t, 1, s.Len())
+}
+
+func TestMaxKeys(t *testing.T) {
+	s := New(1, time.Minute, 10)
+	now := time.Now()
+
+	for i := range 20 {
+		assert.True(t, s.Allow(fmt.Sprintf("10.0.0.%d", i), now))
+	}
+
+	assert.Equal(t, 10, s.Len())
+	// the last client is still tracked
+	assert.False(t, s.Allow("10.0.0.19", now))
+}
+
+func BenchmarkAllow(b *testing.B) {
+	s := New(10, time.Second, 0)
+	now := time.Now()
+	i := 0
+
+	for b.Loop() {
+		s.Allow(fmt.Sprintf("10.0.%d.%d", (i/256)%256, i%256), now)
+		i++
+	}
+}
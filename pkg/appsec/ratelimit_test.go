@@ -0,0 +1,103 @@
+package appsec
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+func TestBuildRateLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		limits      []RateLimit
+		expectedErr string
+	}{
+		{
+			name:   "valid",
+			limits: []RateLimit{{Name: "per-ip", Capacity: 10, LeakSpeed: "1s", Remediation: CaptchaRemediation}},
+		},
+		{
+			name:        "no name",
+			limits:      []RateLimit{{Capacity: 10, LeakSpeed: "1s"}},
+			expectedErr: "invalid rate limit: missing name",
+		},
+		{
+			name:        "bad name",
+			limits:      []RateLimit{{Name: "per 'ip'", Capacity: 10, LeakSpeed: "1s"}},
+			expectedErr: `invalid name "per 'ip'"`,
+		},
+		{
+			name:        "no capacity",
+			limits:      []RateLimit{{Name: "per-ip", LeakSpeed: "1s"}},
+			expectedErr: "per-ip: capacity must be positive",
+		},
+		{
+			name:        "bad leakspeed",
+			limits:      []RateLimit{{Name: "per-ip", Capacity: 10, LeakSpeed: "fast"}},
+			expectedErr: "per-ip: invalid leakspeed fast",
+		},
+		{
+			name:        "bad filter",
+			limits:      []RateLimit{{Name: "per-ip", Capacity: 10, LeakSpeed: "1s", Filter: "req.Method"}},
+			expectedErr: "per-ip: unable to compile filter",
+		},
+		{
+			name:        "bad key",
+			limits:      []RateLimit{{Name: "per-ip", Capacity: 10, LeakSpeed: "1s", Key: "foo("}},
+			expectedErr: "per-ip: unable to compile key",
+		},
+		{
+			name: "duplicate",
+			limits: []RateLimit{
+				{Name: "per-ip", Capacity: 10, LeakSpeed: "1s"},
+				{Name: "per-ip", Capacity: 5, LeakSpeed: "1s"},
+			},
+			expectedErr: "duplicate rate limit per-ip",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := AppsecConfig{Logger: log.NewEntry(log.StandardLogger()), RateLimits: tc.limits}
+
+			runtime, err := cfg.Build()
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if tc.expectedErr != "" {
+				return
+			}
+
+			require.Len(t, runtime.RateLimiters, 1)
+			require.Len(t, runtime.InBandRules, 1)
+			assert.Contains(t, runtime.InBandRules[0].NativeRules[0], "msg:'ratelimit:per-ip'")
+			assert.Equal(t, CaptchaRemediation, runtime.RemediationByTag["crowdsec-ratelimit:per-ip"])
+		})
+	}
+}
+
+func TestTxRuleCollision(t *testing.T) {
+	variable, _, err := txRule("ratelimit:per-ip")
+	require.NoError(t, err)
+
+	id, err := strconv.Atoi(strings.TrimPrefix(variable, "crowdsec_"))
+	require.NoError(t, err)
+
+	// the same name gets the same rule
+	again, _, err := txRule("ratelimit:per-ip")
+	require.NoError(t, err)
+	assert.Equal(t, variable, again)
+
+	previous := AppsecRulesDetails[id]
+	AppsecRulesDetails[id] = RulesDetails{Name: "other-rule"}
+
+	t.Cleanup(func() { AppsecRulesDetails[id] = previous })
+
+	_, _, err = txRule("ratelimit:per-ip")
+	cstest.RequireErrorContains(t, err, "is already used by other-rule")
+}
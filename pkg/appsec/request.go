@@ -10,7 +10,9 @@ import (
 	"net/url"
 	"os"
 	"regexp"
+	"strconv"
 
+	"github.com/crowdsecurity/crowdsec/pkg/appsec/bot"
 	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
 	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
@@ -24,6 +26,13 @@ const (
 	APIKeyHeaderName      = "X-Crowdsec-Appsec-Api-Key"
 	UserAgentHeaderName   = "X-Crowdsec-Appsec-User-Agent"
 	HTTPVersionHeaderName = "X-Crowdsec-Appsec-Http-Version"
+	// optional, set by the remediation components terminating TLS or seeing the raw headers, for the bot detection
+	JA4HeaderName         = "X-Crowdsec-Appsec-Ja4"
+	JA3HeaderName         = "X-Crowdsec-Appsec-Ja3"
+	HeaderOrderHeaderName = "X-Crowdsec-Appsec-Header-Order" // comma separated header names
+	// set by the remediation component when it forwards the response of the upstream server:
+	// the headers and body of the appsec request are then the response headers and body
+	ResponseCodeHeaderName = "X-Crowdsec-Appsec-Response-Code"
 )
 
 type ParsedRequest struct {
@@ -47,6 +56,15 @@ type ParsedRequest struct {
 	AppsecEngine         string                  `json:"appsec_engine,omitempty"`
 	RemoteAddrNormalized string                  `json:"normalized_remote_addr,omitempty"`
 	HTTPRequest          *http.Request           `json:"-"`
+	IsResponse           bool                    `json:"is_response,omitempty"`
+	ResponseCode         int                     `json:"response_code,omitempty"`
+	ResponseHeaders      http.Header             `json:"response_headers,omitempty"`
+	ResponseBody         []byte                  `json:"response_body,omitempty"`
+	JA4                  string                  `json:"ja4,omitempty"`
+	JA3                  string                  `json:"ja3,omitempty"`
+	HeaderOrder          []string                `json:"header_order,omitempty"`
+	Bot                  bot.Result              `json:"-"`
+	Identity             map[string]string       `json:"-"` // set by the identity extractors of the appsec-config
 }
 
 type ReqDumpFilter struct {
@@ -315,6 +333,18 @@ func NewParsedRequestFromRequest(r *http.Request, logger *log.Entry) (ParsedRequ
 
 	userAgent := r.Header.Get(UserAgentHeaderName) //This one is optional
 
+	ja4 := r.Header.Get(JA4HeaderName)
+	ja3 := r.Header.Get(JA3HeaderName)
+	headerOrder := bot.ParseHeaderOrder(r.Header.Get(HeaderOrderHeaderName))
+
+	responseCode := 0
+	if code := r.Header.Get(ResponseCodeHeaderName); code != "" {
+		responseCode, err = strconv.Atoi(code)
+		if err != nil || responseCode < 100 || responseCode > 599 {
+			return ParsedRequest{}, fmt.Errorf("invalid '%s' header: %s", ResponseCodeHeaderName, code)
+		}
+	}
+
 	httpVersion := r.Header.Get(HTTPVersionHeaderName)
 	if httpVersion == "" {
 		logger.Debugf("missing '%s' header", HTTPVersionHeaderName)
@@ -345,6 +375,22 @@ func NewParsedRequestFromRequest(r *http.Request, logger *log.Entry) (ParsedRequ
 	delete(r.Header, UserAgentHeaderName)
 	delete(r.Header, APIKeyHeaderName)
 	delete(r.Header, HTTPVersionHeaderName)
+	delete(r.Header, ResponseCodeHeaderName)
+	delete(r.Header, JA4HeaderName)
+	delete(r.Header, JA3HeaderName)
+	delete(r.Header, HeaderOrderHeaderName)
+
+	// when inspecting a response, the original request headers and body are not forwarded
+	var responseHeaders http.Header
+
+	var responseBody []byte
+
+	if responseCode != 0 {
+		responseHeaders = r.Header
+		responseBody = body
+		r.Header = make(http.Header)
+		body = nil
+	}
 
 	originalHTTPRequest := r.Clone(r.Context())
 	originalHTTPRequest.Body = io.NopCloser(bytes.NewBuffer(body))
@@ -402,5 +448,12 @@ func NewParsedRequestFromRequest(r *http.Request, logger *log.Entry) (ParsedRequ
 		ResponseChannel:      make(chan AppsecTempResponse),
 		RemoteAddrNormalized: remoteAddrNormalized,
 		HTTPRequest:          originalHTTPRequest,
+		IsResponse:           responseCode != 0,
+		ResponseCode:         responseCode,
+		ResponseHeaders:      responseHeaders,
+		ResponseBody:         responseBody,
+		JA4:                  ja4,
+		JA3:                  ja3,
+		HeaderOrder:          headerOrder,
 	}, nil
 }
@@ -1,6 +1,17 @@
 package appsec
 
-import "testing"
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
 
 func TestBodyDumper(t *testing.T) {
 	tests := []struct {
@@ -176,3 +187,56 @@ func TestBodyDumper(t *testing.T) {
 		})
 	}
 }
+
+func TestNewParsedRequestFromResponse(t *testing.T) {
+	newRequest := func(code string) *http.Request {
+		r := httptest.NewRequest(http.MethodPost, "/", strings.NewReader("<h1>Index of /backup</h1>"))
+		r.Header.Set(IPHeaderName, "1.2.3.4")
+		r.Header.Set(URIHeaderName, "/backup/?C=M")
+		r.Header.Set(VerbHeaderName, http.MethodGet)
+		r.Header.Set(UserAgentHeaderName, "curl/8.0")
+		r.Header.Set(ResponseCodeHeaderName, code)
+		r.Header.Set("Content-Type", "text/html")
+
+		return r
+	}
+
+	logger := log.WithField("test", "response")
+
+	req, err := NewParsedRequestFromRequest(newRequest("200"), logger)
+	require.NoError(t, err)
+
+	assert.True(t, req.IsResponse)
+	assert.Equal(t, http.StatusOK, req.ResponseCode)
+	assert.Equal(t, "/backup/?C=M", req.URI)
+	assert.Equal(t, http.MethodGet, req.Method)
+	assert.Equal(t, "M", req.Args.Get("C"))
+	assert.Equal(t, "<h1>Index of /backup</h1>", string(req.ResponseBody))
+	assert.Equal(t, "text/html", req.ResponseHeaders.Get("Content-Type"))
+	assert.Empty(t, req.ResponseHeaders.Get(ResponseCodeHeaderName))
+	assert.Empty(t, req.Body)
+	assert.Equal(t, http.Header{"User-Agent": {"curl/8.0"}}, req.Headers)
+
+	_, err = NewParsedRequestFromRequest(newRequest("OK"), logger)
+	cstest.RequireErrorContains(t, err, "invalid 'X-Crowdsec-Appsec-Response-Code' header: OK")
+}
+
+func TestNewParsedRequestWithFingerprints(t *testing.T) {
+	r := httptest.NewRequest(http.MethodPost, "/", http.NoBody)
+	r.Header.Set(IPHeaderName, "1.2.3.4")
+	r.Header.Set(URIHeaderName, "/")
+	r.Header.Set(VerbHeaderName, http.MethodGet)
+	r.Header.Set(JA4HeaderName, "t13d1516h2_8daaf6152771_02713d6af862")
+	r.Header.Set(JA3HeaderName, "773906b0efdefa24a7f2b8eb6985bf37")
+	r.Header.Set(HeaderOrderHeaderName, "Host, User-Agent,Accept,,")
+	r.Header.Set("Accept", "*/*")
+
+	req, err := NewParsedRequestFromRequest(r, log.WithField("test", "fingerprints"))
+	require.NoError(t, err)
+
+	assert.Equal(t, "t13d1516h2_8daaf6152771_02713d6af862", req.JA4)
+	assert.Equal(t, "773906b0efdefa24a7f2b8eb6985bf37", req.JA3)
+	assert.Equal(t, []string{"host", "user-agent", "accept"}, req.HeaderOrder)
+	// the forwarded fingerprints are not inspected as request headers
+	assert.Equal(t, http.Header{"Accept": {"*/*"}}, req.Headers)
+}
@@ -72,6 +72,22 @@ func (t *ExtendedTransaction) WriteRequestBody(body []byte) (*types.Interruption
 	return t.Tx.WriteRequestBody(body)
 }
 
+func (t *ExtendedTransaction) AddResponseHeader(name string, value string) {
+	t.Tx.AddResponseHeader(name, value)
+}
+
+func (t *ExtendedTransaction) ProcessResponseHeaders(code int, proto string) *types.Interruption {
+	return t.Tx.ProcessResponseHeaders(code, proto)
+}
+
+func (t *ExtendedTransaction) WriteResponseBody(body []byte) (*types.Interruption, int, error) {
+	return t.Tx.WriteResponseBody(body)
+}
+
+func (t *ExtendedTransaction) ProcessResponseBody() (*types.Interruption, error) {
+	return t.Tx.ProcessResponseBody()
+}
+
 func (t *ExtendedTransaction) Interruption() *types.Interruption {
 	return t.Tx.Interruption()
 }
@@ -23,6 +23,9 @@ func GetPreEvalEnv(w *AppsecRuntimeConfig, request *ParsedRequest) map[string]in
 		"IsInBand":                request.IsInBand,
 		"IsOutBand":               request.IsOutBand,
 		"req":                     request.HTTPRequest,
+		"bot_score":               request.Bot.Score,
+		"bot_class":               request.Bot.Class,
+		"identity":                request.Identity,
 		"RemoveInBandRuleByID":    w.RemoveInbandRuleByID,
 		"RemoveInBandRuleByName":  w.RemoveInbandRuleByName,
 		"RemoveInBandRuleByTag":   w.RemoveInbandRuleByTag,
@@ -35,12 +38,24 @@ func GetPreEvalEnv(w *AppsecRuntimeConfig, request *ParsedRequest) map[string]in
 	}
 }
 
+func GetRateLimitEnv(request *ParsedRequest) map[string]interface{} {
+	return map[string]interface{}{
+		"req":       request.HTTPRequest,
+		"bot_score": request.Bot.Score,
+		"bot_class": request.Bot.Class,
+		"identity":  request.Identity,
+	}
+}
+
 func GetPostEvalEnv(w *AppsecRuntimeConfig, request *ParsedRequest) map[string]interface{} {
 	return map[string]interface{}{
 		"IsInBand":    request.IsInBand,
 		"IsOutBand":   request.IsOutBand,
 		"DumpRequest": request.DumpRequest,
 		"req":         request.HTTPRequest,
+		"bot_score":   request.Bot.Score,
+		"bot_class":   request.Bot.Class,
+		"identity":    request.Identity,
 	}
 }
 
@@ -48,6 +63,9 @@ func GetOnMatchEnv(w *AppsecRuntimeConfig, request *ParsedRequest, evt types.Eve
 	return map[string]interface{}{
 		"evt":            evt,
 		"req":            request.HTTPRequest,
+		"bot_score":      request.Bot.Score,
+		"bot_class":      request.Bot.Class,
+		"identity":       request.Identity,
 		"IsInBand":       request.IsInBand,
 		"IsOutBand":      request.IsOutBand,
 		"SetRemediation": w.SetAction,
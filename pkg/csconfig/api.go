@@ -237,6 +237,10 @@ type LocalApiServerCfg struct {
 	CapiWhitelistsPath            string                   `yaml:"capi_whitelists_path,omitempty"`
 	CapiWhitelists                *CapiWhitelist           `yaml:"-"`
 	AutoRegister                  *LocalAPIAutoRegisterCfg `yaml:"auto_registration,omitempty"`
+	CTIEnrichment                 *CTIEnrichmentCfg        `yaml:"cti_enrichment,omitempty"`
+	Feeds                         []*FeedCfg               `yaml:"feeds,omitempty"`
+	Federation                    *FederationCfg           `yaml:"federation,omitempty"`
+	DurableNotifications          bool                     `yaml:"durable_notifications,omitempty"` // store the notifications in the database until they are delivered
 }
 
 func (c *LocalApiServerCfg) GetTrustedIPs() ([]net.IPNet, error) {
@@ -273,6 +277,44 @@ type CapiWhitelist struct {
 	Cidrs []*net.IPNet `yaml:"cidrs,omitempty"`
 }
 
+// CTIEnrichmentCfg enables the lookup of the alert sources in the CTI, in the background. The cached
+// reputations are available to the profiles and notifications. The API key is the one of the api.cti section.
+// Unless the lookups are synchronous, the first alert of an IP is not enriched: its lookup happens after
+// the profiles, and the filters on the reputation don't match it.
+type CTIEnrichmentCfg struct {
+	Enabled     bool          `yaml:"enabled"`
+	CacheTTL    time.Duration `yaml:"cache_ttl,omitempty"`   // how long a lookup is kept in the database
+	Timeout     time.Duration `yaml:"timeout,omitempty"`     // of each lookup
+	Synchronous bool          `yaml:"synchronous,omitempty"` // look up the missing IPs before the profiles, the machines wait up to timeout
+	Key         string        `yaml:"-"`
+}
+
+func (c *CTIEnrichmentCfg) Load(cti *CTICfg) error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if cti == nil || cti.Key == nil || *cti.Key == "" {
+		return errors.New("api.cti.key is required to enable cti_enrichment")
+	}
+
+	c.Key = *cti.Key
+
+	if c.CacheTTL == 0 {
+		c.CacheTTL = 24 * time.Hour
+	}
+
+	if c.Timeout == 0 {
+		c.Timeout = 2 * time.Second
+	}
+
+	if c.CacheTTL < 0 || c.Timeout < 0 {
+		return errors.New("cti_enrichment cache_ttl and timeout can't be negative")
+	}
+
+	return nil
+}
+
 type LocalAPIAutoRegisterCfg struct {
 	Enable              *bool        `yaml:"enabled"`
 	Token               string       `yaml:"token"`
@@ -375,6 +417,14 @@ func (c *Config) LoadAPIServer(inCli bool) error {
 		return err
 	}
 
+	if err := c.API.Server.LoadFeeds(); err != nil {
+		return fmt.Errorf("while loading feeds: %w", err)
+	}
+
+	if err := c.API.Server.LoadFederation(); err != nil {
+		return fmt.Errorf("while loading federation: %w", err)
+	}
+
 	if c.API.Server.CapiWhitelistsPath != "" && !inCli {
 		log.Infof("loaded capi whitelist from %s: %d IPs, %d CIDRs", c.API.Server.CapiWhitelistsPath, len(c.API.Server.CapiWhitelists.Ips), len(c.API.Server.CapiWhitelists.Cidrs))
 	}
@@ -421,6 +471,12 @@ func (c *Config) LoadAPIServer(inCli bool) error {
 		}
 	}
 
+	if c.API.Server.CTIEnrichment != nil {
+		if err := c.API.Server.CTIEnrichment.Load(c.API.CTI); err != nil {
+			return fmt.Errorf("loading CTI enrichment configuration: %w", err)
+		}
+	}
+
 	return nil
 }
 
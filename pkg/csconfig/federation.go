@@ -0,0 +1,114 @@
+package csconfig
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// FederationCfg shares decisions between local APIs. A LAPI can publish a selection of its
+// decisions to its peers, and pull the decisions published by other LAPIs.
+// Both directions require TLS client authentication.
+type FederationCfg struct {
+	Publish *FederationPublishCfg `yaml:"publish,omitempty"`
+	Peers   []*FederationPeerCfg  `yaml:"peers,omitempty"`
+}
+
+// FederationPublishCfg selects the decisions served to the peers. Empty lists match everything.
+// The decisions received from other peers are never published, to avoid loops.
+type FederationPublishCfg struct {
+	Enabled             bool     `yaml:"enabled"`
+	Scopes              []string `yaml:"scopes,omitempty"`
+	Origins             []string `yaml:"origins,omitempty"`
+	ScenariosContaining []string `yaml:"scenarios_containing,omitempty"`
+}
+
+// FederationPeerCfg is a LAPI to pull decisions from
+type FederationPeerCfg struct {
+	Name               string        `yaml:"name"`
+	URL                string        `yaml:"url"`
+	Interval           time.Duration `yaml:"interval,omitempty"`
+	Timeout            time.Duration `yaml:"timeout,omitempty"`
+	CACertPath         string        `yaml:"ca_cert_path,omitempty"`
+	CertPath           string        `yaml:"cert_path"`
+	KeyPath            string        `yaml:"key_path"`
+	InsecureSkipVerify bool          `yaml:"insecure_skip_verify,omitempty"`
+}
+
+func (p *FederationPeerCfg) Load() error {
+	if p.Name == "" {
+		return errors.New("missing name")
+	}
+
+	if strings.ContainsAny(p.Name, ": ") {
+		return fmt.Errorf("peer %s: name can't contain spaces or colons", p.Name)
+	}
+
+	u, err := url.Parse(p.URL)
+	if err != nil {
+		return fmt.Errorf("peer %s: invalid url: %w", p.Name, err)
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("peer %s: url must use https", p.Name)
+	}
+
+	if p.CertPath == "" || p.KeyPath == "" {
+		return fmt.Errorf("peer %s: cert_path and key_path are required", p.Name)
+	}
+
+	if p.Interval == 0 {
+		p.Interval = time.Minute
+	}
+
+	if p.Timeout == 0 {
+		p.Timeout = 30 * time.Second
+	}
+
+	if p.Interval < 10*time.Second {
+		return fmt.Errorf("peer %s: interval must be at least 10s", p.Name)
+	}
+
+	return nil
+}
+
+func (c *LocalApiServerCfg) LoadFederation() error {
+	if c.Federation == nil {
+		return nil
+	}
+
+	if publish := c.Federation.Publish; publish != nil && publish.Enabled {
+		if c.TLS == nil || len(c.TLS.AllowedPeersOU) == 0 {
+			return errors.New("publishing decisions requires tls.peers_allowed_ou")
+		}
+
+		if slices.Contains(publish.Origins, types.FederationOrigin) {
+			return errors.New("the decisions of the peers can't be published")
+		}
+	}
+
+	names := make(map[string]bool, len(c.Federation.Peers))
+
+	for idx, peer := range c.Federation.Peers {
+		if peer == nil {
+			return fmt.Errorf("peer #%d is empty", idx)
+		}
+
+		if err := peer.Load(); err != nil {
+			return err
+		}
+
+		if names[peer.Name] {
+			return fmt.Errorf("duplicate peer name %s", peer.Name)
+		}
+
+		names[peer.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package csconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+func TestLoadFederation(t *testing.T) {
+	peer := func(name string, url string) *FederationPeerCfg {
+		return &FederationPeerCfg{Name: name, URL: url, CertPath: "/tmp/peer.pem", KeyPath: "/tmp/peer-key.pem"}
+	}
+
+	tests := []struct {
+		name        string
+		cfg         LocalApiServerCfg
+		expectedErr string
+	}{
+		{
+			name: "peers",
+			cfg: LocalApiServerCfg{Federation: &FederationCfg{
+				Peers: []*FederationPeerCfg{peer("dc2", "https://dc2:8080"), peer("dc3", "https://dc3:8080")},
+			}},
+		},
+		{
+			name: "publish",
+			cfg: LocalApiServerCfg{
+				TLS:        &TLSCfg{AllowedPeersOU: []string{"peer"}},
+				Federation: &FederationCfg{Publish: &FederationPublishCfg{Enabled: true}},
+			},
+		},
+		{
+			name:        "publish without peer OU",
+			cfg:         LocalApiServerCfg{Federation: &FederationCfg{Publish: &FederationPublishCfg{Enabled: true}}},
+			expectedErr: "publishing decisions requires tls.peers_allowed_ou",
+		},
+		{
+			name: "publish federation origin",
+			cfg: LocalApiServerCfg{
+				TLS:        &TLSCfg{AllowedPeersOU: []string{"peer"}},
+				Federation: &FederationCfg{Publish: &FederationPublishCfg{Enabled: true, Origins: []string{"federation"}}},
+			},
+			expectedErr: "the decisions of the peers can't be published",
+		},
+		{
+			name:        "plain http",
+			cfg:         LocalApiServerCfg{Federation: &FederationCfg{Peers: []*FederationPeerCfg{peer("dc2", "http://dc2:8080")}}},
+			expectedErr: "peer dc2: url must use https",
+		},
+		{
+			name:        "bad name",
+			cfg:         LocalApiServerCfg{Federation: &FederationCfg{Peers: []*FederationPeerCfg{peer("dc:2", "https://dc2:8080")}}},
+			expectedErr: "peer dc:2: name can't contain spaces or colons",
+		},
+		{
+			name: "duplicate",
+			cfg: LocalApiServerCfg{Federation: &FederationCfg{
+				Peers: []*FederationPeerCfg{peer("dc2", "https://dc2:8080"), peer("dc2", "https://dc3:8080")},
+			}},
+			expectedErr: "duplicate peer name dc2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.cfg.LoadFederation()
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if tc.expectedErr != "" {
+				return
+			}
+
+			for _, p := range tc.cfg.Federation.Peers {
+				assert.Equal(t, time.Minute, p.Interval)
+				assert.Equal(t, 30*time.Second, p.Timeout)
+			}
+		})
+	}
+}
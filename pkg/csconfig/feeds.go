@@ -0,0 +1,130 @@
+package csconfig
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	FeedFormatPlain = "plain"
+	FeedFormatCSV   = "csv"
+	FeedFormatMISP  = "misp"
+	FeedFormatSTIX  = "stix"
+)
+
+var FeedFormats = []string{FeedFormatPlain, FeedFormatCSV, FeedFormatMISP, FeedFormatSTIX}
+
+// FeedCfg is an external threat-intel feed, periodically converted to decisions by the local API
+type FeedCfg struct {
+	Name      string            `yaml:"name"`
+	URL       string            `yaml:"url,omitempty"`
+	Path      string            `yaml:"path,omitempty"`
+	Format    string            `yaml:"format"`
+	Scope     string            `yaml:"scope,omitempty"` // default: Ip or Range, depending on the value
+	Type      string            `yaml:"type,omitempty"`
+	Duration  time.Duration     `yaml:"duration,omitempty"`
+	Interval  time.Duration     `yaml:"interval,omitempty"`
+	Timeout   time.Duration     `yaml:"timeout,omitempty"`
+	Headers   map[string]string `yaml:"headers,omitempty"`    // for authentication or TAXII content negotiation
+	CSVColumn string            `yaml:"csv_column,omitempty"` // name of the column with the values, default: the first one
+}
+
+// Source returns the location of the feed, for display
+func (f *FeedCfg) Source() string {
+	if f.URL != "" {
+		return f.URL
+	}
+
+	return f.Path
+}
+
+func (f *FeedCfg) Load() error {
+	if f.Name == "" {
+		return errors.New("missing name")
+	}
+
+	if strings.ContainsAny(f.Name, ": ") {
+		return fmt.Errorf("feed %s: name can't contain spaces or colons", f.Name)
+	}
+
+	switch {
+	case f.URL == "" && f.Path == "":
+		return fmt.Errorf("feed %s: one of url or path is required", f.Name)
+	case f.URL != "" && f.Path != "":
+		return fmt.Errorf("feed %s: url and path are mutually exclusive", f.Name)
+	case f.URL != "":
+		u, err := url.Parse(f.URL)
+		if err != nil {
+			return fmt.Errorf("feed %s: invalid url: %w", f.Name, err)
+		}
+
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("feed %s: unsupported url scheme '%s'", f.Name, u.Scheme)
+		}
+	}
+
+	if !slices.Contains(FeedFormats, f.Format) {
+		return fmt.Errorf("feed %s: format must be one of %s", f.Name, strings.Join(FeedFormats, ", "))
+	}
+
+	if f.CSVColumn != "" && f.Format != FeedFormatCSV {
+		return fmt.Errorf("feed %s: csv_column is only valid with the csv format", f.Name)
+	}
+
+	if f.Scope != "" && (f.Format == FeedFormatMISP || f.Format == FeedFormatSTIX) {
+		return fmt.Errorf("feed %s: scope can't be set with the %s format, only IP indicators are imported", f.Name, f.Format)
+	}
+
+	if f.Type == "" {
+		f.Type = "ban"
+	}
+
+	if f.Interval == 0 {
+		f.Interval = time.Hour
+	}
+
+	if f.Duration == 0 {
+		f.Duration = 24 * time.Hour
+	}
+
+	if f.Timeout == 0 {
+		f.Timeout = 30 * time.Second
+	}
+
+	if f.Interval < time.Minute {
+		return fmt.Errorf("feed %s: interval must be at least 1m", f.Name)
+	}
+
+	// the decisions are refreshed at each pull, they must not expire in between
+	if f.Duration <= f.Interval {
+		return fmt.Errorf("feed %s: duration (%s) must be longer than interval (%s)", f.Name, f.Duration, f.Interval)
+	}
+
+	return nil
+}
+
+func (c *LocalApiServerCfg) LoadFeeds() error {
+	names := make(map[string]bool, len(c.Feeds))
+
+	for idx, feed := range c.Feeds {
+		if feed == nil {
+			return fmt.Errorf("feed #%d is empty", idx)
+		}
+
+		if err := feed.Load(); err != nil {
+			return err
+		}
+
+		if names[feed.Name] {
+			return fmt.Errorf("duplicate feed name %s", feed.Name)
+		}
+
+		names[feed.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package csconfig
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+)
+
+func TestFeedLoad(t *testing.T) {
+	tests := []struct {
+		name        string
+		feed        FeedCfg
+		expectedErr string
+	}{
+		{
+			name: "defaults",
+			feed: FeedCfg{Name: "f", Path: "/tmp/f.txt", Format: "plain"},
+		},
+		{
+			name:        "no source",
+			feed:        FeedCfg{Name: "f", Format: "plain"},
+			expectedErr: "feed f: one of url or path is required",
+		},
+		{
+			name:        "bad format",
+			feed:        FeedCfg{Name: "f", Path: "/tmp/f.txt", Format: "xml"},
+			expectedErr: "feed f: format must be one of plain, csv, misp, stix",
+		},
+		{
+			name:        "bad scheme",
+			feed:        FeedCfg{Name: "f", URL: "ftp://example.com/f.txt", Format: "plain"},
+			expectedErr: "feed f: unsupported url scheme 'ftp'",
+		},
+		{
+			name:        "short duration",
+			feed:        FeedCfg{Name: "f", Path: "/tmp/f.txt", Format: "plain", Interval: time.Hour, Duration: time.Hour},
+			expectedErr: "feed f: duration (1h0m0s) must be longer than interval (1h0m0s)",
+		},
+		{
+			name:        "scope with stix",
+			feed:        FeedCfg{Name: "f", Path: "/tmp/f.json", Format: "stix", Scope: "Ip"},
+			expectedErr: "feed f: scope can't be set with the stix format",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.feed.Load()
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if tc.expectedErr != "" {
+				return
+			}
+
+			assert.Equal(t, "ban", tc.feed.Type)
+			assert.Equal(t, time.Hour, tc.feed.Interval)
+			assert.Equal(t, 24*time.Hour, tc.feed.Duration)
+		})
+	}
+}
@@ -30,6 +30,33 @@ type ProfileCfg struct {
 	OnFailure     string            `yaml:"on_failure,omitempty"` // continue or break
 	OnError       string            `yaml:"on_error,omitempty"`   // continue, break, error, report, apply, ignore
 	Notifications []string          `yaml:"notifications,omitempty"`
+	Aggregation   *AggregationCfg   `yaml:"aggregation,omitempty"`
+	Escalation    *EscalationCfg    `yaml:"escalation,omitempty"`
+}
+
+// EscalationCfg computes the duration of the decisions from the number of previous decisions on the same value:
+// the first offense gets the first duration, the second one the second duration, and so on
+type EscalationCfg struct {
+	Durations []string `yaml:"durations"`
+	Lookback  string   `yaml:"lookback,omitempty"` // only count the decisions created in this period, default: 720h
+	Decay     string   `yaml:"decay,omitempty"`    // go down one step for each period elapsed since the end of the last decision
+}
+
+// AggregationCfg collapses the Ip decisions of a profile into a Range decision
+// when enough addresses of the same network are banned within a time window
+type AggregationCfg struct {
+	Window           string                 `yaml:"window,omitempty"`   // default: 1h
+	Duration         *string                `yaml:"duration,omitempty"` // default: duration of the decision that triggered the aggregation
+	ExpireIndividual bool                   `yaml:"expire_individual,omitempty"`
+	IPv4             []AggregationThreshold `yaml:"ipv4,omitempty"`
+	IPv6             []AggregationThreshold `yaml:"ipv6,omitempty"`
+}
+
+// AggregationThreshold is the number of distinct addresses in a network of the given prefix length
+// required to create a Range decision
+type AggregationThreshold struct {
+	Prefix    int `yaml:"prefix"`
+	Threshold int `yaml:"threshold"`
 }
 
 func (c *LocalApiServerCfg) LoadProfiles() error {
@@ -37,11 +64,27 @@ func (c *LocalApiServerCfg) LoadProfiles() error {
 		return errors.New("empty profiles path")
 	}
 
-	patcher := yamlpatch.NewPatcher(c.ProfilesPath, ".local")
+	profiles, err := LoadProfilesFile(c.ProfilesPath)
+	if err != nil {
+		return err
+	}
+
+	c.Profiles = append(c.Profiles, profiles...)
+
+	if len(c.Profiles) == 0 {
+		return errors.New("zero profiles loaded for LAPI")
+	}
+
+	return nil
+}
+
+// LoadProfilesFile reads the profiles from a file, with its .local patch if any
+func LoadProfilesFile(path string) ([]*ProfileCfg, error) {
+	patcher := yamlpatch.NewPatcher(path, ".local")
 
 	fcontent, err := patcher.PrependedPatchContent()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	reader := bytes.NewReader(fcontent)
@@ -49,6 +92,8 @@ func (c *LocalApiServerCfg) LoadProfiles() error {
 	dec := yaml.NewDecoder(reader)
 	dec.KnownFields(true)
 
+	profiles := []*ProfileCfg{}
+
 	for {
 		t := ProfileCfg{}
 
@@ -58,15 +103,11 @@ func (c *LocalApiServerCfg) LoadProfiles() error {
 				break
 			}
 
-			return fmt.Errorf("while decoding %s: %w", c.ProfilesPath, err)
+			return nil, fmt.Errorf("while decoding %s: %w", path, err)
 		}
 
-		c.Profiles = append(c.Profiles, &t)
-	}
-
-	if len(c.Profiles) == 0 {
-		return errors.New("zero profiles loaded for LAPI")
+		profiles = append(profiles, &t)
 	}
 
-	return nil
+	return profiles, nil
 }
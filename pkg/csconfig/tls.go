@@ -18,6 +18,7 @@ type TLSCfg struct {
 	CACertPath         string         `yaml:"ca_cert_path"`
 	AllowedAgentsOU    []string       `yaml:"agents_allowed_ou"`
 	AllowedBouncersOU  []string       `yaml:"bouncers_allowed_ou"`
+	AllowedPeersOU     []string       `yaml:"peers_allowed_ou,omitempty"`
 	CRLPath            string         `yaml:"crl_path"`
 	CacheExpiration    *time.Duration `yaml:"cache_expiration,omitempty"`
 }
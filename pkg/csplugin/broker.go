@@ -13,13 +13,14 @@ import (
 	"time"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/expr-lang/expr/vm"
 	"github.com/google/uuid"
 	plugin "github.com/hashicorp/go-plugin"
+	"github.com/prometheus/client_golang/prometheus"
 	log "github.com/sirupsen/logrus"
 	"gopkg.in/tomb.v2"
 	"gopkg.in/yaml.v2"
 
-	"github.com/crowdsecurity/go-cs-lib/csstring"
 	"github.com/crowdsecurity/go-cs-lib/ptr"
 	"github.com/crowdsecurity/go-cs-lib/slicetools"
 
@@ -51,6 +52,10 @@ type PluginBroker struct {
 	pluginKillMethods               []func()
 	pluginProcConfig                *csconfig.PluginCfg
 	pluginsTypesToDispatch          map[string]struct{}
+	queue                           NotificationQueue
+	queueSignal                     chan struct{}
+	dedupByPluginName               map[string]*deduplicator
+	filtersByPluginName             map[string][]*vm.Program
 }
 
 // holder to determine where to dispatch config and how to format messages
@@ -64,6 +69,13 @@ type PluginConfig struct {
 
 	Format string `yaml:"format,omitempty"` // specific to notification plugins
 
+	Filters []string `yaml:"filters,omitempty"` // expr over the alert, the plugin is notified if one of them matches
+
+	Builtin bool `yaml:"builtin,omitempty"` // run the notifier in the local API process instead of a plugin binary
+
+	Dedup  *DedupConfig  `yaml:"dedup,omitempty"`
+	Digest *DigestConfig `yaml:"digest,omitempty"`
+
 	Config map[string]interface{} `yaml:",inline"` // to keep the plugin-specific config
 }
 
@@ -82,11 +94,25 @@ func (pb *PluginBroker) Init(ctx context.Context, pluginCfg *csconfig.PluginCfg,
 	pb.profileConfigs = profileConfigs
 	pb.pluginProcConfig = pluginCfg
 	pb.pluginsTypesToDispatch = make(map[string]struct{})
+	pb.dedupByPluginName = make(map[string]*deduplicator)
+	pb.filtersByPluginName = make(map[string][]*vm.Program)
 
 	if err := pb.loadConfig(configPaths.NotificationDir); err != nil {
 		return fmt.Errorf("while loading plugin config: %w", err)
 	}
 
+	if err := pb.compileDedup(); err != nil {
+		return fmt.Errorf("while loading plugin config: %w", err)
+	}
+
+	if err := pb.compileFilters(); err != nil {
+		return fmt.Errorf("while loading plugin config: %w", err)
+	}
+
+	if err := pb.loadBuiltinNotifiers(ctx); err != nil {
+		return fmt.Errorf("while loading builtin notifiers: %w", err)
+	}
+
 	if err := pb.loadPlugins(ctx, configPaths.PluginDir); err != nil {
 		return fmt.Errorf("while loading plugin: %w", err)
 	}
@@ -109,6 +135,15 @@ func (pb *PluginBroker) Run(pluginTomb *tomb.Tomb) {
 
 	pb.watcher.Start(&tomb.Tomb{})
 
+	queueTomb := tomb.Tomb{}
+
+	if pb.queue != nil {
+		queueTomb.Go(func() error {
+			pb.runQueue(ctx, &queueTomb)
+			return nil
+		})
+	}
+
 	for {
 		select {
 		case profileAlert := <-pb.PluginChannel:
@@ -129,7 +164,21 @@ func (pb *PluginBroker) Run(pluginTomb *tomb.Tomb) {
 					threshold = 1
 				}
 
+				// a digest is a single message
+				if pb.pluginConfigByName[pluginName].Digest != nil {
+					threshold = max(len(tmpAlerts), 1)
+				}
+
 				for _, chunk := range slicetools.Chunks(tmpAlerts, threshold) {
+					if pb.queue != nil {
+						err := pb.enqueueNotification(ctx, pluginName, chunk)
+						if err == nil {
+							continue
+						}
+
+						log.WithField("plugin", pluginName).Errorf("unable to queue notification, sending it now: %s", err)
+					}
+
 					if err := pb.pushNotificationsToPlugin(ctx, pluginName, chunk); err != nil {
 						log.WithField("plugin:", pluginName).Error(err)
 					}
@@ -143,6 +192,9 @@ func (pb *PluginBroker) Run(pluginTomb *tomb.Tomb) {
 			for {
 				select {
 				case <-pb.watcher.tomb.Dead():
+					queueTomb.Kill(nil)
+					_ = queueTomb.Wait()
+
 					log.Info("killing all plugins")
 					pb.Kill()
 
@@ -155,6 +207,16 @@ func (pb *PluginBroker) Run(pluginTomb *tomb.Tomb) {
 					pb.alertsByPluginName[pluginName] = make([]*models.Alert, 0)
 					pluginMutex.Unlock()
 
+					// the queued notifications will be delivered after a restart
+					if pb.queue != nil {
+						err := pb.enqueueNotification(ctx, pluginName, tmpAlerts)
+						if err == nil {
+							continue
+						}
+
+						log.WithField("plugin", pluginName).Errorf("unable to queue notification, sending it now: %s", err)
+					}
+
 					if err := pb.pushNotificationsToPlugin(ctx, pluginName, tmpAlerts); err != nil {
 						log.WithField("plugin:", pluginName).Error(err)
 					}
@@ -171,6 +233,14 @@ func (pb *PluginBroker) addProfileAlert(profileAlert ProfileAlert) {
 			continue
 		}
 
+		if !pb.acceptsAlert(pluginName, profileAlert.Alert) {
+			continue
+		}
+
+		if pb.isDuplicate(pluginName, profileAlert.Alert) {
+			continue
+		}
+
 		pluginMutex.Lock()
 		pb.alertsByPluginName[pluginName] = append(pb.alertsByPluginName[pluginName], profileAlert.Alert)
 		pluginMutex.Unlock()
@@ -209,6 +279,20 @@ func (pb *PluginBroker) loadConfig(path string) error {
 		for _, pluginConfig := range pluginConfigs {
 			SetRequiredFields(&pluginConfig)
 
+			if pluginConfig.Digest != nil {
+				if err := validateDigest(pluginConfig.Digest); err != nil {
+					return fmt.Errorf("notification %s: %w", pluginConfig.Name, err)
+				}
+
+				if pluginConfig.GroupWait != 0 || pluginConfig.GroupThreshold != 0 {
+					log.Warningf("notification %s: group_wait and group_threshold are ignored in digest mode", pluginConfig.Name)
+				}
+
+				// the watcher will send the pending alerts at each interval
+				pluginConfig.GroupWait = pluginConfig.Digest.Interval
+				pluginConfig.GroupThreshold = 0
+			}
+
 			if _, ok := pb.pluginConfigByName[pluginConfig.Name]; ok {
 				log.Warningf("notification '%s' is defined multiple times", pluginConfig.Name)
 			}
@@ -231,6 +315,10 @@ func (pb *PluginBroker) verifyPluginConfigsWithProfile() error {
 				return fmt.Errorf("config file for plugin %s not found", pluginName)
 			}
 
+			if pb.pluginConfigByName[pluginName].Builtin {
+				continue
+			}
+
 			pb.pluginsTypesToDispatch[pb.pluginConfigByName[pluginName].Type] = struct{}{}
 		}
 	}
@@ -252,6 +340,11 @@ func (pb *PluginBroker) verifyPluginBinaryWithProfile() error {
 }
 
 func (pb *PluginBroker) loadPlugins(ctx context.Context, path string) error {
+	// all the notifications are builtin
+	if len(pb.pluginsTypesToDispatch) == 0 {
+		return pb.verifyPluginBinaryWithProfile()
+	}
+
 	binaryPaths, err := listFilesAtPath(path)
 	if err != nil {
 		return err
@@ -281,17 +374,15 @@ func (pb *PluginBroker) loadPlugins(ctx context.Context, path string) error {
 		}
 
 		for _, pc := range pb.pluginConfigByName {
-			if pc.Type != pSubtype {
+			if pc.Type != pSubtype || pc.Builtin {
 				continue
 			}
 
-			data, err := yaml.Marshal(pc)
+			data, err := marshalPluginConfig(pc)
 			if err != nil {
 				return err
 			}
 
-			data = []byte(csstring.StrictExpand(string(data), os.LookupEnv))
-
 			_, err = pluginClient.Configure(ctx, &protobufs.Config{Config: data})
 			if err != nil {
 				return fmt.Errorf("while configuring %s: %w", pc.Name, err)
@@ -378,7 +469,7 @@ func (pb *PluginBroker) pushNotificationsToPlugin(ctx context.Context, pluginNam
 		return nil
 	}
 
-	message, err := FormatAlerts(pb.pluginConfigByName[pluginName].Format, alerts)
+	message, err := pb.formatMessage(pluginName, alerts)
 	if err != nil {
 		return err
 	}
@@ -387,6 +478,7 @@ func (pb *PluginBroker) pushNotificationsToPlugin(ctx context.Context, pluginNam
 
 	for i := 1; i <= pb.pluginConfigByName[pluginName].MaxRetry; i++ {
 		if err = pb.tryNotify(ctx, pluginName, message); err == nil {
+			NotificationsDelivered.With(prometheus.Labels{"plugin": pluginName}).Inc()
 			return nil
 		}
 
@@ -395,9 +487,22 @@ func (pb *PluginBroker) pushNotificationsToPlugin(ctx context.Context, pluginNam
 		backoffDuration *= 2
 	}
 
+	NotificationsFailed.With(prometheus.Labels{"plugin": pluginName}).Inc()
+
 	return err
 }
 
+// formatMessage renders the alerts with the format template of the plugin, as a digest if configured
+func (pb *PluginBroker) formatMessage(pluginName string, alerts []*models.Alert) (string, error) {
+	pc := pb.pluginConfigByName[pluginName]
+
+	if pc.Digest != nil {
+		return FormatDigest(pc.Format, NewDigest(alerts, pc.Digest, time.Now().UTC()))
+	}
+
+	return FormatAlerts(pc.Format, alerts)
+}
+
 func ParsePluginConfigFile(path string) ([]PluginConfig, error) {
 	parsedConfigs := make([]PluginConfig, 0)
 
@@ -0,0 +1,86 @@
+package csplugin
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/hashicorp/go-hclog"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v2"
+
+	"github.com/crowdsecurity/go-cs-lib/csstring"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	emailnotifier "github.com/crowdsecurity/crowdsec/pkg/notifiers/email"
+	filenotifier "github.com/crowdsecurity/crowdsec/pkg/notifiers/file"
+	httpnotifier "github.com/crowdsecurity/crowdsec/pkg/notifiers/http"
+	slacknotifier "github.com/crowdsecurity/crowdsec/pkg/notifiers/slack"
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// Notifier is implemented by the notifiers that can run inside the local API. It's the same interface
+// as the one served by the plugin binaries, so a notifier can be built both ways.
+type Notifier = protobufs.NotifierServer
+
+// builtinNotifiers are the notifier types that don't require a plugin binary when the config has "builtin: true"
+var builtinNotifiers = map[string]func(logger hclog.Logger) Notifier{
+	"email": func(logger hclog.Logger) Notifier { return emailnotifier.New(logger) },
+	"file":  func(logger hclog.Logger) Notifier { return filenotifier.New(logger) },
+	"http":  func(logger hclog.Logger) Notifier { return httpnotifier.New(logger) },
+	"slack": func(logger hclog.Logger) Notifier { return slacknotifier.New(logger) },
+}
+
+// marshalPluginConfig is the configuration sent to a notifier, with the environment variables expanded
+func marshalPluginConfig(pc PluginConfig) ([]byte, error) {
+	data, err := yaml.Marshal(pc)
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(csstring.StrictExpand(string(data), os.LookupEnv)), nil
+}
+
+// loadBuiltinNotifiers configures the in-process notifiers. There is one instance per type, as with the binaries.
+func (pb *PluginBroker) loadBuiltinNotifiers(ctx context.Context) error {
+	notifierByType := make(map[string]Notifier)
+
+	for _, pc := range pb.pluginConfigByName {
+		if !pc.Builtin || !pb.profilesContainPlugin(pc.Name) {
+			continue
+		}
+
+		notifier, ok := notifierByType[pc.Type]
+		if !ok {
+			newNotifier, ok := builtinNotifiers[pc.Type]
+			if !ok {
+				return fmt.Errorf("notification %s: there is no builtin notifier of type %s", pc.Name, pc.Type)
+			}
+
+			// each notifier has its own logger, because they can change the log level
+			l := log.New()
+			if err := types.ConfigureLogger(l, ptr.Of(log.TraceLevel)); err != nil {
+				return err
+			}
+
+			notifier = newNotifier(NewHCLogAdapter(l, pc.Type+"-notifier"))
+			notifierByType[pc.Type] = notifier
+		}
+
+		data, err := marshalPluginConfig(pc)
+		if err != nil {
+			return err
+		}
+
+		if _, err := notifier.Configure(ctx, &protobufs.Config{Config: data}); err != nil {
+			return fmt.Errorf("while configuring %s: %w", pc.Name, err)
+		}
+
+		log.Infof("registered builtin notifier %s", pc.Name)
+
+		pb.notificationPluginByName[pc.Name] = notifier
+	}
+
+	return nil
+}
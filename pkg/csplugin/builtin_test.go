@@ -0,0 +1,74 @@
+package csplugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+func initBuiltinBroker(t *testing.T, config string) (*PluginBroker, error) {
+	t.Helper()
+
+	notificationDir := t.TempDir()
+	pluginDir := t.TempDir()
+
+	require.NoError(t, os.WriteFile(filepath.Join(notificationDir, "notification.yaml"), []byte(config), 0o600))
+
+	profiles := []*csconfig.ProfileCfg{{Name: "default", Notifications: []string{"builtin_notification"}}}
+
+	pb := &PluginBroker{}
+	err := pb.Init(t.Context(), &csconfig.PluginCfg{}, profiles, &csconfig.ConfigurationPaths{
+		NotificationDir: notificationDir,
+		PluginDir:       pluginDir,
+	})
+
+	return pb, err
+}
+
+func TestBuiltinNotifier(t *testing.T) {
+	logPath := filepath.Join(t.TempDir(), "notifications.log")
+
+	// there is no plugin binary: the notifier runs in process
+	pb, err := initBuiltinBroker(t, `
+type: file
+name: builtin_notification
+builtin: true
+log_path: `+logPath+`
+format: |
+  {{range .}}{{.Scenario}}
+  {{end -}}
+`)
+	require.NoError(t, err)
+
+	alerts := []*models.Alert{
+		{Scenario: ptr.Of("crowdsecurity/ssh-bf")},
+		{Scenario: ptr.Of("crowdsecurity/http-probing")},
+	}
+
+	require.NoError(t, pb.pushNotificationsToPlugin(t.Context(), "builtin_notification", alerts))
+
+	content, err := os.ReadFile(logPath)
+	require.NoError(t, err)
+	assert.Equal(t, "crowdsecurity/ssh-bf\ncrowdsecurity/http-probing\n", string(content))
+}
+
+func TestBuiltinNotifierErrors(t *testing.T) {
+	_, err := initBuiltinBroker(t, "type: splunk\nname: builtin_notification\nbuiltin: true\n")
+	cstest.RequireErrorContains(t, err, "notification builtin_notification: there is no builtin notifier of type splunk")
+
+	_, err = initBuiltinBroker(t, "type: email\nname: builtin_notification\nbuiltin: true\n")
+	cstest.RequireErrorContains(t, err, "while configuring builtin_notification: SMTP host is not set")
+
+	// without builtin, the binary is required
+	_, err = initBuiltinBroker(t, "type: file\nname: builtin_notification\n")
+	cstest.RequireErrorContains(t, err, "binary for plugin builtin_notification not found")
+}
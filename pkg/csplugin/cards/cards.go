@@ -0,0 +1,221 @@
+// Package cards builds a chat-agnostic representation of the alerts, shared by the
+// notification plugins that render rich messages (Teams, Mattermost, Discord).
+package cards
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+const (
+	DefaultCTIURL     = "https://app.crowdsec.net/cti/"
+	DefaultConsoleURL = "https://app.crowdsec.net/alerts"
+	DefaultMaxAlerts  = 10
+)
+
+// Severity drives the color of a card
+type Severity string
+
+const (
+	SeverityInfo    Severity = "info"    // no decision
+	SeverityWarning Severity = "warning" // captcha, throttle...
+	SeverityDanger  Severity = "danger"  // ban
+)
+
+// Options are the plugin settings that change the content of the cards
+type Options struct {
+	CTIURL     string `yaml:"cti_url"` // prefix of the CTI link, the IP is appended
+	ConsoleURL string `yaml:"console_url"`
+	MaxAlerts  int    `yaml:"max_alerts"` // the other alerts of the message are only counted
+}
+
+// Fact is a name/value pair displayed in a card
+type Fact struct {
+	Name  string
+	Value string
+}
+
+// Link is rendered as a button, when the chat supports it
+type Link struct {
+	Title string
+	URL   string
+}
+
+// Card is the content of one alert
+type Card struct {
+	Title     string
+	Subtitle  string
+	Severity  Severity
+	Timestamp string
+	Facts     []Fact
+	Decisions []string
+	Context   []Fact
+	Links     []Link
+}
+
+// Message is the content of one notification: the cards of the alerts, or a plain text
+// when the format of the plugin doesn't produce alerts
+type Message struct {
+	Text    string
+	Cards   []Card
+	Omitted int // number of alerts without a card, beyond max_alerts
+}
+
+// SetDefaults fills the unset options
+func (o *Options) SetDefaults() {
+	if o.ConsoleURL == "" {
+		o.ConsoleURL = DefaultConsoleURL
+	}
+
+	if o.CTIURL == "" {
+		o.CTIURL = DefaultCTIURL
+	}
+
+	if o.MaxAlerts <= 0 {
+		o.MaxAlerts = DefaultMaxAlerts
+	}
+}
+
+// ParseAlerts decodes the output of a format like '{{.|toJson}}': a list of alerts, or a single one
+func ParseAlerts(text string) ([]*models.Alert, error) {
+	text = strings.TrimSpace(text)
+
+	var alerts []*models.Alert
+
+	switch {
+	case strings.HasPrefix(text, "["):
+		if err := json.Unmarshal([]byte(text), &alerts); err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(text, "{"):
+		alert := &models.Alert{}
+		if err := json.Unmarshal([]byte(text), alert); err != nil {
+			return nil, err
+		}
+
+		alerts = append(alerts, alert)
+	default:
+		return nil, errors.New("not a JSON object or array")
+	}
+
+	// a Digest or any other object would decode as an empty alert
+	for _, alert := range alerts {
+		if alert == nil || alert.Scenario == nil {
+			return nil, errors.New("not a list of alerts")
+		}
+	}
+
+	return alerts, nil
+}
+
+// NewMessage builds the cards from the notification text, or falls back to the text itself
+func NewMessage(text string, opts Options) Message {
+	alerts, err := ParseAlerts(text)
+	if err != nil || len(alerts) == 0 {
+		return Message{Text: text}
+	}
+
+	msg := Message{}
+
+	for i, alert := range alerts {
+		if i >= opts.MaxAlerts {
+			msg.Omitted = len(alerts) - i
+			break
+		}
+
+		msg.Cards = append(msg.Cards, NewCard(alert, opts))
+	}
+
+	return msg
+}
+
+// contextValue flattens the values of the alert context, which are JSON lists of strings
+func contextValue(value string) string {
+	var values []string
+
+	if err := json.Unmarshal([]byte(value), &values); err != nil {
+		return value
+	}
+
+	return strings.Join(values, ", ")
+}
+
+func severity(alert *models.Alert) Severity {
+	switch alert.GetSeverity() {
+	case "high":
+		return SeverityDanger
+	case "medium":
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+func NewCard(alert *models.Alert, opts Options) Card {
+	card := Card{
+		Title:    alert.GetScenario(),
+		Severity: severity(alert),
+	}
+
+	if alert.StartAt != nil {
+		card.Timestamp = *alert.StartAt
+	}
+
+	source := alert.Source
+	if source == nil {
+		source = &models.Source{}
+	}
+
+	if source.GetValue() != "" {
+		card.Subtitle = fmt.Sprintf("%s %s", source.GetScope(), source.GetValue())
+		card.Facts = append(card.Facts, Fact{Name: "Source", Value: card.Subtitle})
+	}
+
+	if source.Cn != "" {
+		card.Facts = append(card.Facts, Fact{Name: "Country", Value: source.Cn})
+	}
+
+	if as := strings.TrimSpace(source.GetAsNumberName()); as != "" {
+		card.Facts = append(card.Facts, Fact{Name: "AS", Value: as})
+	}
+
+	if alert.MachineID != "" {
+		card.Facts = append(card.Facts, Fact{Name: "Machine", Value: alert.MachineID})
+	}
+
+	card.Facts = append(card.Facts, Fact{Name: "Events", Value: fmt.Sprint(alert.GetEventsCount())})
+
+	for _, decision := range alert.Decisions {
+		if decision.Type == nil || decision.Value == nil {
+			continue
+		}
+
+		line := fmt.Sprintf("%s %s", *decision.Type, *decision.Value)
+		if decision.Duration != nil {
+			line += " for " + *decision.Duration
+		}
+
+		card.Decisions = append(card.Decisions, line)
+	}
+
+	for _, meta := range alert.Meta {
+		if meta == nil || meta.Key == "" {
+			continue
+		}
+
+		card.Context = append(card.Context, Fact{Name: meta.Key, Value: contextValue(meta.Value)})
+	}
+
+	if strings.EqualFold(source.GetScope(), types.Ip) && source.GetValue() != "" {
+		card.Links = append(card.Links, Link{Title: "CrowdSec CTI", URL: opts.CTIURL + source.GetValue()})
+	}
+
+	card.Links = append(card.Links, Link{Title: "Console", URL: opts.ConsoleURL})
+
+	return card
+}
@@ -0,0 +1,175 @@
+package cards
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+func testAlert(ip string, decisionType string) *models.Alert {
+	return &models.Alert{
+		Scenario:    ptr.Of("crowdsecurity/ssh-bf"),
+		MachineID:   "machine1",
+		EventsCount: ptr.Of(int32(6)),
+		StartAt:     ptr.Of("2024-01-01T10:00:00Z"),
+		Source: &models.Source{
+			Scope:    ptr.Of("Ip"),
+			Value:    ptr.Of(ip),
+			Cn:       "FR",
+			AsNumber: "16276",
+			AsName:   "OVH SAS",
+		},
+		Decisions: []*models.Decision{
+			{Type: ptr.Of(decisionType), Value: ptr.Of(ip), Duration: ptr.Of("4h")},
+		},
+		Meta: models.Meta{
+			{Key: "target_user", Value: `["root","admin"]`},
+		},
+	}
+}
+
+func testText(t *testing.T, alerts ...*models.Alert) string {
+	b, err := json.Marshal(alerts)
+	require.NoError(t, err)
+
+	return string(b)
+}
+
+func testOptions() Options {
+	opts := Options{}
+	opts.SetDefaults()
+
+	return opts
+}
+
+func TestParseAlerts(t *testing.T) {
+	alerts, err := ParseAlerts(testText(t, testAlert("1.2.3.4", "ban")))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+	assert.Equal(t, "1.2.3.4", alerts[0].GetValue())
+
+	single, err := json.Marshal(testAlert("1.2.3.5", "ban"))
+	require.NoError(t, err)
+
+	alerts, err = ParseAlerts(string(single))
+	require.NoError(t, err)
+	require.Len(t, alerts, 1)
+
+	_, err = ParseAlerts("1.2.3.4 will get ban")
+	require.Error(t, err)
+
+	_, err = ParseAlerts(`{"AlertCount": 3}`)
+	require.Error(t, err)
+}
+
+func TestNewCard(t *testing.T) {
+	card := NewCard(testAlert("1.2.3.4", "ban"), testOptions())
+
+	assert.Equal(t, "crowdsecurity/ssh-bf", card.Title)
+	assert.Equal(t, "Ip 1.2.3.4", card.Subtitle)
+	assert.Equal(t, SeverityDanger, card.Severity)
+	assert.Equal(t, []Fact{
+		{Name: "Source", Value: "Ip 1.2.3.4"},
+		{Name: "Country", Value: "FR"},
+		{Name: "AS", Value: "16276 OVH SAS"},
+		{Name: "Machine", Value: "machine1"},
+		{Name: "Events", Value: "6"},
+	}, card.Facts)
+	assert.Equal(t, []string{"ban 1.2.3.4 for 4h"}, card.Decisions)
+	assert.Equal(t, []Fact{{Name: "target_user", Value: "root, admin"}}, card.Context)
+	assert.Equal(t, []Link{
+		{Title: "CrowdSec CTI", URL: "https://app.crowdsec.net/cti/1.2.3.4"},
+		{Title: "Console", URL: DefaultConsoleURL},
+	}, card.Links)
+
+	card = NewCard(testAlert("1.2.3.4", "captcha"), testOptions())
+	assert.Equal(t, SeverityWarning, card.Severity)
+}
+
+func TestNewMessage(t *testing.T) {
+	opts := testOptions()
+	opts.MaxAlerts = 2
+
+	msg := NewMessage(testText(t, testAlert("1.2.3.4", "ban"), testAlert("1.2.3.5", "ban"), testAlert("1.2.3.6", "ban")), opts)
+	assert.Empty(t, msg.Text)
+	assert.Len(t, msg.Cards, 2)
+	assert.Equal(t, 1, msg.Omitted)
+
+	msg = NewMessage("plain text", opts)
+	assert.Equal(t, "plain text", msg.Text)
+	assert.Empty(t, msg.Cards)
+}
+
+func TestRenderers(t *testing.T) {
+	msg := NewMessage(testText(t, testAlert("1.2.3.4", "ban")), testOptions())
+
+	teams := msg.Teams()
+	require.Len(t, teams.Attachments, 1)
+	assert.Equal(t, adaptiveCardContentType, teams.Attachments[0].ContentType)
+	require.Len(t, teams.Attachments[0].Content.Body, 1)
+	assert.Equal(t, "Container", teams.Attachments[0].Content.Body[0]["type"])
+
+	mm := msg.Mattermost()
+	require.Len(t, mm.Attachments, 1)
+	assert.Equal(t, "#d9534f", mm.Attachments[0].Color)
+	assert.Equal(t, "https://app.crowdsec.net/cti/1.2.3.4", mm.Attachments[0].TitleLink)
+
+	discord := msg.Discord()
+	require.Len(t, discord, 1)
+	require.Len(t, discord[0].Embeds, 1)
+	assert.Equal(t, 0xd9534f, discord[0].Embeds[0].Color)
+
+	plain := NewMessage("plain text", testOptions())
+	assert.Equal(t, "plain text", plain.Mattermost().Text)
+	assert.Equal(t, "plain text", plain.Discord()[0].Content)
+	assert.Equal(t, "plain text", plain.Teams().Attachments[0].Content.Body[0]["text"])
+}
+
+func TestDiscordSplit(t *testing.T) {
+	alerts := make([]*models.Alert, 0, 12)
+	for range 12 {
+		alerts = append(alerts, testAlert("1.2.3.4", "ban"))
+	}
+
+	opts := testOptions()
+	opts.MaxAlerts = 15
+
+	messages := NewMessage(testText(t, alerts...), opts).Discord()
+	require.Len(t, messages, 2)
+	assert.Len(t, messages[0].Embeds, 10)
+	assert.Len(t, messages[1].Embeds, 2)
+}
+
+func TestPostJSON(t *testing.T) {
+	var received TeamsMessage
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/fail" {
+			http.Error(w, "bad payload", http.StatusBadRequest)
+			return
+		}
+
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	msg := NewMessage(testText(t, testAlert("1.2.3.4", "ban")), testOptions())
+
+	require.NoError(t, PostJSON(ctx, ts.Client(), ts.URL, msg.Teams()))
+	assert.Equal(t, "message", received.Type)
+
+	err := PostJSON(ctx, ts.Client(), ts.URL+"/fail", msg.Teams())
+	cstest.RequireErrorContains(t, err, "webhook returned status code 400: bad payload")
+}
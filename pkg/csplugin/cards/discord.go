@@ -0,0 +1,103 @@
+package cards
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	discordMaxEmbeds  = 10
+	discordMaxContent = 2000
+	discordMaxField   = 1024
+)
+
+// DiscordMessage is the body accepted by the Discord webhooks
+type DiscordMessage struct {
+	Content   string         `json:"content,omitempty"`
+	Username  string         `json:"username,omitempty"`
+	AvatarURL string         `json:"avatar_url,omitempty"`
+	Embeds    []DiscordEmbed `json:"embeds,omitempty"`
+}
+
+type DiscordEmbed struct {
+	Title       string         `json:"title"`
+	URL         string         `json:"url,omitempty"`
+	Description string         `json:"description,omitempty"`
+	Color       int            `json:"color"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+	Fields      []DiscordField `json:"fields,omitempty"`
+}
+
+type DiscordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline,omitempty"`
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n-3] + "..."
+}
+
+func (c Card) discordEmbed() DiscordEmbed {
+	color, _ := strconv.ParseInt(strings.TrimPrefix(c.Severity.hexColor(), "#"), 16, 32)
+
+	embed := DiscordEmbed{
+		Title:       c.Title,
+		Description: c.Subtitle,
+		Color:       int(color),
+		Timestamp:   c.Timestamp,
+	}
+
+	if len(c.Links) > 0 {
+		embed.URL = c.Links[0].URL
+	}
+
+	for _, f := range c.Facts {
+		embed.Fields = append(embed.Fields, DiscordField{Name: f.Name, Value: truncate(f.Value, discordMaxField), Inline: true})
+	}
+
+	if len(c.Decisions) > 0 {
+		embed.Fields = append(embed.Fields, DiscordField{Name: "Decisions", Value: truncate(strings.Join(c.Decisions, "\n"), discordMaxField)})
+	}
+
+	for _, f := range c.Context {
+		embed.Fields = append(embed.Fields, DiscordField{Name: f.Name, Value: truncate(f.Value, discordMaxField)})
+	}
+
+	if len(c.Links) > 0 {
+		embed.Fields = append(embed.Fields, DiscordField{Name: "Links", Value: markdownLinks(c.Links)})
+	}
+
+	return embed
+}
+
+// Discord renders the message as embeds. A webhook message can't have more than
+// 10 embeds, so there can be several messages.
+func (m Message) Discord() []DiscordMessage {
+	if len(m.Cards) == 0 {
+		return []DiscordMessage{{Content: truncate(m.Text, discordMaxContent)}}
+	}
+
+	var messages []DiscordMessage
+
+	for i := 0; i < len(m.Cards); i += discordMaxEmbeds {
+		msg := DiscordMessage{}
+
+		for _, c := range m.Cards[i:min(i+discordMaxEmbeds, len(m.Cards))] {
+			msg.Embeds = append(msg.Embeds, c.discordEmbed())
+		}
+
+		messages = append(messages, msg)
+	}
+
+	if m.Omitted > 0 {
+		messages[len(messages)-1].Content = fmt.Sprintf("... and %d more alert(s)", m.Omitted)
+	}
+
+	return messages
+}
@@ -0,0 +1,104 @@
+package cards
+
+import (
+	"fmt"
+	"strings"
+)
+
+// MattermostMessage is the body accepted by the Mattermost incoming webhooks
+type MattermostMessage struct {
+	Text        string                 `json:"text,omitempty"`
+	Channel     string                 `json:"channel,omitempty"`
+	Username    string                 `json:"username,omitempty"`
+	IconURL     string                 `json:"icon_url,omitempty"`
+	IconEmoji   string                 `json:"icon_emoji,omitempty"`
+	Attachments []MattermostAttachment `json:"attachments,omitempty"`
+}
+
+type MattermostAttachment struct {
+	Fallback  string            `json:"fallback"`
+	Color     string            `json:"color"`
+	Title     string            `json:"title"`
+	TitleLink string            `json:"title_link,omitempty"`
+	Text      string            `json:"text,omitempty"`
+	Fields    []MattermostField `json:"fields,omitempty"`
+	Footer    string            `json:"footer,omitempty"`
+}
+
+type MattermostField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+func (s Severity) hexColor() string {
+	switch s {
+	case SeverityDanger:
+		return "#d9534f"
+	case SeverityWarning:
+		return "#f0ad4e"
+	default:
+		return "#5bc0de"
+	}
+}
+
+// markdownLinks renders the links of a card, for the chats that have no buttons
+func markdownLinks(links []Link) string {
+	parts := make([]string, 0, len(links))
+
+	for _, l := range links {
+		parts = append(parts, fmt.Sprintf("[%s](%s)", l.Title, l.URL))
+	}
+
+	return strings.Join(parts, " | ")
+}
+
+func (c Card) mattermostAttachment() MattermostAttachment {
+	att := MattermostAttachment{
+		Fallback: strings.TrimSpace(c.Title + " " + c.Subtitle),
+		Color:    c.Severity.hexColor(),
+		Title:    c.Title,
+		Footer:   c.Timestamp,
+	}
+
+	if len(c.Links) > 0 {
+		att.TitleLink = c.Links[0].URL
+	}
+
+	for _, f := range c.Facts {
+		att.Fields = append(att.Fields, MattermostField{Title: f.Name, Value: f.Value, Short: true})
+	}
+
+	if len(c.Decisions) > 0 {
+		att.Fields = append(att.Fields, MattermostField{Title: "Decisions", Value: strings.Join(c.Decisions, "\n")})
+	}
+
+	for _, f := range c.Context {
+		att.Fields = append(att.Fields, MattermostField{Title: f.Name, Value: f.Value})
+	}
+
+	att.Text = markdownLinks(c.Links)
+
+	return att
+}
+
+// Mattermost renders the message with an attachment per alert. Mattermost has no link
+// buttons in webhooks, the links go in the title and the text of the attachments.
+func (m Message) Mattermost() MattermostMessage {
+	msg := MattermostMessage{}
+
+	if len(m.Cards) == 0 {
+		msg.Text = m.Text
+		return msg
+	}
+
+	for _, c := range m.Cards {
+		msg.Attachments = append(msg.Attachments, c.mattermostAttachment())
+	}
+
+	if m.Omitted > 0 {
+		msg.Text = fmt.Sprintf("... and %d more alert(s)", m.Omitted)
+	}
+
+	return msg
+}
@@ -0,0 +1,134 @@
+package cards
+
+import (
+	"fmt"
+	"strings"
+)
+
+const adaptiveCardContentType = "application/vnd.microsoft.card.adaptive"
+
+// TeamsMessage is the body accepted by the Teams incoming webhooks and workflows
+type TeamsMessage struct {
+	Type        string            `json:"type"`
+	Attachments []TeamsAttachment `json:"attachments"`
+}
+
+type TeamsAttachment struct {
+	ContentType string       `json:"contentType"`
+	Content     AdaptiveCard `json:"content"`
+}
+
+type AdaptiveCard struct {
+	Schema  string           `json:"$schema"`
+	Type    string           `json:"type"`
+	Version string           `json:"version"`
+	Body    []map[string]any `json:"body"`
+	MSTeams map[string]any   `json:"msteams,omitempty"`
+}
+
+func (s Severity) adaptiveStyle() string {
+	switch s {
+	case SeverityDanger:
+		return "attention"
+	case SeverityWarning:
+		return "warning"
+	default:
+		return "accent"
+	}
+}
+
+func textBlock(text string, props map[string]any) map[string]any {
+	block := map[string]any{
+		"type": "TextBlock",
+		"text": text,
+		"wrap": true,
+	}
+
+	for k, v := range props {
+		block[k] = v
+	}
+
+	return block
+}
+
+func factSet(facts []Fact) map[string]any {
+	items := make([]map[string]string, 0, len(facts))
+
+	for _, f := range facts {
+		items = append(items, map[string]string{"title": f.Name, "value": f.Value})
+	}
+
+	return map[string]any{
+		"type":  "FactSet",
+		"facts": items,
+	}
+}
+
+func (c Card) adaptiveContainer() map[string]any {
+	items := []map[string]any{
+		textBlock(c.Title, map[string]any{"size": "medium", "weight": "bolder", "color": c.Severity.adaptiveStyle()}),
+	}
+
+	if c.Timestamp != "" {
+		items = append(items, textBlock(c.Timestamp, map[string]any{"isSubtle": true, "spacing": "none"}))
+	}
+
+	items = append(items, factSet(c.Facts))
+
+	if len(c.Decisions) > 0 {
+		items = append(items,
+			textBlock("Decisions", map[string]any{"weight": "bolder"}),
+			textBlock("- "+strings.Join(c.Decisions, "\r- "), nil))
+	}
+
+	if len(c.Context) > 0 {
+		items = append(items, textBlock("Context", map[string]any{"weight": "bolder"}), factSet(c.Context))
+	}
+
+	if len(c.Links) > 0 {
+		actions := make([]map[string]any, 0, len(c.Links))
+		for _, l := range c.Links {
+			actions = append(actions, map[string]any{"type": "Action.OpenUrl", "title": l.Title, "url": l.URL})
+		}
+
+		items = append(items, map[string]any{"type": "ActionSet", "actions": actions})
+	}
+
+	return map[string]any{
+		"type":      "Container",
+		"style":     "emphasis",
+		"separator": true,
+		"items":     items,
+	}
+}
+
+// Teams renders the message as a single Adaptive Card, with a container per alert
+func (m Message) Teams() TeamsMessage {
+	var body []map[string]any
+
+	if len(m.Cards) == 0 {
+		body = append(body, textBlock(m.Text, nil))
+	}
+
+	for _, c := range m.Cards {
+		body = append(body, c.adaptiveContainer())
+	}
+
+	if m.Omitted > 0 {
+		body = append(body, textBlock(fmt.Sprintf("... and %d more alert(s)", m.Omitted), map[string]any{"isSubtle": true}))
+	}
+
+	return TeamsMessage{
+		Type: "message",
+		Attachments: []TeamsAttachment{{
+			ContentType: adaptiveCardContentType,
+			Content: AdaptiveCard{
+				Schema:  "http://adaptivecards.io/schemas/adaptive-card.json",
+				Type:    "AdaptiveCard",
+				Version: "1.4",
+				Body:    body,
+				MSTeams: map[string]any{"width": "Full"},
+			},
+		}},
+	}
+}
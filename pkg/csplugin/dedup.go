@@ -0,0 +1,119 @@
+package csplugin
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+// how often the expired deduplication keys are forgotten
+const dedupPruneInterval = time.Minute
+
+// DedupConfig suppresses the alerts that share the same key, for the duration of the window
+type DedupConfig struct {
+	Key    string        `yaml:"key"`    // expr over the alert, ie. Alert.GetScenario() + Alert.Source.AsNumber
+	Window time.Duration `yaml:"window"` // how long an alert with the same key is suppressed
+}
+
+type deduplicator struct {
+	key       *vm.Program
+	window    time.Duration
+	lastSeen  map[string]time.Time
+	lastPrune time.Time
+	logger    *log.Entry
+}
+
+func newDeduplicator(pluginName string, cfg *DedupConfig) (*deduplicator, error) {
+	if cfg.Key == "" {
+		return nil, errors.New("dedup key is required")
+	}
+
+	if cfg.Window <= 0 {
+		return nil, errors.New("dedup window must be positive")
+	}
+
+	program, err := expr.Compile(cfg.Key, exprhelpers.GetExprOptions(map[string]interface{}{"Alert": &models.Alert{}})...)
+	if err != nil {
+		return nil, fmt.Errorf("while compiling dedup key: %w", err)
+	}
+
+	return &deduplicator{
+		key:      program,
+		window:   cfg.Window,
+		lastSeen: make(map[string]time.Time),
+		logger:   log.WithField("plugin", pluginName),
+	}, nil
+}
+
+// suppress returns true if an alert with the same key has been let through during the window.
+// Alerts for which the key can't be computed are never suppressed.
+func (d *deduplicator) suppress(alert *models.Alert, now time.Time) bool {
+	output, err := exprhelpers.Run(d.key, map[string]interface{}{"Alert": alert}, d.logger, false)
+	if err != nil {
+		d.logger.Warningf("failed to compute dedup key: %s", err)
+		return false
+	}
+
+	key := fmt.Sprint(output)
+
+	if now.Sub(d.lastPrune) > dedupPruneInterval {
+		for k, seen := range d.lastSeen {
+			if now.Sub(seen) >= d.window {
+				delete(d.lastSeen, k)
+			}
+		}
+
+		d.lastPrune = now
+	}
+
+	if seen, ok := d.lastSeen[key]; ok && now.Sub(seen) < d.window {
+		d.logger.Debugf("suppressing alert with dedup key '%s'", key)
+		return true
+	}
+
+	d.lastSeen[key] = now
+
+	return false
+}
+
+// compileDedup prepares the deduplication of the plugins that have it configured
+func (pb *PluginBroker) compileDedup() error {
+	for name, pc := range pb.pluginConfigByName {
+		if pc.Dedup == nil {
+			continue
+		}
+
+		d, err := newDeduplicator(name, pc.Dedup)
+		if err != nil {
+			return fmt.Errorf("notification %s: %w", name, err)
+		}
+
+		pb.dedupByPluginName[name] = d
+	}
+
+	return nil
+}
+
+// isDuplicate checks the alert against the deduplication window of the plugin, if any
+func (pb *PluginBroker) isDuplicate(pluginName string, alert *models.Alert) bool {
+	d, ok := pb.dedupByPluginName[pluginName]
+	if !ok {
+		return false
+	}
+
+	if !d.suppress(alert, time.Now()) {
+		return false
+	}
+
+	NotificationsSuppressed.With(prometheus.Labels{"plugin": pluginName}).Inc()
+
+	return true
+}
@@ -0,0 +1,77 @@
+package csplugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+func TestNewDeduplicator(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         DedupConfig
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  DedupConfig{Key: "Alert.GetScenario() + Alert.Source.AsNumber", Window: time.Hour},
+		},
+		{
+			name:        "no key",
+			cfg:         DedupConfig{Window: time.Hour},
+			expectedErr: "dedup key is required",
+		},
+		{
+			name:        "no window",
+			cfg:         DedupConfig{Key: "Alert.GetScenario()"},
+			expectedErr: "dedup window must be positive",
+		},
+		{
+			name:        "bad key",
+			cfg:         DedupConfig{Key: "Alert.Nope", Window: time.Hour},
+			expectedErr: "while compiling dedup key",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newDeduplicator("dummy", &tc.cfg)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestDeduplicatorSuppress(t *testing.T) {
+	d, err := newDeduplicator("dummy", &DedupConfig{
+		Key:    "Alert.GetScenario() + '/' + Alert.Source.AsNumber",
+		Window: time.Hour,
+	})
+	require.NoError(t, err)
+
+	alert := func(ip string, asn string) *models.Alert {
+		return &models.Alert{
+			Scenario: ptr.Of("crowdsecurity/ssh-bf"),
+			Source:   &models.Source{Scope: ptr.Of("Ip"), Value: ptr.Of(ip), AsNumber: asn},
+		}
+	}
+
+	now := time.Now()
+
+	assert.False(t, d.suppress(alert("1.2.3.4", "1234"), now))
+	assert.True(t, d.suppress(alert("1.2.3.5", "1234"), now.Add(time.Minute)))
+	assert.False(t, d.suppress(alert("5.6.7.8", "5678"), now.Add(time.Minute)))
+	// the window starts with the first alert that was let through
+	assert.False(t, d.suppress(alert("1.2.3.6", "1234"), now.Add(time.Hour)))
+	assert.True(t, d.suppress(alert("1.2.3.7", "1234"), now.Add(time.Hour+time.Minute)))
+
+	// expired keys are forgotten
+	d.suppress(alert("9.9.9.9", "9999"), now.Add(3*time.Hour))
+	assert.Len(t, d.lastSeen, 1)
+}
@@ -0,0 +1,141 @@
+package csplugin
+
+import (
+	"cmp"
+	"errors"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+const defaultDigestTop = 5
+
+// DigestConfig replaces the individual notifications with a periodic summary.
+// In digest mode, the format template receives a Digest object instead of a list of alerts.
+type DigestConfig struct {
+	Interval time.Duration `yaml:"interval"`
+	Top      int           `yaml:"top,omitempty"` // number of entries in the top lists, default 5
+}
+
+// DigestEntry is a value and the number of alerts it appears in
+type DigestEntry struct {
+	Value string
+	Count int
+}
+
+// Digest summarizes the alerts received by a plugin during an interval
+type Digest struct {
+	Since         time.Time
+	Until         time.Time
+	AlertCount    int
+	DecisionCount int
+	TopScenarios  []DigestEntry
+	TopSources    []DigestEntry
+	TopCountries  []DigestEntry
+	TopAS         []DigestEntry
+	Alerts        []*models.Alert
+}
+
+func validateDigest(cfg *DigestConfig) error {
+	if cfg.Interval <= 0 {
+		return errors.New("digest interval must be positive")
+	}
+
+	if cfg.Top < 0 {
+		return errors.New("digest top can't be negative")
+	}
+
+	if cfg.Top == 0 {
+		cfg.Top = defaultDigestTop
+	}
+
+	return nil
+}
+
+// topEntries sorts the counters by decreasing count, then value, and keeps the first n
+func topEntries(counts map[string]int, n int) []DigestEntry {
+	entries := make([]DigestEntry, 0, len(counts))
+
+	for value, count := range counts {
+		entries = append(entries, DigestEntry{Value: value, Count: count})
+	}
+
+	slices.SortFunc(entries, func(a, b DigestEntry) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Value, b.Value)
+	})
+
+	if len(entries) > n {
+		entries = entries[:n]
+	}
+
+	return entries
+}
+
+func NewDigest(alerts []*models.Alert, cfg *DigestConfig, until time.Time) *Digest {
+	scenarios := map[string]int{}
+	sources := map[string]int{}
+	countries := map[string]int{}
+	asns := map[string]int{}
+
+	digest := &Digest{
+		Since:      until.Add(-cfg.Interval),
+		Until:      until,
+		AlertCount: len(alerts),
+		Alerts:     alerts,
+	}
+
+	for _, alert := range alerts {
+		digest.DecisionCount += len(alert.Decisions)
+
+		if alert.Scenario != nil {
+			scenarios[*alert.Scenario]++
+		}
+
+		if alert.Source == nil {
+			continue
+		}
+
+		if alert.Source.Value != nil {
+			sources[*alert.Source.Value]++
+		}
+
+		if alert.Source.Cn != "" {
+			countries[alert.Source.Cn]++
+		}
+
+		if alert.Source.AsNumber != "" {
+			asns[strings.TrimSpace(alert.Source.AsNumber+" "+alert.Source.AsName)]++
+		}
+	}
+
+	digest.TopScenarios = topEntries(scenarios, cfg.Top)
+	digest.TopSources = topEntries(sources, cfg.Top)
+	digest.TopCountries = topEntries(countries, cfg.Top)
+	digest.TopAS = topEntries(asns, cfg.Top)
+
+	return digest
+}
+
+func FormatDigest(format string, digest *Digest) (string, error) {
+	template, err := template.New("").Funcs(sprig.TxtFuncMap()).Funcs(funcMap()).Parse(format)
+	if err != nil {
+		return "", err
+	}
+
+	b := new(strings.Builder)
+
+	if err := template.Execute(b, digest); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,51 @@
+package csplugin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+func TestDigest(t *testing.T) {
+	cfg := &DigestConfig{Interval: time.Hour}
+	require.NoError(t, validateDigest(cfg))
+	assert.Equal(t, defaultDigestTop, cfg.Top)
+
+	cfg.Top = 2
+
+	alert := func(scenario string, ip string, cn string) *models.Alert {
+		return &models.Alert{
+			Scenario:  ptr.Of(scenario),
+			Source:    &models.Source{Scope: ptr.Of("Ip"), Value: ptr.Of(ip), Cn: cn, AsNumber: "1234", AsName: "ACME"},
+			Decisions: []*models.Decision{{}},
+		}
+	}
+
+	alerts := []*models.Alert{
+		alert("crowdsecurity/ssh-bf", "1.2.3.4", "FR"),
+		alert("crowdsecurity/ssh-bf", "1.2.3.5", "FR"),
+		alert("crowdsecurity/http-probing", "1.2.3.4", "DE"),
+		alert("crowdsecurity/http-crawl", "1.2.3.6", "US"),
+	}
+
+	until := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	digest := NewDigest(alerts, cfg, until)
+
+	assert.Equal(t, 4, digest.AlertCount)
+	assert.Equal(t, 4, digest.DecisionCount)
+	assert.Equal(t, until.Add(-time.Hour), digest.Since)
+	assert.Equal(t, []DigestEntry{{"crowdsecurity/ssh-bf", 2}, {"crowdsecurity/http-crawl", 1}}, digest.TopScenarios)
+	assert.Equal(t, []DigestEntry{{"1.2.3.4", 2}, {"1.2.3.5", 1}}, digest.TopSources)
+	assert.Equal(t, []DigestEntry{{"FR", 2}, {"DE", 1}}, digest.TopCountries)
+	assert.Equal(t, []DigestEntry{{"1234 ACME", 4}}, digest.TopAS)
+
+	message, err := FormatDigest(`{{.AlertCount}} alerts{{range .TopScenarios}}, {{.Value}}={{.Count}}{{end}}`, digest)
+	require.NoError(t, err)
+	assert.Equal(t, "4 alerts, crowdsecurity/ssh-bf=2, crowdsecurity/http-crawl=1", message)
+}
@@ -0,0 +1,71 @@
+package csplugin
+
+import (
+	"fmt"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdsecurity/crowdsec/pkg/exprhelpers"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+// compileFilters prepares the filters of the plugins that have some
+func (pb *PluginBroker) compileFilters() error {
+	for name, pc := range pb.pluginConfigByName {
+		if len(pc.Filters) == 0 {
+			continue
+		}
+
+		programs := make([]*vm.Program, 0, len(pc.Filters))
+
+		for _, filter := range pc.Filters {
+			program, err := expr.Compile(filter, exprhelpers.GetExprOptions(map[string]interface{}{"Alert": &models.Alert{}})...)
+			if err != nil {
+				return fmt.Errorf("notification %s: error compiling filter '%s': %w", name, filter, err)
+			}
+
+			programs = append(programs, program)
+		}
+
+		pb.filtersByPluginName[name] = programs
+	}
+
+	return nil
+}
+
+// acceptsAlert tells if the alert matches one of the filters of the plugin, like the filters of a profile.
+// A plugin without filters accepts all the alerts of its profiles. A filter that fails to run, or doesn't
+// return a bool, rejects the alert: a broken filter must not relay the alerts it was meant to keep out.
+func (pb *PluginBroker) acceptsAlert(pluginName string, alert *models.Alert) bool {
+	programs, ok := pb.filtersByPluginName[pluginName]
+	if !ok {
+		return true
+	}
+
+	logger := log.WithField("plugin", pluginName)
+	filters := pb.pluginConfigByName[pluginName].Filters
+
+	for idx, program := range programs {
+		output, err := exprhelpers.Run(program, map[string]interface{}{"Alert": alert}, logger, false)
+		if err != nil {
+			logger.Errorf("while running filter '%s', alert %s rejected: %s", filters[idx], alert.GetScenario(), err)
+			return false
+		}
+
+		matched, ok := output.(bool)
+		if !ok {
+			logger.Errorf("filter '%s' returned %T instead of bool, alert %s rejected", filters[idx], output, alert.GetScenario())
+			return false
+		}
+
+		if matched {
+			return true
+		}
+	}
+
+	logger.Debugf("alert %s doesn't match any filter", alert.GetScenario())
+
+	return false
+}
@@ -0,0 +1,82 @@
+package csplugin
+
+import (
+	"testing"
+
+	"github.com/expr-lang/expr/vm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+func newFilterBroker(t *testing.T, filters ...string) *PluginBroker {
+	pb := &PluginBroker{
+		pluginConfigByName:  map[string]PluginConfig{"security": {Name: "security", Filters: filters}},
+		filtersByPluginName: make(map[string][]*vm.Program),
+	}
+
+	require.NoError(t, pb.compileFilters())
+
+	return pb
+}
+
+func TestCompileFilters(t *testing.T) {
+	pb := &PluginBroker{
+		pluginConfigByName:  map[string]PluginConfig{"security": {Name: "security", Filters: []string{"Alert.Nope"}}},
+		filtersByPluginName: make(map[string][]*vm.Program),
+	}
+
+	err := pb.compileFilters()
+	cstest.RequireErrorContains(t, err, "notification security: error compiling filter 'Alert.Nope'")
+}
+
+func TestAcceptsAlert(t *testing.T) {
+	alert := func(scenario string, decisionType string, origin string, cn string) *models.Alert {
+		a := &models.Alert{
+			Scenario: ptr.Of(scenario),
+			Source:   &models.Source{Scope: ptr.Of("Ip"), Value: ptr.Of("1.2.3.4"), Cn: cn},
+		}
+
+		if decisionType != "" {
+			a.Decisions = []*models.Decision{{Type: ptr.Of(decisionType), Origin: ptr.Of(origin)}}
+		}
+
+		return a
+	}
+
+	sshBan := alert("crowdsecurity/ssh-bf", "ban", "crowdsec", "FR")
+	httpCaptcha := alert("crowdsecurity/http-probing", "captcha", "crowdsec", "US")
+	manual := alert("manual 'ban' from 'localhost'", "ban", "cscli", "")
+	noDecision := alert("crowdsecurity/ssh-slow-bf", "", "", "DE")
+
+	tests := []struct {
+		name     string
+		filters  []string
+		expected []bool // sshBan, httpCaptcha, manual, noDecision
+	}{
+		{"no filter", nil, []bool{true, true, true, true}},
+		{"severity", []string{`Alert.GetSeverity() == "high"`}, []bool{true, false, true, false}},
+		{"scenario glob", []string{`Match("crowdsecurity/ssh-*", Alert.GetScenario())`}, []bool{true, false, false, true}},
+		{"origin", []string{`Alert.GetOrigin() == "cscli"`}, []bool{false, false, true, false}},
+		{"scope", []string{`Alert.GetScope() == "Ip"`}, []bool{true, true, true, true}},
+		{"country", []string{`Alert.GetCountry() in ["FR", "DE"]`}, []bool{true, false, false, true}},
+		{"any filter", []string{`Alert.GetCountry() == "US"`, `Alert.GetOrigin() == "cscli"`}, []bool{false, true, true, false}},
+		// a broken filter rejects the alerts
+		{"not a bool", []string{`Alert.GetScenario()`}, []bool{false, false, false, false}},
+		{"runtime error", []string{`Alert.GetScenario() matches Alert.GetScenario() + "("`}, []bool{false, false, false, false}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pb := newFilterBroker(t, tc.filters...)
+
+			for i, a := range []*models.Alert{sshBan, httpCaptcha, manual, noDecision} {
+				assert.Equal(t, tc.expected[i], pb.acceptsAlert("security", a), "alert %d", i)
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package csplugin
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var NotificationsQueued = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cs_lapi_notifications_queued_total",
+		Help: "Number of notifications added to the delivery queue, per plugin.",
+	},
+	[]string{"plugin"},
+)
+
+var NotificationsDelivered = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cs_lapi_notifications_delivered_total",
+		Help: "Number of notifications delivered, per plugin.",
+	},
+	[]string{"plugin"},
+)
+
+var NotificationsFailed = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cs_lapi_notifications_failed_total",
+		Help: "Number of notifications that ran out of retries, per plugin.",
+	},
+	[]string{"plugin"},
+)
+
+var NotificationsSuppressed = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "cs_lapi_notifications_suppressed_total",
+		Help: "Number of alerts not notified because of deduplication, per plugin.",
+	},
+	[]string{"plugin"},
+)
@@ -0,0 +1,150 @@
+package csplugin
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/tomb.v2"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+const (
+	queuePollInterval = 5 * time.Second
+	queueBatchSize    = 100
+	queueMaxBackoff   = time.Hour
+)
+
+// NotificationQueue stores the notifications until they are delivered, so they survive a restart of the local API
+type NotificationQueue interface {
+	EnqueueNotification(ctx context.Context, plugin string, message string, alertCount int) (*ent.Notification, error)
+	PendingNotifications(ctx context.Context, now time.Time, limit int) ([]*ent.Notification, error)
+	ClaimNotification(ctx context.Context, id int, now time.Time) (bool, error)
+	MarkNotificationDelivered(ctx context.Context, id int) error
+	RecordNotificationFailure(ctx context.Context, id int, lastError string, nextAttempt *time.Time) error
+}
+
+// SetQueue enables the durable delivery of notifications. It must be called before Run.
+func (pb *PluginBroker) SetQueue(queue NotificationQueue) {
+	pb.queue = queue
+	pb.queueSignal = make(chan struct{}, 1)
+}
+
+// retryBackoff returns the delay before the next attempt, after the given number of failed attempts
+func retryBackoff(attempts int) time.Duration {
+	backoff := time.Second << min(max(attempts-1, 0), 12)
+
+	return min(backoff, queueMaxBackoff)
+}
+
+// enqueueNotification formats the alerts and stores the resulting message, to be delivered by runQueue
+func (pb *PluginBroker) enqueueNotification(ctx context.Context, pluginName string, alerts []*models.Alert) error {
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	message, err := pb.formatMessage(pluginName, alerts)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pb.queue.EnqueueNotification(ctx, pluginName, message, len(alerts)); err != nil {
+		return err
+	}
+
+	NotificationsQueued.With(prometheus.Labels{"plugin": pluginName}).Inc()
+
+	select {
+	case pb.queueSignal <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// runQueue delivers the pending notifications until the tomb is dying
+func (pb *PluginBroker) runQueue(ctx context.Context, t *tomb.Tomb) {
+	ticker := time.NewTicker(queuePollInterval)
+	defer ticker.Stop()
+
+	for {
+		pb.deliverPending(ctx, t)
+
+		select {
+		case <-t.Dying():
+			return
+		case <-ticker.C:
+		case <-pb.queueSignal:
+		}
+	}
+}
+
+// deliverPending makes one attempt for each notification that is due. Failed notifications are
+// rescheduled with an exponential backoff, until they reach max_retry attempts.
+func (pb *PluginBroker) deliverPending(ctx context.Context, t *tomb.Tomb) {
+	now := time.Now().UTC()
+
+	pending, err := pb.queue.PendingNotifications(ctx, now, queueBatchSize)
+	if err != nil {
+		log.Errorf("while reading the notification queue: %s", err)
+		return
+	}
+
+	for _, n := range pending {
+		if !t.Alive() {
+			return
+		}
+
+		logger := log.WithField("plugin", n.Plugin)
+
+		claimed, err := pb.queue.ClaimNotification(ctx, n.ID, time.Now().UTC())
+		if err != nil {
+			logger.Error(err)
+			continue
+		}
+
+		if !claimed {
+			logger.Debugf("notification %d is already being delivered", n.ID)
+			continue
+		}
+
+		_, configured := pb.notificationPluginByName[n.Plugin]
+		if configured {
+			err = pb.tryNotify(ctx, n.Plugin, n.Message)
+		} else {
+			err = fmt.Errorf("plugin %s is not configured", n.Plugin)
+		}
+
+		if err == nil {
+			NotificationsDelivered.With(prometheus.Labels{"plugin": n.Plugin}).Inc()
+
+			if err := pb.queue.MarkNotificationDelivered(ctx, n.ID); err != nil {
+				logger.Error(err)
+			}
+
+			continue
+		}
+
+		attempts := n.Attempts + 1
+
+		var nextAttempt *time.Time
+
+		if configured && attempts < pb.pluginConfigByName[n.Plugin].MaxRetry {
+			nextAttempt = ptr.Of(now.Add(retryBackoff(attempts)))
+			logger.Errorf("%s error, retry num %d at %s", err, attempts, nextAttempt.Format(time.RFC3339))
+		} else {
+			NotificationsFailed.With(prometheus.Labels{"plugin": n.Plugin}).Inc()
+			logger.Errorf("%s error, giving up notification %d after %d attempt(s)", err, n.ID, attempts)
+		}
+
+		if err := pb.queue.RecordNotificationFailure(ctx, n.ID, err.Error(), nextAttempt); err != nil {
+			logger.Error(err)
+		}
+	}
+}
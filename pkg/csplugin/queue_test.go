@@ -0,0 +1,141 @@
+package csplugin
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/tomb.v2"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+type fakeNotifier struct {
+	protobufs.UnimplementedNotifierServer
+	fail     bool
+	received []string
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, n *protobufs.Notification) (*protobufs.Empty, error) {
+	if f.fail {
+		return nil, errors.New("service unavailable")
+	}
+
+	f.received = append(f.received, n.Text)
+
+	return &protobufs.Empty{}, nil
+}
+
+func TestRetryBackoff(t *testing.T) {
+	assert.Equal(t, time.Second, retryBackoff(1))
+	assert.Equal(t, 2*time.Second, retryBackoff(2))
+	assert.Equal(t, 8*time.Second, retryBackoff(4))
+	assert.Equal(t, time.Hour, retryBackoff(20))
+}
+
+func TestNotificationQueue(t *testing.T) {
+	ctx := t.Context()
+
+	db, err := database.NewClient(ctx, &csconfig.DatabaseCfg{
+		Type:   "sqlite",
+		DbName: "crowdsec",
+		DbPath: ":memory:",
+	})
+	require.NoError(t, err)
+
+	notifier := &fakeNotifier{fail: true}
+
+	pb := PluginBroker{
+		pluginConfigByName: map[string]PluginConfig{
+			"dummy": {Name: "dummy", Format: "{{len .}} alert(s)", MaxRetry: 2, TimeOut: time.Second},
+		},
+		notificationPluginByName: map[string]protobufs.NotifierServer{"dummy": notifier},
+	}
+	pb.SetQueue(db)
+
+	err = pb.enqueueNotification(ctx, "dummy", []*models.Alert{{}, {}})
+	require.NoError(t, err)
+
+	alive := &tomb.Tomb{}
+
+	queued, err := db.ListNotifications(ctx, database.NotificationFilter{})
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+
+	// another local API claimed it first
+	claimed, err := db.ClaimNotification(ctx, queued[0].ID, time.Now().UTC())
+	require.NoError(t, err)
+	assert.True(t, claimed)
+
+	claimed, err = db.ClaimNotification(ctx, queued[0].ID, time.Now().UTC())
+	require.NoError(t, err)
+	assert.False(t, claimed)
+
+	pb.deliverPending(ctx, alive)
+
+	queued, err = db.ListNotifications(ctx, database.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StatusSending, queued[0].Status)
+	assert.Equal(t, 0, queued[0].Attempts)
+
+	// and crashed: the claim expires
+	_, err = db.Ent.Notification.UpdateOneID(queued[0].ID).SetUpdatedAt(time.Now().UTC().Add(-time.Hour)).Save(ctx)
+	require.NoError(t, err)
+
+	// first attempt fails, the notification is rescheduled
+	pb.deliverPending(ctx, alive)
+
+	queued, err = db.ListNotifications(ctx, database.NotificationFilter{})
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.Equal(t, notification.StatusPending, queued[0].Status)
+	assert.Equal(t, 1, queued[0].Attempts)
+	assert.Equal(t, 2, queued[0].AlertCount)
+	assert.Equal(t, "service unavailable", queued[0].LastError)
+	assert.True(t, queued[0].NextAttemptAt.After(time.Now().UTC()))
+
+	// not due yet
+	pb.deliverPending(ctx, alive)
+
+	queued, err = db.ListNotifications(ctx, database.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, queued[0].Attempts)
+
+	// max_retry is reached, the notification is dead-lettered
+	_, err = db.Ent.Notification.UpdateOneID(queued[0].ID).SetNextAttemptAt(time.Now().UTC().Add(-time.Second)).Save(ctx)
+	require.NoError(t, err)
+
+	pb.deliverPending(ctx, alive)
+
+	queued, err = db.ListNotifications(ctx, database.NotificationFilter{})
+	require.NoError(t, err)
+	assert.Equal(t, notification.StatusFailed, queued[0].Status)
+	assert.Equal(t, 2, queued[0].Attempts)
+
+	// retried by hand once the service is back
+	nb, err := db.RetryNotifications(ctx, database.NotificationFilter{Statuses: []notification.Status{notification.StatusFailed}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, nb)
+
+	notifier.fail = false
+
+	pb.deliverPending(ctx, alive)
+
+	assert.Equal(t, []string{"2 alert(s)"}, notifier.received)
+
+	queued, err = db.ListNotifications(ctx, database.NotificationFilter{Statuses: []notification.Status{notification.StatusDelivered}})
+	require.NoError(t, err)
+	require.Len(t, queued, 1)
+	assert.NotNil(t, queued[0].DeliveredAt)
+
+	nb, err = db.PurgeNotifications(ctx, database.NotificationFilter{Statuses: []notification.Status{notification.StatusDelivered}})
+	require.NoError(t, err)
+	assert.Equal(t, 1, nb)
+}
@@ -0,0 +1,177 @@
+package csprofiles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+)
+
+const defaultAggregationWindow = time.Hour
+
+// Aggregation is the compiled form of csconfig.AggregationCfg
+type Aggregation struct {
+	Window           time.Duration
+	Duration         string
+	ExpireIndividual bool
+	IPv4             []csconfig.AggregationThreshold
+	IPv6             []csconfig.AggregationThreshold
+}
+
+// AggregationTarget is a network that may be banned instead of the addresses it contains
+type AggregationTarget struct {
+	Network   *net.IPNet
+	Threshold int
+}
+
+func validateThresholds(thresholds []csconfig.AggregationThreshold, maxPrefix int) error {
+	for _, t := range thresholds {
+		if t.Prefix <= 0 || t.Prefix >= maxPrefix {
+			return fmt.Errorf("invalid prefix length %d: must be between 1 and %d", t.Prefix, maxPrefix-1)
+		}
+
+		if t.Threshold < 2 {
+			return fmt.Errorf("invalid threshold %d for prefix /%d: must be at least 2", t.Threshold, t.Prefix)
+		}
+	}
+
+	return nil
+}
+
+func newAggregation(cfg *csconfig.AggregationCfg) (*Aggregation, error) {
+	agg := &Aggregation{
+		Window:           defaultAggregationWindow,
+		ExpireIndividual: cfg.ExpireIndividual,
+		IPv4:             cfg.IPv4,
+		IPv6:             cfg.IPv6,
+	}
+
+	if cfg.Window != "" {
+		window, err := time.ParseDuration(cfg.Window)
+		if err != nil {
+			return nil, fmt.Errorf("invalid window '%s': %w", cfg.Window, err)
+		}
+
+		agg.Window = window
+	}
+
+	if cfg.Duration != nil {
+		if _, err := time.ParseDuration(*cfg.Duration); err != nil {
+			return nil, fmt.Errorf("invalid duration '%s': %w", *cfg.Duration, err)
+		}
+
+		agg.Duration = *cfg.Duration
+	}
+
+	if len(cfg.IPv4) == 0 && len(cfg.IPv6) == 0 {
+		return nil, errors.New("at least one ipv4 or ipv6 threshold is required")
+	}
+
+	if err := validateThresholds(cfg.IPv4, 32); err != nil {
+		return nil, fmt.Errorf("ipv4: %w", err)
+	}
+
+	if err := validateThresholds(cfg.IPv6, 128); err != nil {
+		return nil, fmt.Errorf("ipv6: %w", err)
+	}
+
+	return agg, nil
+}
+
+// Targets returns the networks containing the address of an Ip decision, for each configured prefix length
+func (a *Aggregation) Targets(value string) []AggregationTarget {
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil
+	}
+
+	bits := 128
+	thresholds := a.IPv6
+
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		bits = 32
+		thresholds = a.IPv4
+	}
+
+	ret := make([]AggregationTarget, 0, len(thresholds))
+
+	for _, t := range thresholds {
+		mask := net.CIDRMask(t.Prefix, bits)
+		ret = append(ret, AggregationTarget{
+			Network:   &net.IPNet{IP: ip.Mask(mask), Mask: mask},
+			Threshold: t.Threshold,
+		})
+	}
+
+	return ret
+}
+
+// NewAggregatedAlert builds the alert holding the Range decision that replaces the Ip decisions on the given values.
+// The aggregated values and decision IDs are kept in the alert meta for traceability.
+func (profile *Runtime) NewAggregatedAlert(source *models.Alert, ref *models.Decision, network *net.IPNet, values []string, decisionIDs []int) (*models.Alert, error) {
+	cidr := network.String()
+
+	jsonValues, err := json.Marshal(values)
+	if err != nil {
+		return nil, err
+	}
+
+	jsonIDs, err := json.Marshal(decisionIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	duration := profile.Aggregation.Duration
+	if duration == "" {
+		duration = *ref.Duration
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	message := fmt.Sprintf("%d Ip decisions (%s) aggregated into %s by profile %s", len(values), *ref.Type, cidr, profile.Cfg.Name)
+
+	decision := &models.Decision{
+		Duration:  ptr.Of(duration),
+		Origin:    ptr.Of(*ref.Origin),
+		Scenario:  ptr.Of(*ref.Scenario),
+		Scope:     ptr.Of(types.Range),
+		Type:      ptr.Of(*ref.Type),
+		Value:     ptr.Of(cidr),
+		Simulated: ptr.Of(false),
+	}
+
+	return &models.Alert{
+		Capacity:        ptr.Of(int32(0)),
+		Decisions:       []*models.Decision{decision},
+		Events:          []*models.Event{},
+		EventsCount:     ptr.Of(int32(len(values))),
+		Leakspeed:       ptr.Of("0"),
+		Message:         &message,
+		ScenarioHash:    ptr.Of(""),
+		Scenario:        ptr.Of(*source.Scenario),
+		ScenarioVersion: ptr.Of(""),
+		Simulated:       ptr.Of(false),
+		Source: &models.Source{
+			Scope: ptr.Of(types.Range),
+			Value: ptr.Of(cidr),
+			Range: cidr,
+		},
+		StartAt:     ptr.Of(now),
+		StopAt:      ptr.Of(now),
+		CreatedAt:   now,
+		Remediation: true,
+		Meta: models.Meta{
+			{Key: "aggregation_profile", Value: profile.Cfg.Name},
+			{Key: "aggregated_values", Value: string(jsonValues)},
+			{Key: "aggregated_decisions", Value: string(jsonIDs)},
+			{Key: "aggregation_trigger", Value: strings.Join([]string{*source.Scenario, *ref.Value}, " on ")},
+		},
+	}, nil
+}
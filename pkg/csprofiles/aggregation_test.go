@@ -0,0 +1,129 @@
+package csprofiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestNewAggregation(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         csconfig.AggregationCfg
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg: csconfig.AggregationCfg{
+				Window:   "10m",
+				Duration: ptr.Of("24h"),
+				IPv4:     []csconfig.AggregationThreshold{{Prefix: 24, Threshold: 10}},
+				IPv6:     []csconfig.AggregationThreshold{{Prefix: 64, Threshold: 5}},
+			},
+		},
+		{
+			name:        "no threshold",
+			cfg:         csconfig.AggregationCfg{},
+			expectedErr: "at least one ipv4 or ipv6 threshold is required",
+		},
+		{
+			name: "bad window",
+			cfg: csconfig.AggregationCfg{
+				Window: "1 hour",
+				IPv4:   []csconfig.AggregationThreshold{{Prefix: 24, Threshold: 10}},
+			},
+			expectedErr: "invalid window '1 hour'",
+		},
+		{
+			name: "bad ipv4 prefix",
+			cfg: csconfig.AggregationCfg{
+				IPv4: []csconfig.AggregationThreshold{{Prefix: 32, Threshold: 10}},
+			},
+			expectedErr: "ipv4: invalid prefix length 32: must be between 1 and 31",
+		},
+		{
+			name: "bad threshold",
+			cfg: csconfig.AggregationCfg{
+				IPv6: []csconfig.AggregationThreshold{{Prefix: 64, Threshold: 1}},
+			},
+			expectedErr: "ipv6: invalid threshold 1 for prefix /64: must be at least 2",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newAggregation(&tc.cfg)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestAggregationTargets(t *testing.T) {
+	agg, err := newAggregation(&csconfig.AggregationCfg{
+		IPv4: []csconfig.AggregationThreshold{{Prefix: 24, Threshold: 10}, {Prefix: 16, Threshold: 100}},
+		IPv6: []csconfig.AggregationThreshold{{Prefix: 64, Threshold: 5}},
+	})
+	require.NoError(t, err)
+
+	targets := agg.Targets("1.2.3.4")
+	require.Len(t, targets, 2)
+	assert.Equal(t, "1.2.3.0/24", targets[0].Network.String())
+	assert.Equal(t, 10, targets[0].Threshold)
+	assert.Equal(t, "1.2.0.0/16", targets[1].Network.String())
+
+	targets = agg.Targets("2001:db8:1:2:3::4")
+	require.Len(t, targets, 1)
+	assert.Equal(t, "2001:db8:1:2::/64", targets[0].Network.String())
+
+	assert.Empty(t, agg.Targets("not an ip"))
+}
+
+func TestNewAggregatedAlert(t *testing.T) {
+	profiles, err := NewProfile([]*csconfig.ProfileCfg{{
+		Name:    "aggregate",
+		Filters: []string{"true"},
+		Aggregation: &csconfig.AggregationCfg{
+			IPv4: []csconfig.AggregationThreshold{{Prefix: 24, Threshold: 2}},
+		},
+	}})
+	require.NoError(t, err)
+
+	source := &models.Alert{Scenario: ptr.Of("crowdsecurity/ssh-bf")}
+	ref := &models.Decision{
+		Duration: ptr.Of("4h"),
+		Origin:   ptr.Of(types.CrowdSecOrigin),
+		Scenario: ptr.Of("crowdsecurity/ssh-bf"),
+		Scope:    ptr.Of(types.Ip),
+		Type:     ptr.Of("ban"),
+		Value:    ptr.Of("1.2.3.5"),
+	}
+
+	target := profiles[0].Aggregation.Targets("1.2.3.5")[0]
+
+	alert, err := profiles[0].NewAggregatedAlert(source, ref, target.Network, []string{"1.2.3.4", "1.2.3.5"}, []int{1, 2})
+	require.NoError(t, err)
+
+	require.Len(t, alert.Decisions, 1)
+	assert.Equal(t, "1.2.3.0/24", *alert.Decisions[0].Value)
+	assert.Equal(t, types.Range, *alert.Decisions[0].Scope)
+	assert.Equal(t, "4h", *alert.Decisions[0].Duration)
+	assert.Equal(t, "ban", *alert.Decisions[0].Type)
+	assert.Equal(t, "2 Ip decisions (ban) aggregated into 1.2.3.0/24 by profile aggregate", *alert.Message)
+
+	meta := map[string]string{}
+	for _, m := range alert.Meta {
+		meta[m.Key] = m.Value
+	}
+
+	assert.Equal(t, `["1.2.3.4","1.2.3.5"]`, meta["aggregated_values"])
+	assert.Equal(t, `[1,2]`, meta["aggregated_decisions"])
+	assert.Equal(t, "aggregate", meta["aggregation_profile"])
+}
@@ -1,6 +1,7 @@
 package csprofiles
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -20,6 +21,9 @@ type Runtime struct {
 	RuntimeDurationExpr *vm.Program          `json:"-" yaml:"-"`
 	Cfg                 *csconfig.ProfileCfg `json:"-" yaml:"-"`
 	Logger              *log.Entry           `json:"-" yaml:"-"`
+	Aggregation         *Aggregation         `json:"-" yaml:"-"`
+	Escalation          *Escalation          `json:"-" yaml:"-"`
+	DecisionHistory     DecisionHistory      `json:"-" yaml:"-"`
 }
 
 const defaultDuration = "4h"
@@ -74,8 +78,24 @@ func NewProfile(profilesCfg []*csconfig.ProfileCfg) ([]*Runtime, error) {
 			runtime.RuntimeDurationExpr = runtimeDurationExpr
 		}
 
+		if profile.Aggregation != nil {
+			if runtime.Aggregation, err = newAggregation(profile.Aggregation); err != nil {
+				return nil, fmt.Errorf("invalid aggregation of %s: %w", profile.Name, err)
+			}
+		}
+
+		if profile.Escalation != nil {
+			if profile.DurationExpr != "" {
+				return nil, fmt.Errorf("duration_expr and escalation are mutually exclusive in %s", profile.Name)
+			}
+
+			if runtime.Escalation, err = newEscalation(profile.Escalation); err != nil {
+				return nil, fmt.Errorf("invalid escalation of %s: %w", profile.Name, err)
+			}
+		}
+
 		for _, decision := range profile.Decisions {
-			if runtime.RuntimeDurationExpr == nil {
+			if runtime.RuntimeDurationExpr == nil && runtime.Escalation == nil {
 				var duration string
 				if decision.Duration != nil {
 					duration = *decision.Duration
@@ -96,9 +116,20 @@ func NewProfile(profilesCfg []*csconfig.ProfileCfg) ([]*Runtime, error) {
 	return profilesRuntime, nil
 }
 
-func (profile *Runtime) GenerateDecisionFromProfile(alert *models.Alert) ([]*models.Decision, error) {
+func (profile *Runtime) GenerateDecisionFromProfile(ctx context.Context, alert *models.Alert) ([]*models.Decision, error) {
 	var decisions []*models.Decision
 
+	escalationStep := -1
+
+	if profile.Escalation != nil {
+		step, err := profile.escalationStep(ctx, *alert.Source.Value)
+		if err != nil {
+			profile.Logger.Warningf("Failed to compute escalation step : %v", err)
+		} else {
+			escalationStep = step
+		}
+	}
+
 	for _, refDecision := range profile.Cfg.Decisions {
 		decision := models.Decision{}
 		/*the reference decision from profile is in simulated mode */
@@ -144,6 +175,13 @@ func (profile *Runtime) GenerateDecisionFromProfile(alert *models.Alert) ([]*mod
 			}
 		}
 
+		if escalationStep >= 0 {
+			*decision.Duration = profile.Escalation.Durations[escalationStep]
+			decision.EscalationStep = int64(escalationStep + 1)
+		} else if profile.Escalation != nil && *decision.Duration == "" {
+			*decision.Duration = profile.Escalation.Durations[0]
+		}
+
 		decision.Type = new(string)
 		*decision.Type = *refDecision.Type
 
@@ -166,7 +204,7 @@ func (profile *Runtime) GenerateDecisionFromProfile(alert *models.Alert) ([]*mod
 }
 
 // EvaluateProfile is going to evaluate an Alert against a profile to generate Decisions
-func (profile *Runtime) EvaluateProfile(alert *models.Alert) ([]*models.Decision, bool, error) {
+func (profile *Runtime) EvaluateProfile(ctx context.Context, alert *models.Alert) ([]*models.Decision, bool, error) {
 	var decisions []*models.Decision
 
 	matched := false
@@ -188,7 +226,7 @@ func (profile *Runtime) EvaluateProfile(alert *models.Alert) ([]*models.Decision
 			if out {
 				matched = true
 				/*the expression matched, create the associated decision*/
-				subdecisions, err := profile.GenerateDecisionFromProfile(alert)
+				subdecisions, err := profile.GenerateDecisionFromProfile(ctx, alert)
 				if err != nil {
 					return nil, matched, fmt.Errorf("while generating decision from profile %s: %w", profile.Cfg.Name, err)
 				}
@@ -0,0 +1,113 @@
+package csprofiles
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+const defaultEscalationLookback = 30 * 24 * time.Hour
+
+// DecisionHistory gives access to the past decisions of the profiles on a value, to compute escalation steps.
+// The offenses are the alerts with decisions: the decisions from other origins (CAPI, lists, cscli...) are not counted.
+type DecisionHistory interface {
+	CountProfileAlertsSinceByValue(ctx context.Context, decisionValue string, since time.Time) (int, error)
+	GetLastProfileDecisionUntilByValue(ctx context.Context, decisionValue string) (*time.Time, error)
+}
+
+// Escalation is the compiled form of csconfig.EscalationCfg
+type Escalation struct {
+	Durations []string
+	Lookback  time.Duration
+	Decay     time.Duration
+}
+
+func newEscalation(cfg *csconfig.EscalationCfg) (*Escalation, error) {
+	esc := &Escalation{
+		Durations: make([]string, len(cfg.Durations)),
+		Lookback:  defaultEscalationLookback,
+	}
+
+	if len(cfg.Durations) == 0 {
+		return nil, errors.New("at least one duration is required")
+	}
+
+	for i, d := range cfg.Durations {
+		duration, err := types.ParseDuration(d)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration '%s': %w", d, err)
+		}
+
+		esc.Durations[i] = d
+
+		// the duration of the decisions is parsed with time.ParseDuration, which doesn't know about days
+		if _, err := time.ParseDuration(d); err != nil {
+			esc.Durations[i] = duration.String()
+		}
+	}
+
+	if cfg.Lookback != "" {
+		lookback, err := types.ParseDuration(cfg.Lookback)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lookback '%s': %w", cfg.Lookback, err)
+		}
+
+		esc.Lookback = lookback
+	}
+
+	if cfg.Decay != "" {
+		decay, err := types.ParseDuration(cfg.Decay)
+		if err != nil {
+			return nil, fmt.Errorf("invalid decay '%s': %w", cfg.Decay, err)
+		}
+
+		if decay <= 0 {
+			return nil, fmt.Errorf("invalid decay '%s': must be positive", cfg.Decay)
+		}
+
+		esc.Decay = decay
+	}
+
+	return esc, nil
+}
+
+// Step returns the index of the duration to apply, given the number of previous decisions
+// and the expiration of the last one (nil if there is none)
+func (e *Escalation) Step(previous int, lastUntil *time.Time, now time.Time) int {
+	step := previous
+
+	if e.Decay > 0 && lastUntil != nil && now.After(*lastUntil) {
+		step -= int(now.Sub(*lastUntil) / e.Decay)
+	}
+
+	return min(max(step, 0), len(e.Durations)-1)
+}
+
+// escalationStep looks up the decision history of the value to find the escalation step to apply
+func (profile *Runtime) escalationStep(ctx context.Context, value string) (int, error) {
+	if profile.DecisionHistory == nil {
+		return 0, errors.New("no decision history available")
+	}
+
+	now := time.Now().UTC()
+
+	previous, err := profile.DecisionHistory.CountProfileAlertsSinceByValue(ctx, value, now.Add(-profile.Escalation.Lookback))
+	if err != nil {
+		return 0, fmt.Errorf("while counting offenses: %w", err)
+	}
+
+	var lastUntil *time.Time
+
+	if previous > 0 && profile.Escalation.Decay > 0 {
+		lastUntil, err = profile.DecisionHistory.GetLastProfileDecisionUntilByValue(ctx, value)
+		if err != nil {
+			return 0, fmt.Errorf("while getting last decision: %w", err)
+		}
+	}
+
+	return profile.Escalation.Step(previous, lastUntil, now), nil
+}
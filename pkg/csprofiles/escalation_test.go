@@ -0,0 +1,139 @@
+package csprofiles
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+type fakeHistory struct {
+	count     int
+	lastUntil *time.Time
+}
+
+func (f *fakeHistory) CountProfileAlertsSinceByValue(_ context.Context, _ string, _ time.Time) (int, error) {
+	return f.count, nil
+}
+
+func (f *fakeHistory) GetLastProfileDecisionUntilByValue(_ context.Context, _ string) (*time.Time, error) {
+	return f.lastUntil, nil
+}
+
+func TestNewEscalation(t *testing.T) {
+	tests := []struct {
+		name        string
+		cfg         csconfig.EscalationCfg
+		expectedErr string
+	}{
+		{
+			name: "valid",
+			cfg:  csconfig.EscalationCfg{Durations: []string{"4h", "24h"}, Lookback: "720h", Decay: "168h"},
+		},
+		{
+			name: "days",
+			cfg:  csconfig.EscalationCfg{Durations: []string{"4h", "7d"}, Lookback: "30d", Decay: "7d"},
+		},
+		{
+			name:        "no duration",
+			cfg:         csconfig.EscalationCfg{},
+			expectedErr: "at least one duration is required",
+		},
+		{
+			name:        "bad duration",
+			cfg:         csconfig.EscalationCfg{Durations: []string{"4h", "7x"}},
+			expectedErr: "invalid duration '7x'",
+		},
+		{
+			name:        "bad decay",
+			cfg:         csconfig.EscalationCfg{Durations: []string{"4h"}, Decay: "-1h"},
+			expectedErr: "invalid decay '-1h': must be positive",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := newEscalation(&tc.cfg)
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestEscalationDays(t *testing.T) {
+	esc, err := newEscalation(&csconfig.EscalationCfg{Durations: []string{"4h", "7d"}, Lookback: "30d", Decay: "7d"})
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"4h", "168h0m0s"}, esc.Durations)
+	assert.Equal(t, 30*24*time.Hour, esc.Lookback)
+	assert.Equal(t, 7*24*time.Hour, esc.Decay)
+}
+
+func TestEscalationStep(t *testing.T) {
+	esc, err := newEscalation(&csconfig.EscalationCfg{
+		Durations: []string{"4h", "24h", "168h", "720h"},
+		Decay:     "168h",
+	})
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	week := 7 * 24 * time.Hour
+
+	assert.Equal(t, 0, esc.Step(0, nil, now))
+	assert.Equal(t, 1, esc.Step(1, ptr.Of(now.Add(time.Hour)), now))
+	assert.Equal(t, 3, esc.Step(10, ptr.Of(now.Add(-time.Hour)), now))
+	// two decay periods since the last decision expired
+	assert.Equal(t, 1, esc.Step(3, ptr.Of(now.Add(-2*week-time.Hour)), now))
+	assert.Equal(t, 0, esc.Step(1, ptr.Of(now.Add(-10*week)), now))
+}
+
+func TestGenerateDecisionWithEscalation(t *testing.T) {
+	_, err := NewProfile([]*csconfig.ProfileCfg{{
+		Name:         "both",
+		Filters:      []string{"true"},
+		DurationExpr: "'1h'",
+		Escalation:   &csconfig.EscalationCfg{Durations: []string{"4h"}},
+	}})
+	cstest.RequireErrorContains(t, err, "duration_expr and escalation are mutually exclusive in both")
+
+	profiles, err := NewProfile([]*csconfig.ProfileCfg{{
+		Name:       "escalate",
+		Filters:    []string{"true"},
+		Decisions:  []models.Decision{{Type: &typ}},
+		Escalation: &csconfig.EscalationCfg{Durations: []string{"4h", "24h", "168h"}},
+	}})
+	require.NoError(t, err)
+
+	history := &fakeHistory{}
+	profiles[0].DecisionHistory = history
+
+	alert := &models.Alert{
+		Scenario: ptr.Of(scenario),
+		Source:   &models.Source{Scope: ptr.Of("Ip"), Value: ptr.Of("1.2.3.4")},
+	}
+
+	for count, expected := range []string{"4h", "24h", "168h", "168h"} {
+		history.count = count
+
+		decisions, err := profiles[0].GenerateDecisionFromProfile(t.Context(), alert)
+		require.NoError(t, err)
+		require.Len(t, decisions, 1)
+		assert.Equal(t, expected, *decisions[0].Duration)
+		assert.Equal(t, int64(min(count, 2)+1), decisions[0].EscalationStep)
+	}
+
+	// without history, fall back to the first duration
+	profiles[0].DecisionHistory = nil
+
+	decisions, err := profiles[0].GenerateDecisionFromProfile(t.Context(), alert)
+	require.NoError(t, err)
+	assert.Equal(t, "4h", *decisions[0].Duration)
+	assert.Equal(t, int64(0), decisions[0].EscalationStep)
+}
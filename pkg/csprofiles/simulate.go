@@ -0,0 +1,160 @@
+package csprofiles
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// ProfileResult is the outcome of a matching profile during a simulation
+type ProfileResult struct {
+	Profile       string             `json:"profile"`
+	Decisions     []*models.Decision `json:"decisions,omitempty"`
+	Notifications []string           `json:"notifications,omitempty"`
+	Aggregation   bool               `json:"aggregation,omitempty"`
+	Error         string             `json:"error,omitempty"`
+}
+
+// SimulationResult describes what the local API would do with an alert, given a list of profiles
+type SimulationResult struct {
+	Matched []ProfileResult `json:"matched"`
+	// Decisions are the decisions that would be attached to the alert
+	Decisions []*models.Decision `json:"decisions"`
+	// Error is set when a profile error would make the local API reject the alert
+	Error string `json:"error,omitempty"`
+}
+
+// hasManualDecisions returns true if the alert carries decisions that were not generated by the profiles
+// (cscli, console...): they are kept as-is and the profiles are only evaluated for notifications
+func hasManualDecisions(alert *models.Alert) bool {
+	for _, decision := range alert.Decisions {
+		if decision.Origin == nil || !strings.HasPrefix(*decision.Origin, types.CrowdSecOrigin) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Simulate runs an alert through the profiles the same way the local API does when the alert is pushed
+// (see CreateAlert in pkg/apiserver/controllers/v1), without side effects. Decisions previously generated
+// by profiles are discarded, so that alerts read from the database can be replayed.
+func Simulate(ctx context.Context, profiles []*Runtime, alert *models.Alert) *SimulationResult {
+	result := &SimulationResult{
+		Matched: []ProfileResult{},
+	}
+
+	replay := *alert
+
+	if hasManualDecisions(alert) {
+		result.Decisions = alert.Decisions
+
+		// the errors are skipped whatever on_error says, the decisions are already there
+		for _, profile := range profiles {
+			_, matched, err := profile.EvaluateProfile(ctx, &replay)
+			if err != nil || !matched {
+				continue
+			}
+
+			result.Matched = append(result.Matched, ProfileResult{
+				Profile:       profile.Cfg.Name,
+				Notifications: profile.Cfg.Notifications,
+			})
+
+			if profile.Cfg.OnSuccess == "break" {
+				break
+			}
+		}
+
+		return result
+	}
+
+	replay.Decisions = nil
+
+	for _, profile := range profiles {
+		profileDecisions, matched, err := profile.EvaluateProfile(ctx, &replay)
+		forceBreak := false
+		profileErr := ""
+
+		if err != nil {
+			profileErr = err.Error()
+
+			switch profile.Cfg.OnError {
+			case "apply":
+				matched = true
+			case "continue", "ignore":
+			case "break":
+				forceBreak = true
+			default:
+				result.Error = fmt.Sprintf("profile %s: %s", profile.Cfg.Name, err)
+				result.Decisions = nil
+
+				return result
+			}
+		}
+
+		// a profile that doesn't match never stops the loop, even with on_error: break
+		if !matched {
+			continue
+		}
+
+		if len(result.Decisions) == 0 {
+			result.Decisions = profileDecisions
+		}
+
+		result.Matched = append(result.Matched, ProfileResult{
+			Profile:       profile.Cfg.Name,
+			Decisions:     profileDecisions,
+			Notifications: profile.Cfg.Notifications,
+			Aggregation:   profile.Aggregation != nil,
+			Error:         profileErr,
+		})
+
+		if profile.Cfg.OnSuccess == "break" || forceBreak {
+			break
+		}
+	}
+
+	return result
+}
+
+// Summary is a one-line description of the result, suitable for display and comparison
+func (r *SimulationResult) Summary() string {
+	if r.Error != "" {
+		return "error: " + r.Error
+	}
+
+	if len(r.Matched) == 0 {
+		return "no match"
+	}
+
+	parts := make([]string, 0, len(r.Matched)+len(r.Decisions))
+
+	names := make([]string, 0, len(r.Matched))
+	notifications := []string{}
+
+	for _, m := range r.Matched {
+		names = append(names, m.Profile)
+		notifications = append(notifications, m.Notifications...)
+	}
+
+	parts = append(parts, "profiles="+strings.Join(names, ","))
+
+	for _, d := range r.Decisions {
+		parts = append(parts, fmt.Sprintf("%s %s:%s %s", *d.Type, *d.Scope, *d.Value, *d.Duration))
+	}
+
+	if len(notifications) > 0 {
+		parts = append(parts, "notify="+strings.Join(notifications, ","))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// Changed returns true if the two results would lead to different decisions or notifications
+func (r *SimulationResult) Changed(other *SimulationResult) bool {
+	return r.Summary() != other.Summary()
+}
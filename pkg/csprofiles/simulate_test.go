@@ -0,0 +1,109 @@
+package csprofiles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+func TestSimulate(t *testing.T) {
+	current, err := NewProfile([]*csconfig.ProfileCfg{
+		{
+			Name:          "ip",
+			Filters:       []string{`Alert.GetScope() == "Ip"`},
+			Decisions:     []models.Decision{{Type: ptr.Of("ban"), Duration: ptr.Of("4h")}},
+			Notifications: []string{"slack_default"},
+			OnSuccess:     "break",
+		},
+		{
+			Name:      "fallback",
+			Filters:   []string{"true"},
+			Decisions: []models.Decision{{Type: ptr.Of("captcha"), Duration: ptr.Of("1h")}},
+		},
+	})
+	require.NoError(t, err)
+
+	candidate, err := NewProfile([]*csconfig.ProfileCfg{
+		{
+			Name:      "ip",
+			Filters:   []string{`Alert.GetScope() == "Ip"`},
+			Decisions: []models.Decision{{Type: ptr.Of("ban"), Duration: ptr.Of("24h")}},
+			OnSuccess: "continue",
+		},
+		{
+			Name:      "broken",
+			Filters:   []string{`Alert.GetValue()`},
+			OnError:   "continue",
+			OnSuccess: "break",
+		},
+		{
+			Name:          "notify",
+			Filters:       []string{"true"},
+			Notifications: []string{"http_default"},
+		},
+	})
+	require.NoError(t, err)
+
+	alert := &models.Alert{
+		Scenario: ptr.Of(scenario),
+		Source:   &models.Source{Scope: ptr.Of("Ip"), Value: ptr.Of("1.2.3.4")},
+		// decisions generated when the alert was first received, they are replaced
+		Decisions: []*models.Decision{{
+			Origin:   ptr.Of("crowdsec"),
+			Type:     ptr.Of("ban"),
+			Scope:    ptr.Of("Ip"),
+			Value:    ptr.Of("1.2.3.4"),
+			Duration: ptr.Of("4h"),
+		}},
+	}
+
+	before := Simulate(t.Context(), current, alert)
+	require.Empty(t, before.Error)
+	require.Len(t, before.Matched, 1)
+	assert.Equal(t, "ip", before.Matched[0].Profile)
+	assert.Equal(t, "profiles=ip ban Ip:1.2.3.4 4h notify=slack_default", before.Summary())
+
+	after := Simulate(t.Context(), candidate, alert)
+	require.Empty(t, after.Error)
+	require.Len(t, after.Matched, 2)
+	assert.Equal(t, "profiles=ip,notify ban Ip:1.2.3.4 24h notify=http_default", after.Summary())
+	assert.True(t, before.Changed(after))
+
+	// the original alert is left untouched
+	require.Len(t, alert.Decisions, 1)
+	assert.Equal(t, "4h", *alert.Decisions[0].Duration)
+
+	// manual decisions are kept, profiles only trigger notifications
+	alert.Decisions[0].Origin = ptr.Of("cscli")
+
+	manual := Simulate(t.Context(), candidate, alert)
+	assert.Equal(t, "profiles=ip,notify ban Ip:1.2.3.4 4h notify=http_default", manual.Summary())
+	assert.Empty(t, manual.Matched[0].Decisions)
+
+	// with manual decisions, the errors are skipped whatever on_error says
+	candidate[1].Cfg.OnError = ""
+
+	manual = Simulate(t.Context(), candidate, alert)
+	require.Empty(t, manual.Error)
+	assert.Equal(t, "profiles=ip,notify ban Ip:1.2.3.4 4h notify=http_default", manual.Summary())
+
+	// otherwise, an unhandled error makes the alert rejected
+	alert.Decisions[0].Origin = ptr.Of("crowdsec")
+
+	rejected := Simulate(t.Context(), candidate, alert)
+	assert.Contains(t, rejected.Summary(), "error: profile broken")
+	assert.Empty(t, rejected.Decisions)
+
+	// a profile breaking on error doesn't stop the loop when it doesn't match
+	candidate[1].Cfg.OnError = "break"
+
+	broken := Simulate(t.Context(), candidate, alert)
+	require.Empty(t, broken.Error)
+	assert.Equal(t, "profiles=ip,notify ban Ip:1.2.3.4 24h notify=http_default", broken.Summary())
+}
@@ -0,0 +1,279 @@
+// Package ctienrich adds the CTI reputation of the source to the alerts received by the local API.
+// The lookups are cached in the database, so an IP is only queried once per TTL.
+//
+// By default, the alerts are only enriched from the cache: the missing IPs are queued once the alerts
+// are stored, and looked up by a background worker, so the CTI never slows down the machines. The first
+// alert of an IP has no reputation then, and the profiles or notifications filtering on it miss it.
+// With synchronous lookups, the missing IPs are looked up while the alert is received instead, each
+// lookup waiting at most for the timeout of the client.
+package ctienrich
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/tomb.v2"
+
+	"github.com/crowdsecurity/crowdsec/pkg/cticlient"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// when hitting quotas, the lookups are suspended for a while
+const backOffDuration = 5 * time.Minute
+
+// ReputationUnknown is stored for the IPs the CTI knows nothing about
+const ReputationUnknown = "unknown"
+
+// the IPs to look up are dropped when the queue is full, they are queued again with their next alert
+const queueSize = 1000
+
+// Client is the part of cticlient.CrowdsecCTIClient used by the enricher
+type Client interface {
+	GetIPInfo(ip string) (*cticlient.SmokeItem, error)
+}
+
+// Store caches the lookups, it's implemented by database.Client
+type Store interface {
+	GetIPReputation(ctx context.Context, ip string, notBefore time.Time) (*models.CTIInfo, error)
+	SetIPReputation(ctx context.Context, ip string, info *models.CTIInfo) error
+}
+
+type Enricher struct {
+	client      Client
+	store       Store
+	ttl         time.Duration
+	synchronous bool
+	logger      *log.Entry
+
+	queue   chan string
+	t       tomb.Tomb
+	started bool
+
+	mu           sync.Mutex
+	pending      map[string]struct{} // the queued IPs, to look them up only once
+	disabled     bool                // the API key was refused
+	backOffUntil time.Time
+}
+
+func New(client Client, store Store, ttl time.Duration, synchronous bool, logger *log.Entry) *Enricher {
+	if logger == nil {
+		logger = log.WithField("module", "cti-enrichment")
+	}
+
+	return &Enricher{
+		client:      client,
+		store:       store,
+		ttl:         ttl,
+		synchronous: synchronous,
+		logger:      logger,
+		queue:       make(chan string, queueSize),
+		pending:     make(map[string]struct{}),
+	}
+}
+
+// Start looks up the queued IPs in the background, until Shutdown is called
+func (e *Enricher) Start(ctx context.Context) {
+	e.started = true
+
+	e.t.Go(func() error {
+		for {
+			select {
+			case <-e.t.Dying():
+				return nil
+			case ip := <-e.queue:
+				e.Lookup(ctx, ip)
+
+				e.mu.Lock()
+				delete(e.pending, ip)
+				e.mu.Unlock()
+			}
+		}
+	})
+}
+
+func (e *Enricher) Shutdown() {
+	// the tomb can't be waited for if it has no goroutine
+	if !e.started {
+		return
+	}
+
+	e.t.Kill(nil)
+
+	if err := e.t.Wait(); err != nil {
+		e.logger.Errorf("while stopping the enrichment: %s", err)
+	}
+}
+
+// FromSmoke keeps the fields of the CTI response that are useful in profiles and notifications
+func FromSmoke(item *cticlient.SmokeItem) *models.CTIInfo {
+	info := &models.CTIInfo{
+		Reputation:   item.Reputation,
+		Confidence:   item.Confidence,
+		IPRangeScore: int64(item.IpRangeScore),
+		FetchedAt:    time.Now().UTC().Format(time.RFC3339),
+	}
+
+	// the client returns an empty item for the IPs that are not found
+	if info.Reputation == "" {
+		info.Reputation = ReputationUnknown
+	}
+
+	if item.BackgroundNoiseScore != nil {
+		info.BackgroundNoiseScore = int64(*item.BackgroundNoiseScore)
+	}
+
+	for _, behavior := range item.Behaviors {
+		if behavior != nil {
+			info.Behaviors = append(info.Behaviors, behavior.Name)
+		}
+	}
+
+	for _, classification := range item.Classifications.Classifications {
+		info.Classifications = append(info.Classifications, classification.Name)
+	}
+
+	for _, fp := range item.Classifications.FalsePositives {
+		info.FalsePositives = append(info.FalsePositives, fp.Name)
+	}
+
+	return info
+}
+
+// available tells if the CTI can be queried, or is disabled or throttled
+func (e *Enricher) available() bool {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return !e.disabled && time.Now().After(e.backOffUntil)
+}
+
+func (e *Enricher) handleError(err error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	switch {
+	case errors.Is(err, cticlient.ErrUnauthorized):
+		e.disabled = true
+		e.logger.Errorf("invalid CTI API key, disabling the enrichment of alerts")
+	case errors.Is(err, cticlient.ErrLimit):
+		e.backOffUntil = time.Now().Add(backOffDuration)
+		e.logger.Errorf("CTI API is throttled, will try again in %s", backOffDuration)
+	default:
+		e.logger.Warningf("CTI API error: %s", err)
+	}
+}
+
+// Lookup returns the reputation of an IP, from the cache or the CTI. It returns nil
+// if the information is not available, the errors are only logged.
+func (e *Enricher) Lookup(ctx context.Context, ip string) *models.CTIInfo {
+	if info := e.cached(ctx, ip); info != nil {
+		return info
+	}
+
+	return e.fetch(ctx, ip)
+}
+
+// fetch queries the CTI and caches the result
+func (e *Enricher) fetch(ctx context.Context, ip string) *models.CTIInfo {
+	if !e.available() {
+		return nil
+	}
+
+	item, err := e.client.GetIPInfo(ip)
+	if err != nil {
+		e.handleError(err)
+		return nil
+	}
+
+	info := FromSmoke(item)
+
+	if err := e.store.SetIPReputation(ctx, ip, info); err != nil {
+		e.logger.Warningf("while caching the reputation of %s: %s", ip, err)
+	}
+
+	return info
+}
+
+func (e *Enricher) cached(ctx context.Context, ip string) *models.CTIInfo {
+	info, err := e.store.GetIPReputation(ctx, ip, time.Now().UTC().Add(-e.ttl))
+	if err != nil {
+		e.logger.Warningf("while reading the reputation of %s: %s", ip, err)
+	}
+
+	return info
+}
+
+// sourceIP returns the IP of the source of the alert, or an empty string for other scopes
+func sourceIP(alert *models.Alert) string {
+	if alert == nil || alert.Source == nil {
+		return ""
+	}
+
+	if !strings.EqualFold(alert.Source.GetScope(), types.Ip) {
+		return ""
+	}
+
+	if ip := alert.Source.GetValue(); ip != "" {
+		return ip
+	}
+
+	return alert.Source.IP
+}
+
+// Enrich sets the CTI information of the alert from the cache, or from the CTI with synchronous lookups,
+// if the source is an IP. The information sent by the machine is never trusted, it is always replaced:
+// with a nil enricher, it is only removed.
+func (e *Enricher) Enrich(ctx context.Context, alert *models.Alert) {
+	if alert == nil {
+		return
+	}
+
+	alert.Cti = nil
+
+	ip := sourceIP(alert)
+	if e == nil || ip == "" {
+		return
+	}
+
+	alert.Cti = e.cached(ctx, ip)
+
+	if alert.Cti == nil && e.synchronous {
+		alert.Cti = e.fetch(ctx, ip)
+	}
+}
+
+// Queue schedules the lookup of the sources that were not found in the cache.
+// It never blocks: when the queue is full, the IPs are dropped.
+func (e *Enricher) Queue(alerts []*models.Alert) {
+	for _, alert := range alerts {
+		if alert.Cti != nil {
+			continue
+		}
+
+		ip := sourceIP(alert)
+		if ip == "" {
+			continue
+		}
+
+		e.mu.Lock()
+
+		if _, ok := e.pending[ip]; ok {
+			e.mu.Unlock()
+			continue
+		}
+
+		select {
+		case e.queue <- ip:
+			e.pending[ip] = struct{}{}
+		default:
+			e.logger.Debugf("lookup queue is full, dropping %s", ip)
+		}
+
+		e.mu.Unlock()
+	}
+}
@@ -0,0 +1,235 @@
+package ctienrich
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/cticlient"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+type mockClient struct {
+	items map[string]*cticlient.SmokeItem
+	err   error
+	calls int
+}
+
+func (m *mockClient) GetIPInfo(ip string) (*cticlient.SmokeItem, error) {
+	m.calls++
+
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	if item, ok := m.items[ip]; ok {
+		return item, nil
+	}
+
+	return &cticlient.SmokeItem{}, nil
+}
+
+func newStore(t *testing.T) *database.Client {
+	ctx := t.Context()
+
+	dbClient, err := database.NewClient(ctx, &csconfig.DatabaseCfg{
+		Type:   "sqlite",
+		DbName: "crowdsec",
+		DbPath: ":memory:",
+	})
+	require.NoError(t, err)
+
+	return dbClient
+}
+
+func ipAlert(ip string) *models.Alert {
+	return &models.Alert{
+		Scenario: ptr.Of("crowdsecurity/ssh-bf"),
+		Source: &models.Source{
+			Scope: ptr.Of("Ip"),
+			Value: ptr.Of(ip),
+		},
+	}
+}
+
+func maliciousItem() *cticlient.SmokeItem {
+	return &cticlient.SmokeItem{
+		Ip:                   "1.2.3.4",
+		Reputation:           "malicious",
+		Confidence:           "high",
+		IpRangeScore:         3,
+		BackgroundNoiseScore: ptr.Of(8),
+		Behaviors:            []*cticlient.CTIBehavior{{Name: "ssh:bruteforce"}},
+		Classifications: cticlient.CTIClassifications{
+			Classifications: []cticlient.CTIClassification{{Name: "scanner:masscan"}},
+			FalsePositives:  []cticlient.CTIClassification{{Name: "seo:crawler"}},
+		},
+	}
+}
+
+func TestFromSmoke(t *testing.T) {
+	info := FromSmoke(maliciousItem())
+
+	assert.Equal(t, "malicious", info.Reputation)
+	assert.Equal(t, "high", info.Confidence)
+	assert.Equal(t, int64(3), info.IPRangeScore)
+	assert.Equal(t, int64(8), info.BackgroundNoiseScore)
+	assert.Equal(t, []string{"ssh:bruteforce"}, info.Behaviors)
+	assert.Equal(t, []string{"scanner:masscan"}, info.Classifications)
+	assert.Equal(t, []string{"seo:crawler"}, info.FalsePositives)
+	assert.NotEmpty(t, info.FetchedAt)
+
+	assert.Equal(t, ReputationUnknown, FromSmoke(&cticlient.SmokeItem{}).Reputation)
+}
+
+func TestLookup(t *testing.T) {
+	ctx := t.Context()
+	client := &mockClient{items: map[string]*cticlient.SmokeItem{"1.2.3.4": maliciousItem()}}
+	enricher := New(client, newStore(t), time.Hour, false, nil)
+
+	info := enricher.Lookup(ctx, "1.2.3.4")
+	require.NotNil(t, info)
+	assert.Equal(t, "malicious", info.Reputation)
+	assert.Equal(t, []string{"ssh:bruteforce"}, info.Behaviors)
+
+	// the second lookup is served by the database
+	info = enricher.Lookup(ctx, "1.2.3.4")
+	assert.Equal(t, "malicious", info.Reputation)
+	assert.Equal(t, 1, client.calls)
+
+	info = enricher.Lookup(ctx, "5.6.7.8")
+	assert.Equal(t, ReputationUnknown, info.Reputation)
+	assert.Equal(t, 2, client.calls)
+}
+
+func TestEnrich(t *testing.T) {
+	ctx := t.Context()
+	client := &mockClient{items: map[string]*cticlient.SmokeItem{"1.2.3.4": maliciousItem()}}
+	store := newStore(t)
+	enricher := New(client, store, time.Hour, false, nil)
+
+	// the alerts are only enriched from the cache
+	alert := ipAlert("1.2.3.4")
+	enricher.Enrich(ctx, alert)
+	assert.Nil(t, alert.Cti)
+
+	require.NoError(t, store.SetIPReputation(ctx, "1.2.3.4", FromSmoke(maliciousItem())))
+
+	alert = ipAlert("1.2.3.4")
+	enricher.Enrich(ctx, alert)
+	require.NotNil(t, alert.Cti)
+	assert.Equal(t, "malicious", alert.GetReputation())
+	assert.Equal(t, []string{"ssh:bruteforce"}, alert.GetBehaviors())
+	assert.Equal(t, []string{"scanner:masscan"}, alert.GetClassifications())
+
+	// the information sent by the machine is replaced
+	alert = ipAlert("5.6.7.8")
+	alert.Cti = &models.CTIInfo{Reputation: "safe"}
+	enricher.Enrich(ctx, alert)
+	assert.Nil(t, alert.Cti)
+
+	// only IP sources are enriched
+	alert = ipAlert("1.2.3.0/24")
+	alert.Source.Scope = ptr.Of("Range")
+	enricher.Enrich(ctx, alert)
+	assert.Nil(t, alert.Cti)
+
+	assert.Equal(t, 0, client.calls)
+
+	// without enricher, the information sent by the machine is removed
+	var disabled *Enricher
+
+	alert = ipAlert("1.2.3.4")
+	alert.Cti = &models.CTIInfo{Reputation: "safe"}
+	disabled.Enrich(ctx, alert)
+	assert.Nil(t, alert.Cti)
+}
+
+func TestEnrichSynchronous(t *testing.T) {
+	ctx := t.Context()
+	client := &mockClient{items: map[string]*cticlient.SmokeItem{"1.2.3.4": maliciousItem()}}
+	enricher := New(client, newStore(t), time.Hour, true, nil)
+
+	// the first alert is enriched
+	alert := ipAlert("1.2.3.4")
+	enricher.Enrich(ctx, alert)
+	require.NotNil(t, alert.Cti)
+	assert.Equal(t, "malicious", alert.GetReputation())
+
+	// then the cache is used
+	alert = ipAlert("1.2.3.4")
+	enricher.Enrich(ctx, alert)
+	assert.Equal(t, "malicious", alert.GetReputation())
+	assert.Equal(t, 1, client.calls)
+}
+
+func TestQueue(t *testing.T) {
+	ctx := t.Context()
+	client := &mockClient{items: map[string]*cticlient.SmokeItem{"1.2.3.4": maliciousItem()}}
+	store := newStore(t)
+	enricher := New(client, store, time.Hour, false, nil)
+
+	cached := ipAlert("5.6.7.8")
+	cached.Cti = &models.CTIInfo{Reputation: "safe"}
+
+	// the same IP is only looked up once
+	enricher.Queue([]*models.Alert{ipAlert("1.2.3.4"), ipAlert("1.2.3.4"), cached})
+	assert.Len(t, enricher.queue, 1)
+
+	enricher.Start(ctx)
+
+	assert.Eventually(t, func() bool {
+		info, err := store.GetIPReputation(ctx, "1.2.3.4", time.Time{})
+		return err == nil && info != nil && info.Reputation == "malicious"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	enricher.Shutdown()
+
+	assert.Equal(t, 1, client.calls)
+	assert.Empty(t, enricher.pending)
+}
+
+func TestLookupExpired(t *testing.T) {
+	ctx := t.Context()
+	client := &mockClient{items: map[string]*cticlient.SmokeItem{"1.2.3.4": maliciousItem()}}
+	store := newStore(t)
+
+	require.NoError(t, store.SetIPReputation(ctx, "1.2.3.4", &models.CTIInfo{Reputation: "safe"}))
+
+	// with a zero TTL, the cached entry is always stale
+	enricher := New(client, store, 0, false, nil)
+
+	info := enricher.Lookup(ctx, "1.2.3.4")
+	assert.Equal(t, "malicious", info.Reputation)
+	assert.Equal(t, 1, client.calls)
+
+	info, err := store.GetIPReputation(ctx, "1.2.3.4", time.Time{})
+	require.NoError(t, err)
+	assert.Equal(t, "malicious", info.Reputation)
+}
+
+func TestLookupErrors(t *testing.T) {
+	ctx := t.Context()
+
+	client := &mockClient{err: cticlient.ErrLimit}
+	enricher := New(client, newStore(t), time.Hour, false, nil)
+
+	assert.Nil(t, enricher.Lookup(ctx, "1.2.3.4"))
+
+	// throttled: no more requests until the backoff ends
+	enricher.Lookup(ctx, "1.2.3.4")
+	assert.Equal(t, 1, client.calls)
+
+	client = &mockClient{err: cticlient.ErrUnauthorized}
+	enricher = New(client, newStore(t), time.Hour, false, nil)
+
+	enricher.Lookup(ctx, "1.2.3.4")
+	enricher.Lookup(ctx, "5.6.7.8")
+	assert.Equal(t, 1, client.calls)
+}
@@ -40,7 +40,7 @@ func handleScopeFilter(scope string, predicates *[]predicate.Alert) {
 }
 
 func handleTimeFilters(param, value string, predicates *[]predicate.Alert) error {
-	duration, err := ParseDuration(value)
+	duration, err := types.ParseDuration(value)
 	if err != nil {
 		return fmt.Errorf("while parsing duration: %w", err)
 	}
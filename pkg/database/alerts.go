@@ -409,7 +409,8 @@ func (c *Client) createDecisionChunk(ctx context.Context, simulated bool, stopAt
 			SetScope(*decisionItem.Scope).
 			SetOrigin(*decisionItem.Origin).
 			SetSimulated(simulated).
-			SetUUID(decisionItem.UUID)
+			SetUUID(decisionItem.UUID).
+			SetEscalationStep(int(decisionItem.EscalationStep))
 
 		decisionCreate = append(decisionCreate, newDecision)
 	}
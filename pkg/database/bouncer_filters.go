@@ -0,0 +1,162 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"entgo.io/ent/dialect"
+	"entgo.io/ent/dialect/sql"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/alert"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/decision"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
+)
+
+// Filter keys set by LAPI from the bouncer policy. They are reserved: any value
+// provided by the bouncer in the query string is discarded.
+const (
+	bouncerFilterPrefix           = "bouncer_"
+	bouncerFilterScopes           = bouncerFilterPrefix + "scopes"
+	bouncerFilterOrigins          = bouncerFilterPrefix + "origins"
+	bouncerFilterScenarios        = bouncerFilterPrefix + "scenarios"
+	bouncerFilterExcludeCountries = bouncerFilterPrefix + "exclude_countries"
+	bouncerFilterExcludeAS        = bouncerFilterPrefix + "exclude_as"
+)
+
+// ParseBouncerFilters builds a bouncer policy from a list of key=value[,value...] specs,
+// as provided on the command line.
+func ParseBouncerFilters(specs []string) (*schema.BouncerFilters, error) {
+	filters := &schema.BouncerFilters{}
+
+	for _, spec := range specs {
+		key, value, ok := strings.Cut(spec, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid filter '%s': expected key=value", spec)
+		}
+
+		values := splitFilterValues(value)
+
+		switch strings.TrimSpace(key) {
+		case "scopes", "scope":
+			filters.Scopes = append(filters.Scopes, values...)
+		case "origins", "origin":
+			filters.Origins = append(filters.Origins, values...)
+		case "scenarios", "scenario":
+			filters.Scenarios = append(filters.Scenarios, values...)
+		case "exclude_countries", "exclude_country":
+			filters.ExcludeCountries = append(filters.ExcludeCountries, values...)
+		case "exclude_as":
+			filters.ExcludeAS = append(filters.ExcludeAS, values...)
+		case "max_decisions":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("invalid max_decisions value '%s': must be a positive integer", value)
+			}
+
+			filters.MaxDecisions = n
+		default:
+			return nil, fmt.Errorf("unknown filter '%s'", key)
+		}
+	}
+
+	return filters, nil
+}
+
+// ApplyBouncerFilters adds the bouncer policy to the query filters, overriding any
+// reserved key the bouncer may have tried to set itself.
+func ApplyBouncerFilters(filters map[string][]string, policy *schema.BouncerFilters) {
+	for key := range filters {
+		if strings.HasPrefix(key, bouncerFilterPrefix) {
+			delete(filters, key)
+		}
+	}
+
+	if policy == nil {
+		return
+	}
+
+	set := func(key string, values []string) {
+		if len(values) > 0 {
+			filters[key] = []string{strings.Join(values, ",")}
+		}
+	}
+
+	set(bouncerFilterScopes, policy.Scopes)
+	set(bouncerFilterOrigins, policy.Origins)
+	set(bouncerFilterScenarios, policy.Scenarios)
+	set(bouncerFilterExcludeCountries, policy.ExcludeCountries)
+	set(bouncerFilterExcludeAS, policy.ExcludeAS)
+}
+
+func splitFilterValues(value string) []string {
+	ret := []string{}
+
+	for _, v := range strings.Split(value, ",") {
+		v = strings.TrimSpace(v)
+		if v != "" {
+			ret = append(ret, v)
+		}
+	}
+
+	return ret
+}
+
+// scenarioGlobPredicate matches the decision scenario against a pattern with '*' wildcards.
+// Characters between wildcards are matched literally and in order, with an anchored LIKE.
+func scenarioGlobPredicate(glob string) predicate.Decision {
+	parts := strings.Split(glob, "*")
+	if len(parts) == 1 {
+		return decision.ScenarioEQ(glob)
+	}
+
+	escaped := false
+
+	for i, part := range parts {
+		parts[i] = likeEscaper.Replace(part)
+		if parts[i] != part {
+			escaped = true
+		}
+	}
+
+	pattern := strings.Join(parts, "%")
+
+	return predicate.Decision(func(s *sql.Selector) {
+		s.Where(sql.P(func(b *sql.Builder) {
+			b.Ident(s.C(decision.FieldScenario)).WriteOp(sql.OpLike).Arg(pattern)
+			// mysql and postgres use the backslash by default
+			if escaped && b.Dialect() == dialect.SQLite {
+				b.WriteString(" ESCAPE ").Arg(`\`)
+			}
+		}))
+	})
+}
+
+// likeEscaper escapes the characters of a LIKE pattern, to match them literally
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// buildBouncerFilterPredicate translates one of the reserved bouncer policy keys to a predicate.
+func buildBouncerFilterPredicate(param string, value string) (predicate.Decision, bool) {
+	values := splitFilterValues(value)
+
+	switch param {
+	case bouncerFilterScopes:
+		return decision.ScopeIn(normalizeScopes(values)...), true
+	case bouncerFilterOrigins:
+		return decision.OriginIn(values...), true
+	case bouncerFilterScenarios:
+		predicates := make([]predicate.Decision, len(values))
+		for i, glob := range values {
+			predicates[i] = scenarioGlobPredicate(glob)
+		}
+
+		return decision.Or(predicates...), true
+	case bouncerFilterExcludeCountries:
+		return decision.Not(decision.HasOwnerWith(alert.SourceCountryIn(values...))), true
+	case bouncerFilterExcludeAS:
+		return decision.Not(decision.HasOwnerWith(alert.SourceAsNumberIn(values...))), true
+	}
+
+	return nil, false
+}
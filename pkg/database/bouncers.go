@@ -10,6 +10,7 @@ import (
 
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/bouncer"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 	"github.com/crowdsecurity/crowdsec/pkg/models"
 )
 
@@ -141,6 +142,15 @@ func (c *Client) UpdateBouncerLastPull(ctx context.Context, lastPull time.Time,
 	return nil
 }
 
+func (c *Client) UpdateBouncerFilters(ctx context.Context, filters *schema.BouncerFilters, id int) error {
+	_, err := c.Ent.Bouncer.UpdateOneID(id).SetFilters(filters).Save(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to update bouncer filters in database: %w", err)
+	}
+
+	return nil
+}
+
 func (c *Client) UpdateBouncerIP(ctx context.Context, ipAddr string, id int) error {
 	_, err := c.Ent.Bouncer.UpdateOneID(id).SetIPAddress(ipAddr).Save(ctx)
 	if err != nil {
@@ -54,20 +54,7 @@ func BuildDecisionRequestWithFilter(query *ent.DecisionQuery, filter map[string]
 				return nil, errors.Wrapf(InvalidFilter, "invalid contains value : %s", err)
 			}
 		case "scopes", "scope": // Swagger mentions both of them, let's just support both to make sure we don't break anything
-			scopes := strings.Split(value[0], ",")
-			for i, scope := range scopes {
-				switch strings.ToLower(scope) {
-				case "ip":
-					scopes[i] = types.Ip
-				case "range":
-					scopes[i] = types.Range
-				case "country":
-					scopes[i] = types.Country
-				case "as":
-					scopes[i] = types.AS
-				}
-			}
-
+			scopes := normalizeScopes(strings.Split(value[0], ","))
 			query = query.Where(decision.ScopeIn(scopes...))
 		case "value":
 			query = query.Where(decision.ValueEQ(value[0]))
@@ -110,6 +97,10 @@ func BuildDecisionRequestWithFilter(query *ent.DecisionQuery, filter map[string]
 				return nil, errors.Wrapf(InvalidFilter, "invalid id_gt value : %s", err)
 			}
 			query = query.Where(decision.IDGT(id))
+		default:
+			if p, ok := buildBouncerFilterPredicate(param, value[0]); ok {
+				query = query.Where(p)
+			}
 		}
 	}
 
@@ -121,6 +112,24 @@ func BuildDecisionRequestWithFilter(query *ent.DecisionQuery, filter map[string]
 	return query, nil
 }
 
+// normalizeScopes converts the lowercase scope names accepted by the API to their canonical form
+func normalizeScopes(scopes []string) []string {
+	for i, scope := range scopes {
+		switch strings.ToLower(scope) {
+		case "ip":
+			scopes[i] = types.Ip
+		case "range":
+			scopes[i] = types.Range
+		case "country":
+			scopes[i] = types.Country
+		case "as":
+			scopes[i] = types.AS
+		}
+	}
+
+	return scopes
+}
+
 func (c *Client) QueryAllDecisionsWithFilters(ctx context.Context, filters map[string][]string) ([]*ent.Decision, error) {
 	query := c.Ent.Decision.Query().Where(
 		decision.UntilGT(time.Now().UTC()),
@@ -317,6 +326,35 @@ func (c *Client) QueryNewDecisionsSinceWithFilters(ctx context.Context, since *t
 	return data, nil
 }
 
+// QueryActiveDecisionsInRangeSince returns the active, non-simulated decisions of the given scope, type and origin
+// that are contained in ipRange and were created after since
+func (c *Client) QueryActiveDecisionsInRangeSince(ctx context.Context, scope string, decisionType string, origin string, ipRange string, since time.Time) ([]*ent.Decision, error) {
+	query := c.Ent.Decision.Query().Where(
+		decision.UntilGT(time.Now().UTC()),
+		decision.CreatedAtGT(since),
+	)
+
+	query, err := BuildDecisionRequestWithFilter(query, map[string][]string{
+		"scopes":   {scope},
+		"type":     {decisionType},
+		"origins":  {origin},
+		"range":    {ipRange},
+		"contains": {"false"},
+	})
+	if err != nil {
+		c.Log.Warningf("QueryActiveDecisionsInRangeSince : %s", err)
+		return []*ent.Decision{}, errors.Wrapf(QueryFail, "decisions in range '%s'", ipRange)
+	}
+
+	data, err := query.Order(ent.Asc(decision.FieldID)).All(ctx)
+	if err != nil {
+		c.Log.Warningf("QueryActiveDecisionsInRangeSince : %s", err)
+		return []*ent.Decision{}, errors.Wrapf(QueryFail, "decisions in range '%s'", ipRange)
+	}
+
+	return data, nil
+}
+
 func (c *Client) DeleteDecisionsWithFilter(ctx context.Context, filter map[string][]string) (string, []*ent.Decision, error) {
 	var err error
 	var start_ip, start_sfx, end_ip, end_sfx int64
@@ -763,6 +801,68 @@ func (c *Client) CountDecisionsSinceByValue(ctx context.Context, decisionValue s
 	return count, nil
 }
 
+// profileDecisionPredicate matches the decisions generated by the profiles of the local API, whose origin is
+// "crowdsec" or "crowdsec/<origin of the profile decision>"
+var profileDecisionPredicate = decision.Or(
+	decision.OriginEQ(types.CrowdSecOrigin),
+	decision.OriginHasPrefix(types.CrowdSecOrigin+"/"),
+)
+
+// CountProfileAlertsSinceByValue counts the alerts for which the profiles generated decisions on the value since the
+// given time: an alert with several decisions is a single offense
+func (c *Client) CountProfileAlertsSinceByValue(ctx context.Context, decisionValue string, since time.Time) (int, error) {
+	ip_sz, start_ip, start_sfx, end_ip, end_sfx, err := types.Addr2Ints(decisionValue)
+	if err != nil {
+		return 0, errors.Wrapf(InvalidIPOrRange, "unable to convert '%s' to int: %s", decisionValue, err)
+	}
+
+	contains := true
+	decisions := c.Ent.Decision.Query().Where(
+		decision.CreatedAtGT(since),
+		profileDecisionPredicate,
+	)
+
+	decisions, err = applyStartIpEndIpFilter(decisions, contains, ip_sz, start_ip, start_sfx, end_ip, end_sfx)
+	if err != nil {
+		return 0, errors.Wrapf(err, "fail to apply StartIpEndIpFilter")
+	}
+
+	count, err := decisions.QueryOwner().Count(ctx)
+	if err != nil {
+		return 0, errors.Wrapf(err, "fail to count alerts")
+	}
+
+	return count, nil
+}
+
+// GetLastProfileDecisionUntilByValue returns the expiration time of the most recent decision generated by the profiles
+// on the value, or nil if there is none
+func (c *Client) GetLastProfileDecisionUntilByValue(ctx context.Context, decisionValue string) (*time.Time, error) {
+	ip_sz, start_ip, start_sfx, end_ip, end_sfx, err := types.Addr2Ints(decisionValue)
+	if err != nil {
+		return nil, errors.Wrapf(InvalidIPOrRange, "unable to convert '%s' to int: %s", decisionValue, err)
+	}
+
+	contains := true
+	decisions := c.Ent.Decision.Query().Where(profileDecisionPredicate)
+
+	decisions, err = applyStartIpEndIpFilter(decisions, contains, ip_sz, start_ip, start_sfx, end_ip, end_sfx)
+	if err != nil {
+		return nil, errors.Wrapf(err, "fail to apply StartIpEndIpFilter")
+	}
+
+	last, err := decisions.Order(ent.Desc(decision.FieldUntil)).First(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+
+		return nil, errors.Wrapf(err, "fail to get last decision")
+	}
+
+	return last.Until, nil
+}
+
 func applyStartIpEndIpFilter(decisions *ent.DecisionQuery, contains bool, ip_sz int, start_ip int64, start_sfx int64, end_ip int64, end_sfx int64) (*ent.DecisionQuery, error) {
 	if ip_sz == 4 {
 		if contains {
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestProfileDecisionHistory(t *testing.T) {
+	ctx := context.Background()
+	dbClient := getDBClient(t, ctx)
+
+	now := time.Now().UTC()
+
+	ip_sz, start_ip, start_sfx, end_ip, end_sfx, err := types.Addr2Ints("1.2.3.4")
+	require.NoError(t, err)
+
+	// an offense with two decisions, another one, then decisions from other origins
+	alerts := [][]string{
+		{types.CrowdSecOrigin, types.CrowdSecOrigin + "/ssh"},
+		{types.CrowdSecOrigin},
+		{types.CscliOrigin, types.CAPIOrigin, types.ListOrigin},
+	}
+
+	i := 0
+
+	for _, origins := range alerts {
+		alert, err := dbClient.Ent.Alert.Create().
+			SetScenario("crowdsecurity/ssh-bf").
+			Save(ctx)
+		require.NoError(t, err)
+
+		for _, origin := range origins {
+			_, err := dbClient.Ent.Decision.Create().
+				SetUntil(now.Add(time.Duration(i) * time.Hour)).
+				SetScenario("crowdsecurity/ssh-bf").
+				SetType("ban").
+				SetScope(types.Ip).
+				SetValue("1.2.3.4").
+				SetOrigin(origin).
+				SetIPSize(int64(ip_sz)).
+				SetStartIP(start_ip).
+				SetStartSuffix(start_sfx).
+				SetEndIP(end_ip).
+				SetEndSuffix(end_sfx).
+				SetOwner(alert).
+				Save(ctx)
+			require.NoError(t, err)
+
+			i++
+		}
+	}
+
+	count, err := dbClient.CountProfileAlertsSinceByValue(ctx, "1.2.3.4", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = dbClient.CountDecisionsSinceByValue(ctx, "1.2.3.4", now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 6, count)
+
+	until, err := dbClient.GetLastProfileDecisionUntilByValue(ctx, "1.2.3.4")
+	require.NoError(t, err)
+	require.NotNil(t, until)
+	assert.WithinDuration(t, now.Add(2*time.Hour), *until, time.Second)
+
+	until, err = dbClient.GetLastProfileDecisionUntilByValue(ctx, "5.6.7.8")
+	require.NoError(t, err)
+	assert.Nil(t, until)
+}
@@ -3,6 +3,7 @@
 package ent
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/bouncer"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 )
 
 // Bouncer is the model entity for the Bouncer schema.
@@ -44,7 +46,9 @@ type Bouncer struct {
 	// Featureflags holds the value of the "featureflags" field.
 	Featureflags string `json:"featureflags,omitempty"`
 	// AutoCreated holds the value of the "auto_created" field.
-	AutoCreated  bool `json:"auto_created"`
+	AutoCreated bool `json:"auto_created"`
+	// Filters holds the value of the "filters" field.
+	Filters      *schema.BouncerFilters `json:"filters,omitempty"`
 	selectValues sql.SelectValues
 }
 
@@ -53,6 +57,8 @@ func (*Bouncer) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
+		case bouncer.FieldFilters:
+			values[i] = new([]byte)
 		case bouncer.FieldRevoked, bouncer.FieldAutoCreated:
 			values[i] = new(sql.NullBool)
 		case bouncer.FieldID:
@@ -167,6 +173,14 @@ func (b *Bouncer) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				b.AutoCreated = value.Bool
 			}
+		case bouncer.FieldFilters:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field filters", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &b.Filters); err != nil {
+					return fmt.Errorf("unmarshal field filters: %w", err)
+				}
+			}
 		default:
 			b.selectValues.Set(columns[i], values[i])
 		}
@@ -245,6 +259,9 @@ func (b *Bouncer) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("auto_created=")
 	builder.WriteString(fmt.Sprintf("%v", b.AutoCreated))
+	builder.WriteString(", ")
+	builder.WriteString("filters=")
+	builder.WriteString(fmt.Sprintf("%v", b.Filters))
 	builder.WriteByte(')')
 	return builder.String()
 }
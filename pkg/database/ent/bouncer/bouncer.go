@@ -41,6 +41,8 @@ const (
 	FieldFeatureflags = "featureflags"
 	// FieldAutoCreated holds the string denoting the auto_created field in the database.
 	FieldAutoCreated = "auto_created"
+	// FieldFilters holds the string denoting the filters field in the database.
+	FieldFilters = "filters"
 	// Table holds the table name of the bouncer in the database.
 	Table = "bouncers"
 )
@@ -62,6 +64,7 @@ var Columns = []string{
 	FieldOsversion,
 	FieldFeatureflags,
 	FieldAutoCreated,
+	FieldFilters,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
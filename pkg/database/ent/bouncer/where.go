@@ -919,6 +919,16 @@ func AutoCreatedNEQ(v bool) predicate.Bouncer {
 	return predicate.Bouncer(sql.FieldNEQ(FieldAutoCreated, v))
 }
 
+// FiltersIsNil applies the IsNil predicate on the "filters" field.
+func FiltersIsNil() predicate.Bouncer {
+	return predicate.Bouncer(sql.FieldIsNull(FieldFilters))
+}
+
+// FiltersNotNil applies the NotNil predicate on the "filters" field.
+func FiltersNotNil() predicate.Bouncer {
+	return predicate.Bouncer(sql.FieldNotNull(FieldFilters))
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Bouncer) predicate.Bouncer {
 	return predicate.Bouncer(sql.AndPredicates(predicates...))
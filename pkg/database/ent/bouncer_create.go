@@ -11,6 +11,7 @@ import (
 	"entgo.io/ent/dialect/sql/sqlgraph"
 	"entgo.io/ent/schema/field"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/bouncer"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 )
 
 // BouncerCreate is the builder for creating a Bouncer entity.
@@ -192,6 +193,12 @@ func (bc *BouncerCreate) SetNillableAutoCreated(b *bool) *BouncerCreate {
 	return bc
 }
 
+// SetFilters sets the "filters" field.
+func (bc *BouncerCreate) SetFilters(sf *schema.BouncerFilters) *BouncerCreate {
+	bc.mutation.SetFilters(sf)
+	return bc
+}
+
 // Mutation returns the BouncerMutation object of the builder.
 func (bc *BouncerCreate) Mutation() *BouncerMutation {
 	return bc.mutation
@@ -354,6 +361,10 @@ func (bc *BouncerCreate) createSpec() (*Bouncer, *sqlgraph.CreateSpec) {
 		_spec.SetField(bouncer.FieldAutoCreated, field.TypeBool, value)
 		_node.AutoCreated = value
 	}
+	if value, ok := bc.mutation.Filters(); ok {
+		_spec.SetField(bouncer.FieldFilters, field.TypeJSON, value)
+		_node.Filters = value
+	}
 	return _node, _spec
 }
 
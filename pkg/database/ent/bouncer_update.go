@@ -13,6 +13,7 @@ import (
 	"entgo.io/ent/schema/field"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/bouncer"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 )
 
 // BouncerUpdate is the builder for updating Bouncer entities.
@@ -216,6 +217,18 @@ func (bu *BouncerUpdate) ClearFeatureflags() *BouncerUpdate {
 	return bu
 }
 
+// SetFilters sets the "filters" field.
+func (bu *BouncerUpdate) SetFilters(sf *schema.BouncerFilters) *BouncerUpdate {
+	bu.mutation.SetFilters(sf)
+	return bu
+}
+
+// ClearFilters clears the value of the "filters" field.
+func (bu *BouncerUpdate) ClearFilters() *BouncerUpdate {
+	bu.mutation.ClearFilters()
+	return bu
+}
+
 // Mutation returns the BouncerMutation object of the builder.
 func (bu *BouncerUpdate) Mutation() *BouncerMutation {
 	return bu.mutation
@@ -320,6 +333,12 @@ func (bu *BouncerUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if bu.mutation.FeatureflagsCleared() {
 		_spec.ClearField(bouncer.FieldFeatureflags, field.TypeString)
 	}
+	if value, ok := bu.mutation.Filters(); ok {
+		_spec.SetField(bouncer.FieldFilters, field.TypeJSON, value)
+	}
+	if bu.mutation.FiltersCleared() {
+		_spec.ClearField(bouncer.FieldFilters, field.TypeJSON)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, bu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{bouncer.Label}
@@ -528,6 +547,18 @@ func (buo *BouncerUpdateOne) ClearFeatureflags() *BouncerUpdateOne {
 	return buo
 }
 
+// SetFilters sets the "filters" field.
+func (buo *BouncerUpdateOne) SetFilters(sf *schema.BouncerFilters) *BouncerUpdateOne {
+	buo.mutation.SetFilters(sf)
+	return buo
+}
+
+// ClearFilters clears the value of the "filters" field.
+func (buo *BouncerUpdateOne) ClearFilters() *BouncerUpdateOne {
+	buo.mutation.ClearFilters()
+	return buo
+}
+
 // Mutation returns the BouncerMutation object of the builder.
 func (buo *BouncerUpdateOne) Mutation() *BouncerMutation {
 	return buo.mutation
@@ -662,6 +693,12 @@ func (buo *BouncerUpdateOne) sqlSave(ctx context.Context) (_node *Bouncer, err e
 	if buo.mutation.FeatureflagsCleared() {
 		_spec.ClearField(bouncer.FieldFeatureflags, field.TypeString)
 	}
+	if value, ok := buo.mutation.Filters(); ok {
+		_spec.SetField(bouncer.FieldFilters, field.TypeJSON, value)
+	}
+	if buo.mutation.FiltersCleared() {
+		_spec.ClearField(bouncer.FieldFilters, field.TypeJSON)
+	}
 	_node = &Bouncer{config: buo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
@@ -22,10 +22,12 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/configitem"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/decision"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/event"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/lock"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/machine"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/meta"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/metric"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
 )
 
 // Client is the client that holds all ent builders.
@@ -47,6 +49,8 @@ type Client struct {
 	Decision *DecisionClient
 	// Event is the client for interacting with the Event builders.
 	Event *EventClient
+	// IPReputation is the client for interacting with the IPReputation builders.
+	IPReputation *IPReputationClient
 	// Lock is the client for interacting with the Lock builders.
 	Lock *LockClient
 	// Machine is the client for interacting with the Machine builders.
@@ -55,6 +59,8 @@ type Client struct {
 	Meta *MetaClient
 	// Metric is the client for interacting with the Metric builders.
 	Metric *MetricClient
+	// Notification is the client for interacting with the Notification builders.
+	Notification *NotificationClient
 }
 
 // NewClient creates a new client configured with the given options.
@@ -73,10 +79,12 @@ func (c *Client) init() {
 	c.ConfigItem = NewConfigItemClient(c.config)
 	c.Decision = NewDecisionClient(c.config)
 	c.Event = NewEventClient(c.config)
+	c.IPReputation = NewIPReputationClient(c.config)
 	c.Lock = NewLockClient(c.config)
 	c.Machine = NewMachineClient(c.config)
 	c.Meta = NewMetaClient(c.config)
 	c.Metric = NewMetricClient(c.config)
+	c.Notification = NewNotificationClient(c.config)
 }
 
 type (
@@ -176,10 +184,12 @@ func (c *Client) Tx(ctx context.Context) (*Tx, error) {
 		ConfigItem:    NewConfigItemClient(cfg),
 		Decision:      NewDecisionClient(cfg),
 		Event:         NewEventClient(cfg),
+		IPReputation:  NewIPReputationClient(cfg),
 		Lock:          NewLockClient(cfg),
 		Machine:       NewMachineClient(cfg),
 		Meta:          NewMetaClient(cfg),
 		Metric:        NewMetricClient(cfg),
+		Notification:  NewNotificationClient(cfg),
 	}, nil
 }
 
@@ -206,10 +216,12 @@ func (c *Client) BeginTx(ctx context.Context, opts *sql.TxOptions) (*Tx, error)
 		ConfigItem:    NewConfigItemClient(cfg),
 		Decision:      NewDecisionClient(cfg),
 		Event:         NewEventClient(cfg),
+		IPReputation:  NewIPReputationClient(cfg),
 		Lock:          NewLockClient(cfg),
 		Machine:       NewMachineClient(cfg),
 		Meta:          NewMetaClient(cfg),
 		Metric:        NewMetricClient(cfg),
+		Notification:  NewNotificationClient(cfg),
 	}, nil
 }
 
@@ -240,7 +252,7 @@ func (c *Client) Close() error {
 func (c *Client) Use(hooks ...Hook) {
 	for _, n := range []interface{ Use(...Hook) }{
 		c.Alert, c.AllowList, c.AllowListItem, c.Bouncer, c.ConfigItem, c.Decision,
-		c.Event, c.Lock, c.Machine, c.Meta, c.Metric,
+		c.Event, c.IPReputation, c.Lock, c.Machine, c.Meta, c.Metric, c.Notification,
 	} {
 		n.Use(hooks...)
 	}
@@ -251,7 +263,7 @@ func (c *Client) Use(hooks ...Hook) {
 func (c *Client) Intercept(interceptors ...Interceptor) {
 	for _, n := range []interface{ Intercept(...Interceptor) }{
 		c.Alert, c.AllowList, c.AllowListItem, c.Bouncer, c.ConfigItem, c.Decision,
-		c.Event, c.Lock, c.Machine, c.Meta, c.Metric,
+		c.Event, c.IPReputation, c.Lock, c.Machine, c.Meta, c.Metric, c.Notification,
 	} {
 		n.Intercept(interceptors...)
 	}
@@ -274,6 +286,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.Decision.mutate(ctx, m)
 	case *EventMutation:
 		return c.Event.mutate(ctx, m)
+	case *IPReputationMutation:
+		return c.IPReputation.mutate(ctx, m)
 	case *LockMutation:
 		return c.Lock.mutate(ctx, m)
 	case *MachineMutation:
@@ -282,6 +296,8 @@ func (c *Client) Mutate(ctx context.Context, m Mutation) (Value, error) {
 		return c.Meta.mutate(ctx, m)
 	case *MetricMutation:
 		return c.Metric.mutate(ctx, m)
+	case *NotificationMutation:
+		return c.Notification.mutate(ctx, m)
 	default:
 		return nil, fmt.Errorf("ent: unknown mutation type %T", m)
 	}
@@ -1346,6 +1362,139 @@ func (c *EventClient) mutate(ctx context.Context, m *EventMutation) (Value, erro
 	}
 }
 
+// IPReputationClient is a client for the IPReputation schema.
+type IPReputationClient struct {
+	config
+}
+
+// NewIPReputationClient returns a client for the IPReputation from the given config.
+func NewIPReputationClient(c config) *IPReputationClient {
+	return &IPReputationClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `ipreputation.Hooks(f(g(h())))`.
+func (c *IPReputationClient) Use(hooks ...Hook) {
+	c.hooks.IPReputation = append(c.hooks.IPReputation, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `ipreputation.Intercept(f(g(h())))`.
+func (c *IPReputationClient) Intercept(interceptors ...Interceptor) {
+	c.inters.IPReputation = append(c.inters.IPReputation, interceptors...)
+}
+
+// Create returns a builder for creating a IPReputation entity.
+func (c *IPReputationClient) Create() *IPReputationCreate {
+	mutation := newIPReputationMutation(c.config, OpCreate)
+	return &IPReputationCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of IPReputation entities.
+func (c *IPReputationClient) CreateBulk(builders ...*IPReputationCreate) *IPReputationCreateBulk {
+	return &IPReputationCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *IPReputationClient) MapCreateBulk(slice any, setFunc func(*IPReputationCreate, int)) *IPReputationCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &IPReputationCreateBulk{err: fmt.Errorf("calling to IPReputationClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*IPReputationCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &IPReputationCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for IPReputation.
+func (c *IPReputationClient) Update() *IPReputationUpdate {
+	mutation := newIPReputationMutation(c.config, OpUpdate)
+	return &IPReputationUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *IPReputationClient) UpdateOne(ir *IPReputation) *IPReputationUpdateOne {
+	mutation := newIPReputationMutation(c.config, OpUpdateOne, withIPReputation(ir))
+	return &IPReputationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *IPReputationClient) UpdateOneID(id int) *IPReputationUpdateOne {
+	mutation := newIPReputationMutation(c.config, OpUpdateOne, withIPReputationID(id))
+	return &IPReputationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for IPReputation.
+func (c *IPReputationClient) Delete() *IPReputationDelete {
+	mutation := newIPReputationMutation(c.config, OpDelete)
+	return &IPReputationDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *IPReputationClient) DeleteOne(ir *IPReputation) *IPReputationDeleteOne {
+	return c.DeleteOneID(ir.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *IPReputationClient) DeleteOneID(id int) *IPReputationDeleteOne {
+	builder := c.Delete().Where(ipreputation.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &IPReputationDeleteOne{builder}
+}
+
+// Query returns a query builder for IPReputation.
+func (c *IPReputationClient) Query() *IPReputationQuery {
+	return &IPReputationQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeIPReputation},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a IPReputation entity by its id.
+func (c *IPReputationClient) Get(ctx context.Context, id int) (*IPReputation, error) {
+	return c.Query().Where(ipreputation.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *IPReputationClient) GetX(ctx context.Context, id int) *IPReputation {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *IPReputationClient) Hooks() []Hook {
+	return c.hooks.IPReputation
+}
+
+// Interceptors returns the client interceptors.
+func (c *IPReputationClient) Interceptors() []Interceptor {
+	return c.inters.IPReputation
+}
+
+func (c *IPReputationClient) mutate(ctx context.Context, m *IPReputationMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&IPReputationCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&IPReputationUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&IPReputationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&IPReputationDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown IPReputation mutation op: %q", m.Op())
+	}
+}
+
 // LockClient is a client for the Lock schema.
 type LockClient struct {
 	config
@@ -1910,14 +2059,147 @@ func (c *MetricClient) mutate(ctx context.Context, m *MetricMutation) (Value, er
 	}
 }
 
+// NotificationClient is a client for the Notification schema.
+type NotificationClient struct {
+	config
+}
+
+// NewNotificationClient returns a client for the Notification from the given config.
+func NewNotificationClient(c config) *NotificationClient {
+	return &NotificationClient{config: c}
+}
+
+// Use adds a list of mutation hooks to the hooks stack.
+// A call to `Use(f, g, h)` equals to `notification.Hooks(f(g(h())))`.
+func (c *NotificationClient) Use(hooks ...Hook) {
+	c.hooks.Notification = append(c.hooks.Notification, hooks...)
+}
+
+// Intercept adds a list of query interceptors to the interceptors stack.
+// A call to `Intercept(f, g, h)` equals to `notification.Intercept(f(g(h())))`.
+func (c *NotificationClient) Intercept(interceptors ...Interceptor) {
+	c.inters.Notification = append(c.inters.Notification, interceptors...)
+}
+
+// Create returns a builder for creating a Notification entity.
+func (c *NotificationClient) Create() *NotificationCreate {
+	mutation := newNotificationMutation(c.config, OpCreate)
+	return &NotificationCreate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// CreateBulk returns a builder for creating a bulk of Notification entities.
+func (c *NotificationClient) CreateBulk(builders ...*NotificationCreate) *NotificationCreateBulk {
+	return &NotificationCreateBulk{config: c.config, builders: builders}
+}
+
+// MapCreateBulk creates a bulk creation builder from the given slice. For each item in the slice, the function creates
+// a builder and applies setFunc on it.
+func (c *NotificationClient) MapCreateBulk(slice any, setFunc func(*NotificationCreate, int)) *NotificationCreateBulk {
+	rv := reflect.ValueOf(slice)
+	if rv.Kind() != reflect.Slice {
+		return &NotificationCreateBulk{err: fmt.Errorf("calling to NotificationClient.MapCreateBulk with wrong type %T, need slice", slice)}
+	}
+	builders := make([]*NotificationCreate, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		builders[i] = c.Create()
+		setFunc(builders[i], i)
+	}
+	return &NotificationCreateBulk{config: c.config, builders: builders}
+}
+
+// Update returns an update builder for Notification.
+func (c *NotificationClient) Update() *NotificationUpdate {
+	mutation := newNotificationMutation(c.config, OpUpdate)
+	return &NotificationUpdate{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOne returns an update builder for the given entity.
+func (c *NotificationClient) UpdateOne(n *Notification) *NotificationUpdateOne {
+	mutation := newNotificationMutation(c.config, OpUpdateOne, withNotification(n))
+	return &NotificationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// UpdateOneID returns an update builder for the given id.
+func (c *NotificationClient) UpdateOneID(id int) *NotificationUpdateOne {
+	mutation := newNotificationMutation(c.config, OpUpdateOne, withNotificationID(id))
+	return &NotificationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// Delete returns a delete builder for Notification.
+func (c *NotificationClient) Delete() *NotificationDelete {
+	mutation := newNotificationMutation(c.config, OpDelete)
+	return &NotificationDelete{config: c.config, hooks: c.Hooks(), mutation: mutation}
+}
+
+// DeleteOne returns a builder for deleting the given entity.
+func (c *NotificationClient) DeleteOne(n *Notification) *NotificationDeleteOne {
+	return c.DeleteOneID(n.ID)
+}
+
+// DeleteOneID returns a builder for deleting the given entity by its id.
+func (c *NotificationClient) DeleteOneID(id int) *NotificationDeleteOne {
+	builder := c.Delete().Where(notification.ID(id))
+	builder.mutation.id = &id
+	builder.mutation.op = OpDeleteOne
+	return &NotificationDeleteOne{builder}
+}
+
+// Query returns a query builder for Notification.
+func (c *NotificationClient) Query() *NotificationQuery {
+	return &NotificationQuery{
+		config: c.config,
+		ctx:    &QueryContext{Type: TypeNotification},
+		inters: c.Interceptors(),
+	}
+}
+
+// Get returns a Notification entity by its id.
+func (c *NotificationClient) Get(ctx context.Context, id int) (*Notification, error) {
+	return c.Query().Where(notification.ID(id)).Only(ctx)
+}
+
+// GetX is like Get, but panics if an error occurs.
+func (c *NotificationClient) GetX(ctx context.Context, id int) *Notification {
+	obj, err := c.Get(ctx, id)
+	if err != nil {
+		panic(err)
+	}
+	return obj
+}
+
+// Hooks returns the client hooks.
+func (c *NotificationClient) Hooks() []Hook {
+	return c.hooks.Notification
+}
+
+// Interceptors returns the client interceptors.
+func (c *NotificationClient) Interceptors() []Interceptor {
+	return c.inters.Notification
+}
+
+func (c *NotificationClient) mutate(ctx context.Context, m *NotificationMutation) (Value, error) {
+	switch m.Op() {
+	case OpCreate:
+		return (&NotificationCreate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdate:
+		return (&NotificationUpdate{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpUpdateOne:
+		return (&NotificationUpdateOne{config: c.config, hooks: c.Hooks(), mutation: m}).Save(ctx)
+	case OpDelete, OpDeleteOne:
+		return (&NotificationDelete{config: c.config, hooks: c.Hooks(), mutation: m}).Exec(ctx)
+	default:
+		return nil, fmt.Errorf("ent: unknown Notification mutation op: %q", m.Op())
+	}
+}
+
 // hooks and interceptors per client, for fast access.
 type (
 	hooks struct {
-		Alert, AllowList, AllowListItem, Bouncer, ConfigItem, Decision, Event, Lock,
-		Machine, Meta, Metric []ent.Hook
+		Alert, AllowList, AllowListItem, Bouncer, ConfigItem, Decision, Event,
+		IPReputation, Lock, Machine, Meta, Metric, Notification []ent.Hook
 	}
 	inters struct {
-		Alert, AllowList, AllowListItem, Bouncer, ConfigItem, Decision, Event, Lock,
-		Machine, Meta, Metric []ent.Interceptor
+		Alert, AllowList, AllowListItem, Bouncer, ConfigItem, Decision, Event,
+		IPReputation, Lock, Machine, Meta, Metric, Notification []ent.Interceptor
 	}
 )
@@ -50,6 +50,8 @@ type Decision struct {
 	UUID string `json:"uuid,omitempty"`
 	// AlertDecisions holds the value of the "alert_decisions" field.
 	AlertDecisions int `json:"alert_decisions,omitempty"`
+	// EscalationStep holds the value of the "escalation_step" field.
+	EscalationStep int `json:"escalation_step,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the DecisionQuery when eager-loading is set.
 	Edges        DecisionEdges `json:"edges"`
@@ -83,7 +85,7 @@ func (*Decision) scanValues(columns []string) ([]any, error) {
 		switch columns[i] {
 		case decision.FieldSimulated:
 			values[i] = new(sql.NullBool)
-		case decision.FieldID, decision.FieldStartIP, decision.FieldEndIP, decision.FieldStartSuffix, decision.FieldEndSuffix, decision.FieldIPSize, decision.FieldAlertDecisions:
+		case decision.FieldID, decision.FieldStartIP, decision.FieldEndIP, decision.FieldStartSuffix, decision.FieldEndSuffix, decision.FieldIPSize, decision.FieldAlertDecisions, decision.FieldEscalationStep:
 			values[i] = new(sql.NullInt64)
 		case decision.FieldScenario, decision.FieldType, decision.FieldScope, decision.FieldValue, decision.FieldOrigin, decision.FieldUUID:
 			values[i] = new(sql.NullString)
@@ -207,6 +209,12 @@ func (d *Decision) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				d.AlertDecisions = int(value.Int64)
 			}
+		case decision.FieldEscalationStep:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field escalation_step", values[i])
+			} else if value.Valid {
+				d.EscalationStep = int(value.Int64)
+			}
 		default:
 			d.selectValues.Set(columns[i], values[i])
 		}
@@ -297,6 +305,9 @@ func (d *Decision) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("alert_decisions=")
 	builder.WriteString(fmt.Sprintf("%v", d.AlertDecisions))
+	builder.WriteString(", ")
+	builder.WriteString("escalation_step=")
+	builder.WriteString(fmt.Sprintf("%v", d.EscalationStep))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -46,6 +46,8 @@ const (
 	FieldUUID = "uuid"
 	// FieldAlertDecisions holds the string denoting the alert_decisions field in the database.
 	FieldAlertDecisions = "alert_decisions"
+	// FieldEscalationStep holds the string denoting the escalation_step field in the database.
+	FieldEscalationStep = "escalation_step"
 	// EdgeOwner holds the string denoting the owner edge name in mutations.
 	EdgeOwner = "owner"
 	// Table holds the table name of the decision in the database.
@@ -78,6 +80,7 @@ var Columns = []string{
 	FieldSimulated,
 	FieldUUID,
 	FieldAlertDecisions,
+	FieldEscalationStep,
 }
 
 // ValidColumn reports if the column name is valid (part of the table columns).
@@ -189,6 +192,11 @@ func ByAlertDecisions(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldAlertDecisions, opts...).ToFunc()
 }
 
+// ByEscalationStep orders the results by the escalation_step field.
+func ByEscalationStep(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldEscalationStep, opts...).ToFunc()
+}
+
 // ByOwnerField orders the results by owner field.
 func ByOwnerField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -135,6 +135,11 @@ func AlertDecisions(v int) predicate.Decision {
 	return predicate.Decision(sql.FieldEQ(FieldAlertDecisions, v))
 }
 
+// EscalationStep applies equality check predicate on the "escalation_step" field. It's identical to EscalationStepEQ.
+func EscalationStep(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldEQ(FieldEscalationStep, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Decision {
 	return predicate.Decision(sql.FieldEQ(FieldCreatedAt, v))
@@ -955,6 +960,56 @@ func AlertDecisionsNotNil() predicate.Decision {
 	return predicate.Decision(sql.FieldNotNull(FieldAlertDecisions))
 }
 
+// EscalationStepEQ applies the EQ predicate on the "escalation_step" field.
+func EscalationStepEQ(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldEQ(FieldEscalationStep, v))
+}
+
+// EscalationStepNEQ applies the NEQ predicate on the "escalation_step" field.
+func EscalationStepNEQ(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldNEQ(FieldEscalationStep, v))
+}
+
+// EscalationStepIn applies the In predicate on the "escalation_step" field.
+func EscalationStepIn(vs ...int) predicate.Decision {
+	return predicate.Decision(sql.FieldIn(FieldEscalationStep, vs...))
+}
+
+// EscalationStepNotIn applies the NotIn predicate on the "escalation_step" field.
+func EscalationStepNotIn(vs ...int) predicate.Decision {
+	return predicate.Decision(sql.FieldNotIn(FieldEscalationStep, vs...))
+}
+
+// EscalationStepGT applies the GT predicate on the "escalation_step" field.
+func EscalationStepGT(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldGT(FieldEscalationStep, v))
+}
+
+// EscalationStepGTE applies the GTE predicate on the "escalation_step" field.
+func EscalationStepGTE(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldGTE(FieldEscalationStep, v))
+}
+
+// EscalationStepLT applies the LT predicate on the "escalation_step" field.
+func EscalationStepLT(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldLT(FieldEscalationStep, v))
+}
+
+// EscalationStepLTE applies the LTE predicate on the "escalation_step" field.
+func EscalationStepLTE(v int) predicate.Decision {
+	return predicate.Decision(sql.FieldLTE(FieldEscalationStep, v))
+}
+
+// EscalationStepIsNil applies the IsNil predicate on the "escalation_step" field.
+func EscalationStepIsNil() predicate.Decision {
+	return predicate.Decision(sql.FieldIsNull(FieldEscalationStep))
+}
+
+// EscalationStepNotNil applies the NotNil predicate on the "escalation_step" field.
+func EscalationStepNotNil() predicate.Decision {
+	return predicate.Decision(sql.FieldNotNull(FieldEscalationStep))
+}
+
 // HasOwner applies the HasEdge predicate on the "owner" edge.
 func HasOwner() predicate.Decision {
 	return predicate.Decision(func(s *sql.Selector) {
@@ -205,6 +205,20 @@ func (dc *DecisionCreate) SetNillableAlertDecisions(i *int) *DecisionCreate {
 	return dc
 }
 
+// SetEscalationStep sets the "escalation_step" field.
+func (dc *DecisionCreate) SetEscalationStep(i int) *DecisionCreate {
+	dc.mutation.SetEscalationStep(i)
+	return dc
+}
+
+// SetNillableEscalationStep sets the "escalation_step" field if the given value is not nil.
+func (dc *DecisionCreate) SetNillableEscalationStep(i *int) *DecisionCreate {
+	if i != nil {
+		dc.SetEscalationStep(*i)
+	}
+	return dc
+}
+
 // SetOwnerID sets the "owner" edge to the Alert entity by ID.
 func (dc *DecisionCreate) SetOwnerID(id int) *DecisionCreate {
 	dc.mutation.SetOwnerID(id)
@@ -385,6 +399,10 @@ func (dc *DecisionCreate) createSpec() (*Decision, *sqlgraph.CreateSpec) {
 		_spec.SetField(decision.FieldUUID, field.TypeString, value)
 		_node.UUID = value
 	}
+	if value, ok := dc.mutation.EscalationStep(); ok {
+		_spec.SetField(decision.FieldEscalationStep, field.TypeInt, value)
+		_node.EscalationStep = value
+	}
 	if nodes := dc.mutation.OwnerIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
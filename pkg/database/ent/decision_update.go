@@ -177,6 +177,9 @@ func (du *DecisionUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if du.mutation.UUIDCleared() {
 		_spec.ClearField(decision.FieldUUID, field.TypeString)
 	}
+	if du.mutation.EscalationStepCleared() {
+		_spec.ClearField(decision.FieldEscalationStep, field.TypeInt)
+	}
 	if du.mutation.OwnerCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -404,6 +407,9 @@ func (duo *DecisionUpdateOne) sqlSave(ctx context.Context) (_node *Decision, err
 	if duo.mutation.UUIDCleared() {
 		_spec.ClearField(decision.FieldUUID, field.TypeString)
 	}
+	if duo.mutation.EscalationStepCleared() {
+		_spec.ClearField(decision.FieldEscalationStep, field.TypeInt)
+	}
 	if duo.mutation.OwnerCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -19,10 +19,12 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/configitem"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/decision"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/event"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/lock"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/machine"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/meta"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/metric"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
 )
 
 // ent aliases to avoid import conflicts in user's code.
@@ -90,10 +92,12 @@ func checkColumn(table, column string) error {
 			configitem.Table:    configitem.ValidColumn,
 			decision.Table:      decision.ValidColumn,
 			event.Table:         event.ValidColumn,
+			ipreputation.Table:  ipreputation.ValidColumn,
 			lock.Table:          lock.ValidColumn,
 			machine.Table:       machine.ValidColumn,
 			meta.Table:          meta.ValidColumn,
 			metric.Table:        metric.ValidColumn,
+			notification.Table:  notification.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
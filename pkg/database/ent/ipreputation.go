@@ -0,0 +1,150 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
+)
+
+// IPReputation is the model entity for the IPReputation schema.
+type IPReputation struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// UpdatedAt holds the value of the "updated_at" field.
+	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// IP holds the value of the "ip" field.
+	IP string `json:"ip,omitempty"`
+	// JSON representation of models.CTIInfo
+	Data string `json:"data,omitempty"`
+	// FetchedAt holds the value of the "fetched_at" field.
+	FetchedAt    time.Time `json:"fetched_at,omitempty"`
+	selectValues sql.SelectValues
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*IPReputation) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case ipreputation.FieldID:
+			values[i] = new(sql.NullInt64)
+		case ipreputation.FieldIP, ipreputation.FieldData:
+			values[i] = new(sql.NullString)
+		case ipreputation.FieldCreatedAt, ipreputation.FieldUpdatedAt, ipreputation.FieldFetchedAt:
+			values[i] = new(sql.NullTime)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the IPReputation fields.
+func (ir *IPReputation) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case ipreputation.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			ir.ID = int(value.Int64)
+		case ipreputation.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				ir.CreatedAt = value.Time
+			}
+		case ipreputation.FieldUpdatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field updated_at", values[i])
+			} else if value.Valid {
+				ir.UpdatedAt = value.Time
+			}
+		case ipreputation.FieldIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip", values[i])
+			} else if value.Valid {
+				ir.IP = value.String
+			}
+		case ipreputation.FieldData:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field data", values[i])
+			} else if value.Valid {
+				ir.Data = value.String
+			}
+		case ipreputation.FieldFetchedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field fetched_at", values[i])
+			} else if value.Valid {
+				ir.FetchedAt = value.Time
+			}
+		default:
+			ir.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the IPReputation.
+// This includes values selected through modifiers, order, etc.
+func (ir *IPReputation) Value(name string) (ent.Value, error) {
+	return ir.selectValues.Get(name)
+}
+
+// Update returns a builder for updating this IPReputation.
+// Note that you need to call IPReputation.Unwrap() before calling this method if this IPReputation
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (ir *IPReputation) Update() *IPReputationUpdateOne {
+	return NewIPReputationClient(ir.config).UpdateOne(ir)
+}
+
+// Unwrap unwraps the IPReputation entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (ir *IPReputation) Unwrap() *IPReputation {
+	_tx, ok := ir.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: IPReputation is not a transactional entity")
+	}
+	ir.config.driver = _tx.drv
+	return ir
+}
+
+// String implements the fmt.Stringer.
+func (ir *IPReputation) String() string {
+	var builder strings.Builder
+	builder.WriteString("IPReputation(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", ir.ID))
+	builder.WriteString("created_at=")
+	builder.WriteString(ir.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("updated_at=")
+	builder.WriteString(ir.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("ip=")
+	builder.WriteString(ir.IP)
+	builder.WriteString(", ")
+	builder.WriteString("data=")
+	builder.WriteString(ir.Data)
+	builder.WriteString(", ")
+	builder.WriteString("fetched_at=")
+	builder.WriteString(ir.FetchedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// IPReputations is a parsable slice of IPReputation.
+type IPReputations []*IPReputation
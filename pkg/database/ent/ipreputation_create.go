@@ -0,0 +1,278 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
+)
+
+// IPReputationCreate is the builder for creating a IPReputation entity.
+type IPReputationCreate struct {
+	config
+	mutation *IPReputationMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (irc *IPReputationCreate) SetCreatedAt(t time.Time) *IPReputationCreate {
+	irc.mutation.SetCreatedAt(t)
+	return irc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (irc *IPReputationCreate) SetNillableCreatedAt(t *time.Time) *IPReputationCreate {
+	if t != nil {
+		irc.SetCreatedAt(*t)
+	}
+	return irc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (irc *IPReputationCreate) SetUpdatedAt(t time.Time) *IPReputationCreate {
+	irc.mutation.SetUpdatedAt(t)
+	return irc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (irc *IPReputationCreate) SetNillableUpdatedAt(t *time.Time) *IPReputationCreate {
+	if t != nil {
+		irc.SetUpdatedAt(*t)
+	}
+	return irc
+}
+
+// SetIP sets the "ip" field.
+func (irc *IPReputationCreate) SetIP(s string) *IPReputationCreate {
+	irc.mutation.SetIP(s)
+	return irc
+}
+
+// SetData sets the "data" field.
+func (irc *IPReputationCreate) SetData(s string) *IPReputationCreate {
+	irc.mutation.SetData(s)
+	return irc
+}
+
+// SetFetchedAt sets the "fetched_at" field.
+func (irc *IPReputationCreate) SetFetchedAt(t time.Time) *IPReputationCreate {
+	irc.mutation.SetFetchedAt(t)
+	return irc
+}
+
+// SetNillableFetchedAt sets the "fetched_at" field if the given value is not nil.
+func (irc *IPReputationCreate) SetNillableFetchedAt(t *time.Time) *IPReputationCreate {
+	if t != nil {
+		irc.SetFetchedAt(*t)
+	}
+	return irc
+}
+
+// Mutation returns the IPReputationMutation object of the builder.
+func (irc *IPReputationCreate) Mutation() *IPReputationMutation {
+	return irc.mutation
+}
+
+// Save creates the IPReputation in the database.
+func (irc *IPReputationCreate) Save(ctx context.Context) (*IPReputation, error) {
+	irc.defaults()
+	return withHooks(ctx, irc.sqlSave, irc.mutation, irc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (irc *IPReputationCreate) SaveX(ctx context.Context) *IPReputation {
+	v, err := irc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (irc *IPReputationCreate) Exec(ctx context.Context) error {
+	_, err := irc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (irc *IPReputationCreate) ExecX(ctx context.Context) {
+	if err := irc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (irc *IPReputationCreate) defaults() {
+	if _, ok := irc.mutation.CreatedAt(); !ok {
+		v := ipreputation.DefaultCreatedAt()
+		irc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := irc.mutation.UpdatedAt(); !ok {
+		v := ipreputation.DefaultUpdatedAt()
+		irc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := irc.mutation.FetchedAt(); !ok {
+		v := ipreputation.DefaultFetchedAt()
+		irc.mutation.SetFetchedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (irc *IPReputationCreate) check() error {
+	if _, ok := irc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "IPReputation.created_at"`)}
+	}
+	if _, ok := irc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "IPReputation.updated_at"`)}
+	}
+	if _, ok := irc.mutation.IP(); !ok {
+		return &ValidationError{Name: "ip", err: errors.New(`ent: missing required field "IPReputation.ip"`)}
+	}
+	if _, ok := irc.mutation.Data(); !ok {
+		return &ValidationError{Name: "data", err: errors.New(`ent: missing required field "IPReputation.data"`)}
+	}
+	if _, ok := irc.mutation.FetchedAt(); !ok {
+		return &ValidationError{Name: "fetched_at", err: errors.New(`ent: missing required field "IPReputation.fetched_at"`)}
+	}
+	return nil
+}
+
+func (irc *IPReputationCreate) sqlSave(ctx context.Context) (*IPReputation, error) {
+	if err := irc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := irc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, irc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	irc.mutation.id = &_node.ID
+	irc.mutation.done = true
+	return _node, nil
+}
+
+func (irc *IPReputationCreate) createSpec() (*IPReputation, *sqlgraph.CreateSpec) {
+	var (
+		_node = &IPReputation{config: irc.config}
+		_spec = sqlgraph.NewCreateSpec(ipreputation.Table, sqlgraph.NewFieldSpec(ipreputation.FieldID, field.TypeInt))
+	)
+	if value, ok := irc.mutation.CreatedAt(); ok {
+		_spec.SetField(ipreputation.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := irc.mutation.UpdatedAt(); ok {
+		_spec.SetField(ipreputation.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := irc.mutation.IP(); ok {
+		_spec.SetField(ipreputation.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := irc.mutation.Data(); ok {
+		_spec.SetField(ipreputation.FieldData, field.TypeString, value)
+		_node.Data = value
+	}
+	if value, ok := irc.mutation.FetchedAt(); ok {
+		_spec.SetField(ipreputation.FieldFetchedAt, field.TypeTime, value)
+		_node.FetchedAt = value
+	}
+	return _node, _spec
+}
+
+// IPReputationCreateBulk is the builder for creating many IPReputation entities in bulk.
+type IPReputationCreateBulk struct {
+	config
+	err      error
+	builders []*IPReputationCreate
+}
+
+// Save creates the IPReputation entities in the database.
+func (ircb *IPReputationCreateBulk) Save(ctx context.Context) ([]*IPReputation, error) {
+	if ircb.err != nil {
+		return nil, ircb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ircb.builders))
+	nodes := make([]*IPReputation, len(ircb.builders))
+	mutators := make([]Mutator, len(ircb.builders))
+	for i := range ircb.builders {
+		func(i int, root context.Context) {
+			builder := ircb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*IPReputationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ircb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ircb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ircb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ircb *IPReputationCreateBulk) SaveX(ctx context.Context) []*IPReputation {
+	v, err := ircb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ircb *IPReputationCreateBulk) Exec(ctx context.Context) error {
+	_, err := ircb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ircb *IPReputationCreateBulk) ExecX(ctx context.Context) {
+	if err := ircb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
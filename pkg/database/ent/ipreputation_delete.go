@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+)
+
+// IPReputationDelete is the builder for deleting a IPReputation entity.
+type IPReputationDelete struct {
+	config
+	hooks    []Hook
+	mutation *IPReputationMutation
+}
+
+// Where appends a list predicates to the IPReputationDelete builder.
+func (ird *IPReputationDelete) Where(ps ...predicate.IPReputation) *IPReputationDelete {
+	ird.mutation.Where(ps...)
+	return ird
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (ird *IPReputationDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, ird.sqlExec, ird.mutation, ird.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ird *IPReputationDelete) ExecX(ctx context.Context) int {
+	n, err := ird.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (ird *IPReputationDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(ipreputation.Table, sqlgraph.NewFieldSpec(ipreputation.FieldID, field.TypeInt))
+	if ps := ird.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, ird.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	ird.mutation.done = true
+	return affected, err
+}
+
+// IPReputationDeleteOne is the builder for deleting a single IPReputation entity.
+type IPReputationDeleteOne struct {
+	ird *IPReputationDelete
+}
+
+// Where appends a list predicates to the IPReputationDelete builder.
+func (irdo *IPReputationDeleteOne) Where(ps ...predicate.IPReputation) *IPReputationDeleteOne {
+	irdo.ird.mutation.Where(ps...)
+	return irdo
+}
+
+// Exec executes the deletion query.
+func (irdo *IPReputationDeleteOne) Exec(ctx context.Context) error {
+	n, err := irdo.ird.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{ipreputation.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (irdo *IPReputationDeleteOne) ExecX(ctx context.Context) {
+	if err := irdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
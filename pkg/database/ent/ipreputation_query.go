@@ -0,0 +1,527 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+)
+
+// IPReputationQuery is the builder for querying IPReputation entities.
+type IPReputationQuery struct {
+	config
+	ctx        *QueryContext
+	order      []ipreputation.OrderOption
+	inters     []Interceptor
+	predicates []predicate.IPReputation
+	// intermediate query (i.e. traversal path).
+	sql  *sql.Selector
+	path func(context.Context) (*sql.Selector, error)
+}
+
+// Where adds a new predicate for the IPReputationQuery builder.
+func (irq *IPReputationQuery) Where(ps ...predicate.IPReputation) *IPReputationQuery {
+	irq.predicates = append(irq.predicates, ps...)
+	return irq
+}
+
+// Limit the number of records to be returned by this query.
+func (irq *IPReputationQuery) Limit(limit int) *IPReputationQuery {
+	irq.ctx.Limit = &limit
+	return irq
+}
+
+// Offset to start from.
+func (irq *IPReputationQuery) Offset(offset int) *IPReputationQuery {
+	irq.ctx.Offset = &offset
+	return irq
+}
+
+// Unique configures the query builder to filter duplicate records on query.
+// By default, unique is set to true, and can be disabled using this method.
+func (irq *IPReputationQuery) Unique(unique bool) *IPReputationQuery {
+	irq.ctx.Unique = &unique
+	return irq
+}
+
+// Order specifies how the records should be ordered.
+func (irq *IPReputationQuery) Order(o ...ipreputation.OrderOption) *IPReputationQuery {
+	irq.order = append(irq.order, o...)
+	return irq
+}
+
+// First returns the first IPReputation entity from the query.
+// Returns a *NotFoundError when no IPReputation was found.
+func (irq *IPReputationQuery) First(ctx context.Context) (*IPReputation, error) {
+	nodes, err := irq.Limit(1).All(setContextOp(ctx, irq.ctx, ent.OpQueryFirst))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, &NotFoundError{ipreputation.Label}
+	}
+	return nodes[0], nil
+}
+
+// FirstX is like First, but panics if an error occurs.
+func (irq *IPReputationQuery) FirstX(ctx context.Context) *IPReputation {
+	node, err := irq.First(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return node
+}
+
+// FirstID returns the first IPReputation ID from the query.
+// Returns a *NotFoundError when no IPReputation ID was found.
+func (irq *IPReputationQuery) FirstID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = irq.Limit(1).IDs(setContextOp(ctx, irq.ctx, ent.OpQueryFirstID)); err != nil {
+		return
+	}
+	if len(ids) == 0 {
+		err = &NotFoundError{ipreputation.Label}
+		return
+	}
+	return ids[0], nil
+}
+
+// FirstIDX is like FirstID, but panics if an error occurs.
+func (irq *IPReputationQuery) FirstIDX(ctx context.Context) int {
+	id, err := irq.FirstID(ctx)
+	if err != nil && !IsNotFound(err) {
+		panic(err)
+	}
+	return id
+}
+
+// Only returns a single IPReputation entity found by the query, ensuring it only returns one.
+// Returns a *NotSingularError when more than one IPReputation entity is found.
+// Returns a *NotFoundError when no IPReputation entities are found.
+func (irq *IPReputationQuery) Only(ctx context.Context) (*IPReputation, error) {
+	nodes, err := irq.Limit(2).All(setContextOp(ctx, irq.ctx, ent.OpQueryOnly))
+	if err != nil {
+		return nil, err
+	}
+	switch len(nodes) {
+	case 1:
+		return nodes[0], nil
+	case 0:
+		return nil, &NotFoundError{ipreputation.Label}
+	default:
+		return nil, &NotSingularError{ipreputation.Label}
+	}
+}
+
+// OnlyX is like Only, but panics if an error occurs.
+func (irq *IPReputationQuery) OnlyX(ctx context.Context) *IPReputation {
+	node, err := irq.Only(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// OnlyID is like Only, but returns the only IPReputation ID in the query.
+// Returns a *NotSingularError when more than one IPReputation ID is found.
+// Returns a *NotFoundError when no entities are found.
+func (irq *IPReputationQuery) OnlyID(ctx context.Context) (id int, err error) {
+	var ids []int
+	if ids, err = irq.Limit(2).IDs(setContextOp(ctx, irq.ctx, ent.OpQueryOnlyID)); err != nil {
+		return
+	}
+	switch len(ids) {
+	case 1:
+		id = ids[0]
+	case 0:
+		err = &NotFoundError{ipreputation.Label}
+	default:
+		err = &NotSingularError{ipreputation.Label}
+	}
+	return
+}
+
+// OnlyIDX is like OnlyID, but panics if an error occurs.
+func (irq *IPReputationQuery) OnlyIDX(ctx context.Context) int {
+	id, err := irq.OnlyID(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// All executes the query and returns a list of IPReputations.
+func (irq *IPReputationQuery) All(ctx context.Context) ([]*IPReputation, error) {
+	ctx = setContextOp(ctx, irq.ctx, ent.OpQueryAll)
+	if err := irq.prepareQuery(ctx); err != nil {
+		return nil, err
+	}
+	qr := querierAll[[]*IPReputation, *IPReputationQuery]()
+	return withInterceptors[[]*IPReputation](ctx, irq, qr, irq.inters)
+}
+
+// AllX is like All, but panics if an error occurs.
+func (irq *IPReputationQuery) AllX(ctx context.Context) []*IPReputation {
+	nodes, err := irq.All(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return nodes
+}
+
+// IDs executes the query and returns a list of IPReputation IDs.
+func (irq *IPReputationQuery) IDs(ctx context.Context) (ids []int, err error) {
+	if irq.ctx.Unique == nil && irq.path != nil {
+		irq.Unique(true)
+	}
+	ctx = setContextOp(ctx, irq.ctx, ent.OpQueryIDs)
+	if err = irq.Select(ipreputation.FieldID).Scan(ctx, &ids); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// IDsX is like IDs, but panics if an error occurs.
+func (irq *IPReputationQuery) IDsX(ctx context.Context) []int {
+	ids, err := irq.IDs(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return ids
+}
+
+// Count returns the count of the given query.
+func (irq *IPReputationQuery) Count(ctx context.Context) (int, error) {
+	ctx = setContextOp(ctx, irq.ctx, ent.OpQueryCount)
+	if err := irq.prepareQuery(ctx); err != nil {
+		return 0, err
+	}
+	return withInterceptors[int](ctx, irq, querierCount[*IPReputationQuery](), irq.inters)
+}
+
+// CountX is like Count, but panics if an error occurs.
+func (irq *IPReputationQuery) CountX(ctx context.Context) int {
+	count, err := irq.Count(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return count
+}
+
+// Exist returns true if the query has elements in the graph.
+func (irq *IPReputationQuery) Exist(ctx context.Context) (bool, error) {
+	ctx = setContextOp(ctx, irq.ctx, ent.OpQueryExist)
+	switch _, err := irq.FirstID(ctx); {
+	case IsNotFound(err):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("ent: check existence: %w", err)
+	default:
+		return true, nil
+	}
+}
+
+// ExistX is like Exist, but panics if an error occurs.
+func (irq *IPReputationQuery) ExistX(ctx context.Context) bool {
+	exist, err := irq.Exist(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return exist
+}
+
+// Clone returns a duplicate of the IPReputationQuery builder, including all associated steps. It can be
+// used to prepare common query builders and use them differently after the clone is made.
+func (irq *IPReputationQuery) Clone() *IPReputationQuery {
+	if irq == nil {
+		return nil
+	}
+	return &IPReputationQuery{
+		config:     irq.config,
+		ctx:        irq.ctx.Clone(),
+		order:      append([]ipreputation.OrderOption{}, irq.order...),
+		inters:     append([]Interceptor{}, irq.inters...),
+		predicates: append([]predicate.IPReputation{}, irq.predicates...),
+		// clone intermediate query.
+		sql:  irq.sql.Clone(),
+		path: irq.path,
+	}
+}
+
+// GroupBy is used to group vertices by one or more fields/columns.
+// It is often used with aggregate functions, like: count, max, mean, min, sum.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//		Count int `json:"count,omitempty"`
+//	}
+//
+//	client.IPReputation.Query().
+//		GroupBy(ipreputation.FieldCreatedAt).
+//		Aggregate(ent.Count()).
+//		Scan(ctx, &v)
+func (irq *IPReputationQuery) GroupBy(field string, fields ...string) *IPReputationGroupBy {
+	irq.ctx.Fields = append([]string{field}, fields...)
+	grbuild := &IPReputationGroupBy{build: irq}
+	grbuild.flds = &irq.ctx.Fields
+	grbuild.label = ipreputation.Label
+	grbuild.scan = grbuild.Scan
+	return grbuild
+}
+
+// Select allows the selection one or more fields/columns for the given query,
+// instead of selecting all fields in the entity.
+//
+// Example:
+//
+//	var v []struct {
+//		CreatedAt time.Time `json:"created_at,omitempty"`
+//	}
+//
+//	client.IPReputation.Query().
+//		Select(ipreputation.FieldCreatedAt).
+//		Scan(ctx, &v)
+func (irq *IPReputationQuery) Select(fields ...string) *IPReputationSelect {
+	irq.ctx.Fields = append(irq.ctx.Fields, fields...)
+	sbuild := &IPReputationSelect{IPReputationQuery: irq}
+	sbuild.label = ipreputation.Label
+	sbuild.flds, sbuild.scan = &irq.ctx.Fields, sbuild.Scan
+	return sbuild
+}
+
+// Aggregate returns a IPReputationSelect configured with the given aggregations.
+func (irq *IPReputationQuery) Aggregate(fns ...AggregateFunc) *IPReputationSelect {
+	return irq.Select().Aggregate(fns...)
+}
+
+func (irq *IPReputationQuery) prepareQuery(ctx context.Context) error {
+	for _, inter := range irq.inters {
+		if inter == nil {
+			return fmt.Errorf("ent: uninitialized interceptor (forgotten import ent/runtime?)")
+		}
+		if trv, ok := inter.(Traverser); ok {
+			if err := trv.Traverse(ctx, irq); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range irq.ctx.Fields {
+		if !ipreputation.ValidColumn(f) {
+			return &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+		}
+	}
+	if irq.path != nil {
+		prev, err := irq.path(ctx)
+		if err != nil {
+			return err
+		}
+		irq.sql = prev
+	}
+	return nil
+}
+
+func (irq *IPReputationQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*IPReputation, error) {
+	var (
+		nodes = []*IPReputation{}
+		_spec = irq.querySpec()
+	)
+	_spec.ScanValues = func(columns []string) ([]any, error) {
+		return (*IPReputation).scanValues(nil, columns)
+	}
+	_spec.Assign = func(columns []string, values []any) error {
+		node := &IPReputation{config: irq.config}
+		nodes = append(nodes, node)
+		return node.assignValues(columns, values)
+	}
+	for i := range hooks {
+		hooks[i](ctx, _spec)
+	}
+	if err := sqlgraph.QueryNodes(ctx, irq.driver, _spec); err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nodes, nil
+	}
+	return nodes, nil
+}
+
+func (irq *IPReputationQuery) sqlCount(ctx context.Context) (int, error) {
+	_spec := irq.querySpec()
+	_spec.Node.Columns = irq.ctx.Fields
+	if len(irq.ctx.Fields) > 0 {
+		_spec.Unique = irq.ctx.Unique != nil && *irq.ctx.Unique
+	}
+	return sqlgraph.CountNodes(ctx, irq.driver, _spec)
+}
+
+func (irq *IPReputationQuery) querySpec() *sqlgraph.QuerySpec {
+	_spec := sqlgraph.NewQuerySpec(ipreputation.Table, ipreputation.Columns, sqlgraph.NewFieldSpec(ipreputation.FieldID, field.TypeInt))
+	_spec.From = irq.sql
+	if unique := irq.ctx.Unique; unique != nil {
+		_spec.Unique = *unique
+	} else if irq.path != nil {
+		_spec.Unique = true
+	}
+	if fields := irq.ctx.Fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, ipreputation.FieldID)
+		for i := range fields {
+			if fields[i] != ipreputation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
+			}
+		}
+	}
+	if ps := irq.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if limit := irq.ctx.Limit; limit != nil {
+		_spec.Limit = *limit
+	}
+	if offset := irq.ctx.Offset; offset != nil {
+		_spec.Offset = *offset
+	}
+	if ps := irq.order; len(ps) > 0 {
+		_spec.Order = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	return _spec
+}
+
+func (irq *IPReputationQuery) sqlQuery(ctx context.Context) *sql.Selector {
+	builder := sql.Dialect(irq.driver.Dialect())
+	t1 := builder.Table(ipreputation.Table)
+	columns := irq.ctx.Fields
+	if len(columns) == 0 {
+		columns = ipreputation.Columns
+	}
+	selector := builder.Select(t1.Columns(columns...)...).From(t1)
+	if irq.sql != nil {
+		selector = irq.sql
+		selector.Select(selector.Columns(columns...)...)
+	}
+	if irq.ctx.Unique != nil && *irq.ctx.Unique {
+		selector.Distinct()
+	}
+	for _, p := range irq.predicates {
+		p(selector)
+	}
+	for _, p := range irq.order {
+		p(selector)
+	}
+	if offset := irq.ctx.Offset; offset != nil {
+		// limit is mandatory for offset clause. We start
+		// with default value, and override it below if needed.
+		selector.Offset(*offset).Limit(math.MaxInt32)
+	}
+	if limit := irq.ctx.Limit; limit != nil {
+		selector.Limit(*limit)
+	}
+	return selector
+}
+
+// IPReputationGroupBy is the group-by builder for IPReputation entities.
+type IPReputationGroupBy struct {
+	selector
+	build *IPReputationQuery
+}
+
+// Aggregate adds the given aggregation functions to the group-by query.
+func (irgb *IPReputationGroupBy) Aggregate(fns ...AggregateFunc) *IPReputationGroupBy {
+	irgb.fns = append(irgb.fns, fns...)
+	return irgb
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (irgb *IPReputationGroupBy) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, irgb.build.ctx, ent.OpQueryGroupBy)
+	if err := irgb.build.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*IPReputationQuery, *IPReputationGroupBy](ctx, irgb.build, irgb, irgb.build.inters, v)
+}
+
+func (irgb *IPReputationGroupBy) sqlScan(ctx context.Context, root *IPReputationQuery, v any) error {
+	selector := root.sqlQuery(ctx).Select()
+	aggregation := make([]string, 0, len(irgb.fns))
+	for _, fn := range irgb.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	if len(selector.SelectedColumns()) == 0 {
+		columns := make([]string, 0, len(*irgb.flds)+len(irgb.fns))
+		for _, f := range *irgb.flds {
+			columns = append(columns, selector.C(f))
+		}
+		columns = append(columns, aggregation...)
+		selector.Select(columns...)
+	}
+	selector.GroupBy(selector.Columns(*irgb.flds...)...)
+	if err := selector.Err(); err != nil {
+		return err
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := irgb.build.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
+
+// IPReputationSelect is the builder for selecting fields of IPReputation entities.
+type IPReputationSelect struct {
+	*IPReputationQuery
+	selector
+}
+
+// Aggregate adds the given aggregation functions to the selector query.
+func (irs *IPReputationSelect) Aggregate(fns ...AggregateFunc) *IPReputationSelect {
+	irs.fns = append(irs.fns, fns...)
+	return irs
+}
+
+// Scan applies the selector query and scans the result into the given value.
+func (irs *IPReputationSelect) Scan(ctx context.Context, v any) error {
+	ctx = setContextOp(ctx, irs.ctx, ent.OpQuerySelect)
+	if err := irs.prepareQuery(ctx); err != nil {
+		return err
+	}
+	return scanWithInterceptors[*IPReputationQuery, *IPReputationSelect](ctx, irs.IPReputationQuery, irs, irs.inters, v)
+}
+
+func (irs *IPReputationSelect) sqlScan(ctx context.Context, root *IPReputationQuery, v any) error {
+	selector := root.sqlQuery(ctx)
+	aggregation := make([]string, 0, len(irs.fns))
+	for _, fn := range irs.fns {
+		aggregation = append(aggregation, fn(selector))
+	}
+	switch n := len(*irs.selector.flds); {
+	case n == 0 && len(aggregation) > 0:
+		selector.Select(aggregation...)
+	case n != 0 && len(aggregation) > 0:
+		selector.AppendSelect(aggregation...)
+	}
+	rows := &sql.Rows{}
+	query, args := selector.Query()
+	if err := irs.driver.Query(ctx, query, args, rows); err != nil {
+		return err
+	}
+	defer rows.Close()
+	return sql.ScanSlice(rows, v)
+}
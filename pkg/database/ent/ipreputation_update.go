@@ -0,0 +1,280 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+)
+
+// IPReputationUpdate is the builder for updating IPReputation entities.
+type IPReputationUpdate struct {
+	config
+	hooks    []Hook
+	mutation *IPReputationMutation
+}
+
+// Where appends a list predicates to the IPReputationUpdate builder.
+func (iru *IPReputationUpdate) Where(ps ...predicate.IPReputation) *IPReputationUpdate {
+	iru.mutation.Where(ps...)
+	return iru
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (iru *IPReputationUpdate) SetUpdatedAt(t time.Time) *IPReputationUpdate {
+	iru.mutation.SetUpdatedAt(t)
+	return iru
+}
+
+// SetData sets the "data" field.
+func (iru *IPReputationUpdate) SetData(s string) *IPReputationUpdate {
+	iru.mutation.SetData(s)
+	return iru
+}
+
+// SetNillableData sets the "data" field if the given value is not nil.
+func (iru *IPReputationUpdate) SetNillableData(s *string) *IPReputationUpdate {
+	if s != nil {
+		iru.SetData(*s)
+	}
+	return iru
+}
+
+// SetFetchedAt sets the "fetched_at" field.
+func (iru *IPReputationUpdate) SetFetchedAt(t time.Time) *IPReputationUpdate {
+	iru.mutation.SetFetchedAt(t)
+	return iru
+}
+
+// SetNillableFetchedAt sets the "fetched_at" field if the given value is not nil.
+func (iru *IPReputationUpdate) SetNillableFetchedAt(t *time.Time) *IPReputationUpdate {
+	if t != nil {
+		iru.SetFetchedAt(*t)
+	}
+	return iru
+}
+
+// Mutation returns the IPReputationMutation object of the builder.
+func (iru *IPReputationUpdate) Mutation() *IPReputationMutation {
+	return iru.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iru *IPReputationUpdate) Save(ctx context.Context) (int, error) {
+	iru.defaults()
+	return withHooks(ctx, iru.sqlSave, iru.mutation, iru.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iru *IPReputationUpdate) SaveX(ctx context.Context) int {
+	affected, err := iru.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iru *IPReputationUpdate) Exec(ctx context.Context) error {
+	_, err := iru.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iru *IPReputationUpdate) ExecX(ctx context.Context) {
+	if err := iru.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (iru *IPReputationUpdate) defaults() {
+	if _, ok := iru.mutation.UpdatedAt(); !ok {
+		v := ipreputation.UpdateDefaultUpdatedAt()
+		iru.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (iru *IPReputationUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(ipreputation.Table, ipreputation.Columns, sqlgraph.NewFieldSpec(ipreputation.FieldID, field.TypeInt))
+	if ps := iru.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iru.mutation.UpdatedAt(); ok {
+		_spec.SetField(ipreputation.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := iru.mutation.Data(); ok {
+		_spec.SetField(ipreputation.FieldData, field.TypeString, value)
+	}
+	if value, ok := iru.mutation.FetchedAt(); ok {
+		_spec.SetField(ipreputation.FieldFetchedAt, field.TypeTime, value)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, iru.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ipreputation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iru.mutation.done = true
+	return n, nil
+}
+
+// IPReputationUpdateOne is the builder for updating a single IPReputation entity.
+type IPReputationUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *IPReputationMutation
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (iruo *IPReputationUpdateOne) SetUpdatedAt(t time.Time) *IPReputationUpdateOne {
+	iruo.mutation.SetUpdatedAt(t)
+	return iruo
+}
+
+// SetData sets the "data" field.
+func (iruo *IPReputationUpdateOne) SetData(s string) *IPReputationUpdateOne {
+	iruo.mutation.SetData(s)
+	return iruo
+}
+
+// SetNillableData sets the "data" field if the given value is not nil.
+func (iruo *IPReputationUpdateOne) SetNillableData(s *string) *IPReputationUpdateOne {
+	if s != nil {
+		iruo.SetData(*s)
+	}
+	return iruo
+}
+
+// SetFetchedAt sets the "fetched_at" field.
+func (iruo *IPReputationUpdateOne) SetFetchedAt(t time.Time) *IPReputationUpdateOne {
+	iruo.mutation.SetFetchedAt(t)
+	return iruo
+}
+
+// SetNillableFetchedAt sets the "fetched_at" field if the given value is not nil.
+func (iruo *IPReputationUpdateOne) SetNillableFetchedAt(t *time.Time) *IPReputationUpdateOne {
+	if t != nil {
+		iruo.SetFetchedAt(*t)
+	}
+	return iruo
+}
+
+// Mutation returns the IPReputationMutation object of the builder.
+func (iruo *IPReputationUpdateOne) Mutation() *IPReputationMutation {
+	return iruo.mutation
+}
+
+// Where appends a list predicates to the IPReputationUpdate builder.
+func (iruo *IPReputationUpdateOne) Where(ps ...predicate.IPReputation) *IPReputationUpdateOne {
+	iruo.mutation.Where(ps...)
+	return iruo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (iruo *IPReputationUpdateOne) Select(field string, fields ...string) *IPReputationUpdateOne {
+	iruo.fields = append([]string{field}, fields...)
+	return iruo
+}
+
+// Save executes the query and returns the updated IPReputation entity.
+func (iruo *IPReputationUpdateOne) Save(ctx context.Context) (*IPReputation, error) {
+	iruo.defaults()
+	return withHooks(ctx, iruo.sqlSave, iruo.mutation, iruo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iruo *IPReputationUpdateOne) SaveX(ctx context.Context) *IPReputation {
+	node, err := iruo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (iruo *IPReputationUpdateOne) Exec(ctx context.Context) error {
+	_, err := iruo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iruo *IPReputationUpdateOne) ExecX(ctx context.Context) {
+	if err := iruo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (iruo *IPReputationUpdateOne) defaults() {
+	if _, ok := iruo.mutation.UpdatedAt(); !ok {
+		v := ipreputation.UpdateDefaultUpdatedAt()
+		iruo.mutation.SetUpdatedAt(v)
+	}
+}
+
+func (iruo *IPReputationUpdateOne) sqlSave(ctx context.Context) (_node *IPReputation, err error) {
+	_spec := sqlgraph.NewUpdateSpec(ipreputation.Table, ipreputation.Columns, sqlgraph.NewFieldSpec(ipreputation.FieldID, field.TypeInt))
+	id, ok := iruo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "IPReputation.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := iruo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, ipreputation.FieldID)
+		for _, f := range fields {
+			if !ipreputation.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != ipreputation.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := iruo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iruo.mutation.UpdatedAt(); ok {
+		_spec.SetField(ipreputation.FieldUpdatedAt, field.TypeTime, value)
+	}
+	if value, ok := iruo.mutation.Data(); ok {
+		_spec.SetField(ipreputation.FieldData, field.TypeString, value)
+	}
+	if value, ok := iruo.mutation.FetchedAt(); ok {
+		_spec.SetField(ipreputation.FieldFetchedAt, field.TypeTime, value)
+	}
+	_node = &IPReputation{config: iruo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, iruo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{ipreputation.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	iruo.mutation.done = true
+	return _node, nil
+}
@@ -135,6 +135,7 @@ var (
 		{Name: "osversion", Type: field.TypeString, Nullable: true},
 		{Name: "featureflags", Type: field.TypeString, Nullable: true},
 		{Name: "auto_created", Type: field.TypeBool, Default: false},
+		{Name: "filters", Type: field.TypeJSON, Nullable: true},
 	}
 	// BouncersTable holds the schema information for the "bouncers" table.
 	BouncersTable = &schema.Table{
@@ -174,6 +175,7 @@ var (
 		{Name: "origin", Type: field.TypeString},
 		{Name: "simulated", Type: field.TypeBool, Default: false},
 		{Name: "uuid", Type: field.TypeString, Nullable: true},
+		{Name: "escalation_step", Type: field.TypeInt, Nullable: true},
 		{Name: "alert_decisions", Type: field.TypeInt, Nullable: true},
 	}
 	// DecisionsTable holds the schema information for the "decisions" table.
@@ -184,7 +186,7 @@ var (
 		ForeignKeys: []*schema.ForeignKey{
 			{
 				Symbol:     "decisions_alerts_decisions",
-				Columns:    []*schema.Column{DecisionsColumns[16]},
+				Columns:    []*schema.Column{DecisionsColumns[17]},
 				RefColumns: []*schema.Column{AlertsColumns[0]},
 				OnDelete:   schema.Cascade,
 			},
@@ -208,7 +210,7 @@ var (
 			{
 				Name:    "decision_alert_decisions",
 				Unique:  false,
-				Columns: []*schema.Column{DecisionsColumns[16]},
+				Columns: []*schema.Column{DecisionsColumns[17]},
 			},
 		},
 	}
@@ -242,6 +244,21 @@ var (
 			},
 		},
 	}
+	// IPReputationsColumns holds the columns for the "ip_reputations" table.
+	IPReputationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "ip", Type: field.TypeString, Unique: true},
+		{Name: "data", Type: field.TypeString, Size: 2147483647},
+		{Name: "fetched_at", Type: field.TypeTime},
+	}
+	// IPReputationsTable holds the schema information for the "ip_reputations" table.
+	IPReputationsTable = &schema.Table{
+		Name:       "ip_reputations",
+		Columns:    IPReputationsColumns,
+		PrimaryKey: []*schema.Column{IPReputationsColumns[0]},
+	}
 	// LocksColumns holds the columns for the "locks" table.
 	LocksColumns = []*schema.Column{
 		{Name: "id", Type: field.TypeInt, Increment: true},
@@ -325,6 +342,33 @@ var (
 		Columns:    MetricsColumns,
 		PrimaryKey: []*schema.Column{MetricsColumns[0]},
 	}
+	// NotificationsColumns holds the columns for the "notifications" table.
+	NotificationsColumns = []*schema.Column{
+		{Name: "id", Type: field.TypeInt, Increment: true},
+		{Name: "created_at", Type: field.TypeTime},
+		{Name: "updated_at", Type: field.TypeTime},
+		{Name: "plugin", Type: field.TypeString},
+		{Name: "message", Type: field.TypeString, Size: 2147483647},
+		{Name: "alert_count", Type: field.TypeInt, Default: 0},
+		{Name: "status", Type: field.TypeEnum, Enums: []string{"pending", "sending", "delivered", "failed"}, Default: "pending"},
+		{Name: "attempts", Type: field.TypeInt, Default: 0},
+		{Name: "next_attempt_at", Type: field.TypeTime},
+		{Name: "last_error", Type: field.TypeString, Nullable: true, Size: 2147483647},
+		{Name: "delivered_at", Type: field.TypeTime, Nullable: true},
+	}
+	// NotificationsTable holds the schema information for the "notifications" table.
+	NotificationsTable = &schema.Table{
+		Name:       "notifications",
+		Columns:    NotificationsColumns,
+		PrimaryKey: []*schema.Column{NotificationsColumns[0]},
+		Indexes: []*schema.Index{
+			{
+				Name:    "notification_status_next_attempt_at",
+				Unique:  false,
+				Columns: []*schema.Column{NotificationsColumns[6], NotificationsColumns[8]},
+			},
+		},
+	}
 	// AllowListAllowlistItemsColumns holds the columns for the "allow_list_allowlist_items" table.
 	AllowListAllowlistItemsColumns = []*schema.Column{
 		{Name: "allow_list_id", Type: field.TypeInt},
@@ -359,10 +403,12 @@ var (
 		ConfigItemsTable,
 		DecisionsTable,
 		EventsTable,
+		IPReputationsTable,
 		LocksTable,
 		MachinesTable,
 		MetaTable,
 		MetricsTable,
+		NotificationsTable,
 		AllowListAllowlistItemsTable,
 	}
 )
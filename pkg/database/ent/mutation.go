@@ -18,10 +18,12 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/configitem"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/decision"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/event"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/lock"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/machine"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/meta"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/metric"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 )
@@ -42,10 +44,12 @@ const (
 	TypeConfigItem    = "ConfigItem"
 	TypeDecision      = "Decision"
 	TypeEvent         = "Event"
+	TypeIPReputation  = "IPReputation"
 	TypeLock          = "Lock"
 	TypeMachine       = "Machine"
 	TypeMeta          = "Meta"
 	TypeMetric        = "Metric"
+	TypeNotification  = "Notification"
 )
 
 // AlertMutation represents an operation that mutates the Alert nodes in the graph.
@@ -4420,6 +4424,7 @@ type BouncerMutation struct {
 	osversion     *string
 	featureflags  *string
 	auto_created  *bool
+	filters       **schema.BouncerFilters
 	clearedFields map[string]struct{}
 	done          bool
 	oldValue      func(context.Context) (*Bouncer, error)
@@ -5119,6 +5124,55 @@ func (m *BouncerMutation) ResetAutoCreated() {
 	m.auto_created = nil
 }
 
+// SetFilters sets the "filters" field.
+func (m *BouncerMutation) SetFilters(sf *schema.BouncerFilters) {
+	m.filters = &sf
+}
+
+// Filters returns the value of the "filters" field in the mutation.
+func (m *BouncerMutation) Filters() (r *schema.BouncerFilters, exists bool) {
+	v := m.filters
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFilters returns the old "filters" field's value of the Bouncer entity.
+// If the Bouncer object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *BouncerMutation) OldFilters(ctx context.Context) (v *schema.BouncerFilters, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFilters is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFilters requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFilters: %w", err)
+	}
+	return oldValue.Filters, nil
+}
+
+// ClearFilters clears the value of the "filters" field.
+func (m *BouncerMutation) ClearFilters() {
+	m.filters = nil
+	m.clearedFields[bouncer.FieldFilters] = struct{}{}
+}
+
+// FiltersCleared returns if the "filters" field was cleared in this mutation.
+func (m *BouncerMutation) FiltersCleared() bool {
+	_, ok := m.clearedFields[bouncer.FieldFilters]
+	return ok
+}
+
+// ResetFilters resets all changes to the "filters" field.
+func (m *BouncerMutation) ResetFilters() {
+	m.filters = nil
+	delete(m.clearedFields, bouncer.FieldFilters)
+}
+
 // Where appends a list predicates to the BouncerMutation builder.
 func (m *BouncerMutation) Where(ps ...predicate.Bouncer) {
 	m.predicates = append(m.predicates, ps...)
@@ -5153,7 +5207,7 @@ func (m *BouncerMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *BouncerMutation) Fields() []string {
-	fields := make([]string, 0, 14)
+	fields := make([]string, 0, 15)
 	if m.created_at != nil {
 		fields = append(fields, bouncer.FieldCreatedAt)
 	}
@@ -5196,6 +5250,9 @@ func (m *BouncerMutation) Fields() []string {
 	if m.auto_created != nil {
 		fields = append(fields, bouncer.FieldAutoCreated)
 	}
+	if m.filters != nil {
+		fields = append(fields, bouncer.FieldFilters)
+	}
 	return fields
 }
 
@@ -5232,6 +5289,8 @@ func (m *BouncerMutation) Field(name string) (ent.Value, bool) {
 		return m.Featureflags()
 	case bouncer.FieldAutoCreated:
 		return m.AutoCreated()
+	case bouncer.FieldFilters:
+		return m.Filters()
 	}
 	return nil, false
 }
@@ -5269,6 +5328,8 @@ func (m *BouncerMutation) OldField(ctx context.Context, name string) (ent.Value,
 		return m.OldFeatureflags(ctx)
 	case bouncer.FieldAutoCreated:
 		return m.OldAutoCreated(ctx)
+	case bouncer.FieldFilters:
+		return m.OldFilters(ctx)
 	}
 	return nil, fmt.Errorf("unknown Bouncer field %s", name)
 }
@@ -5376,6 +5437,13 @@ func (m *BouncerMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetAutoCreated(v)
 		return nil
+	case bouncer.FieldFilters:
+		v, ok := value.(*schema.BouncerFilters)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFilters(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Bouncer field %s", name)
 }
@@ -5427,6 +5495,9 @@ func (m *BouncerMutation) ClearedFields() []string {
 	if m.FieldCleared(bouncer.FieldFeatureflags) {
 		fields = append(fields, bouncer.FieldFeatureflags)
 	}
+	if m.FieldCleared(bouncer.FieldFilters) {
+		fields = append(fields, bouncer.FieldFilters)
+	}
 	return fields
 }
 
@@ -5462,6 +5533,9 @@ func (m *BouncerMutation) ClearField(name string) error {
 	case bouncer.FieldFeatureflags:
 		m.ClearFeatureflags()
 		return nil
+	case bouncer.FieldFilters:
+		m.ClearFilters()
+		return nil
 	}
 	return fmt.Errorf("unknown Bouncer nullable field %s", name)
 }
@@ -5512,6 +5586,9 @@ func (m *BouncerMutation) ResetField(name string) error {
 	case bouncer.FieldAutoCreated:
 		m.ResetAutoCreated()
 		return nil
+	case bouncer.FieldFilters:
+		m.ResetFilters()
+		return nil
 	}
 	return fmt.Errorf("unknown Bouncer field %s", name)
 }
@@ -6055,35 +6132,37 @@ func (m *ConfigItemMutation) ResetEdge(name string) error {
 // DecisionMutation represents an operation that mutates the Decision nodes in the graph.
 type DecisionMutation struct {
 	config
-	op              Op
-	typ             string
-	id              *int
-	created_at      *time.Time
-	updated_at      *time.Time
-	until           *time.Time
-	scenario        *string
-	_type           *string
-	start_ip        *int64
-	addstart_ip     *int64
-	end_ip          *int64
-	addend_ip       *int64
-	start_suffix    *int64
-	addstart_suffix *int64
-	end_suffix      *int64
-	addend_suffix   *int64
-	ip_size         *int64
-	addip_size      *int64
-	scope           *string
-	value           *string
-	origin          *string
-	simulated       *bool
-	uuid            *string
-	clearedFields   map[string]struct{}
-	owner           *int
-	clearedowner    bool
-	done            bool
-	oldValue        func(context.Context) (*Decision, error)
-	predicates      []predicate.Decision
+	op                 Op
+	typ                string
+	id                 *int
+	created_at         *time.Time
+	updated_at         *time.Time
+	until              *time.Time
+	scenario           *string
+	_type              *string
+	start_ip           *int64
+	addstart_ip        *int64
+	end_ip             *int64
+	addend_ip          *int64
+	start_suffix       *int64
+	addstart_suffix    *int64
+	end_suffix         *int64
+	addend_suffix      *int64
+	ip_size            *int64
+	addip_size         *int64
+	scope              *string
+	value              *string
+	origin             *string
+	simulated          *bool
+	uuid               *string
+	escalation_step    *int
+	addescalation_step *int
+	clearedFields      map[string]struct{}
+	owner              *int
+	clearedowner       bool
+	done               bool
+	oldValue           func(context.Context) (*Decision, error)
+	predicates         []predicate.Decision
 }
 
 var _ ent.Mutation = (*DecisionMutation)(nil)
@@ -6969,6 +7048,76 @@ func (m *DecisionMutation) ResetAlertDecisions() {
 	delete(m.clearedFields, decision.FieldAlertDecisions)
 }
 
+// SetEscalationStep sets the "escalation_step" field.
+func (m *DecisionMutation) SetEscalationStep(i int) {
+	m.escalation_step = &i
+	m.addescalation_step = nil
+}
+
+// EscalationStep returns the value of the "escalation_step" field in the mutation.
+func (m *DecisionMutation) EscalationStep() (r int, exists bool) {
+	v := m.escalation_step
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldEscalationStep returns the old "escalation_step" field's value of the Decision entity.
+// If the Decision object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *DecisionMutation) OldEscalationStep(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldEscalationStep is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldEscalationStep requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldEscalationStep: %w", err)
+	}
+	return oldValue.EscalationStep, nil
+}
+
+// AddEscalationStep adds i to the "escalation_step" field.
+func (m *DecisionMutation) AddEscalationStep(i int) {
+	if m.addescalation_step != nil {
+		*m.addescalation_step += i
+	} else {
+		m.addescalation_step = &i
+	}
+}
+
+// AddedEscalationStep returns the value that was added to the "escalation_step" field in this mutation.
+func (m *DecisionMutation) AddedEscalationStep() (r int, exists bool) {
+	v := m.addescalation_step
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ClearEscalationStep clears the value of the "escalation_step" field.
+func (m *DecisionMutation) ClearEscalationStep() {
+	m.escalation_step = nil
+	m.addescalation_step = nil
+	m.clearedFields[decision.FieldEscalationStep] = struct{}{}
+}
+
+// EscalationStepCleared returns if the "escalation_step" field was cleared in this mutation.
+func (m *DecisionMutation) EscalationStepCleared() bool {
+	_, ok := m.clearedFields[decision.FieldEscalationStep]
+	return ok
+}
+
+// ResetEscalationStep resets all changes to the "escalation_step" field.
+func (m *DecisionMutation) ResetEscalationStep() {
+	m.escalation_step = nil
+	m.addescalation_step = nil
+	delete(m.clearedFields, decision.FieldEscalationStep)
+}
+
 // SetOwnerID sets the "owner" edge to the Alert entity by id.
 func (m *DecisionMutation) SetOwnerID(id int) {
 	m.owner = &id
@@ -7043,7 +7192,7 @@ func (m *DecisionMutation) Type() string {
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
 func (m *DecisionMutation) Fields() []string {
-	fields := make([]string, 0, 16)
+	fields := make([]string, 0, 17)
 	if m.created_at != nil {
 		fields = append(fields, decision.FieldCreatedAt)
 	}
@@ -7092,6 +7241,9 @@ func (m *DecisionMutation) Fields() []string {
 	if m.owner != nil {
 		fields = append(fields, decision.FieldAlertDecisions)
 	}
+	if m.escalation_step != nil {
+		fields = append(fields, decision.FieldEscalationStep)
+	}
 	return fields
 }
 
@@ -7132,6 +7284,8 @@ func (m *DecisionMutation) Field(name string) (ent.Value, bool) {
 		return m.UUID()
 	case decision.FieldAlertDecisions:
 		return m.AlertDecisions()
+	case decision.FieldEscalationStep:
+		return m.EscalationStep()
 	}
 	return nil, false
 }
@@ -7173,6 +7327,8 @@ func (m *DecisionMutation) OldField(ctx context.Context, name string) (ent.Value
 		return m.OldUUID(ctx)
 	case decision.FieldAlertDecisions:
 		return m.OldAlertDecisions(ctx)
+	case decision.FieldEscalationStep:
+		return m.OldEscalationStep(ctx)
 	}
 	return nil, fmt.Errorf("unknown Decision field %s", name)
 }
@@ -7294,6 +7450,13 @@ func (m *DecisionMutation) SetField(name string, value ent.Value) error {
 		}
 		m.SetAlertDecisions(v)
 		return nil
+	case decision.FieldEscalationStep:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetEscalationStep(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Decision field %s", name)
 }
@@ -7317,6 +7480,9 @@ func (m *DecisionMutation) AddedFields() []string {
 	if m.addip_size != nil {
 		fields = append(fields, decision.FieldIPSize)
 	}
+	if m.addescalation_step != nil {
+		fields = append(fields, decision.FieldEscalationStep)
+	}
 	return fields
 }
 
@@ -7335,6 +7501,8 @@ func (m *DecisionMutation) AddedField(name string) (ent.Value, bool) {
 		return m.AddedEndSuffix()
 	case decision.FieldIPSize:
 		return m.AddedIPSize()
+	case decision.FieldEscalationStep:
+		return m.AddedEscalationStep()
 	}
 	return nil, false
 }
@@ -7379,6 +7547,13 @@ func (m *DecisionMutation) AddField(name string, value ent.Value) error {
 		}
 		m.AddIPSize(v)
 		return nil
+	case decision.FieldEscalationStep:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddEscalationStep(v)
+		return nil
 	}
 	return fmt.Errorf("unknown Decision numeric field %s", name)
 }
@@ -7411,6 +7586,9 @@ func (m *DecisionMutation) ClearedFields() []string {
 	if m.FieldCleared(decision.FieldAlertDecisions) {
 		fields = append(fields, decision.FieldAlertDecisions)
 	}
+	if m.FieldCleared(decision.FieldEscalationStep) {
+		fields = append(fields, decision.FieldEscalationStep)
+	}
 	return fields
 }
 
@@ -7449,6 +7627,9 @@ func (m *DecisionMutation) ClearField(name string) error {
 	case decision.FieldAlertDecisions:
 		m.ClearAlertDecisions()
 		return nil
+	case decision.FieldEscalationStep:
+		m.ClearEscalationStep()
+		return nil
 	}
 	return fmt.Errorf("unknown Decision nullable field %s", name)
 }
@@ -7505,6 +7686,9 @@ func (m *DecisionMutation) ResetField(name string) error {
 	case decision.FieldAlertDecisions:
 		m.ResetAlertDecisions()
 		return nil
+	case decision.FieldEscalationStep:
+		m.ResetEscalationStep()
+		return nil
 	}
 	return fmt.Errorf("unknown Decision field %s", name)
 }
@@ -8217,31 +8401,34 @@ func (m *EventMutation) ResetEdge(name string) error {
 	return fmt.Errorf("unknown Event edge %s", name)
 }
 
-// LockMutation represents an operation that mutates the Lock nodes in the graph.
-type LockMutation struct {
+// IPReputationMutation represents an operation that mutates the IPReputation nodes in the graph.
+type IPReputationMutation struct {
 	config
 	op            Op
 	typ           string
 	id            *int
-	name          *string
 	created_at    *time.Time
+	updated_at    *time.Time
+	ip            *string
+	data          *string
+	fetched_at    *time.Time
 	clearedFields map[string]struct{}
 	done          bool
-	oldValue      func(context.Context) (*Lock, error)
-	predicates    []predicate.Lock
+	oldValue      func(context.Context) (*IPReputation, error)
+	predicates    []predicate.IPReputation
 }
 
-var _ ent.Mutation = (*LockMutation)(nil)
+var _ ent.Mutation = (*IPReputationMutation)(nil)
 
-// lockOption allows management of the mutation configuration using functional options.
-type lockOption func(*LockMutation)
+// ipreputationOption allows management of the mutation configuration using functional options.
+type ipreputationOption func(*IPReputationMutation)
 
-// newLockMutation creates new mutation for the Lock entity.
-func newLockMutation(c config, op Op, opts ...lockOption) *LockMutation {
-	m := &LockMutation{
+// newIPReputationMutation creates new mutation for the IPReputation entity.
+func newIPReputationMutation(c config, op Op, opts ...ipreputationOption) *IPReputationMutation {
+	m := &IPReputationMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeLock,
+		typ:           TypeIPReputation,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -8250,20 +8437,20 @@ func newLockMutation(c config, op Op, opts ...lockOption) *LockMutation {
 	return m
 }
 
-// withLockID sets the ID field of the mutation.
-func withLockID(id int) lockOption {
-	return func(m *LockMutation) {
+// withIPReputationID sets the ID field of the mutation.
+func withIPReputationID(id int) ipreputationOption {
+	return func(m *IPReputationMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Lock
+			value *IPReputation
 		)
-		m.oldValue = func(ctx context.Context) (*Lock, error) {
+		m.oldValue = func(ctx context.Context) (*IPReputation, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Lock.Get(ctx, id)
+					value, err = m.Client().IPReputation.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -8272,10 +8459,10 @@ func withLockID(id int) lockOption {
 	}
 }
 
-// withLock sets the old Lock of the mutation.
-func withLock(node *Lock) lockOption {
-	return func(m *LockMutation) {
-		m.oldValue = func(context.Context) (*Lock, error) {
+// withIPReputation sets the old IPReputation of the mutation.
+func withIPReputation(node *IPReputation) ipreputationOption {
+	return func(m *IPReputationMutation) {
+		m.oldValue = func(context.Context) (*IPReputation, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -8284,7 +8471,7 @@ func withLock(node *Lock) lockOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m LockMutation) Client() *Client {
+func (m IPReputationMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -8292,7 +8479,7 @@ func (m LockMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m LockMutation) Tx() (*Tx, error) {
+func (m IPReputationMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -8303,7 +8490,7 @@ func (m LockMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *LockMutation) ID() (id int, exists bool) {
+func (m *IPReputationMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -8314,7 +8501,7 @@ func (m *LockMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *LockMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *IPReputationMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -8323,93 +8510,201 @@ func (m *LockMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Lock.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().IPReputation.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetName sets the "name" field.
-func (m *LockMutation) SetName(s string) {
-	m.name = &s
+// SetCreatedAt sets the "created_at" field.
+func (m *IPReputationMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// Name returns the value of the "name" field in the mutation.
-func (m *LockMutation) Name() (r string, exists bool) {
-	v := m.name
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *IPReputationMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldName returns the old "name" field's value of the Lock entity.
-// If the Lock object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the IPReputation entity.
+// If the IPReputation object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *LockMutation) OldName(ctx context.Context) (v string, err error) {
+func (m *IPReputationMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldName is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldName requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldName: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.Name, nil
+	return oldValue.CreatedAt, nil
 }
 
-// ResetName resets all changes to the "name" field.
-func (m *LockMutation) ResetName() {
-	m.name = nil
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *IPReputationMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *LockMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetUpdatedAt sets the "updated_at" field.
+func (m *IPReputationMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *LockMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *IPReputationMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Lock entity.
-// If the Lock object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the IPReputation entity.
+// If the IPReputation object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *LockMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *IPReputationMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *LockMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *IPReputationMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// Where appends a list predicates to the LockMutation builder.
-func (m *LockMutation) Where(ps ...predicate.Lock) {
+// SetIP sets the "ip" field.
+func (m *IPReputationMutation) SetIP(s string) {
+	m.ip = &s
+}
+
+// IP returns the value of the "ip" field in the mutation.
+func (m *IPReputationMutation) IP() (r string, exists bool) {
+	v := m.ip
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIP returns the old "ip" field's value of the IPReputation entity.
+// If the IPReputation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IPReputationMutation) OldIP(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIP is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIP requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIP: %w", err)
+	}
+	return oldValue.IP, nil
+}
+
+// ResetIP resets all changes to the "ip" field.
+func (m *IPReputationMutation) ResetIP() {
+	m.ip = nil
+}
+
+// SetData sets the "data" field.
+func (m *IPReputationMutation) SetData(s string) {
+	m.data = &s
+}
+
+// Data returns the value of the "data" field in the mutation.
+func (m *IPReputationMutation) Data() (r string, exists bool) {
+	v := m.data
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldData returns the old "data" field's value of the IPReputation entity.
+// If the IPReputation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IPReputationMutation) OldData(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldData is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldData requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldData: %w", err)
+	}
+	return oldValue.Data, nil
+}
+
+// ResetData resets all changes to the "data" field.
+func (m *IPReputationMutation) ResetData() {
+	m.data = nil
+}
+
+// SetFetchedAt sets the "fetched_at" field.
+func (m *IPReputationMutation) SetFetchedAt(t time.Time) {
+	m.fetched_at = &t
+}
+
+// FetchedAt returns the value of the "fetched_at" field in the mutation.
+func (m *IPReputationMutation) FetchedAt() (r time.Time, exists bool) {
+	v := m.fetched_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFetchedAt returns the old "fetched_at" field's value of the IPReputation entity.
+// If the IPReputation object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *IPReputationMutation) OldFetchedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFetchedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFetchedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFetchedAt: %w", err)
+	}
+	return oldValue.FetchedAt, nil
+}
+
+// ResetFetchedAt resets all changes to the "fetched_at" field.
+func (m *IPReputationMutation) ResetFetchedAt() {
+	m.fetched_at = nil
+}
+
+// Where appends a list predicates to the IPReputationMutation builder.
+func (m *IPReputationMutation) Where(ps ...predicate.IPReputation) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the LockMutation builder. Using this method,
+// WhereP appends storage-level predicates to the IPReputationMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *LockMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Lock, len(ps))
+func (m *IPReputationMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.IPReputation, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -8417,30 +8712,39 @@ func (m *LockMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *LockMutation) Op() Op {
+func (m *IPReputationMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *LockMutation) SetOp(op Op) {
+func (m *IPReputationMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Lock).
-func (m *LockMutation) Type() string {
+// Type returns the node type of this mutation (IPReputation).
+func (m *IPReputationMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *LockMutation) Fields() []string {
-	fields := make([]string, 0, 2)
-	if m.name != nil {
-		fields = append(fields, lock.FieldName)
-	}
+func (m *IPReputationMutation) Fields() []string {
+	fields := make([]string, 0, 5)
 	if m.created_at != nil {
-		fields = append(fields, lock.FieldCreatedAt)
+		fields = append(fields, ipreputation.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, ipreputation.FieldUpdatedAt)
+	}
+	if m.ip != nil {
+		fields = append(fields, ipreputation.FieldIP)
+	}
+	if m.data != nil {
+		fields = append(fields, ipreputation.FieldData)
+	}
+	if m.fetched_at != nil {
+		fields = append(fields, ipreputation.FieldFetchedAt)
 	}
 	return fields
 }
@@ -8448,12 +8752,18 @@ func (m *LockMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *LockMutation) Field(name string) (ent.Value, bool) {
+func (m *IPReputationMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case lock.FieldName:
-		return m.Name()
-	case lock.FieldCreatedAt:
+	case ipreputation.FieldCreatedAt:
 		return m.CreatedAt()
+	case ipreputation.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case ipreputation.FieldIP:
+		return m.IP()
+	case ipreputation.FieldData:
+		return m.Data()
+	case ipreputation.FieldFetchedAt:
+		return m.FetchedAt()
 	}
 	return nil, false
 }
@@ -8461,184 +8771,203 @@ func (m *LockMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *LockMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *IPReputationMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case lock.FieldName:
-		return m.OldName(ctx)
-	case lock.FieldCreatedAt:
+	case ipreputation.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
+	case ipreputation.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case ipreputation.FieldIP:
+		return m.OldIP(ctx)
+	case ipreputation.FieldData:
+		return m.OldData(ctx)
+	case ipreputation.FieldFetchedAt:
+		return m.OldFetchedAt(ctx)
 	}
-	return nil, fmt.Errorf("unknown Lock field %s", name)
+	return nil, fmt.Errorf("unknown IPReputation field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *LockMutation) SetField(name string, value ent.Value) error {
+func (m *IPReputationMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case lock.FieldName:
+	case ipreputation.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case ipreputation.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case ipreputation.FieldIP:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetName(v)
+		m.SetIP(v)
 		return nil
-	case lock.FieldCreatedAt:
+	case ipreputation.FieldData:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetData(v)
+		return nil
+	case ipreputation.FieldFetchedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedAt(v)
+		m.SetFetchedAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Lock field %s", name)
+	return fmt.Errorf("unknown IPReputation field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *LockMutation) AddedFields() []string {
+func (m *IPReputationMutation) AddedFields() []string {
 	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *LockMutation) AddedField(name string) (ent.Value, bool) {
+func (m *IPReputationMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *LockMutation) AddField(name string, value ent.Value) error {
+func (m *IPReputationMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Lock numeric field %s", name)
+	return fmt.Errorf("unknown IPReputation numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *LockMutation) ClearedFields() []string {
+func (m *IPReputationMutation) ClearedFields() []string {
 	return nil
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *LockMutation) FieldCleared(name string) bool {
+func (m *IPReputationMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *LockMutation) ClearField(name string) error {
-	return fmt.Errorf("unknown Lock nullable field %s", name)
+func (m *IPReputationMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown IPReputation nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *LockMutation) ResetField(name string) error {
+func (m *IPReputationMutation) ResetField(name string) error {
 	switch name {
-	case lock.FieldName:
-		m.ResetName()
-		return nil
-	case lock.FieldCreatedAt:
+	case ipreputation.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
+	case ipreputation.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case ipreputation.FieldIP:
+		m.ResetIP()
+		return nil
+	case ipreputation.FieldData:
+		m.ResetData()
+		return nil
+	case ipreputation.FieldFetchedAt:
+		m.ResetFetchedAt()
+		return nil
 	}
-	return fmt.Errorf("unknown Lock field %s", name)
+	return fmt.Errorf("unknown IPReputation field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *LockMutation) AddedEdges() []string {
+func (m *IPReputationMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *LockMutation) AddedIDs(name string) []ent.Value {
+func (m *IPReputationMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *LockMutation) RemovedEdges() []string {
+func (m *IPReputationMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *LockMutation) RemovedIDs(name string) []ent.Value {
+func (m *IPReputationMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *LockMutation) ClearedEdges() []string {
+func (m *IPReputationMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *LockMutation) EdgeCleared(name string) bool {
+func (m *IPReputationMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *LockMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Lock unique edge %s", name)
+func (m *IPReputationMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown IPReputation unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *LockMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Lock edge %s", name)
+func (m *IPReputationMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown IPReputation edge %s", name)
 }
 
-// MachineMutation represents an operation that mutates the Machine nodes in the graph.
-type MachineMutation struct {
+// LockMutation represents an operation that mutates the Lock nodes in the graph.
+type LockMutation struct {
 	config
-	op             Op
-	typ            string
-	id             *int
-	created_at     *time.Time
-	updated_at     *time.Time
-	last_push      *time.Time
-	last_heartbeat *time.Time
-	machineId      *string
-	password       *string
-	ipAddress      *string
-	scenarios      *string
-	version        *string
-	isValidated    *bool
-	auth_type      *string
-	osname         *string
-	osversion      *string
-	featureflags   *string
-	hubstate       *map[string][]schema.ItemState
-	datasources    *map[string]int64
-	clearedFields  map[string]struct{}
-	alerts         map[int]struct{}
-	removedalerts  map[int]struct{}
-	clearedalerts  bool
-	done           bool
-	oldValue       func(context.Context) (*Machine, error)
-	predicates     []predicate.Machine
+	op            Op
+	typ           string
+	id            *int
+	name          *string
+	created_at    *time.Time
+	clearedFields map[string]struct{}
+	done          bool
+	oldValue      func(context.Context) (*Lock, error)
+	predicates    []predicate.Lock
 }
 
-var _ ent.Mutation = (*MachineMutation)(nil)
+var _ ent.Mutation = (*LockMutation)(nil)
 
-// machineOption allows management of the mutation configuration using functional options.
-type machineOption func(*MachineMutation)
+// lockOption allows management of the mutation configuration using functional options.
+type lockOption func(*LockMutation)
 
-// newMachineMutation creates new mutation for the Machine entity.
-func newMachineMutation(c config, op Op, opts ...machineOption) *MachineMutation {
-	m := &MachineMutation{
+// newLockMutation creates new mutation for the Lock entity.
+func newLockMutation(c config, op Op, opts ...lockOption) *LockMutation {
+	m := &LockMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeMachine,
+		typ:           TypeLock,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -8647,20 +8976,20 @@ func newMachineMutation(c config, op Op, opts ...machineOption) *MachineMutation
 	return m
 }
 
-// withMachineID sets the ID field of the mutation.
-func withMachineID(id int) machineOption {
-	return func(m *MachineMutation) {
+// withLockID sets the ID field of the mutation.
+func withLockID(id int) lockOption {
+	return func(m *LockMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Machine
+			value *Lock
 		)
-		m.oldValue = func(ctx context.Context) (*Machine, error) {
+		m.oldValue = func(ctx context.Context) (*Lock, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Machine.Get(ctx, id)
+					value, err = m.Client().Lock.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -8669,10 +8998,10 @@ func withMachineID(id int) machineOption {
 	}
 }
 
-// withMachine sets the old Machine of the mutation.
-func withMachine(node *Machine) machineOption {
-	return func(m *MachineMutation) {
-		m.oldValue = func(context.Context) (*Machine, error) {
+// withLock sets the old Lock of the mutation.
+func withLock(node *Lock) lockOption {
+	return func(m *LockMutation) {
+		m.oldValue = func(context.Context) (*Lock, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -8681,7 +9010,7 @@ func withMachine(node *Machine) machineOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m MachineMutation) Client() *Client {
+func (m LockMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -8689,7 +9018,7 @@ func (m MachineMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m MachineMutation) Tx() (*Tx, error) {
+func (m LockMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -8700,7 +9029,7 @@ func (m MachineMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *MachineMutation) ID() (id int, exists bool) {
+func (m *LockMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -8711,7 +9040,7 @@ func (m *MachineMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *MachineMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *LockMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -8720,768 +9049,2041 @@ func (m *MachineMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Machine.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Lock.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *MachineMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
+// SetName sets the "name" field.
+func (m *LockMutation) SetName(s string) {
+	m.name = &s
 }
 
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *MachineMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
+// Name returns the value of the "name" field in the mutation.
+func (m *LockMutation) Name() (r string, exists bool) {
+	v := m.name
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldCreatedAt returns the old "created_at" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldName returns the old "name" field's value of the Lock entity.
+// If the Lock object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *LockMutation) OldName(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldName is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+		return v, errors.New("OldName requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldName: %w", err)
 	}
-	return oldValue.CreatedAt, nil
+	return oldValue.Name, nil
 }
 
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *MachineMutation) ResetCreatedAt() {
-	m.created_at = nil
+// ResetName resets all changes to the "name" field.
+func (m *LockMutation) ResetName() {
+	m.name = nil
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (m *MachineMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetCreatedAt sets the "created_at" field.
+func (m *LockMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *MachineMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *LockMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Lock entity.
+// If the Lock object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *LockMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
-}
-
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *MachineMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+	return oldValue.CreatedAt, nil
 }
 
-// SetLastPush sets the "last_push" field.
-func (m *MachineMutation) SetLastPush(t time.Time) {
-	m.last_push = &t
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *LockMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// LastPush returns the value of the "last_push" field in the mutation.
-func (m *MachineMutation) LastPush() (r time.Time, exists bool) {
-	v := m.last_push
-	if v == nil {
-		return
-	}
-	return *v, true
+// Where appends a list predicates to the LockMutation builder.
+func (m *LockMutation) Where(ps ...predicate.Lock) {
+	m.predicates = append(m.predicates, ps...)
 }
 
-// OldLastPush returns the old "last_push" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldLastPush(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastPush is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastPush requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastPush: %w", err)
+// WhereP appends storage-level predicates to the LockMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *LockMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Lock, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
 	}
-	return oldValue.LastPush, nil
+	m.Where(p...)
 }
 
-// ClearLastPush clears the value of the "last_push" field.
-func (m *MachineMutation) ClearLastPush() {
-	m.last_push = nil
-	m.clearedFields[machine.FieldLastPush] = struct{}{}
-}
-
-// LastPushCleared returns if the "last_push" field was cleared in this mutation.
-func (m *MachineMutation) LastPushCleared() bool {
-	_, ok := m.clearedFields[machine.FieldLastPush]
-	return ok
+// Op returns the operation name.
+func (m *LockMutation) Op() Op {
+	return m.op
 }
 
-// ResetLastPush resets all changes to the "last_push" field.
-func (m *MachineMutation) ResetLastPush() {
-	m.last_push = nil
-	delete(m.clearedFields, machine.FieldLastPush)
+// SetOp allows setting the mutation operation.
+func (m *LockMutation) SetOp(op Op) {
+	m.op = op
 }
 
-// SetLastHeartbeat sets the "last_heartbeat" field.
-func (m *MachineMutation) SetLastHeartbeat(t time.Time) {
-	m.last_heartbeat = &t
+// Type returns the node type of this mutation (Lock).
+func (m *LockMutation) Type() string {
+	return m.typ
 }
 
-// LastHeartbeat returns the value of the "last_heartbeat" field in the mutation.
-func (m *MachineMutation) LastHeartbeat() (r time.Time, exists bool) {
-	v := m.last_heartbeat
-	if v == nil {
-		return
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *LockMutation) Fields() []string {
+	fields := make([]string, 0, 2)
+	if m.name != nil {
+		fields = append(fields, lock.FieldName)
 	}
-	return *v, true
+	if m.created_at != nil {
+		fields = append(fields, lock.FieldCreatedAt)
+	}
+	return fields
 }
 
-// OldLastHeartbeat returns the old "last_heartbeat" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldLastHeartbeat(ctx context.Context) (v *time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldLastHeartbeat is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldLastHeartbeat requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldLastHeartbeat: %w", err)
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *LockMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case lock.FieldName:
+		return m.Name()
+	case lock.FieldCreatedAt:
+		return m.CreatedAt()
 	}
-	return oldValue.LastHeartbeat, nil
+	return nil, false
 }
 
-// ClearLastHeartbeat clears the value of the "last_heartbeat" field.
-func (m *MachineMutation) ClearLastHeartbeat() {
-	m.last_heartbeat = nil
-	m.clearedFields[machine.FieldLastHeartbeat] = struct{}{}
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *LockMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case lock.FieldName:
+		return m.OldName(ctx)
+	case lock.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Lock field %s", name)
 }
 
-// LastHeartbeatCleared returns if the "last_heartbeat" field was cleared in this mutation.
-func (m *MachineMutation) LastHeartbeatCleared() bool {
-	_, ok := m.clearedFields[machine.FieldLastHeartbeat]
-	return ok
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *LockMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case lock.FieldName:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetName(v)
+		return nil
+	case lock.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Lock field %s", name)
 }
 
-// ResetLastHeartbeat resets all changes to the "last_heartbeat" field.
-func (m *MachineMutation) ResetLastHeartbeat() {
-	m.last_heartbeat = nil
-	delete(m.clearedFields, machine.FieldLastHeartbeat)
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *LockMutation) AddedFields() []string {
+	return nil
 }
 
-// SetMachineId sets the "machineId" field.
-func (m *MachineMutation) SetMachineId(s string) {
-	m.machineId = &s
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *LockMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
 }
 
-// MachineId returns the value of the "machineId" field in the mutation.
-func (m *MachineMutation) MachineId() (r string, exists bool) {
-	v := m.machineId
-	if v == nil {
-		return
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *LockMutation) AddField(name string, value ent.Value) error {
+	switch name {
 	}
-	return *v, true
+	return fmt.Errorf("unknown Lock numeric field %s", name)
 }
 
-// OldMachineId returns the old "machineId" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldMachineId(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldMachineId is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldMachineId requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldMachineId: %w", err)
-	}
-	return oldValue.MachineId, nil
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *LockMutation) ClearedFields() []string {
+	return nil
 }
 
-// ResetMachineId resets all changes to the "machineId" field.
-func (m *MachineMutation) ResetMachineId() {
-	m.machineId = nil
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *LockMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
 }
 
-// SetPassword sets the "password" field.
-func (m *MachineMutation) SetPassword(s string) {
-	m.password = &s
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *LockMutation) ClearField(name string) error {
+	return fmt.Errorf("unknown Lock nullable field %s", name)
 }
 
-// Password returns the value of the "password" field in the mutation.
-func (m *MachineMutation) Password() (r string, exists bool) {
-	v := m.password
-	if v == nil {
-		return
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *LockMutation) ResetField(name string) error {
+	switch name {
+	case lock.FieldName:
+		m.ResetName()
+		return nil
+	case lock.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
 	}
-	return *v, true
+	return fmt.Errorf("unknown Lock field %s", name)
 }
 
-// OldPassword returns the old "password" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldPassword(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPassword requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
-	}
-	return oldValue.Password, nil
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *LockMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// ResetPassword resets all changes to the "password" field.
-func (m *MachineMutation) ResetPassword() {
-	m.password = nil
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *LockMutation) AddedIDs(name string) []ent.Value {
+	return nil
 }
 
-// SetIpAddress sets the "ipAddress" field.
-func (m *MachineMutation) SetIpAddress(s string) {
-	m.ipAddress = &s
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *LockMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// IpAddress returns the value of the "ipAddress" field in the mutation.
-func (m *MachineMutation) IpAddress() (r string, exists bool) {
-	v := m.ipAddress
-	if v == nil {
-		return
-	}
-	return *v, true
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *LockMutation) RemovedIDs(name string) []ent.Value {
+	return nil
 }
 
-// OldIpAddress returns the old "ipAddress" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldIpAddress(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldIpAddress is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldIpAddress requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldIpAddress: %w", err)
-	}
-	return oldValue.IpAddress, nil
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *LockMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
+	return edges
 }
 
-// ResetIpAddress resets all changes to the "ipAddress" field.
-func (m *MachineMutation) ResetIpAddress() {
-	m.ipAddress = nil
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *LockMutation) EdgeCleared(name string) bool {
+	return false
 }
 
-// SetScenarios sets the "scenarios" field.
-func (m *MachineMutation) SetScenarios(s string) {
-	m.scenarios = &s
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *LockMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Lock unique edge %s", name)
 }
 
-// Scenarios returns the value of the "scenarios" field in the mutation.
-func (m *MachineMutation) Scenarios() (r string, exists bool) {
-	v := m.scenarios
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *LockMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Lock edge %s", name)
+}
+
+// MachineMutation represents an operation that mutates the Machine nodes in the graph.
+type MachineMutation struct {
+	config
+	op             Op
+	typ            string
+	id             *int
+	created_at     *time.Time
+	updated_at     *time.Time
+	last_push      *time.Time
+	last_heartbeat *time.Time
+	machineId      *string
+	password       *string
+	ipAddress      *string
+	scenarios      *string
+	version        *string
+	isValidated    *bool
+	auth_type      *string
+	osname         *string
+	osversion      *string
+	featureflags   *string
+	hubstate       *map[string][]schema.ItemState
+	datasources    *map[string]int64
+	clearedFields  map[string]struct{}
+	alerts         map[int]struct{}
+	removedalerts  map[int]struct{}
+	clearedalerts  bool
+	done           bool
+	oldValue       func(context.Context) (*Machine, error)
+	predicates     []predicate.Machine
+}
+
+var _ ent.Mutation = (*MachineMutation)(nil)
+
+// machineOption allows management of the mutation configuration using functional options.
+type machineOption func(*MachineMutation)
+
+// newMachineMutation creates new mutation for the Machine entity.
+func newMachineMutation(c config, op Op, opts ...machineOption) *MachineMutation {
+	m := &MachineMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeMachine,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withMachineID sets the ID field of the mutation.
+func withMachineID(id int) machineOption {
+	return func(m *MachineMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Machine
+		)
+		m.oldValue = func(ctx context.Context) (*Machine, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Machine.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
+	}
+}
+
+// withMachine sets the old Machine of the mutation.
+func withMachine(node *Machine) machineOption {
+	return func(m *MachineMutation) {
+		m.oldValue = func(context.Context) (*Machine, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
+}
+
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m MachineMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
+}
+
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m MachineMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
+	}
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
+}
+
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *MachineMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
+	}
+	return *m.id, true
+}
+
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *MachineMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Machine.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (m *MachineMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
+}
+
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *MachineMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldScenarios returns the old "scenarios" field's value of the Machine entity.
+// OldCreatedAt returns the old "created_at" field's value of the Machine entity.
 // If the Machine object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldScenarios(ctx context.Context) (v string, err error) {
+func (m *MachineMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldScenarios is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldScenarios requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldScenarios: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.Scenarios, nil
-}
-
-// ClearScenarios clears the value of the "scenarios" field.
-func (m *MachineMutation) ClearScenarios() {
-	m.scenarios = nil
-	m.clearedFields[machine.FieldScenarios] = struct{}{}
-}
-
-// ScenariosCleared returns if the "scenarios" field was cleared in this mutation.
-func (m *MachineMutation) ScenariosCleared() bool {
-	_, ok := m.clearedFields[machine.FieldScenarios]
-	return ok
+	return oldValue.CreatedAt, nil
 }
 
-// ResetScenarios resets all changes to the "scenarios" field.
-func (m *MachineMutation) ResetScenarios() {
-	m.scenarios = nil
-	delete(m.clearedFields, machine.FieldScenarios)
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *MachineMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetVersion sets the "version" field.
-func (m *MachineMutation) SetVersion(s string) {
-	m.version = &s
+// SetUpdatedAt sets the "updated_at" field.
+func (m *MachineMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// Version returns the value of the "version" field in the mutation.
-func (m *MachineMutation) Version() (r string, exists bool) {
-	v := m.version
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *MachineMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldVersion returns the old "version" field's value of the Machine entity.
+// OldUpdatedAt returns the old "updated_at" field's value of the Machine entity.
 // If the Machine object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldVersion(ctx context.Context) (v string, err error) {
+func (m *MachineMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldVersion requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.Version, nil
-}
-
-// ClearVersion clears the value of the "version" field.
-func (m *MachineMutation) ClearVersion() {
-	m.version = nil
-	m.clearedFields[machine.FieldVersion] = struct{}{}
-}
-
-// VersionCleared returns if the "version" field was cleared in this mutation.
-func (m *MachineMutation) VersionCleared() bool {
-	_, ok := m.clearedFields[machine.FieldVersion]
-	return ok
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetVersion resets all changes to the "version" field.
-func (m *MachineMutation) ResetVersion() {
-	m.version = nil
-	delete(m.clearedFields, machine.FieldVersion)
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *MachineMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// SetIsValidated sets the "isValidated" field.
-func (m *MachineMutation) SetIsValidated(b bool) {
-	m.isValidated = &b
+// SetLastPush sets the "last_push" field.
+func (m *MachineMutation) SetLastPush(t time.Time) {
+	m.last_push = &t
 }
 
-// IsValidated returns the value of the "isValidated" field in the mutation.
-func (m *MachineMutation) IsValidated() (r bool, exists bool) {
-	v := m.isValidated
+// LastPush returns the value of the "last_push" field in the mutation.
+func (m *MachineMutation) LastPush() (r time.Time, exists bool) {
+	v := m.last_push
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldIsValidated returns the old "isValidated" field's value of the Machine entity.
+// OldLastPush returns the old "last_push" field's value of the Machine entity.
 // If the Machine object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldIsValidated(ctx context.Context) (v bool, err error) {
+func (m *MachineMutation) OldLastPush(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldIsValidated is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastPush is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldIsValidated requires an ID field in the mutation")
+		return v, errors.New("OldLastPush requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldIsValidated: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastPush: %w", err)
+	}
+	return oldValue.LastPush, nil
+}
+
+// ClearLastPush clears the value of the "last_push" field.
+func (m *MachineMutation) ClearLastPush() {
+	m.last_push = nil
+	m.clearedFields[machine.FieldLastPush] = struct{}{}
+}
+
+// LastPushCleared returns if the "last_push" field was cleared in this mutation.
+func (m *MachineMutation) LastPushCleared() bool {
+	_, ok := m.clearedFields[machine.FieldLastPush]
+	return ok
+}
+
+// ResetLastPush resets all changes to the "last_push" field.
+func (m *MachineMutation) ResetLastPush() {
+	m.last_push = nil
+	delete(m.clearedFields, machine.FieldLastPush)
+}
+
+// SetLastHeartbeat sets the "last_heartbeat" field.
+func (m *MachineMutation) SetLastHeartbeat(t time.Time) {
+	m.last_heartbeat = &t
+}
+
+// LastHeartbeat returns the value of the "last_heartbeat" field in the mutation.
+func (m *MachineMutation) LastHeartbeat() (r time.Time, exists bool) {
+	v := m.last_heartbeat
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldLastHeartbeat returns the old "last_heartbeat" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldLastHeartbeat(ctx context.Context) (v *time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldLastHeartbeat is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldLastHeartbeat requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldLastHeartbeat: %w", err)
+	}
+	return oldValue.LastHeartbeat, nil
+}
+
+// ClearLastHeartbeat clears the value of the "last_heartbeat" field.
+func (m *MachineMutation) ClearLastHeartbeat() {
+	m.last_heartbeat = nil
+	m.clearedFields[machine.FieldLastHeartbeat] = struct{}{}
+}
+
+// LastHeartbeatCleared returns if the "last_heartbeat" field was cleared in this mutation.
+func (m *MachineMutation) LastHeartbeatCleared() bool {
+	_, ok := m.clearedFields[machine.FieldLastHeartbeat]
+	return ok
+}
+
+// ResetLastHeartbeat resets all changes to the "last_heartbeat" field.
+func (m *MachineMutation) ResetLastHeartbeat() {
+	m.last_heartbeat = nil
+	delete(m.clearedFields, machine.FieldLastHeartbeat)
+}
+
+// SetMachineId sets the "machineId" field.
+func (m *MachineMutation) SetMachineId(s string) {
+	m.machineId = &s
+}
+
+// MachineId returns the value of the "machineId" field in the mutation.
+func (m *MachineMutation) MachineId() (r string, exists bool) {
+	v := m.machineId
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMachineId returns the old "machineId" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldMachineId(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMachineId is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMachineId requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMachineId: %w", err)
+	}
+	return oldValue.MachineId, nil
+}
+
+// ResetMachineId resets all changes to the "machineId" field.
+func (m *MachineMutation) ResetMachineId() {
+	m.machineId = nil
+}
+
+// SetPassword sets the "password" field.
+func (m *MachineMutation) SetPassword(s string) {
+	m.password = &s
+}
+
+// Password returns the value of the "password" field in the mutation.
+func (m *MachineMutation) Password() (r string, exists bool) {
+	v := m.password
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPassword returns the old "password" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldPassword(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPassword is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPassword requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPassword: %w", err)
+	}
+	return oldValue.Password, nil
+}
+
+// ResetPassword resets all changes to the "password" field.
+func (m *MachineMutation) ResetPassword() {
+	m.password = nil
+}
+
+// SetIpAddress sets the "ipAddress" field.
+func (m *MachineMutation) SetIpAddress(s string) {
+	m.ipAddress = &s
+}
+
+// IpAddress returns the value of the "ipAddress" field in the mutation.
+func (m *MachineMutation) IpAddress() (r string, exists bool) {
+	v := m.ipAddress
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIpAddress returns the old "ipAddress" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldIpAddress(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIpAddress is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIpAddress requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIpAddress: %w", err)
+	}
+	return oldValue.IpAddress, nil
+}
+
+// ResetIpAddress resets all changes to the "ipAddress" field.
+func (m *MachineMutation) ResetIpAddress() {
+	m.ipAddress = nil
+}
+
+// SetScenarios sets the "scenarios" field.
+func (m *MachineMutation) SetScenarios(s string) {
+	m.scenarios = &s
+}
+
+// Scenarios returns the value of the "scenarios" field in the mutation.
+func (m *MachineMutation) Scenarios() (r string, exists bool) {
+	v := m.scenarios
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldScenarios returns the old "scenarios" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldScenarios(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldScenarios is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldScenarios requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldScenarios: %w", err)
+	}
+	return oldValue.Scenarios, nil
+}
+
+// ClearScenarios clears the value of the "scenarios" field.
+func (m *MachineMutation) ClearScenarios() {
+	m.scenarios = nil
+	m.clearedFields[machine.FieldScenarios] = struct{}{}
+}
+
+// ScenariosCleared returns if the "scenarios" field was cleared in this mutation.
+func (m *MachineMutation) ScenariosCleared() bool {
+	_, ok := m.clearedFields[machine.FieldScenarios]
+	return ok
+}
+
+// ResetScenarios resets all changes to the "scenarios" field.
+func (m *MachineMutation) ResetScenarios() {
+	m.scenarios = nil
+	delete(m.clearedFields, machine.FieldScenarios)
+}
+
+// SetVersion sets the "version" field.
+func (m *MachineMutation) SetVersion(s string) {
+	m.version = &s
+}
+
+// Version returns the value of the "version" field in the mutation.
+func (m *MachineMutation) Version() (r string, exists bool) {
+	v := m.version
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldVersion returns the old "version" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldVersion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldVersion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldVersion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldVersion: %w", err)
+	}
+	return oldValue.Version, nil
+}
+
+// ClearVersion clears the value of the "version" field.
+func (m *MachineMutation) ClearVersion() {
+	m.version = nil
+	m.clearedFields[machine.FieldVersion] = struct{}{}
+}
+
+// VersionCleared returns if the "version" field was cleared in this mutation.
+func (m *MachineMutation) VersionCleared() bool {
+	_, ok := m.clearedFields[machine.FieldVersion]
+	return ok
+}
+
+// ResetVersion resets all changes to the "version" field.
+func (m *MachineMutation) ResetVersion() {
+	m.version = nil
+	delete(m.clearedFields, machine.FieldVersion)
+}
+
+// SetIsValidated sets the "isValidated" field.
+func (m *MachineMutation) SetIsValidated(b bool) {
+	m.isValidated = &b
+}
+
+// IsValidated returns the value of the "isValidated" field in the mutation.
+func (m *MachineMutation) IsValidated() (r bool, exists bool) {
+	v := m.isValidated
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldIsValidated returns the old "isValidated" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldIsValidated(ctx context.Context) (v bool, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldIsValidated is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldIsValidated requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldIsValidated: %w", err)
+	}
+	return oldValue.IsValidated, nil
+}
+
+// ResetIsValidated resets all changes to the "isValidated" field.
+func (m *MachineMutation) ResetIsValidated() {
+	m.isValidated = nil
+}
+
+// SetAuthType sets the "auth_type" field.
+func (m *MachineMutation) SetAuthType(s string) {
+	m.auth_type = &s
+}
+
+// AuthType returns the value of the "auth_type" field in the mutation.
+func (m *MachineMutation) AuthType() (r string, exists bool) {
+	v := m.auth_type
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAuthType returns the old "auth_type" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldAuthType(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAuthType is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAuthType requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAuthType: %w", err)
+	}
+	return oldValue.AuthType, nil
+}
+
+// ResetAuthType resets all changes to the "auth_type" field.
+func (m *MachineMutation) ResetAuthType() {
+	m.auth_type = nil
+}
+
+// SetOsname sets the "osname" field.
+func (m *MachineMutation) SetOsname(s string) {
+	m.osname = &s
+}
+
+// Osname returns the value of the "osname" field in the mutation.
+func (m *MachineMutation) Osname() (r string, exists bool) {
+	v := m.osname
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOsname returns the old "osname" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldOsname(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOsname is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOsname requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOsname: %w", err)
+	}
+	return oldValue.Osname, nil
+}
+
+// ClearOsname clears the value of the "osname" field.
+func (m *MachineMutation) ClearOsname() {
+	m.osname = nil
+	m.clearedFields[machine.FieldOsname] = struct{}{}
+}
+
+// OsnameCleared returns if the "osname" field was cleared in this mutation.
+func (m *MachineMutation) OsnameCleared() bool {
+	_, ok := m.clearedFields[machine.FieldOsname]
+	return ok
+}
+
+// ResetOsname resets all changes to the "osname" field.
+func (m *MachineMutation) ResetOsname() {
+	m.osname = nil
+	delete(m.clearedFields, machine.FieldOsname)
+}
+
+// SetOsversion sets the "osversion" field.
+func (m *MachineMutation) SetOsversion(s string) {
+	m.osversion = &s
+}
+
+// Osversion returns the value of the "osversion" field in the mutation.
+func (m *MachineMutation) Osversion() (r string, exists bool) {
+	v := m.osversion
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldOsversion returns the old "osversion" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldOsversion(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldOsversion is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldOsversion requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldOsversion: %w", err)
+	}
+	return oldValue.Osversion, nil
+}
+
+// ClearOsversion clears the value of the "osversion" field.
+func (m *MachineMutation) ClearOsversion() {
+	m.osversion = nil
+	m.clearedFields[machine.FieldOsversion] = struct{}{}
+}
+
+// OsversionCleared returns if the "osversion" field was cleared in this mutation.
+func (m *MachineMutation) OsversionCleared() bool {
+	_, ok := m.clearedFields[machine.FieldOsversion]
+	return ok
+}
+
+// ResetOsversion resets all changes to the "osversion" field.
+func (m *MachineMutation) ResetOsversion() {
+	m.osversion = nil
+	delete(m.clearedFields, machine.FieldOsversion)
+}
+
+// SetFeatureflags sets the "featureflags" field.
+func (m *MachineMutation) SetFeatureflags(s string) {
+	m.featureflags = &s
+}
+
+// Featureflags returns the value of the "featureflags" field in the mutation.
+func (m *MachineMutation) Featureflags() (r string, exists bool) {
+	v := m.featureflags
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldFeatureflags returns the old "featureflags" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldFeatureflags(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldFeatureflags is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldFeatureflags requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldFeatureflags: %w", err)
+	}
+	return oldValue.Featureflags, nil
+}
+
+// ClearFeatureflags clears the value of the "featureflags" field.
+func (m *MachineMutation) ClearFeatureflags() {
+	m.featureflags = nil
+	m.clearedFields[machine.FieldFeatureflags] = struct{}{}
+}
+
+// FeatureflagsCleared returns if the "featureflags" field was cleared in this mutation.
+func (m *MachineMutation) FeatureflagsCleared() bool {
+	_, ok := m.clearedFields[machine.FieldFeatureflags]
+	return ok
+}
+
+// ResetFeatureflags resets all changes to the "featureflags" field.
+func (m *MachineMutation) ResetFeatureflags() {
+	m.featureflags = nil
+	delete(m.clearedFields, machine.FieldFeatureflags)
+}
+
+// SetHubstate sets the "hubstate" field.
+func (m *MachineMutation) SetHubstate(ms map[string][]schema.ItemState) {
+	m.hubstate = &ms
+}
+
+// Hubstate returns the value of the "hubstate" field in the mutation.
+func (m *MachineMutation) Hubstate() (r map[string][]schema.ItemState, exists bool) {
+	v := m.hubstate
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldHubstate returns the old "hubstate" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldHubstate(ctx context.Context) (v map[string][]schema.ItemState, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldHubstate is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldHubstate requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldHubstate: %w", err)
+	}
+	return oldValue.Hubstate, nil
+}
+
+// ClearHubstate clears the value of the "hubstate" field.
+func (m *MachineMutation) ClearHubstate() {
+	m.hubstate = nil
+	m.clearedFields[machine.FieldHubstate] = struct{}{}
+}
+
+// HubstateCleared returns if the "hubstate" field was cleared in this mutation.
+func (m *MachineMutation) HubstateCleared() bool {
+	_, ok := m.clearedFields[machine.FieldHubstate]
+	return ok
+}
+
+// ResetHubstate resets all changes to the "hubstate" field.
+func (m *MachineMutation) ResetHubstate() {
+	m.hubstate = nil
+	delete(m.clearedFields, machine.FieldHubstate)
+}
+
+// SetDatasources sets the "datasources" field.
+func (m *MachineMutation) SetDatasources(value map[string]int64) {
+	m.datasources = &value
+}
+
+// Datasources returns the value of the "datasources" field in the mutation.
+func (m *MachineMutation) Datasources() (r map[string]int64, exists bool) {
+	v := m.datasources
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldDatasources returns the old "datasources" field's value of the Machine entity.
+// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MachineMutation) OldDatasources(ctx context.Context) (v map[string]int64, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldDatasources is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldDatasources requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldDatasources: %w", err)
+	}
+	return oldValue.Datasources, nil
+}
+
+// ClearDatasources clears the value of the "datasources" field.
+func (m *MachineMutation) ClearDatasources() {
+	m.datasources = nil
+	m.clearedFields[machine.FieldDatasources] = struct{}{}
+}
+
+// DatasourcesCleared returns if the "datasources" field was cleared in this mutation.
+func (m *MachineMutation) DatasourcesCleared() bool {
+	_, ok := m.clearedFields[machine.FieldDatasources]
+	return ok
+}
+
+// ResetDatasources resets all changes to the "datasources" field.
+func (m *MachineMutation) ResetDatasources() {
+	m.datasources = nil
+	delete(m.clearedFields, machine.FieldDatasources)
+}
+
+// AddAlertIDs adds the "alerts" edge to the Alert entity by ids.
+func (m *MachineMutation) AddAlertIDs(ids ...int) {
+	if m.alerts == nil {
+		m.alerts = make(map[int]struct{})
+	}
+	for i := range ids {
+		m.alerts[ids[i]] = struct{}{}
+	}
+}
+
+// ClearAlerts clears the "alerts" edge to the Alert entity.
+func (m *MachineMutation) ClearAlerts() {
+	m.clearedalerts = true
+}
+
+// AlertsCleared reports if the "alerts" edge to the Alert entity was cleared.
+func (m *MachineMutation) AlertsCleared() bool {
+	return m.clearedalerts
+}
+
+// RemoveAlertIDs removes the "alerts" edge to the Alert entity by IDs.
+func (m *MachineMutation) RemoveAlertIDs(ids ...int) {
+	if m.removedalerts == nil {
+		m.removedalerts = make(map[int]struct{})
+	}
+	for i := range ids {
+		delete(m.alerts, ids[i])
+		m.removedalerts[ids[i]] = struct{}{}
+	}
+}
+
+// RemovedAlerts returns the removed IDs of the "alerts" edge to the Alert entity.
+func (m *MachineMutation) RemovedAlertsIDs() (ids []int) {
+	for id := range m.removedalerts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// AlertsIDs returns the "alerts" edge IDs in the mutation.
+func (m *MachineMutation) AlertsIDs() (ids []int) {
+	for id := range m.alerts {
+		ids = append(ids, id)
+	}
+	return
+}
+
+// ResetAlerts resets all changes to the "alerts" edge.
+func (m *MachineMutation) ResetAlerts() {
+	m.alerts = nil
+	m.clearedalerts = false
+	m.removedalerts = nil
+}
+
+// Where appends a list predicates to the MachineMutation builder.
+func (m *MachineMutation) Where(ps ...predicate.Machine) {
+	m.predicates = append(m.predicates, ps...)
+}
+
+// WhereP appends storage-level predicates to the MachineMutation builder. Using this method,
+// users can use type-assertion to append predicates that do not depend on any generated package.
+func (m *MachineMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Machine, len(ps))
+	for i := range ps {
+		p[i] = ps[i]
+	}
+	m.Where(p...)
+}
+
+// Op returns the operation name.
+func (m *MachineMutation) Op() Op {
+	return m.op
+}
+
+// SetOp allows setting the mutation operation.
+func (m *MachineMutation) SetOp(op Op) {
+	m.op = op
+}
+
+// Type returns the node type of this mutation (Machine).
+func (m *MachineMutation) Type() string {
+	return m.typ
+}
+
+// Fields returns all fields that were changed during this mutation. Note that in
+// order to get all numeric fields that were incremented/decremented, call
+// AddedFields().
+func (m *MachineMutation) Fields() []string {
+	fields := make([]string, 0, 16)
+	if m.created_at != nil {
+		fields = append(fields, machine.FieldCreatedAt)
+	}
+	if m.updated_at != nil {
+		fields = append(fields, machine.FieldUpdatedAt)
+	}
+	if m.last_push != nil {
+		fields = append(fields, machine.FieldLastPush)
+	}
+	if m.last_heartbeat != nil {
+		fields = append(fields, machine.FieldLastHeartbeat)
+	}
+	if m.machineId != nil {
+		fields = append(fields, machine.FieldMachineId)
+	}
+	if m.password != nil {
+		fields = append(fields, machine.FieldPassword)
+	}
+	if m.ipAddress != nil {
+		fields = append(fields, machine.FieldIpAddress)
+	}
+	if m.scenarios != nil {
+		fields = append(fields, machine.FieldScenarios)
+	}
+	if m.version != nil {
+		fields = append(fields, machine.FieldVersion)
+	}
+	if m.isValidated != nil {
+		fields = append(fields, machine.FieldIsValidated)
+	}
+	if m.auth_type != nil {
+		fields = append(fields, machine.FieldAuthType)
+	}
+	if m.osname != nil {
+		fields = append(fields, machine.FieldOsname)
+	}
+	if m.osversion != nil {
+		fields = append(fields, machine.FieldOsversion)
+	}
+	if m.featureflags != nil {
+		fields = append(fields, machine.FieldFeatureflags)
+	}
+	if m.hubstate != nil {
+		fields = append(fields, machine.FieldHubstate)
+	}
+	if m.datasources != nil {
+		fields = append(fields, machine.FieldDatasources)
+	}
+	return fields
+}
+
+// Field returns the value of a field with the given name. The second boolean
+// return value indicates that this field was not set, or was not defined in the
+// schema.
+func (m *MachineMutation) Field(name string) (ent.Value, bool) {
+	switch name {
+	case machine.FieldCreatedAt:
+		return m.CreatedAt()
+	case machine.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case machine.FieldLastPush:
+		return m.LastPush()
+	case machine.FieldLastHeartbeat:
+		return m.LastHeartbeat()
+	case machine.FieldMachineId:
+		return m.MachineId()
+	case machine.FieldPassword:
+		return m.Password()
+	case machine.FieldIpAddress:
+		return m.IpAddress()
+	case machine.FieldScenarios:
+		return m.Scenarios()
+	case machine.FieldVersion:
+		return m.Version()
+	case machine.FieldIsValidated:
+		return m.IsValidated()
+	case machine.FieldAuthType:
+		return m.AuthType()
+	case machine.FieldOsname:
+		return m.Osname()
+	case machine.FieldOsversion:
+		return m.Osversion()
+	case machine.FieldFeatureflags:
+		return m.Featureflags()
+	case machine.FieldHubstate:
+		return m.Hubstate()
+	case machine.FieldDatasources:
+		return m.Datasources()
+	}
+	return nil, false
+}
+
+// OldField returns the old value of the field from the database. An error is
+// returned if the mutation operation is not UpdateOne, or the query to the
+// database failed.
+func (m *MachineMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+	switch name {
+	case machine.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case machine.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case machine.FieldLastPush:
+		return m.OldLastPush(ctx)
+	case machine.FieldLastHeartbeat:
+		return m.OldLastHeartbeat(ctx)
+	case machine.FieldMachineId:
+		return m.OldMachineId(ctx)
+	case machine.FieldPassword:
+		return m.OldPassword(ctx)
+	case machine.FieldIpAddress:
+		return m.OldIpAddress(ctx)
+	case machine.FieldScenarios:
+		return m.OldScenarios(ctx)
+	case machine.FieldVersion:
+		return m.OldVersion(ctx)
+	case machine.FieldIsValidated:
+		return m.OldIsValidated(ctx)
+	case machine.FieldAuthType:
+		return m.OldAuthType(ctx)
+	case machine.FieldOsname:
+		return m.OldOsname(ctx)
+	case machine.FieldOsversion:
+		return m.OldOsversion(ctx)
+	case machine.FieldFeatureflags:
+		return m.OldFeatureflags(ctx)
+	case machine.FieldHubstate:
+		return m.OldHubstate(ctx)
+	case machine.FieldDatasources:
+		return m.OldDatasources(ctx)
+	}
+	return nil, fmt.Errorf("unknown Machine field %s", name)
+}
+
+// SetField sets the value of a field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *MachineMutation) SetField(name string, value ent.Value) error {
+	switch name {
+	case machine.FieldCreatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetCreatedAt(v)
+		return nil
+	case machine.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case machine.FieldLastPush:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastPush(v)
+		return nil
+	case machine.FieldLastHeartbeat:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetLastHeartbeat(v)
+		return nil
+	case machine.FieldMachineId:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetMachineId(v)
+		return nil
+	case machine.FieldPassword:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetPassword(v)
+		return nil
+	case machine.FieldIpAddress:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIpAddress(v)
+		return nil
+	case machine.FieldScenarios:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetScenarios(v)
+		return nil
+	case machine.FieldVersion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetVersion(v)
+		return nil
+	case machine.FieldIsValidated:
+		v, ok := value.(bool)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetIsValidated(v)
+		return nil
+	case machine.FieldAuthType:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAuthType(v)
+		return nil
+	case machine.FieldOsname:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOsname(v)
+		return nil
+	case machine.FieldOsversion:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetOsversion(v)
+		return nil
+	case machine.FieldFeatureflags:
+		v, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetFeatureflags(v)
+		return nil
+	case machine.FieldHubstate:
+		v, ok := value.(map[string][]schema.ItemState)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetHubstate(v)
+		return nil
+	case machine.FieldDatasources:
+		v, ok := value.(map[string]int64)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDatasources(v)
+		return nil
+	}
+	return fmt.Errorf("unknown Machine field %s", name)
+}
+
+// AddedFields returns all numeric fields that were incremented/decremented during
+// this mutation.
+func (m *MachineMutation) AddedFields() []string {
+	return nil
+}
+
+// AddedField returns the numeric value that was incremented/decremented on a field
+// with the given name. The second boolean return value indicates that this field
+// was not set, or was not defined in the schema.
+func (m *MachineMutation) AddedField(name string) (ent.Value, bool) {
+	return nil, false
+}
+
+// AddField adds the value to the field with the given name. It returns an error if
+// the field is not defined in the schema, or if the type mismatched the field
+// type.
+func (m *MachineMutation) AddField(name string, value ent.Value) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Machine numeric field %s", name)
+}
+
+// ClearedFields returns all nullable fields that were cleared during this
+// mutation.
+func (m *MachineMutation) ClearedFields() []string {
+	var fields []string
+	if m.FieldCleared(machine.FieldLastPush) {
+		fields = append(fields, machine.FieldLastPush)
+	}
+	if m.FieldCleared(machine.FieldLastHeartbeat) {
+		fields = append(fields, machine.FieldLastHeartbeat)
+	}
+	if m.FieldCleared(machine.FieldScenarios) {
+		fields = append(fields, machine.FieldScenarios)
+	}
+	if m.FieldCleared(machine.FieldVersion) {
+		fields = append(fields, machine.FieldVersion)
+	}
+	if m.FieldCleared(machine.FieldOsname) {
+		fields = append(fields, machine.FieldOsname)
+	}
+	if m.FieldCleared(machine.FieldOsversion) {
+		fields = append(fields, machine.FieldOsversion)
+	}
+	if m.FieldCleared(machine.FieldFeatureflags) {
+		fields = append(fields, machine.FieldFeatureflags)
+	}
+	if m.FieldCleared(machine.FieldHubstate) {
+		fields = append(fields, machine.FieldHubstate)
+	}
+	if m.FieldCleared(machine.FieldDatasources) {
+		fields = append(fields, machine.FieldDatasources)
+	}
+	return fields
+}
+
+// FieldCleared returns a boolean indicating if a field with the given name was
+// cleared in this mutation.
+func (m *MachineMutation) FieldCleared(name string) bool {
+	_, ok := m.clearedFields[name]
+	return ok
+}
+
+// ClearField clears the value of the field with the given name. It returns an
+// error if the field is not defined in the schema.
+func (m *MachineMutation) ClearField(name string) error {
+	switch name {
+	case machine.FieldLastPush:
+		m.ClearLastPush()
+		return nil
+	case machine.FieldLastHeartbeat:
+		m.ClearLastHeartbeat()
+		return nil
+	case machine.FieldScenarios:
+		m.ClearScenarios()
+		return nil
+	case machine.FieldVersion:
+		m.ClearVersion()
+		return nil
+	case machine.FieldOsname:
+		m.ClearOsname()
+		return nil
+	case machine.FieldOsversion:
+		m.ClearOsversion()
+		return nil
+	case machine.FieldFeatureflags:
+		m.ClearFeatureflags()
+		return nil
+	case machine.FieldHubstate:
+		m.ClearHubstate()
+		return nil
+	case machine.FieldDatasources:
+		m.ClearDatasources()
+		return nil
+	}
+	return fmt.Errorf("unknown Machine nullable field %s", name)
+}
+
+// ResetField resets all changes in the mutation for the field with the given name.
+// It returns an error if the field is not defined in the schema.
+func (m *MachineMutation) ResetField(name string) error {
+	switch name {
+	case machine.FieldCreatedAt:
+		m.ResetCreatedAt()
+		return nil
+	case machine.FieldUpdatedAt:
+		m.ResetUpdatedAt()
+		return nil
+	case machine.FieldLastPush:
+		m.ResetLastPush()
+		return nil
+	case machine.FieldLastHeartbeat:
+		m.ResetLastHeartbeat()
+		return nil
+	case machine.FieldMachineId:
+		m.ResetMachineId()
+		return nil
+	case machine.FieldPassword:
+		m.ResetPassword()
+		return nil
+	case machine.FieldIpAddress:
+		m.ResetIpAddress()
+		return nil
+	case machine.FieldScenarios:
+		m.ResetScenarios()
+		return nil
+	case machine.FieldVersion:
+		m.ResetVersion()
+		return nil
+	case machine.FieldIsValidated:
+		m.ResetIsValidated()
+		return nil
+	case machine.FieldAuthType:
+		m.ResetAuthType()
+		return nil
+	case machine.FieldOsname:
+		m.ResetOsname()
+		return nil
+	case machine.FieldOsversion:
+		m.ResetOsversion()
+		return nil
+	case machine.FieldFeatureflags:
+		m.ResetFeatureflags()
+		return nil
+	case machine.FieldHubstate:
+		m.ResetHubstate()
+		return nil
+	case machine.FieldDatasources:
+		m.ResetDatasources()
+		return nil
+	}
+	return fmt.Errorf("unknown Machine field %s", name)
+}
+
+// AddedEdges returns all edge names that were set/added in this mutation.
+func (m *MachineMutation) AddedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.alerts != nil {
+		edges = append(edges, machine.EdgeAlerts)
+	}
+	return edges
+}
+
+// AddedIDs returns all IDs (to other nodes) that were added for the given edge
+// name in this mutation.
+func (m *MachineMutation) AddedIDs(name string) []ent.Value {
+	switch name {
+	case machine.EdgeAlerts:
+		ids := make([]ent.Value, 0, len(m.alerts))
+		for id := range m.alerts {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// RemovedEdges returns all edge names that were removed in this mutation.
+func (m *MachineMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.removedalerts != nil {
+		edges = append(edges, machine.EdgeAlerts)
+	}
+	return edges
+}
+
+// RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
+// the given name in this mutation.
+func (m *MachineMutation) RemovedIDs(name string) []ent.Value {
+	switch name {
+	case machine.EdgeAlerts:
+		ids := make([]ent.Value, 0, len(m.removedalerts))
+		for id := range m.removedalerts {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	return nil
+}
+
+// ClearedEdges returns all edge names that were cleared in this mutation.
+func (m *MachineMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 1)
+	if m.clearedalerts {
+		edges = append(edges, machine.EdgeAlerts)
+	}
+	return edges
+}
+
+// EdgeCleared returns a boolean which indicates if the edge with the given name
+// was cleared in this mutation.
+func (m *MachineMutation) EdgeCleared(name string) bool {
+	switch name {
+	case machine.EdgeAlerts:
+		return m.clearedalerts
+	}
+	return false
+}
+
+// ClearEdge clears the value of the edge with the given name. It returns an error
+// if that edge is not defined in the schema.
+func (m *MachineMutation) ClearEdge(name string) error {
+	switch name {
+	}
+	return fmt.Errorf("unknown Machine unique edge %s", name)
+}
+
+// ResetEdge resets all changes to the edge with the given name in this mutation.
+// It returns an error if the edge is not defined in the schema.
+func (m *MachineMutation) ResetEdge(name string) error {
+	switch name {
+	case machine.EdgeAlerts:
+		m.ResetAlerts()
+		return nil
+	}
+	return fmt.Errorf("unknown Machine edge %s", name)
+}
+
+// MetaMutation represents an operation that mutates the Meta nodes in the graph.
+type MetaMutation struct {
+	config
+	op            Op
+	typ           string
+	id            *int
+	created_at    *time.Time
+	updated_at    *time.Time
+	key           *string
+	value         *string
+	clearedFields map[string]struct{}
+	owner         *int
+	clearedowner  bool
+	done          bool
+	oldValue      func(context.Context) (*Meta, error)
+	predicates    []predicate.Meta
+}
+
+var _ ent.Mutation = (*MetaMutation)(nil)
+
+// metaOption allows management of the mutation configuration using functional options.
+type metaOption func(*MetaMutation)
+
+// newMetaMutation creates new mutation for the Meta entity.
+func newMetaMutation(c config, op Op, opts ...metaOption) *MetaMutation {
+	m := &MetaMutation{
+		config:        c,
+		op:            op,
+		typ:           TypeMeta,
+		clearedFields: make(map[string]struct{}),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// withMetaID sets the ID field of the mutation.
+func withMetaID(id int) metaOption {
+	return func(m *MetaMutation) {
+		var (
+			err   error
+			once  sync.Once
+			value *Meta
+		)
+		m.oldValue = func(ctx context.Context) (*Meta, error) {
+			once.Do(func() {
+				if m.done {
+					err = errors.New("querying old values post mutation is not allowed")
+				} else {
+					value, err = m.Client().Meta.Get(ctx, id)
+				}
+			})
+			return value, err
+		}
+		m.id = &id
 	}
-	return oldValue.IsValidated, nil
 }
 
-// ResetIsValidated resets all changes to the "isValidated" field.
-func (m *MachineMutation) ResetIsValidated() {
-	m.isValidated = nil
+// withMeta sets the old Meta of the mutation.
+func withMeta(node *Meta) metaOption {
+	return func(m *MetaMutation) {
+		m.oldValue = func(context.Context) (*Meta, error) {
+			return node, nil
+		}
+		m.id = &node.ID
+	}
 }
 
-// SetAuthType sets the "auth_type" field.
-func (m *MachineMutation) SetAuthType(s string) {
-	m.auth_type = &s
+// Client returns a new `ent.Client` from the mutation. If the mutation was
+// executed in a transaction (ent.Tx), a transactional client is returned.
+func (m MetaMutation) Client() *Client {
+	client := &Client{config: m.config}
+	client.init()
+	return client
 }
 
-// AuthType returns the value of the "auth_type" field in the mutation.
-func (m *MachineMutation) AuthType() (r string, exists bool) {
-	v := m.auth_type
-	if v == nil {
-		return
+// Tx returns an `ent.Tx` for mutations that were executed in transactions;
+// it returns an error otherwise.
+func (m MetaMutation) Tx() (*Tx, error) {
+	if _, ok := m.driver.(*txDriver); !ok {
+		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
-	return *v, true
+	tx := &Tx{config: m.config}
+	tx.init()
+	return tx, nil
 }
 
-// OldAuthType returns the old "auth_type" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldAuthType(ctx context.Context) (v string, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAuthType is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAuthType requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAuthType: %w", err)
+// ID returns the ID value in the mutation. Note that the ID is only available
+// if it was provided to the builder or after it was returned from the database.
+func (m *MetaMutation) ID() (id int, exists bool) {
+	if m.id == nil {
+		return
 	}
-	return oldValue.AuthType, nil
+	return *m.id, true
 }
 
-// ResetAuthType resets all changes to the "auth_type" field.
-func (m *MachineMutation) ResetAuthType() {
-	m.auth_type = nil
+// IDs queries the database and returns the entity ids that match the mutation's predicate.
+// That means, if the mutation is applied within a transaction with an isolation level such
+// as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
+// or updated by the mutation.
+func (m *MetaMutation) IDs(ctx context.Context) ([]int, error) {
+	switch {
+	case m.op.Is(OpUpdateOne | OpDeleteOne):
+		id, exists := m.ID()
+		if exists {
+			return []int{id}, nil
+		}
+		fallthrough
+	case m.op.Is(OpUpdate | OpDelete):
+		return m.Client().Meta.Query().Where(m.predicates...).IDs(ctx)
+	default:
+		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
+	}
 }
 
-// SetOsname sets the "osname" field.
-func (m *MachineMutation) SetOsname(s string) {
-	m.osname = &s
+// SetCreatedAt sets the "created_at" field.
+func (m *MetaMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// Osname returns the value of the "osname" field in the mutation.
-func (m *MachineMutation) Osname() (r string, exists bool) {
-	v := m.osname
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *MetaMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOsname returns the old "osname" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Meta entity.
+// If the Meta object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldOsname(ctx context.Context) (v string, err error) {
+func (m *MetaMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOsname is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOsname requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOsname: %w", err)
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
 	}
-	return oldValue.Osname, nil
-}
-
-// ClearOsname clears the value of the "osname" field.
-func (m *MachineMutation) ClearOsname() {
-	m.osname = nil
-	m.clearedFields[machine.FieldOsname] = struct{}{}
-}
-
-// OsnameCleared returns if the "osname" field was cleared in this mutation.
-func (m *MachineMutation) OsnameCleared() bool {
-	_, ok := m.clearedFields[machine.FieldOsname]
-	return ok
+	return oldValue.CreatedAt, nil
 }
 
-// ResetOsname resets all changes to the "osname" field.
-func (m *MachineMutation) ResetOsname() {
-	m.osname = nil
-	delete(m.clearedFields, machine.FieldOsname)
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *MetaMutation) ResetCreatedAt() {
+	m.created_at = nil
 }
 
-// SetOsversion sets the "osversion" field.
-func (m *MachineMutation) SetOsversion(s string) {
-	m.osversion = &s
+// SetUpdatedAt sets the "updated_at" field.
+func (m *MetaMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
 }
 
-// Osversion returns the value of the "osversion" field in the mutation.
-func (m *MachineMutation) Osversion() (r string, exists bool) {
-	v := m.osversion
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *MetaMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldOsversion returns the old "osversion" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldUpdatedAt returns the old "updated_at" field's value of the Meta entity.
+// If the Meta object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldOsversion(ctx context.Context) (v string, err error) {
+func (m *MetaMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldOsversion is only allowed on UpdateOne operations")
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldOsversion requires an ID field in the mutation")
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldOsversion: %w", err)
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
 	}
-	return oldValue.Osversion, nil
-}
-
-// ClearOsversion clears the value of the "osversion" field.
-func (m *MachineMutation) ClearOsversion() {
-	m.osversion = nil
-	m.clearedFields[machine.FieldOsversion] = struct{}{}
-}
-
-// OsversionCleared returns if the "osversion" field was cleared in this mutation.
-func (m *MachineMutation) OsversionCleared() bool {
-	_, ok := m.clearedFields[machine.FieldOsversion]
-	return ok
+	return oldValue.UpdatedAt, nil
 }
 
-// ResetOsversion resets all changes to the "osversion" field.
-func (m *MachineMutation) ResetOsversion() {
-	m.osversion = nil
-	delete(m.clearedFields, machine.FieldOsversion)
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *MetaMutation) ResetUpdatedAt() {
+	m.updated_at = nil
 }
 
-// SetFeatureflags sets the "featureflags" field.
-func (m *MachineMutation) SetFeatureflags(s string) {
-	m.featureflags = &s
+// SetKey sets the "key" field.
+func (m *MetaMutation) SetKey(s string) {
+	m.key = &s
 }
 
-// Featureflags returns the value of the "featureflags" field in the mutation.
-func (m *MachineMutation) Featureflags() (r string, exists bool) {
-	v := m.featureflags
+// Key returns the value of the "key" field in the mutation.
+func (m *MetaMutation) Key() (r string, exists bool) {
+	v := m.key
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldFeatureflags returns the old "featureflags" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldKey returns the old "key" field's value of the Meta entity.
+// If the Meta object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldFeatureflags(ctx context.Context) (v string, err error) {
+func (m *MetaMutation) OldKey(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldFeatureflags is only allowed on UpdateOne operations")
+		return v, errors.New("OldKey is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldFeatureflags requires an ID field in the mutation")
+		return v, errors.New("OldKey requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldFeatureflags: %w", err)
+		return v, fmt.Errorf("querying old value for OldKey: %w", err)
 	}
-	return oldValue.Featureflags, nil
-}
-
-// ClearFeatureflags clears the value of the "featureflags" field.
-func (m *MachineMutation) ClearFeatureflags() {
-	m.featureflags = nil
-	m.clearedFields[machine.FieldFeatureflags] = struct{}{}
-}
-
-// FeatureflagsCleared returns if the "featureflags" field was cleared in this mutation.
-func (m *MachineMutation) FeatureflagsCleared() bool {
-	_, ok := m.clearedFields[machine.FieldFeatureflags]
-	return ok
+	return oldValue.Key, nil
 }
 
-// ResetFeatureflags resets all changes to the "featureflags" field.
-func (m *MachineMutation) ResetFeatureflags() {
-	m.featureflags = nil
-	delete(m.clearedFields, machine.FieldFeatureflags)
+// ResetKey resets all changes to the "key" field.
+func (m *MetaMutation) ResetKey() {
+	m.key = nil
 }
 
-// SetHubstate sets the "hubstate" field.
-func (m *MachineMutation) SetHubstate(ms map[string][]schema.ItemState) {
-	m.hubstate = &ms
+// SetValue sets the "value" field.
+func (m *MetaMutation) SetValue(s string) {
+	m.value = &s
 }
 
-// Hubstate returns the value of the "hubstate" field in the mutation.
-func (m *MachineMutation) Hubstate() (r map[string][]schema.ItemState, exists bool) {
-	v := m.hubstate
+// Value returns the value of the "value" field in the mutation.
+func (m *MetaMutation) Value() (r string, exists bool) {
+	v := m.value
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldHubstate returns the old "hubstate" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldValue returns the old "value" field's value of the Meta entity.
+// If the Meta object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldHubstate(ctx context.Context) (v map[string][]schema.ItemState, err error) {
+func (m *MetaMutation) OldValue(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldHubstate is only allowed on UpdateOne operations")
+		return v, errors.New("OldValue is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldHubstate requires an ID field in the mutation")
+		return v, errors.New("OldValue requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldHubstate: %w", err)
+		return v, fmt.Errorf("querying old value for OldValue: %w", err)
 	}
-	return oldValue.Hubstate, nil
-}
-
-// ClearHubstate clears the value of the "hubstate" field.
-func (m *MachineMutation) ClearHubstate() {
-	m.hubstate = nil
-	m.clearedFields[machine.FieldHubstate] = struct{}{}
-}
-
-// HubstateCleared returns if the "hubstate" field was cleared in this mutation.
-func (m *MachineMutation) HubstateCleared() bool {
-	_, ok := m.clearedFields[machine.FieldHubstate]
-	return ok
+	return oldValue.Value, nil
 }
 
-// ResetHubstate resets all changes to the "hubstate" field.
-func (m *MachineMutation) ResetHubstate() {
-	m.hubstate = nil
-	delete(m.clearedFields, machine.FieldHubstate)
+// ResetValue resets all changes to the "value" field.
+func (m *MetaMutation) ResetValue() {
+	m.value = nil
 }
 
-// SetDatasources sets the "datasources" field.
-func (m *MachineMutation) SetDatasources(value map[string]int64) {
-	m.datasources = &value
+// SetAlertMetas sets the "alert_metas" field.
+func (m *MetaMutation) SetAlertMetas(i int) {
+	m.owner = &i
 }
 
-// Datasources returns the value of the "datasources" field in the mutation.
-func (m *MachineMutation) Datasources() (r map[string]int64, exists bool) {
-	v := m.datasources
+// AlertMetas returns the value of the "alert_metas" field in the mutation.
+func (m *MetaMutation) AlertMetas() (r int, exists bool) {
+	v := m.owner
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldDatasources returns the old "datasources" field's value of the Machine entity.
-// If the Machine object wasn't provided to the builder, the object is fetched from the database.
+// OldAlertMetas returns the old "alert_metas" field's value of the Meta entity.
+// If the Meta object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MachineMutation) OldDatasources(ctx context.Context) (v map[string]int64, err error) {
+func (m *MetaMutation) OldAlertMetas(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldDatasources is only allowed on UpdateOne operations")
+		return v, errors.New("OldAlertMetas is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldDatasources requires an ID field in the mutation")
+		return v, errors.New("OldAlertMetas requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldDatasources: %w", err)
+		return v, fmt.Errorf("querying old value for OldAlertMetas: %w", err)
 	}
-	return oldValue.Datasources, nil
+	return oldValue.AlertMetas, nil
 }
 
-// ClearDatasources clears the value of the "datasources" field.
-func (m *MachineMutation) ClearDatasources() {
-	m.datasources = nil
-	m.clearedFields[machine.FieldDatasources] = struct{}{}
+// ClearAlertMetas clears the value of the "alert_metas" field.
+func (m *MetaMutation) ClearAlertMetas() {
+	m.owner = nil
+	m.clearedFields[meta.FieldAlertMetas] = struct{}{}
 }
 
-// DatasourcesCleared returns if the "datasources" field was cleared in this mutation.
-func (m *MachineMutation) DatasourcesCleared() bool {
-	_, ok := m.clearedFields[machine.FieldDatasources]
+// AlertMetasCleared returns if the "alert_metas" field was cleared in this mutation.
+func (m *MetaMutation) AlertMetasCleared() bool {
+	_, ok := m.clearedFields[meta.FieldAlertMetas]
 	return ok
 }
 
-// ResetDatasources resets all changes to the "datasources" field.
-func (m *MachineMutation) ResetDatasources() {
-	m.datasources = nil
-	delete(m.clearedFields, machine.FieldDatasources)
-}
-
-// AddAlertIDs adds the "alerts" edge to the Alert entity by ids.
-func (m *MachineMutation) AddAlertIDs(ids ...int) {
-	if m.alerts == nil {
-		m.alerts = make(map[int]struct{})
-	}
-	for i := range ids {
-		m.alerts[ids[i]] = struct{}{}
-	}
+// ResetAlertMetas resets all changes to the "alert_metas" field.
+func (m *MetaMutation) ResetAlertMetas() {
+	m.owner = nil
+	delete(m.clearedFields, meta.FieldAlertMetas)
 }
 
-// ClearAlerts clears the "alerts" edge to the Alert entity.
-func (m *MachineMutation) ClearAlerts() {
-	m.clearedalerts = true
+// SetOwnerID sets the "owner" edge to the Alert entity by id.
+func (m *MetaMutation) SetOwnerID(id int) {
+	m.owner = &id
 }
 
-// AlertsCleared reports if the "alerts" edge to the Alert entity was cleared.
-func (m *MachineMutation) AlertsCleared() bool {
-	return m.clearedalerts
+// ClearOwner clears the "owner" edge to the Alert entity.
+func (m *MetaMutation) ClearOwner() {
+	m.clearedowner = true
+	m.clearedFields[meta.FieldAlertMetas] = struct{}{}
 }
 
-// RemoveAlertIDs removes the "alerts" edge to the Alert entity by IDs.
-func (m *MachineMutation) RemoveAlertIDs(ids ...int) {
-	if m.removedalerts == nil {
-		m.removedalerts = make(map[int]struct{})
-	}
-	for i := range ids {
-		delete(m.alerts, ids[i])
-		m.removedalerts[ids[i]] = struct{}{}
-	}
+// OwnerCleared reports if the "owner" edge to the Alert entity was cleared.
+func (m *MetaMutation) OwnerCleared() bool {
+	return m.AlertMetasCleared() || m.clearedowner
 }
 
-// RemovedAlerts returns the removed IDs of the "alerts" edge to the Alert entity.
-func (m *MachineMutation) RemovedAlertsIDs() (ids []int) {
-	for id := range m.removedalerts {
-		ids = append(ids, id)
+// OwnerID returns the "owner" edge ID in the mutation.
+func (m *MetaMutation) OwnerID() (id int, exists bool) {
+	if m.owner != nil {
+		return *m.owner, true
 	}
 	return
 }
 
-// AlertsIDs returns the "alerts" edge IDs in the mutation.
-func (m *MachineMutation) AlertsIDs() (ids []int) {
-	for id := range m.alerts {
-		ids = append(ids, id)
+// OwnerIDs returns the "owner" edge IDs in the mutation.
+// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
+// OwnerID instead. It exists only for internal usage by the builders.
+func (m *MetaMutation) OwnerIDs() (ids []int) {
+	if id := m.owner; id != nil {
+		ids = append(ids, *id)
 	}
 	return
 }
 
-// ResetAlerts resets all changes to the "alerts" edge.
-func (m *MachineMutation) ResetAlerts() {
-	m.alerts = nil
-	m.clearedalerts = false
-	m.removedalerts = nil
+// ResetOwner resets all changes to the "owner" edge.
+func (m *MetaMutation) ResetOwner() {
+	m.owner = nil
+	m.clearedowner = false
 }
 
-// Where appends a list predicates to the MachineMutation builder.
-func (m *MachineMutation) Where(ps ...predicate.Machine) {
+// Where appends a list predicates to the MetaMutation builder.
+func (m *MetaMutation) Where(ps ...predicate.Meta) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the MachineMutation builder. Using this method,
+// WhereP appends storage-level predicates to the MetaMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *MachineMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Machine, len(ps))
+func (m *MetaMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Meta, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -9489,72 +11091,39 @@ func (m *MachineMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *MachineMutation) Op() Op {
+func (m *MetaMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *MachineMutation) SetOp(op Op) {
+func (m *MetaMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Machine).
-func (m *MachineMutation) Type() string {
+// Type returns the node type of this mutation (Meta).
+func (m *MetaMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *MachineMutation) Fields() []string {
-	fields := make([]string, 0, 16)
+func (m *MetaMutation) Fields() []string {
+	fields := make([]string, 0, 5)
 	if m.created_at != nil {
-		fields = append(fields, machine.FieldCreatedAt)
+		fields = append(fields, meta.FieldCreatedAt)
 	}
 	if m.updated_at != nil {
-		fields = append(fields, machine.FieldUpdatedAt)
-	}
-	if m.last_push != nil {
-		fields = append(fields, machine.FieldLastPush)
-	}
-	if m.last_heartbeat != nil {
-		fields = append(fields, machine.FieldLastHeartbeat)
-	}
-	if m.machineId != nil {
-		fields = append(fields, machine.FieldMachineId)
-	}
-	if m.password != nil {
-		fields = append(fields, machine.FieldPassword)
-	}
-	if m.ipAddress != nil {
-		fields = append(fields, machine.FieldIpAddress)
-	}
-	if m.scenarios != nil {
-		fields = append(fields, machine.FieldScenarios)
-	}
-	if m.version != nil {
-		fields = append(fields, machine.FieldVersion)
-	}
-	if m.isValidated != nil {
-		fields = append(fields, machine.FieldIsValidated)
-	}
-	if m.auth_type != nil {
-		fields = append(fields, machine.FieldAuthType)
-	}
-	if m.osname != nil {
-		fields = append(fields, machine.FieldOsname)
-	}
-	if m.osversion != nil {
-		fields = append(fields, machine.FieldOsversion)
+		fields = append(fields, meta.FieldUpdatedAt)
 	}
-	if m.featureflags != nil {
-		fields = append(fields, machine.FieldFeatureflags)
+	if m.key != nil {
+		fields = append(fields, meta.FieldKey)
 	}
-	if m.hubstate != nil {
-		fields = append(fields, machine.FieldHubstate)
+	if m.value != nil {
+		fields = append(fields, meta.FieldValue)
 	}
-	if m.datasources != nil {
-		fields = append(fields, machine.FieldDatasources)
+	if m.owner != nil {
+		fields = append(fields, meta.FieldAlertMetas)
 	}
 	return fields
 }
@@ -9562,40 +11131,18 @@ func (m *MachineMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *MachineMutation) Field(name string) (ent.Value, bool) {
+func (m *MetaMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case machine.FieldCreatedAt:
+	case meta.FieldCreatedAt:
 		return m.CreatedAt()
-	case machine.FieldUpdatedAt:
+	case meta.FieldUpdatedAt:
 		return m.UpdatedAt()
-	case machine.FieldLastPush:
-		return m.LastPush()
-	case machine.FieldLastHeartbeat:
-		return m.LastHeartbeat()
-	case machine.FieldMachineId:
-		return m.MachineId()
-	case machine.FieldPassword:
-		return m.Password()
-	case machine.FieldIpAddress:
-		return m.IpAddress()
-	case machine.FieldScenarios:
-		return m.Scenarios()
-	case machine.FieldVersion:
-		return m.Version()
-	case machine.FieldIsValidated:
-		return m.IsValidated()
-	case machine.FieldAuthType:
-		return m.AuthType()
-	case machine.FieldOsname:
-		return m.Osname()
-	case machine.FieldOsversion:
-		return m.Osversion()
-	case machine.FieldFeatureflags:
-		return m.Featureflags()
-	case machine.FieldHubstate:
-		return m.Hubstate()
-	case machine.FieldDatasources:
-		return m.Datasources()
+	case meta.FieldKey:
+		return m.Key()
+	case meta.FieldValue:
+		return m.Value()
+	case meta.FieldAlertMetas:
+		return m.AlertMetas()
 	}
 	return nil, false
 }
@@ -9603,432 +11150,244 @@ func (m *MachineMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *MachineMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *MetaMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case machine.FieldCreatedAt:
+	case meta.FieldCreatedAt:
 		return m.OldCreatedAt(ctx)
-	case machine.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case machine.FieldLastPush:
-		return m.OldLastPush(ctx)
-	case machine.FieldLastHeartbeat:
-		return m.OldLastHeartbeat(ctx)
-	case machine.FieldMachineId:
-		return m.OldMachineId(ctx)
-	case machine.FieldPassword:
-		return m.OldPassword(ctx)
-	case machine.FieldIpAddress:
-		return m.OldIpAddress(ctx)
-	case machine.FieldScenarios:
-		return m.OldScenarios(ctx)
-	case machine.FieldVersion:
-		return m.OldVersion(ctx)
-	case machine.FieldIsValidated:
-		return m.OldIsValidated(ctx)
-	case machine.FieldAuthType:
-		return m.OldAuthType(ctx)
-	case machine.FieldOsname:
-		return m.OldOsname(ctx)
-	case machine.FieldOsversion:
-		return m.OldOsversion(ctx)
-	case machine.FieldFeatureflags:
-		return m.OldFeatureflags(ctx)
-	case machine.FieldHubstate:
-		return m.OldHubstate(ctx)
-	case machine.FieldDatasources:
-		return m.OldDatasources(ctx)
+	case meta.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case meta.FieldKey:
+		return m.OldKey(ctx)
+	case meta.FieldValue:
+		return m.OldValue(ctx)
+	case meta.FieldAlertMetas:
+		return m.OldAlertMetas(ctx)
 	}
-	return nil, fmt.Errorf("unknown Machine field %s", name)
+	return nil, fmt.Errorf("unknown Meta field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MachineMutation) SetField(name string, value ent.Value) error {
+func (m *MetaMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case machine.FieldCreatedAt:
+	case meta.FieldCreatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetCreatedAt(v)
 		return nil
-	case machine.FieldUpdatedAt:
+	case meta.FieldUpdatedAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
 		m.SetUpdatedAt(v)
 		return nil
-	case machine.FieldLastPush:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetLastPush(v)
-		return nil
-	case machine.FieldLastHeartbeat:
-		v, ok := value.(time.Time)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetLastHeartbeat(v)
-		return nil
-	case machine.FieldMachineId:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetMachineId(v)
-		return nil
-	case machine.FieldPassword:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetPassword(v)
-		return nil
-	case machine.FieldIpAddress:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetIpAddress(v)
-		return nil
-	case machine.FieldScenarios:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetScenarios(v)
-		return nil
-	case machine.FieldVersion:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetVersion(v)
-		return nil
-	case machine.FieldIsValidated:
-		v, ok := value.(bool)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetIsValidated(v)
-		return nil
-	case machine.FieldAuthType:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetAuthType(v)
-		return nil
-	case machine.FieldOsname:
-		v, ok := value.(string)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetOsname(v)
-		return nil
-	case machine.FieldOsversion:
+	case meta.FieldKey:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetOsversion(v)
+		m.SetKey(v)
 		return nil
-	case machine.FieldFeatureflags:
+	case meta.FieldValue:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetFeatureflags(v)
-		return nil
-	case machine.FieldHubstate:
-		v, ok := value.(map[string][]schema.ItemState)
-		if !ok {
-			return fmt.Errorf("unexpected type %T for field %s", value, name)
-		}
-		m.SetHubstate(v)
+		m.SetValue(v)
 		return nil
-	case machine.FieldDatasources:
-		v, ok := value.(map[string]int64)
+	case meta.FieldAlertMetas:
+		v, ok := value.(int)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetDatasources(v)
+		m.SetAlertMetas(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Machine field %s", name)
+	return fmt.Errorf("unknown Meta field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *MachineMutation) AddedFields() []string {
-	return nil
+func (m *MetaMutation) AddedFields() []string {
+	var fields []string
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *MachineMutation) AddedField(name string) (ent.Value, bool) {
+func (m *MetaMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MachineMutation) AddField(name string, value ent.Value) error {
+func (m *MetaMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Machine numeric field %s", name)
+	return fmt.Errorf("unknown Meta numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *MachineMutation) ClearedFields() []string {
+func (m *MetaMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(machine.FieldLastPush) {
-		fields = append(fields, machine.FieldLastPush)
-	}
-	if m.FieldCleared(machine.FieldLastHeartbeat) {
-		fields = append(fields, machine.FieldLastHeartbeat)
-	}
-	if m.FieldCleared(machine.FieldScenarios) {
-		fields = append(fields, machine.FieldScenarios)
-	}
-	if m.FieldCleared(machine.FieldVersion) {
-		fields = append(fields, machine.FieldVersion)
-	}
-	if m.FieldCleared(machine.FieldOsname) {
-		fields = append(fields, machine.FieldOsname)
-	}
-	if m.FieldCleared(machine.FieldOsversion) {
-		fields = append(fields, machine.FieldOsversion)
-	}
-	if m.FieldCleared(machine.FieldFeatureflags) {
-		fields = append(fields, machine.FieldFeatureflags)
-	}
-	if m.FieldCleared(machine.FieldHubstate) {
-		fields = append(fields, machine.FieldHubstate)
-	}
-	if m.FieldCleared(machine.FieldDatasources) {
-		fields = append(fields, machine.FieldDatasources)
+	if m.FieldCleared(meta.FieldAlertMetas) {
+		fields = append(fields, meta.FieldAlertMetas)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *MachineMutation) FieldCleared(name string) bool {
+func (m *MetaMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *MachineMutation) ClearField(name string) error {
+func (m *MetaMutation) ClearField(name string) error {
 	switch name {
-	case machine.FieldLastPush:
-		m.ClearLastPush()
-		return nil
-	case machine.FieldLastHeartbeat:
-		m.ClearLastHeartbeat()
-		return nil
-	case machine.FieldScenarios:
-		m.ClearScenarios()
-		return nil
-	case machine.FieldVersion:
-		m.ClearVersion()
-		return nil
-	case machine.FieldOsname:
-		m.ClearOsname()
-		return nil
-	case machine.FieldOsversion:
-		m.ClearOsversion()
-		return nil
-	case machine.FieldFeatureflags:
-		m.ClearFeatureflags()
-		return nil
-	case machine.FieldHubstate:
-		m.ClearHubstate()
-		return nil
-	case machine.FieldDatasources:
-		m.ClearDatasources()
+	case meta.FieldAlertMetas:
+		m.ClearAlertMetas()
 		return nil
 	}
-	return fmt.Errorf("unknown Machine nullable field %s", name)
+	return fmt.Errorf("unknown Meta nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *MachineMutation) ResetField(name string) error {
+func (m *MetaMutation) ResetField(name string) error {
 	switch name {
-	case machine.FieldCreatedAt:
+	case meta.FieldCreatedAt:
 		m.ResetCreatedAt()
 		return nil
-	case machine.FieldUpdatedAt:
+	case meta.FieldUpdatedAt:
 		m.ResetUpdatedAt()
 		return nil
-	case machine.FieldLastPush:
-		m.ResetLastPush()
-		return nil
-	case machine.FieldLastHeartbeat:
-		m.ResetLastHeartbeat()
-		return nil
-	case machine.FieldMachineId:
-		m.ResetMachineId()
-		return nil
-	case machine.FieldPassword:
-		m.ResetPassword()
-		return nil
-	case machine.FieldIpAddress:
-		m.ResetIpAddress()
-		return nil
-	case machine.FieldScenarios:
-		m.ResetScenarios()
-		return nil
-	case machine.FieldVersion:
-		m.ResetVersion()
-		return nil
-	case machine.FieldIsValidated:
-		m.ResetIsValidated()
-		return nil
-	case machine.FieldAuthType:
-		m.ResetAuthType()
-		return nil
-	case machine.FieldOsname:
-		m.ResetOsname()
-		return nil
-	case machine.FieldOsversion:
-		m.ResetOsversion()
-		return nil
-	case machine.FieldFeatureflags:
-		m.ResetFeatureflags()
+	case meta.FieldKey:
+		m.ResetKey()
 		return nil
-	case machine.FieldHubstate:
-		m.ResetHubstate()
+	case meta.FieldValue:
+		m.ResetValue()
 		return nil
-	case machine.FieldDatasources:
-		m.ResetDatasources()
+	case meta.FieldAlertMetas:
+		m.ResetAlertMetas()
 		return nil
 	}
-	return fmt.Errorf("unknown Machine field %s", name)
+	return fmt.Errorf("unknown Meta field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *MachineMutation) AddedEdges() []string {
+func (m *MetaMutation) AddedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.alerts != nil {
-		edges = append(edges, machine.EdgeAlerts)
+	if m.owner != nil {
+		edges = append(edges, meta.EdgeOwner)
 	}
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *MachineMutation) AddedIDs(name string) []ent.Value {
+func (m *MetaMutation) AddedIDs(name string) []ent.Value {
 	switch name {
-	case machine.EdgeAlerts:
-		ids := make([]ent.Value, 0, len(m.alerts))
-		for id := range m.alerts {
-			ids = append(ids, id)
+	case meta.EdgeOwner:
+		if id := m.owner; id != nil {
+			return []ent.Value{*id}
 		}
-		return ids
 	}
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *MachineMutation) RemovedEdges() []string {
+func (m *MetaMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.removedalerts != nil {
-		edges = append(edges, machine.EdgeAlerts)
-	}
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *MachineMutation) RemovedIDs(name string) []ent.Value {
-	switch name {
-	case machine.EdgeAlerts:
-		ids := make([]ent.Value, 0, len(m.removedalerts))
-		for id := range m.removedalerts {
-			ids = append(ids, id)
-		}
-		return ids
-	}
+func (m *MetaMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *MachineMutation) ClearedEdges() []string {
+func (m *MetaMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 1)
-	if m.clearedalerts {
-		edges = append(edges, machine.EdgeAlerts)
+	if m.clearedowner {
+		edges = append(edges, meta.EdgeOwner)
 	}
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *MachineMutation) EdgeCleared(name string) bool {
+func (m *MetaMutation) EdgeCleared(name string) bool {
 	switch name {
-	case machine.EdgeAlerts:
-		return m.clearedalerts
+	case meta.EdgeOwner:
+		return m.clearedowner
 	}
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *MachineMutation) ClearEdge(name string) error {
+func (m *MetaMutation) ClearEdge(name string) error {
 	switch name {
+	case meta.EdgeOwner:
+		m.ClearOwner()
+		return nil
 	}
-	return fmt.Errorf("unknown Machine unique edge %s", name)
+	return fmt.Errorf("unknown Meta unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *MachineMutation) ResetEdge(name string) error {
+func (m *MetaMutation) ResetEdge(name string) error {
 	switch name {
-	case machine.EdgeAlerts:
-		m.ResetAlerts()
+	case meta.EdgeOwner:
+		m.ResetOwner()
 		return nil
 	}
-	return fmt.Errorf("unknown Machine edge %s", name)
+	return fmt.Errorf("unknown Meta edge %s", name)
 }
 
-// MetaMutation represents an operation that mutates the Meta nodes in the graph.
-type MetaMutation struct {
+// MetricMutation represents an operation that mutates the Metric nodes in the graph.
+type MetricMutation struct {
 	config
-	op            Op
-	typ           string
-	id            *int
-	created_at    *time.Time
-	updated_at    *time.Time
-	key           *string
-	value         *string
-	clearedFields map[string]struct{}
-	owner         *int
-	clearedowner  bool
-	done          bool
-	oldValue      func(context.Context) (*Meta, error)
-	predicates    []predicate.Meta
+	op             Op
+	typ            string
+	id             *int
+	generated_type *metric.GeneratedType
+	generated_by   *string
+	received_at    *time.Time
+	pushed_at      *time.Time
+	payload        *string
+	clearedFields  map[string]struct{}
+	done           bool
+	oldValue       func(context.Context) (*Metric, error)
+	predicates     []predicate.Metric
 }
 
-var _ ent.Mutation = (*MetaMutation)(nil)
+var _ ent.Mutation = (*MetricMutation)(nil)
 
-// metaOption allows management of the mutation configuration using functional options.
-type metaOption func(*MetaMutation)
+// metricOption allows management of the mutation configuration using functional options.
+type metricOption func(*MetricMutation)
 
-// newMetaMutation creates new mutation for the Meta entity.
-func newMetaMutation(c config, op Op, opts ...metaOption) *MetaMutation {
-	m := &MetaMutation{
+// newMetricMutation creates new mutation for the Metric entity.
+func newMetricMutation(c config, op Op, opts ...metricOption) *MetricMutation {
+	m := &MetricMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeMeta,
+		typ:           TypeMetric,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -10037,20 +11396,20 @@ func newMetaMutation(c config, op Op, opts ...metaOption) *MetaMutation {
 	return m
 }
 
-// withMetaID sets the ID field of the mutation.
-func withMetaID(id int) metaOption {
-	return func(m *MetaMutation) {
+// withMetricID sets the ID field of the mutation.
+func withMetricID(id int) metricOption {
+	return func(m *MetricMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Meta
+			value *Metric
 		)
-		m.oldValue = func(ctx context.Context) (*Meta, error) {
+		m.oldValue = func(ctx context.Context) (*Metric, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Meta.Get(ctx, id)
+					value, err = m.Client().Metric.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -10059,10 +11418,10 @@ func withMetaID(id int) metaOption {
 	}
 }
 
-// withMeta sets the old Meta of the mutation.
-func withMeta(node *Meta) metaOption {
-	return func(m *MetaMutation) {
-		m.oldValue = func(context.Context) (*Meta, error) {
+// withMetric sets the old Metric of the mutation.
+func withMetric(node *Metric) metricOption {
+	return func(m *MetricMutation) {
+		m.oldValue = func(context.Context) (*Metric, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -10071,7 +11430,7 @@ func withMeta(node *Meta) metaOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m MetaMutation) Client() *Client {
+func (m MetricMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -10079,7 +11438,7 @@ func (m MetaMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m MetaMutation) Tx() (*Tx, error) {
+func (m MetricMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -10090,7 +11449,7 @@ func (m MetaMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *MetaMutation) ID() (id int, exists bool) {
+func (m *MetricMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -10101,7 +11460,7 @@ func (m *MetaMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *MetaMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *MetricMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -10110,254 +11469,214 @@ func (m *MetaMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Meta.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Metric.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (m *MetaMutation) SetCreatedAt(t time.Time) {
-	m.created_at = &t
-}
-
-// CreatedAt returns the value of the "created_at" field in the mutation.
-func (m *MetaMutation) CreatedAt() (r time.Time, exists bool) {
-	v := m.created_at
-	if v == nil {
-		return
-	}
-	return *v, true
-}
-
-// OldCreatedAt returns the old "created_at" field's value of the Meta entity.
-// If the Meta object wasn't provided to the builder, the object is fetched from the database.
-// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetaMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
-	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
-	}
-	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
-	}
-	oldValue, err := m.oldValue(ctx)
-	if err != nil {
-		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
-	}
-	return oldValue.CreatedAt, nil
-}
-
-// ResetCreatedAt resets all changes to the "created_at" field.
-func (m *MetaMutation) ResetCreatedAt() {
-	m.created_at = nil
-}
-
-// SetUpdatedAt sets the "updated_at" field.
-func (m *MetaMutation) SetUpdatedAt(t time.Time) {
-	m.updated_at = &t
+// SetGeneratedType sets the "generated_type" field.
+func (m *MetricMutation) SetGeneratedType(mt metric.GeneratedType) {
+	m.generated_type = &mt
 }
 
-// UpdatedAt returns the value of the "updated_at" field in the mutation.
-func (m *MetaMutation) UpdatedAt() (r time.Time, exists bool) {
-	v := m.updated_at
+// GeneratedType returns the value of the "generated_type" field in the mutation.
+func (m *MetricMutation) GeneratedType() (r metric.GeneratedType, exists bool) {
+	v := m.generated_type
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldUpdatedAt returns the old "updated_at" field's value of the Meta entity.
-// If the Meta object wasn't provided to the builder, the object is fetched from the database.
+// OldGeneratedType returns the old "generated_type" field's value of the Metric entity.
+// If the Metric object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetaMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+func (m *MetricMutation) OldGeneratedType(ctx context.Context) (v metric.GeneratedType, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldGeneratedType is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+		return v, errors.New("OldGeneratedType requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldGeneratedType: %w", err)
 	}
-	return oldValue.UpdatedAt, nil
+	return oldValue.GeneratedType, nil
 }
 
-// ResetUpdatedAt resets all changes to the "updated_at" field.
-func (m *MetaMutation) ResetUpdatedAt() {
-	m.updated_at = nil
+// ResetGeneratedType resets all changes to the "generated_type" field.
+func (m *MetricMutation) ResetGeneratedType() {
+	m.generated_type = nil
 }
 
-// SetKey sets the "key" field.
-func (m *MetaMutation) SetKey(s string) {
-	m.key = &s
+// SetGeneratedBy sets the "generated_by" field.
+func (m *MetricMutation) SetGeneratedBy(s string) {
+	m.generated_by = &s
 }
 
-// Key returns the value of the "key" field in the mutation.
-func (m *MetaMutation) Key() (r string, exists bool) {
-	v := m.key
+// GeneratedBy returns the value of the "generated_by" field in the mutation.
+func (m *MetricMutation) GeneratedBy() (r string, exists bool) {
+	v := m.generated_by
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldKey returns the old "key" field's value of the Meta entity.
-// If the Meta object wasn't provided to the builder, the object is fetched from the database.
+// OldGeneratedBy returns the old "generated_by" field's value of the Metric entity.
+// If the Metric object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetaMutation) OldKey(ctx context.Context) (v string, err error) {
+func (m *MetricMutation) OldGeneratedBy(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldKey is only allowed on UpdateOne operations")
+		return v, errors.New("OldGeneratedBy is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldKey requires an ID field in the mutation")
+		return v, errors.New("OldGeneratedBy requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldKey: %w", err)
+		return v, fmt.Errorf("querying old value for OldGeneratedBy: %w", err)
 	}
-	return oldValue.Key, nil
+	return oldValue.GeneratedBy, nil
 }
 
-// ResetKey resets all changes to the "key" field.
-func (m *MetaMutation) ResetKey() {
-	m.key = nil
+// ResetGeneratedBy resets all changes to the "generated_by" field.
+func (m *MetricMutation) ResetGeneratedBy() {
+	m.generated_by = nil
 }
 
-// SetValue sets the "value" field.
-func (m *MetaMutation) SetValue(s string) {
-	m.value = &s
+// SetReceivedAt sets the "received_at" field.
+func (m *MetricMutation) SetReceivedAt(t time.Time) {
+	m.received_at = &t
 }
 
-// Value returns the value of the "value" field in the mutation.
-func (m *MetaMutation) Value() (r string, exists bool) {
-	v := m.value
+// ReceivedAt returns the value of the "received_at" field in the mutation.
+func (m *MetricMutation) ReceivedAt() (r time.Time, exists bool) {
+	v := m.received_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldValue returns the old "value" field's value of the Meta entity.
-// If the Meta object wasn't provided to the builder, the object is fetched from the database.
+// OldReceivedAt returns the old "received_at" field's value of the Metric entity.
+// If the Metric object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetaMutation) OldValue(ctx context.Context) (v string, err error) {
+func (m *MetricMutation) OldReceivedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldValue is only allowed on UpdateOne operations")
+		return v, errors.New("OldReceivedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldValue requires an ID field in the mutation")
+		return v, errors.New("OldReceivedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldValue: %w", err)
+		return v, fmt.Errorf("querying old value for OldReceivedAt: %w", err)
 	}
-	return oldValue.Value, nil
+	return oldValue.ReceivedAt, nil
 }
 
-// ResetValue resets all changes to the "value" field.
-func (m *MetaMutation) ResetValue() {
-	m.value = nil
+// ResetReceivedAt resets all changes to the "received_at" field.
+func (m *MetricMutation) ResetReceivedAt() {
+	m.received_at = nil
 }
 
-// SetAlertMetas sets the "alert_metas" field.
-func (m *MetaMutation) SetAlertMetas(i int) {
-	m.owner = &i
+// SetPushedAt sets the "pushed_at" field.
+func (m *MetricMutation) SetPushedAt(t time.Time) {
+	m.pushed_at = &t
 }
 
-// AlertMetas returns the value of the "alert_metas" field in the mutation.
-func (m *MetaMutation) AlertMetas() (r int, exists bool) {
-	v := m.owner
+// PushedAt returns the value of the "pushed_at" field in the mutation.
+func (m *MetricMutation) PushedAt() (r time.Time, exists bool) {
+	v := m.pushed_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldAlertMetas returns the old "alert_metas" field's value of the Meta entity.
-// If the Meta object wasn't provided to the builder, the object is fetched from the database.
+// OldPushedAt returns the old "pushed_at" field's value of the Metric entity.
+// If the Metric object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetaMutation) OldAlertMetas(ctx context.Context) (v int, err error) {
+func (m *MetricMutation) OldPushedAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldAlertMetas is only allowed on UpdateOne operations")
+		return v, errors.New("OldPushedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldAlertMetas requires an ID field in the mutation")
+		return v, errors.New("OldPushedAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldAlertMetas: %w", err)
+		return v, fmt.Errorf("querying old value for OldPushedAt: %w", err)
 	}
-	return oldValue.AlertMetas, nil
+	return oldValue.PushedAt, nil
 }
 
-// ClearAlertMetas clears the value of the "alert_metas" field.
-func (m *MetaMutation) ClearAlertMetas() {
-	m.owner = nil
-	m.clearedFields[meta.FieldAlertMetas] = struct{}{}
+// ClearPushedAt clears the value of the "pushed_at" field.
+func (m *MetricMutation) ClearPushedAt() {
+	m.pushed_at = nil
+	m.clearedFields[metric.FieldPushedAt] = struct{}{}
 }
 
-// AlertMetasCleared returns if the "alert_metas" field was cleared in this mutation.
-func (m *MetaMutation) AlertMetasCleared() bool {
-	_, ok := m.clearedFields[meta.FieldAlertMetas]
+// PushedAtCleared returns if the "pushed_at" field was cleared in this mutation.
+func (m *MetricMutation) PushedAtCleared() bool {
+	_, ok := m.clearedFields[metric.FieldPushedAt]
 	return ok
 }
 
-// ResetAlertMetas resets all changes to the "alert_metas" field.
-func (m *MetaMutation) ResetAlertMetas() {
-	m.owner = nil
-	delete(m.clearedFields, meta.FieldAlertMetas)
-}
-
-// SetOwnerID sets the "owner" edge to the Alert entity by id.
-func (m *MetaMutation) SetOwnerID(id int) {
-	m.owner = &id
-}
-
-// ClearOwner clears the "owner" edge to the Alert entity.
-func (m *MetaMutation) ClearOwner() {
-	m.clearedowner = true
-	m.clearedFields[meta.FieldAlertMetas] = struct{}{}
+// ResetPushedAt resets all changes to the "pushed_at" field.
+func (m *MetricMutation) ResetPushedAt() {
+	m.pushed_at = nil
+	delete(m.clearedFields, metric.FieldPushedAt)
 }
 
-// OwnerCleared reports if the "owner" edge to the Alert entity was cleared.
-func (m *MetaMutation) OwnerCleared() bool {
-	return m.AlertMetasCleared() || m.clearedowner
+// SetPayload sets the "payload" field.
+func (m *MetricMutation) SetPayload(s string) {
+	m.payload = &s
 }
 
-// OwnerID returns the "owner" edge ID in the mutation.
-func (m *MetaMutation) OwnerID() (id int, exists bool) {
-	if m.owner != nil {
-		return *m.owner, true
+// Payload returns the value of the "payload" field in the mutation.
+func (m *MetricMutation) Payload() (r string, exists bool) {
+	v := m.payload
+	if v == nil {
+		return
 	}
-	return
+	return *v, true
 }
 
-// OwnerIDs returns the "owner" edge IDs in the mutation.
-// Note that IDs always returns len(IDs) <= 1 for unique edges, and you should use
-// OwnerID instead. It exists only for internal usage by the builders.
-func (m *MetaMutation) OwnerIDs() (ids []int) {
-	if id := m.owner; id != nil {
-		ids = append(ids, *id)
+// OldPayload returns the old "payload" field's value of the Metric entity.
+// If the Metric object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *MetricMutation) OldPayload(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
 	}
-	return
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPayload requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+	}
+	return oldValue.Payload, nil
 }
 
-// ResetOwner resets all changes to the "owner" edge.
-func (m *MetaMutation) ResetOwner() {
-	m.owner = nil
-	m.clearedowner = false
+// ResetPayload resets all changes to the "payload" field.
+func (m *MetricMutation) ResetPayload() {
+	m.payload = nil
 }
 
-// Where appends a list predicates to the MetaMutation builder.
-func (m *MetaMutation) Where(ps ...predicate.Meta) {
+// Where appends a list predicates to the MetricMutation builder.
+func (m *MetricMutation) Where(ps ...predicate.Metric) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the MetaMutation builder. Using this method,
+// WhereP appends storage-level predicates to the MetricMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *MetaMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Meta, len(ps))
+func (m *MetricMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Metric, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -10365,39 +11684,39 @@ func (m *MetaMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *MetaMutation) Op() Op {
+func (m *MetricMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *MetaMutation) SetOp(op Op) {
+func (m *MetricMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Meta).
-func (m *MetaMutation) Type() string {
+// Type returns the node type of this mutation (Metric).
+func (m *MetricMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *MetaMutation) Fields() []string {
+func (m *MetricMutation) Fields() []string {
 	fields := make([]string, 0, 5)
-	if m.created_at != nil {
-		fields = append(fields, meta.FieldCreatedAt)
+	if m.generated_type != nil {
+		fields = append(fields, metric.FieldGeneratedType)
 	}
-	if m.updated_at != nil {
-		fields = append(fields, meta.FieldUpdatedAt)
+	if m.generated_by != nil {
+		fields = append(fields, metric.FieldGeneratedBy)
 	}
-	if m.key != nil {
-		fields = append(fields, meta.FieldKey)
+	if m.received_at != nil {
+		fields = append(fields, metric.FieldReceivedAt)
 	}
-	if m.value != nil {
-		fields = append(fields, meta.FieldValue)
+	if m.pushed_at != nil {
+		fields = append(fields, metric.FieldPushedAt)
 	}
-	if m.owner != nil {
-		fields = append(fields, meta.FieldAlertMetas)
+	if m.payload != nil {
+		fields = append(fields, metric.FieldPayload)
 	}
 	return fields
 }
@@ -10405,18 +11724,18 @@ func (m *MetaMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *MetaMutation) Field(name string) (ent.Value, bool) {
+func (m *MetricMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case meta.FieldCreatedAt:
-		return m.CreatedAt()
-	case meta.FieldUpdatedAt:
-		return m.UpdatedAt()
-	case meta.FieldKey:
-		return m.Key()
-	case meta.FieldValue:
-		return m.Value()
-	case meta.FieldAlertMetas:
-		return m.AlertMetas()
+	case metric.FieldGeneratedType:
+		return m.GeneratedType()
+	case metric.FieldGeneratedBy:
+		return m.GeneratedBy()
+	case metric.FieldReceivedAt:
+		return m.ReceivedAt()
+	case metric.FieldPushedAt:
+		return m.PushedAt()
+	case metric.FieldPayload:
+		return m.Payload()
 	}
 	return nil, false
 }
@@ -10424,244 +11743,222 @@ func (m *MetaMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *MetaMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *MetricMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case meta.FieldCreatedAt:
-		return m.OldCreatedAt(ctx)
-	case meta.FieldUpdatedAt:
-		return m.OldUpdatedAt(ctx)
-	case meta.FieldKey:
-		return m.OldKey(ctx)
-	case meta.FieldValue:
-		return m.OldValue(ctx)
-	case meta.FieldAlertMetas:
-		return m.OldAlertMetas(ctx)
+	case metric.FieldGeneratedType:
+		return m.OldGeneratedType(ctx)
+	case metric.FieldGeneratedBy:
+		return m.OldGeneratedBy(ctx)
+	case metric.FieldReceivedAt:
+		return m.OldReceivedAt(ctx)
+	case metric.FieldPushedAt:
+		return m.OldPushedAt(ctx)
+	case metric.FieldPayload:
+		return m.OldPayload(ctx)
 	}
-	return nil, fmt.Errorf("unknown Meta field %s", name)
+	return nil, fmt.Errorf("unknown Metric field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MetaMutation) SetField(name string, value ent.Value) error {
+func (m *MetricMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case meta.FieldCreatedAt:
-		v, ok := value.(time.Time)
+	case metric.FieldGeneratedType:
+		v, ok := value.(metric.GeneratedType)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetCreatedAt(v)
+		m.SetGeneratedType(v)
 		return nil
-	case meta.FieldUpdatedAt:
-		v, ok := value.(time.Time)
+	case metric.FieldGeneratedBy:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetUpdatedAt(v)
+		m.SetGeneratedBy(v)
 		return nil
-	case meta.FieldKey:
-		v, ok := value.(string)
+	case metric.FieldReceivedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetKey(v)
+		m.SetReceivedAt(v)
 		return nil
-	case meta.FieldValue:
-		v, ok := value.(string)
+	case metric.FieldPushedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetValue(v)
+		m.SetPushedAt(v)
 		return nil
-	case meta.FieldAlertMetas:
-		v, ok := value.(int)
+	case metric.FieldPayload:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetAlertMetas(v)
+		m.SetPayload(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Meta field %s", name)
+	return fmt.Errorf("unknown Metric field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *MetaMutation) AddedFields() []string {
-	var fields []string
-	return fields
+func (m *MetricMutation) AddedFields() []string {
+	return nil
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *MetaMutation) AddedField(name string) (ent.Value, bool) {
-	switch name {
-	}
+func (m *MetricMutation) AddedField(name string) (ent.Value, bool) {
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MetaMutation) AddField(name string, value ent.Value) error {
+func (m *MetricMutation) AddField(name string, value ent.Value) error {
 	switch name {
 	}
-	return fmt.Errorf("unknown Meta numeric field %s", name)
+	return fmt.Errorf("unknown Metric numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *MetaMutation) ClearedFields() []string {
+func (m *MetricMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(meta.FieldAlertMetas) {
-		fields = append(fields, meta.FieldAlertMetas)
+	if m.FieldCleared(metric.FieldPushedAt) {
+		fields = append(fields, metric.FieldPushedAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *MetaMutation) FieldCleared(name string) bool {
+func (m *MetricMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *MetaMutation) ClearField(name string) error {
+func (m *MetricMutation) ClearField(name string) error {
 	switch name {
-	case meta.FieldAlertMetas:
-		m.ClearAlertMetas()
+	case metric.FieldPushedAt:
+		m.ClearPushedAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Meta nullable field %s", name)
+	return fmt.Errorf("unknown Metric nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *MetaMutation) ResetField(name string) error {
+func (m *MetricMutation) ResetField(name string) error {
 	switch name {
-	case meta.FieldCreatedAt:
-		m.ResetCreatedAt()
+	case metric.FieldGeneratedType:
+		m.ResetGeneratedType()
 		return nil
-	case meta.FieldUpdatedAt:
-		m.ResetUpdatedAt()
+	case metric.FieldGeneratedBy:
+		m.ResetGeneratedBy()
 		return nil
-	case meta.FieldKey:
-		m.ResetKey()
+	case metric.FieldReceivedAt:
+		m.ResetReceivedAt()
 		return nil
-	case meta.FieldValue:
-		m.ResetValue()
+	case metric.FieldPushedAt:
+		m.ResetPushedAt()
 		return nil
-	case meta.FieldAlertMetas:
-		m.ResetAlertMetas()
+	case metric.FieldPayload:
+		m.ResetPayload()
 		return nil
 	}
-	return fmt.Errorf("unknown Meta field %s", name)
+	return fmt.Errorf("unknown Metric field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *MetaMutation) AddedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.owner != nil {
-		edges = append(edges, meta.EdgeOwner)
-	}
+func (m *MetricMutation) AddedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *MetaMutation) AddedIDs(name string) []ent.Value {
-	switch name {
-	case meta.EdgeOwner:
-		if id := m.owner; id != nil {
-			return []ent.Value{*id}
-		}
-	}
+func (m *MetricMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *MetaMutation) RemovedEdges() []string {
-	edges := make([]string, 0, 1)
+func (m *MetricMutation) RemovedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *MetaMutation) RemovedIDs(name string) []ent.Value {
+func (m *MetricMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *MetaMutation) ClearedEdges() []string {
-	edges := make([]string, 0, 1)
-	if m.clearedowner {
-		edges = append(edges, meta.EdgeOwner)
-	}
+func (m *MetricMutation) ClearedEdges() []string {
+	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *MetaMutation) EdgeCleared(name string) bool {
-	switch name {
-	case meta.EdgeOwner:
-		return m.clearedowner
-	}
+func (m *MetricMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *MetaMutation) ClearEdge(name string) error {
-	switch name {
-	case meta.EdgeOwner:
-		m.ClearOwner()
-		return nil
-	}
-	return fmt.Errorf("unknown Meta unique edge %s", name)
+func (m *MetricMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Metric unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *MetaMutation) ResetEdge(name string) error {
-	switch name {
-	case meta.EdgeOwner:
-		m.ResetOwner()
-		return nil
-	}
-	return fmt.Errorf("unknown Meta edge %s", name)
+func (m *MetricMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Metric edge %s", name)
 }
 
-// MetricMutation represents an operation that mutates the Metric nodes in the graph.
-type MetricMutation struct {
+// NotificationMutation represents an operation that mutates the Notification nodes in the graph.
+type NotificationMutation struct {
 	config
-	op             Op
-	typ            string
-	id             *int
-	generated_type *metric.GeneratedType
-	generated_by   *string
-	received_at    *time.Time
-	pushed_at      *time.Time
-	payload        *string
-	clearedFields  map[string]struct{}
-	done           bool
-	oldValue       func(context.Context) (*Metric, error)
-	predicates     []predicate.Metric
+	op              Op
+	typ             string
+	id              *int
+	created_at      *time.Time
+	updated_at      *time.Time
+	plugin          *string
+	message         *string
+	alert_count     *int
+	addalert_count  *int
+	status          *notification.Status
+	attempts        *int
+	addattempts     *int
+	next_attempt_at *time.Time
+	last_error      *string
+	delivered_at    *time.Time
+	clearedFields   map[string]struct{}
+	done            bool
+	oldValue        func(context.Context) (*Notification, error)
+	predicates      []predicate.Notification
 }
 
-var _ ent.Mutation = (*MetricMutation)(nil)
+var _ ent.Mutation = (*NotificationMutation)(nil)
 
-// metricOption allows management of the mutation configuration using functional options.
-type metricOption func(*MetricMutation)
+// notificationOption allows management of the mutation configuration using functional options.
+type notificationOption func(*NotificationMutation)
 
-// newMetricMutation creates new mutation for the Metric entity.
-func newMetricMutation(c config, op Op, opts ...metricOption) *MetricMutation {
-	m := &MetricMutation{
+// newNotificationMutation creates new mutation for the Notification entity.
+func newNotificationMutation(c config, op Op, opts ...notificationOption) *NotificationMutation {
+	m := &NotificationMutation{
 		config:        c,
 		op:            op,
-		typ:           TypeMetric,
+		typ:           TypeNotification,
 		clearedFields: make(map[string]struct{}),
 	}
 	for _, opt := range opts {
@@ -10670,20 +11967,20 @@ func newMetricMutation(c config, op Op, opts ...metricOption) *MetricMutation {
 	return m
 }
 
-// withMetricID sets the ID field of the mutation.
-func withMetricID(id int) metricOption {
-	return func(m *MetricMutation) {
+// withNotificationID sets the ID field of the mutation.
+func withNotificationID(id int) notificationOption {
+	return func(m *NotificationMutation) {
 		var (
 			err   error
 			once  sync.Once
-			value *Metric
+			value *Notification
 		)
-		m.oldValue = func(ctx context.Context) (*Metric, error) {
+		m.oldValue = func(ctx context.Context) (*Notification, error) {
 			once.Do(func() {
 				if m.done {
 					err = errors.New("querying old values post mutation is not allowed")
 				} else {
-					value, err = m.Client().Metric.Get(ctx, id)
+					value, err = m.Client().Notification.Get(ctx, id)
 				}
 			})
 			return value, err
@@ -10692,10 +11989,10 @@ func withMetricID(id int) metricOption {
 	}
 }
 
-// withMetric sets the old Metric of the mutation.
-func withMetric(node *Metric) metricOption {
-	return func(m *MetricMutation) {
-		m.oldValue = func(context.Context) (*Metric, error) {
+// withNotification sets the old Notification of the mutation.
+func withNotification(node *Notification) notificationOption {
+	return func(m *NotificationMutation) {
+		m.oldValue = func(context.Context) (*Notification, error) {
 			return node, nil
 		}
 		m.id = &node.ID
@@ -10704,7 +12001,7 @@ func withMetric(node *Metric) metricOption {
 
 // Client returns a new `ent.Client` from the mutation. If the mutation was
 // executed in a transaction (ent.Tx), a transactional client is returned.
-func (m MetricMutation) Client() *Client {
+func (m NotificationMutation) Client() *Client {
 	client := &Client{config: m.config}
 	client.init()
 	return client
@@ -10712,7 +12009,7 @@ func (m MetricMutation) Client() *Client {
 
 // Tx returns an `ent.Tx` for mutations that were executed in transactions;
 // it returns an error otherwise.
-func (m MetricMutation) Tx() (*Tx, error) {
+func (m NotificationMutation) Tx() (*Tx, error) {
 	if _, ok := m.driver.(*txDriver); !ok {
 		return nil, errors.New("ent: mutation is not running in a transaction")
 	}
@@ -10723,7 +12020,7 @@ func (m MetricMutation) Tx() (*Tx, error) {
 
 // ID returns the ID value in the mutation. Note that the ID is only available
 // if it was provided to the builder or after it was returned from the database.
-func (m *MetricMutation) ID() (id int, exists bool) {
+func (m *NotificationMutation) ID() (id int, exists bool) {
 	if m.id == nil {
 		return
 	}
@@ -10734,7 +12031,7 @@ func (m *MetricMutation) ID() (id int, exists bool) {
 // That means, if the mutation is applied within a transaction with an isolation level such
 // as sql.LevelSerializable, the returned ids match the ids of the rows that will be updated
 // or updated by the mutation.
-func (m *MetricMutation) IDs(ctx context.Context) ([]int, error) {
+func (m *NotificationMutation) IDs(ctx context.Context) ([]int, error) {
 	switch {
 	case m.op.Is(OpUpdateOne | OpDeleteOne):
 		id, exists := m.ID()
@@ -10743,214 +12040,447 @@ func (m *MetricMutation) IDs(ctx context.Context) ([]int, error) {
 		}
 		fallthrough
 	case m.op.Is(OpUpdate | OpDelete):
-		return m.Client().Metric.Query().Where(m.predicates...).IDs(ctx)
+		return m.Client().Notification.Query().Where(m.predicates...).IDs(ctx)
 	default:
 		return nil, fmt.Errorf("IDs is not allowed on %s operations", m.op)
 	}
 }
 
-// SetGeneratedType sets the "generated_type" field.
-func (m *MetricMutation) SetGeneratedType(mt metric.GeneratedType) {
-	m.generated_type = &mt
+// SetCreatedAt sets the "created_at" field.
+func (m *NotificationMutation) SetCreatedAt(t time.Time) {
+	m.created_at = &t
 }
 
-// GeneratedType returns the value of the "generated_type" field in the mutation.
-func (m *MetricMutation) GeneratedType() (r metric.GeneratedType, exists bool) {
-	v := m.generated_type
+// CreatedAt returns the value of the "created_at" field in the mutation.
+func (m *NotificationMutation) CreatedAt() (r time.Time, exists bool) {
+	v := m.created_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldGeneratedType returns the old "generated_type" field's value of the Metric entity.
-// If the Metric object wasn't provided to the builder, the object is fetched from the database.
+// OldCreatedAt returns the old "created_at" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetricMutation) OldGeneratedType(ctx context.Context) (v metric.GeneratedType, err error) {
+func (m *NotificationMutation) OldCreatedAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldGeneratedType is only allowed on UpdateOne operations")
+		return v, errors.New("OldCreatedAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldGeneratedType requires an ID field in the mutation")
+		return v, errors.New("OldCreatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldCreatedAt: %w", err)
+	}
+	return oldValue.CreatedAt, nil
+}
+
+// ResetCreatedAt resets all changes to the "created_at" field.
+func (m *NotificationMutation) ResetCreatedAt() {
+	m.created_at = nil
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (m *NotificationMutation) SetUpdatedAt(t time.Time) {
+	m.updated_at = &t
+}
+
+// UpdatedAt returns the value of the "updated_at" field in the mutation.
+func (m *NotificationMutation) UpdatedAt() (r time.Time, exists bool) {
+	v := m.updated_at
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldUpdatedAt returns the old "updated_at" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NotificationMutation) OldUpdatedAt(ctx context.Context) (v time.Time, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldUpdatedAt is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldUpdatedAt requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldUpdatedAt: %w", err)
+	}
+	return oldValue.UpdatedAt, nil
+}
+
+// ResetUpdatedAt resets all changes to the "updated_at" field.
+func (m *NotificationMutation) ResetUpdatedAt() {
+	m.updated_at = nil
+}
+
+// SetPlugin sets the "plugin" field.
+func (m *NotificationMutation) SetPlugin(s string) {
+	m.plugin = &s
+}
+
+// Plugin returns the value of the "plugin" field in the mutation.
+func (m *NotificationMutation) Plugin() (r string, exists bool) {
+	v := m.plugin
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldPlugin returns the old "plugin" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NotificationMutation) OldPlugin(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldPlugin is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldPlugin requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldPlugin: %w", err)
+	}
+	return oldValue.Plugin, nil
+}
+
+// ResetPlugin resets all changes to the "plugin" field.
+func (m *NotificationMutation) ResetPlugin() {
+	m.plugin = nil
+}
+
+// SetMessage sets the "message" field.
+func (m *NotificationMutation) SetMessage(s string) {
+	m.message = &s
+}
+
+// Message returns the value of the "message" field in the mutation.
+func (m *NotificationMutation) Message() (r string, exists bool) {
+	v := m.message
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldMessage returns the old "message" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NotificationMutation) OldMessage(ctx context.Context) (v string, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldMessage is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldMessage requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldMessage: %w", err)
+	}
+	return oldValue.Message, nil
+}
+
+// ResetMessage resets all changes to the "message" field.
+func (m *NotificationMutation) ResetMessage() {
+	m.message = nil
+}
+
+// SetAlertCount sets the "alert_count" field.
+func (m *NotificationMutation) SetAlertCount(i int) {
+	m.alert_count = &i
+	m.addalert_count = nil
+}
+
+// AlertCount returns the value of the "alert_count" field in the mutation.
+func (m *NotificationMutation) AlertCount() (r int, exists bool) {
+	v := m.alert_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldAlertCount returns the old "alert_count" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NotificationMutation) OldAlertCount(ctx context.Context) (v int, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldAlertCount is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldAlertCount requires an ID field in the mutation")
+	}
+	oldValue, err := m.oldValue(ctx)
+	if err != nil {
+		return v, fmt.Errorf("querying old value for OldAlertCount: %w", err)
+	}
+	return oldValue.AlertCount, nil
+}
+
+// AddAlertCount adds i to the "alert_count" field.
+func (m *NotificationMutation) AddAlertCount(i int) {
+	if m.addalert_count != nil {
+		*m.addalert_count += i
+	} else {
+		m.addalert_count = &i
+	}
+}
+
+// AddedAlertCount returns the value that was added to the "alert_count" field in this mutation.
+func (m *NotificationMutation) AddedAlertCount() (r int, exists bool) {
+	v := m.addalert_count
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// ResetAlertCount resets all changes to the "alert_count" field.
+func (m *NotificationMutation) ResetAlertCount() {
+	m.alert_count = nil
+	m.addalert_count = nil
+}
+
+// SetStatus sets the "status" field.
+func (m *NotificationMutation) SetStatus(n notification.Status) {
+	m.status = &n
+}
+
+// Status returns the value of the "status" field in the mutation.
+func (m *NotificationMutation) Status() (r notification.Status, exists bool) {
+	v := m.status
+	if v == nil {
+		return
+	}
+	return *v, true
+}
+
+// OldStatus returns the old "status" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
+// An error is returned if the mutation operation is not UpdateOne, or the database query fails.
+func (m *NotificationMutation) OldStatus(ctx context.Context) (v notification.Status, err error) {
+	if !m.op.Is(OpUpdateOne) {
+		return v, errors.New("OldStatus is only allowed on UpdateOne operations")
+	}
+	if m.id == nil || m.oldValue == nil {
+		return v, errors.New("OldStatus requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldGeneratedType: %w", err)
+		return v, fmt.Errorf("querying old value for OldStatus: %w", err)
 	}
-	return oldValue.GeneratedType, nil
+	return oldValue.Status, nil
 }
 
-// ResetGeneratedType resets all changes to the "generated_type" field.
-func (m *MetricMutation) ResetGeneratedType() {
-	m.generated_type = nil
+// ResetStatus resets all changes to the "status" field.
+func (m *NotificationMutation) ResetStatus() {
+	m.status = nil
 }
 
-// SetGeneratedBy sets the "generated_by" field.
-func (m *MetricMutation) SetGeneratedBy(s string) {
-	m.generated_by = &s
+// SetAttempts sets the "attempts" field.
+func (m *NotificationMutation) SetAttempts(i int) {
+	m.attempts = &i
+	m.addattempts = nil
 }
 
-// GeneratedBy returns the value of the "generated_by" field in the mutation.
-func (m *MetricMutation) GeneratedBy() (r string, exists bool) {
-	v := m.generated_by
+// Attempts returns the value of the "attempts" field in the mutation.
+func (m *NotificationMutation) Attempts() (r int, exists bool) {
+	v := m.attempts
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldGeneratedBy returns the old "generated_by" field's value of the Metric entity.
-// If the Metric object wasn't provided to the builder, the object is fetched from the database.
+// OldAttempts returns the old "attempts" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetricMutation) OldGeneratedBy(ctx context.Context) (v string, err error) {
+func (m *NotificationMutation) OldAttempts(ctx context.Context) (v int, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldGeneratedBy is only allowed on UpdateOne operations")
+		return v, errors.New("OldAttempts is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldGeneratedBy requires an ID field in the mutation")
+		return v, errors.New("OldAttempts requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldGeneratedBy: %w", err)
+		return v, fmt.Errorf("querying old value for OldAttempts: %w", err)
 	}
-	return oldValue.GeneratedBy, nil
+	return oldValue.Attempts, nil
 }
 
-// ResetGeneratedBy resets all changes to the "generated_by" field.
-func (m *MetricMutation) ResetGeneratedBy() {
-	m.generated_by = nil
+// AddAttempts adds i to the "attempts" field.
+func (m *NotificationMutation) AddAttempts(i int) {
+	if m.addattempts != nil {
+		*m.addattempts += i
+	} else {
+		m.addattempts = &i
+	}
 }
 
-// SetReceivedAt sets the "received_at" field.
-func (m *MetricMutation) SetReceivedAt(t time.Time) {
-	m.received_at = &t
+// AddedAttempts returns the value that was added to the "attempts" field in this mutation.
+func (m *NotificationMutation) AddedAttempts() (r int, exists bool) {
+	v := m.addattempts
+	if v == nil {
+		return
+	}
+	return *v, true
 }
 
-// ReceivedAt returns the value of the "received_at" field in the mutation.
-func (m *MetricMutation) ReceivedAt() (r time.Time, exists bool) {
-	v := m.received_at
+// ResetAttempts resets all changes to the "attempts" field.
+func (m *NotificationMutation) ResetAttempts() {
+	m.attempts = nil
+	m.addattempts = nil
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (m *NotificationMutation) SetNextAttemptAt(t time.Time) {
+	m.next_attempt_at = &t
+}
+
+// NextAttemptAt returns the value of the "next_attempt_at" field in the mutation.
+func (m *NotificationMutation) NextAttemptAt() (r time.Time, exists bool) {
+	v := m.next_attempt_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldReceivedAt returns the old "received_at" field's value of the Metric entity.
-// If the Metric object wasn't provided to the builder, the object is fetched from the database.
+// OldNextAttemptAt returns the old "next_attempt_at" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetricMutation) OldReceivedAt(ctx context.Context) (v time.Time, err error) {
+func (m *NotificationMutation) OldNextAttemptAt(ctx context.Context) (v time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldReceivedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldNextAttemptAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldReceivedAt requires an ID field in the mutation")
+		return v, errors.New("OldNextAttemptAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldReceivedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldNextAttemptAt: %w", err)
 	}
-	return oldValue.ReceivedAt, nil
+	return oldValue.NextAttemptAt, nil
 }
 
-// ResetReceivedAt resets all changes to the "received_at" field.
-func (m *MetricMutation) ResetReceivedAt() {
-	m.received_at = nil
+// ResetNextAttemptAt resets all changes to the "next_attempt_at" field.
+func (m *NotificationMutation) ResetNextAttemptAt() {
+	m.next_attempt_at = nil
 }
 
-// SetPushedAt sets the "pushed_at" field.
-func (m *MetricMutation) SetPushedAt(t time.Time) {
-	m.pushed_at = &t
+// SetLastError sets the "last_error" field.
+func (m *NotificationMutation) SetLastError(s string) {
+	m.last_error = &s
 }
 
-// PushedAt returns the value of the "pushed_at" field in the mutation.
-func (m *MetricMutation) PushedAt() (r time.Time, exists bool) {
-	v := m.pushed_at
+// LastError returns the value of the "last_error" field in the mutation.
+func (m *NotificationMutation) LastError() (r string, exists bool) {
+	v := m.last_error
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPushedAt returns the old "pushed_at" field's value of the Metric entity.
-// If the Metric object wasn't provided to the builder, the object is fetched from the database.
+// OldLastError returns the old "last_error" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetricMutation) OldPushedAt(ctx context.Context) (v *time.Time, err error) {
+func (m *NotificationMutation) OldLastError(ctx context.Context) (v string, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPushedAt is only allowed on UpdateOne operations")
+		return v, errors.New("OldLastError is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPushedAt requires an ID field in the mutation")
+		return v, errors.New("OldLastError requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPushedAt: %w", err)
+		return v, fmt.Errorf("querying old value for OldLastError: %w", err)
 	}
-	return oldValue.PushedAt, nil
+	return oldValue.LastError, nil
 }
 
-// ClearPushedAt clears the value of the "pushed_at" field.
-func (m *MetricMutation) ClearPushedAt() {
-	m.pushed_at = nil
-	m.clearedFields[metric.FieldPushedAt] = struct{}{}
+// ClearLastError clears the value of the "last_error" field.
+func (m *NotificationMutation) ClearLastError() {
+	m.last_error = nil
+	m.clearedFields[notification.FieldLastError] = struct{}{}
 }
 
-// PushedAtCleared returns if the "pushed_at" field was cleared in this mutation.
-func (m *MetricMutation) PushedAtCleared() bool {
-	_, ok := m.clearedFields[metric.FieldPushedAt]
+// LastErrorCleared returns if the "last_error" field was cleared in this mutation.
+func (m *NotificationMutation) LastErrorCleared() bool {
+	_, ok := m.clearedFields[notification.FieldLastError]
 	return ok
 }
 
-// ResetPushedAt resets all changes to the "pushed_at" field.
-func (m *MetricMutation) ResetPushedAt() {
-	m.pushed_at = nil
-	delete(m.clearedFields, metric.FieldPushedAt)
+// ResetLastError resets all changes to the "last_error" field.
+func (m *NotificationMutation) ResetLastError() {
+	m.last_error = nil
+	delete(m.clearedFields, notification.FieldLastError)
 }
 
-// SetPayload sets the "payload" field.
-func (m *MetricMutation) SetPayload(s string) {
-	m.payload = &s
+// SetDeliveredAt sets the "delivered_at" field.
+func (m *NotificationMutation) SetDeliveredAt(t time.Time) {
+	m.delivered_at = &t
 }
 
-// Payload returns the value of the "payload" field in the mutation.
-func (m *MetricMutation) Payload() (r string, exists bool) {
-	v := m.payload
+// DeliveredAt returns the value of the "delivered_at" field in the mutation.
+func (m *NotificationMutation) DeliveredAt() (r time.Time, exists bool) {
+	v := m.delivered_at
 	if v == nil {
 		return
 	}
 	return *v, true
 }
 
-// OldPayload returns the old "payload" field's value of the Metric entity.
-// If the Metric object wasn't provided to the builder, the object is fetched from the database.
+// OldDeliveredAt returns the old "delivered_at" field's value of the Notification entity.
+// If the Notification object wasn't provided to the builder, the object is fetched from the database.
 // An error is returned if the mutation operation is not UpdateOne, or the database query fails.
-func (m *MetricMutation) OldPayload(ctx context.Context) (v string, err error) {
+func (m *NotificationMutation) OldDeliveredAt(ctx context.Context) (v *time.Time, err error) {
 	if !m.op.Is(OpUpdateOne) {
-		return v, errors.New("OldPayload is only allowed on UpdateOne operations")
+		return v, errors.New("OldDeliveredAt is only allowed on UpdateOne operations")
 	}
 	if m.id == nil || m.oldValue == nil {
-		return v, errors.New("OldPayload requires an ID field in the mutation")
+		return v, errors.New("OldDeliveredAt requires an ID field in the mutation")
 	}
 	oldValue, err := m.oldValue(ctx)
 	if err != nil {
-		return v, fmt.Errorf("querying old value for OldPayload: %w", err)
+		return v, fmt.Errorf("querying old value for OldDeliveredAt: %w", err)
 	}
-	return oldValue.Payload, nil
+	return oldValue.DeliveredAt, nil
 }
 
-// ResetPayload resets all changes to the "payload" field.
-func (m *MetricMutation) ResetPayload() {
-	m.payload = nil
+// ClearDeliveredAt clears the value of the "delivered_at" field.
+func (m *NotificationMutation) ClearDeliveredAt() {
+	m.delivered_at = nil
+	m.clearedFields[notification.FieldDeliveredAt] = struct{}{}
 }
 
-// Where appends a list predicates to the MetricMutation builder.
-func (m *MetricMutation) Where(ps ...predicate.Metric) {
+// DeliveredAtCleared returns if the "delivered_at" field was cleared in this mutation.
+func (m *NotificationMutation) DeliveredAtCleared() bool {
+	_, ok := m.clearedFields[notification.FieldDeliveredAt]
+	return ok
+}
+
+// ResetDeliveredAt resets all changes to the "delivered_at" field.
+func (m *NotificationMutation) ResetDeliveredAt() {
+	m.delivered_at = nil
+	delete(m.clearedFields, notification.FieldDeliveredAt)
+}
+
+// Where appends a list predicates to the NotificationMutation builder.
+func (m *NotificationMutation) Where(ps ...predicate.Notification) {
 	m.predicates = append(m.predicates, ps...)
 }
 
-// WhereP appends storage-level predicates to the MetricMutation builder. Using this method,
+// WhereP appends storage-level predicates to the NotificationMutation builder. Using this method,
 // users can use type-assertion to append predicates that do not depend on any generated package.
-func (m *MetricMutation) WhereP(ps ...func(*sql.Selector)) {
-	p := make([]predicate.Metric, len(ps))
+func (m *NotificationMutation) WhereP(ps ...func(*sql.Selector)) {
+	p := make([]predicate.Notification, len(ps))
 	for i := range ps {
 		p[i] = ps[i]
 	}
@@ -10958,39 +12488,54 @@ func (m *MetricMutation) WhereP(ps ...func(*sql.Selector)) {
 }
 
 // Op returns the operation name.
-func (m *MetricMutation) Op() Op {
+func (m *NotificationMutation) Op() Op {
 	return m.op
 }
 
 // SetOp allows setting the mutation operation.
-func (m *MetricMutation) SetOp(op Op) {
+func (m *NotificationMutation) SetOp(op Op) {
 	m.op = op
 }
 
-// Type returns the node type of this mutation (Metric).
-func (m *MetricMutation) Type() string {
+// Type returns the node type of this mutation (Notification).
+func (m *NotificationMutation) Type() string {
 	return m.typ
 }
 
 // Fields returns all fields that were changed during this mutation. Note that in
 // order to get all numeric fields that were incremented/decremented, call
 // AddedFields().
-func (m *MetricMutation) Fields() []string {
-	fields := make([]string, 0, 5)
-	if m.generated_type != nil {
-		fields = append(fields, metric.FieldGeneratedType)
+func (m *NotificationMutation) Fields() []string {
+	fields := make([]string, 0, 10)
+	if m.created_at != nil {
+		fields = append(fields, notification.FieldCreatedAt)
 	}
-	if m.generated_by != nil {
-		fields = append(fields, metric.FieldGeneratedBy)
+	if m.updated_at != nil {
+		fields = append(fields, notification.FieldUpdatedAt)
 	}
-	if m.received_at != nil {
-		fields = append(fields, metric.FieldReceivedAt)
+	if m.plugin != nil {
+		fields = append(fields, notification.FieldPlugin)
 	}
-	if m.pushed_at != nil {
-		fields = append(fields, metric.FieldPushedAt)
+	if m.message != nil {
+		fields = append(fields, notification.FieldMessage)
 	}
-	if m.payload != nil {
-		fields = append(fields, metric.FieldPayload)
+	if m.alert_count != nil {
+		fields = append(fields, notification.FieldAlertCount)
+	}
+	if m.status != nil {
+		fields = append(fields, notification.FieldStatus)
+	}
+	if m.attempts != nil {
+		fields = append(fields, notification.FieldAttempts)
+	}
+	if m.next_attempt_at != nil {
+		fields = append(fields, notification.FieldNextAttemptAt)
+	}
+	if m.last_error != nil {
+		fields = append(fields, notification.FieldLastError)
+	}
+	if m.delivered_at != nil {
+		fields = append(fields, notification.FieldDeliveredAt)
 	}
 	return fields
 }
@@ -10998,18 +12543,28 @@ func (m *MetricMutation) Fields() []string {
 // Field returns the value of a field with the given name. The second boolean
 // return value indicates that this field was not set, or was not defined in the
 // schema.
-func (m *MetricMutation) Field(name string) (ent.Value, bool) {
+func (m *NotificationMutation) Field(name string) (ent.Value, bool) {
 	switch name {
-	case metric.FieldGeneratedType:
-		return m.GeneratedType()
-	case metric.FieldGeneratedBy:
-		return m.GeneratedBy()
-	case metric.FieldReceivedAt:
-		return m.ReceivedAt()
-	case metric.FieldPushedAt:
-		return m.PushedAt()
-	case metric.FieldPayload:
-		return m.Payload()
+	case notification.FieldCreatedAt:
+		return m.CreatedAt()
+	case notification.FieldUpdatedAt:
+		return m.UpdatedAt()
+	case notification.FieldPlugin:
+		return m.Plugin()
+	case notification.FieldMessage:
+		return m.Message()
+	case notification.FieldAlertCount:
+		return m.AlertCount()
+	case notification.FieldStatus:
+		return m.Status()
+	case notification.FieldAttempts:
+		return m.Attempts()
+	case notification.FieldNextAttemptAt:
+		return m.NextAttemptAt()
+	case notification.FieldLastError:
+		return m.LastError()
+	case notification.FieldDeliveredAt:
+		return m.DeliveredAt()
 	}
 	return nil, false
 }
@@ -11017,183 +12572,276 @@ func (m *MetricMutation) Field(name string) (ent.Value, bool) {
 // OldField returns the old value of the field from the database. An error is
 // returned if the mutation operation is not UpdateOne, or the query to the
 // database failed.
-func (m *MetricMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
+func (m *NotificationMutation) OldField(ctx context.Context, name string) (ent.Value, error) {
 	switch name {
-	case metric.FieldGeneratedType:
-		return m.OldGeneratedType(ctx)
-	case metric.FieldGeneratedBy:
-		return m.OldGeneratedBy(ctx)
-	case metric.FieldReceivedAt:
-		return m.OldReceivedAt(ctx)
-	case metric.FieldPushedAt:
-		return m.OldPushedAt(ctx)
-	case metric.FieldPayload:
-		return m.OldPayload(ctx)
-	}
-	return nil, fmt.Errorf("unknown Metric field %s", name)
+	case notification.FieldCreatedAt:
+		return m.OldCreatedAt(ctx)
+	case notification.FieldUpdatedAt:
+		return m.OldUpdatedAt(ctx)
+	case notification.FieldPlugin:
+		return m.OldPlugin(ctx)
+	case notification.FieldMessage:
+		return m.OldMessage(ctx)
+	case notification.FieldAlertCount:
+		return m.OldAlertCount(ctx)
+	case notification.FieldStatus:
+		return m.OldStatus(ctx)
+	case notification.FieldAttempts:
+		return m.OldAttempts(ctx)
+	case notification.FieldNextAttemptAt:
+		return m.OldNextAttemptAt(ctx)
+	case notification.FieldLastError:
+		return m.OldLastError(ctx)
+	case notification.FieldDeliveredAt:
+		return m.OldDeliveredAt(ctx)
+	}
+	return nil, fmt.Errorf("unknown Notification field %s", name)
 }
 
 // SetField sets the value of a field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MetricMutation) SetField(name string, value ent.Value) error {
+func (m *NotificationMutation) SetField(name string, value ent.Value) error {
 	switch name {
-	case metric.FieldGeneratedType:
-		v, ok := value.(metric.GeneratedType)
+	case notification.FieldCreatedAt:
+		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetGeneratedType(v)
+		m.SetCreatedAt(v)
 		return nil
-	case metric.FieldGeneratedBy:
+	case notification.FieldUpdatedAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetUpdatedAt(v)
+		return nil
+	case notification.FieldPlugin:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetGeneratedBy(v)
+		m.SetPlugin(v)
 		return nil
-	case metric.FieldReceivedAt:
-		v, ok := value.(time.Time)
+	case notification.FieldMessage:
+		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetReceivedAt(v)
+		m.SetMessage(v)
 		return nil
-	case metric.FieldPushedAt:
+	case notification.FieldAlertCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAlertCount(v)
+		return nil
+	case notification.FieldStatus:
+		v, ok := value.(notification.Status)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetStatus(v)
+		return nil
+	case notification.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetAttempts(v)
+		return nil
+	case notification.FieldNextAttemptAt:
 		v, ok := value.(time.Time)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPushedAt(v)
+		m.SetNextAttemptAt(v)
 		return nil
-	case metric.FieldPayload:
+	case notification.FieldLastError:
 		v, ok := value.(string)
 		if !ok {
 			return fmt.Errorf("unexpected type %T for field %s", value, name)
 		}
-		m.SetPayload(v)
+		m.SetLastError(v)
+		return nil
+	case notification.FieldDeliveredAt:
+		v, ok := value.(time.Time)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.SetDeliveredAt(v)
 		return nil
 	}
-	return fmt.Errorf("unknown Metric field %s", name)
+	return fmt.Errorf("unknown Notification field %s", name)
 }
 
 // AddedFields returns all numeric fields that were incremented/decremented during
 // this mutation.
-func (m *MetricMutation) AddedFields() []string {
-	return nil
+func (m *NotificationMutation) AddedFields() []string {
+	var fields []string
+	if m.addalert_count != nil {
+		fields = append(fields, notification.FieldAlertCount)
+	}
+	if m.addattempts != nil {
+		fields = append(fields, notification.FieldAttempts)
+	}
+	return fields
 }
 
 // AddedField returns the numeric value that was incremented/decremented on a field
 // with the given name. The second boolean return value indicates that this field
 // was not set, or was not defined in the schema.
-func (m *MetricMutation) AddedField(name string) (ent.Value, bool) {
+func (m *NotificationMutation) AddedField(name string) (ent.Value, bool) {
+	switch name {
+	case notification.FieldAlertCount:
+		return m.AddedAlertCount()
+	case notification.FieldAttempts:
+		return m.AddedAttempts()
+	}
 	return nil, false
 }
 
 // AddField adds the value to the field with the given name. It returns an error if
 // the field is not defined in the schema, or if the type mismatched the field
 // type.
-func (m *MetricMutation) AddField(name string, value ent.Value) error {
+func (m *NotificationMutation) AddField(name string, value ent.Value) error {
 	switch name {
+	case notification.FieldAlertCount:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAlertCount(v)
+		return nil
+	case notification.FieldAttempts:
+		v, ok := value.(int)
+		if !ok {
+			return fmt.Errorf("unexpected type %T for field %s", value, name)
+		}
+		m.AddAttempts(v)
+		return nil
 	}
-	return fmt.Errorf("unknown Metric numeric field %s", name)
+	return fmt.Errorf("unknown Notification numeric field %s", name)
 }
 
 // ClearedFields returns all nullable fields that were cleared during this
 // mutation.
-func (m *MetricMutation) ClearedFields() []string {
+func (m *NotificationMutation) ClearedFields() []string {
 	var fields []string
-	if m.FieldCleared(metric.FieldPushedAt) {
-		fields = append(fields, metric.FieldPushedAt)
+	if m.FieldCleared(notification.FieldLastError) {
+		fields = append(fields, notification.FieldLastError)
+	}
+	if m.FieldCleared(notification.FieldDeliveredAt) {
+		fields = append(fields, notification.FieldDeliveredAt)
 	}
 	return fields
 }
 
 // FieldCleared returns a boolean indicating if a field with the given name was
 // cleared in this mutation.
-func (m *MetricMutation) FieldCleared(name string) bool {
+func (m *NotificationMutation) FieldCleared(name string) bool {
 	_, ok := m.clearedFields[name]
 	return ok
 }
 
 // ClearField clears the value of the field with the given name. It returns an
 // error if the field is not defined in the schema.
-func (m *MetricMutation) ClearField(name string) error {
+func (m *NotificationMutation) ClearField(name string) error {
 	switch name {
-	case metric.FieldPushedAt:
-		m.ClearPushedAt()
+	case notification.FieldLastError:
+		m.ClearLastError()
+		return nil
+	case notification.FieldDeliveredAt:
+		m.ClearDeliveredAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Metric nullable field %s", name)
+	return fmt.Errorf("unknown Notification nullable field %s", name)
 }
 
 // ResetField resets all changes in the mutation for the field with the given name.
 // It returns an error if the field is not defined in the schema.
-func (m *MetricMutation) ResetField(name string) error {
+func (m *NotificationMutation) ResetField(name string) error {
 	switch name {
-	case metric.FieldGeneratedType:
-		m.ResetGeneratedType()
+	case notification.FieldCreatedAt:
+		m.ResetCreatedAt()
 		return nil
-	case metric.FieldGeneratedBy:
-		m.ResetGeneratedBy()
+	case notification.FieldUpdatedAt:
+		m.ResetUpdatedAt()
 		return nil
-	case metric.FieldReceivedAt:
-		m.ResetReceivedAt()
+	case notification.FieldPlugin:
+		m.ResetPlugin()
 		return nil
-	case metric.FieldPushedAt:
-		m.ResetPushedAt()
+	case notification.FieldMessage:
+		m.ResetMessage()
 		return nil
-	case metric.FieldPayload:
-		m.ResetPayload()
+	case notification.FieldAlertCount:
+		m.ResetAlertCount()
+		return nil
+	case notification.FieldStatus:
+		m.ResetStatus()
+		return nil
+	case notification.FieldAttempts:
+		m.ResetAttempts()
+		return nil
+	case notification.FieldNextAttemptAt:
+		m.ResetNextAttemptAt()
+		return nil
+	case notification.FieldLastError:
+		m.ResetLastError()
+		return nil
+	case notification.FieldDeliveredAt:
+		m.ResetDeliveredAt()
 		return nil
 	}
-	return fmt.Errorf("unknown Metric field %s", name)
+	return fmt.Errorf("unknown Notification field %s", name)
 }
 
 // AddedEdges returns all edge names that were set/added in this mutation.
-func (m *MetricMutation) AddedEdges() []string {
+func (m *NotificationMutation) AddedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // AddedIDs returns all IDs (to other nodes) that were added for the given edge
 // name in this mutation.
-func (m *MetricMutation) AddedIDs(name string) []ent.Value {
+func (m *NotificationMutation) AddedIDs(name string) []ent.Value {
 	return nil
 }
 
 // RemovedEdges returns all edge names that were removed in this mutation.
-func (m *MetricMutation) RemovedEdges() []string {
+func (m *NotificationMutation) RemovedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // RemovedIDs returns all IDs (to other nodes) that were removed for the edge with
 // the given name in this mutation.
-func (m *MetricMutation) RemovedIDs(name string) []ent.Value {
+func (m *NotificationMutation) RemovedIDs(name string) []ent.Value {
 	return nil
 }
 
 // ClearedEdges returns all edge names that were cleared in this mutation.
-func (m *MetricMutation) ClearedEdges() []string {
+func (m *NotificationMutation) ClearedEdges() []string {
 	edges := make([]string, 0, 0)
 	return edges
 }
 
 // EdgeCleared returns a boolean which indicates if the edge with the given name
 // was cleared in this mutation.
-func (m *MetricMutation) EdgeCleared(name string) bool {
+func (m *NotificationMutation) EdgeCleared(name string) bool {
 	return false
 }
 
 // ClearEdge clears the value of the edge with the given name. It returns an error
 // if that edge is not defined in the schema.
-func (m *MetricMutation) ClearEdge(name string) error {
-	return fmt.Errorf("unknown Metric unique edge %s", name)
+func (m *NotificationMutation) ClearEdge(name string) error {
+	return fmt.Errorf("unknown Notification unique edge %s", name)
 }
 
 // ResetEdge resets all changes to the edge with the given name in this mutation.
 // It returns an error if the edge is not defined in the schema.
-func (m *MetricMutation) ResetEdge(name string) error {
-	return fmt.Errorf("unknown Metric edge %s", name)
+func (m *NotificationMutation) ResetEdge(name string) error {
+	return fmt.Errorf("unknown Notification edge %s", name)
 }
@@ -0,0 +1,394 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
+)
+
+// NotificationCreate is the builder for creating a Notification entity.
+type NotificationCreate struct {
+	config
+	mutation *NotificationMutation
+	hooks    []Hook
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (nc *NotificationCreate) SetCreatedAt(t time.Time) *NotificationCreate {
+	nc.mutation.SetCreatedAt(t)
+	return nc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableCreatedAt(t *time.Time) *NotificationCreate {
+	if t != nil {
+		nc.SetCreatedAt(*t)
+	}
+	return nc
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (nc *NotificationCreate) SetUpdatedAt(t time.Time) *NotificationCreate {
+	nc.mutation.SetUpdatedAt(t)
+	return nc
+}
+
+// SetNillableUpdatedAt sets the "updated_at" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableUpdatedAt(t *time.Time) *NotificationCreate {
+	if t != nil {
+		nc.SetUpdatedAt(*t)
+	}
+	return nc
+}
+
+// SetPlugin sets the "plugin" field.
+func (nc *NotificationCreate) SetPlugin(s string) *NotificationCreate {
+	nc.mutation.SetPlugin(s)
+	return nc
+}
+
+// SetMessage sets the "message" field.
+func (nc *NotificationCreate) SetMessage(s string) *NotificationCreate {
+	nc.mutation.SetMessage(s)
+	return nc
+}
+
+// SetAlertCount sets the "alert_count" field.
+func (nc *NotificationCreate) SetAlertCount(i int) *NotificationCreate {
+	nc.mutation.SetAlertCount(i)
+	return nc
+}
+
+// SetNillableAlertCount sets the "alert_count" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableAlertCount(i *int) *NotificationCreate {
+	if i != nil {
+		nc.SetAlertCount(*i)
+	}
+	return nc
+}
+
+// SetStatus sets the "status" field.
+func (nc *NotificationCreate) SetStatus(n notification.Status) *NotificationCreate {
+	nc.mutation.SetStatus(n)
+	return nc
+}
+
+// SetNillableStatus sets the "status" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableStatus(n *notification.Status) *NotificationCreate {
+	if n != nil {
+		nc.SetStatus(*n)
+	}
+	return nc
+}
+
+// SetAttempts sets the "attempts" field.
+func (nc *NotificationCreate) SetAttempts(i int) *NotificationCreate {
+	nc.mutation.SetAttempts(i)
+	return nc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableAttempts(i *int) *NotificationCreate {
+	if i != nil {
+		nc.SetAttempts(*i)
+	}
+	return nc
+}
+
+// SetNextAttemptAt sets the "next_attempt_at" field.
+func (nc *NotificationCreate) SetNextAttemptAt(t time.Time) *NotificationCreate {
+	nc.mutation.SetNextAttemptAt(t)
+	return nc
+}
+
+// SetNillableNextAttemptAt sets the "next_attempt_at" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableNextAttemptAt(t *time.Time) *NotificationCreate {
+	if t != nil {
+		nc.SetNextAttemptAt(*t)
+	}
+	return nc
+}
+
+// SetLastError sets the "last_error" field.
+func (nc *NotificationCreate) SetLastError(s string) *NotificationCreate {
+	nc.mutation.SetLastError(s)
+	return nc
+}
+
+// SetNillableLastError sets the "last_error" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableLastError(s *string) *NotificationCreate {
+	if s != nil {
+		nc.SetLastError(*s)
+	}
+	return nc
+}
+
+// SetDeliveredAt sets the "delivered_at" field.
+func (nc *NotificationCreate) SetDeliveredAt(t time.Time) *NotificationCreate {
+	nc.mutation.SetDeliveredAt(t)
+	return nc
+}
+
+// SetNillableDeliveredAt sets the "delivered_at" field if the given value is not nil.
+func (nc *NotificationCreate) SetNillableDeliveredAt(t *time.Time) *NotificationCreate {
+	if t != nil {
+		nc.SetDeliveredAt(*t)
+	}
+	return nc
+}
+
+// Mutation returns the NotificationMutation object of the builder.
+func (nc *NotificationCreate) Mutation() *NotificationMutation {
+	return nc.mutation
+}
+
+// Save creates the Notification in the database.
+func (nc *NotificationCreate) Save(ctx context.Context) (*Notification, error) {
+	nc.defaults()
+	return withHooks(ctx, nc.sqlSave, nc.mutation, nc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (nc *NotificationCreate) SaveX(ctx context.Context) *Notification {
+	v, err := nc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (nc *NotificationCreate) Exec(ctx context.Context) error {
+	_, err := nc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (nc *NotificationCreate) ExecX(ctx context.Context) {
+	if err := nc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (nc *NotificationCreate) defaults() {
+	if _, ok := nc.mutation.CreatedAt(); !ok {
+		v := notification.DefaultCreatedAt()
+		nc.mutation.SetCreatedAt(v)
+	}
+	if _, ok := nc.mutation.UpdatedAt(); !ok {
+		v := notification.DefaultUpdatedAt()
+		nc.mutation.SetUpdatedAt(v)
+	}
+	if _, ok := nc.mutation.AlertCount(); !ok {
+		v := notification.DefaultAlertCount
+		nc.mutation.SetAlertCount(v)
+	}
+	if _, ok := nc.mutation.Status(); !ok {
+		v := notification.DefaultStatus
+		nc.mutation.SetStatus(v)
+	}
+	if _, ok := nc.mutation.Attempts(); !ok {
+		v := notification.DefaultAttempts
+		nc.mutation.SetAttempts(v)
+	}
+	if _, ok := nc.mutation.NextAttemptAt(); !ok {
+		v := notification.DefaultNextAttemptAt()
+		nc.mutation.SetNextAttemptAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (nc *NotificationCreate) check() error {
+	if _, ok := nc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Notification.created_at"`)}
+	}
+	if _, ok := nc.mutation.UpdatedAt(); !ok {
+		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Notification.updated_at"`)}
+	}
+	if _, ok := nc.mutation.Plugin(); !ok {
+		return &ValidationError{Name: "plugin", err: errors.New(`ent: missing required field "Notification.plugin"`)}
+	}
+	if _, ok := nc.mutation.Message(); !ok {
+		return &ValidationError{Name: "message", err: errors.New(`ent: missing required field "Notification.message"`)}
+	}
+	if _, ok := nc.mutation.AlertCount(); !ok {
+		return &ValidationError{Name: "alert_count", err: errors.New(`ent: missing required field "Notification.alert_count"`)}
+	}
+	if _, ok := nc.mutation.Status(); !ok {
+		return &ValidationError{Name: "status", err: errors.New(`ent: missing required field "Notification.status"`)}
+	}
+	if v, ok := nc.mutation.Status(); ok {
+		if err := notification.StatusValidator(v); err != nil {
+			return &ValidationError{Name: "status", err: fmt.Errorf(`ent: validator failed for field "Notification.status": %w`, err)}
+		}
+	}
+	if _, ok := nc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "Notification.attempts"`)}
+	}
+	if _, ok := nc.mutation.NextAttemptAt(); !ok {
+		return &ValidationError{Name: "next_attempt_at", err: errors.New(`ent: missing required field "Notification.next_attempt_at"`)}
+	}
+	return nil
+}
+
+func (nc *NotificationCreate) sqlSave(ctx context.Context) (*Notification, error) {
+	if err := nc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := nc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, nc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	nc.mutation.id = &_node.ID
+	nc.mutation.done = true
+	return _node, nil
+}
+
+func (nc *NotificationCreate) createSpec() (*Notification, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Notification{config: nc.config}
+		_spec = sqlgraph.NewCreateSpec(notification.Table, sqlgraph.NewFieldSpec(notification.FieldID, field.TypeInt))
+	)
+	if value, ok := nc.mutation.CreatedAt(); ok {
+		_spec.SetField(notification.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if value, ok := nc.mutation.UpdatedAt(); ok {
+		_spec.SetField(notification.FieldUpdatedAt, field.TypeTime, value)
+		_node.UpdatedAt = value
+	}
+	if value, ok := nc.mutation.Plugin(); ok {
+		_spec.SetField(notification.FieldPlugin, field.TypeString, value)
+		_node.Plugin = value
+	}
+	if value, ok := nc.mutation.Message(); ok {
+		_spec.SetField(notification.FieldMessage, field.TypeString, value)
+		_node.Message = value
+	}
+	if value, ok := nc.mutation.AlertCount(); ok {
+		_spec.SetField(notification.FieldAlertCount, field.TypeInt, value)
+		_node.AlertCount = value
+	}
+	if value, ok := nc.mutation.Status(); ok {
+		_spec.SetField(notification.FieldStatus, field.TypeEnum, value)
+		_node.Status = value
+	}
+	if value, ok := nc.mutation.Attempts(); ok {
+		_spec.SetField(notification.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := nc.mutation.NextAttemptAt(); ok {
+		_spec.SetField(notification.FieldNextAttemptAt, field.TypeTime, value)
+		_node.NextAttemptAt = value
+	}
+	if value, ok := nc.mutation.LastError(); ok {
+		_spec.SetField(notification.FieldLastError, field.TypeString, value)
+		_node.LastError = value
+	}
+	if value, ok := nc.mutation.DeliveredAt(); ok {
+		_spec.SetField(notification.FieldDeliveredAt, field.TypeTime, value)
+		_node.DeliveredAt = &value
+	}
+	return _node, _spec
+}
+
+// NotificationCreateBulk is the builder for creating many Notification entities in bulk.
+type NotificationCreateBulk struct {
+	config
+	err      error
+	builders []*NotificationCreate
+}
+
+// Save creates the Notification entities in the database.
+func (ncb *NotificationCreateBulk) Save(ctx context.Context) ([]*Notification, error) {
+	if ncb.err != nil {
+		return nil, ncb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(ncb.builders))
+	nodes := make([]*Notification, len(ncb.builders))
+	mutators := make([]Mutator, len(ncb.builders))
+	for i := range ncb.builders {
+		func(i int, root context.Context) {
+			builder := ncb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*NotificationMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, ncb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, ncb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, ncb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (ncb *NotificationCreateBulk) SaveX(ctx context.Context) []*Notification {
+	v, err := ncb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (ncb *NotificationCreateBulk) Exec(ctx context.Context) error {
+	_, err := ncb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (ncb *NotificationCreateBulk) ExecX(ctx context.Context) {
+	if err := ncb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -27,6 +27,9 @@ type Decision func(*sql.Selector)
 // Event is the predicate function for event builders.
 type Event func(*sql.Selector)
 
+// IPReputation is the predicate function for ipreputation builders.
+type IPReputation func(*sql.Selector)
+
 // Lock is the predicate function for lock builders.
 type Lock func(*sql.Selector)
 
@@ -38,3 +41,6 @@ type Meta func(*sql.Selector)
 
 // Metric is the predicate function for metric builders.
 type Metric func(*sql.Selector)
+
+// Notification is the predicate function for notification builders.
+type Notification func(*sql.Selector)
@@ -12,9 +12,11 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/configitem"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/decision"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/event"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/lock"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/machine"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/meta"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
 	"github.com/crowdsecurity/crowdsec/pkg/database/ent/schema"
 )
 
@@ -150,6 +152,22 @@ func init() {
 	eventDescSerialized := eventFields[3].Descriptor()
 	// event.SerializedValidator is a validator for the "serialized" field. It is called by the builders before save.
 	event.SerializedValidator = eventDescSerialized.Validators[0].(func(string) error)
+	ipreputationFields := schema.IPReputation{}.Fields()
+	_ = ipreputationFields
+	// ipreputationDescCreatedAt is the schema descriptor for created_at field.
+	ipreputationDescCreatedAt := ipreputationFields[0].Descriptor()
+	// ipreputation.DefaultCreatedAt holds the default value on creation for the created_at field.
+	ipreputation.DefaultCreatedAt = ipreputationDescCreatedAt.Default.(func() time.Time)
+	// ipreputationDescUpdatedAt is the schema descriptor for updated_at field.
+	ipreputationDescUpdatedAt := ipreputationFields[1].Descriptor()
+	// ipreputation.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	ipreputation.DefaultUpdatedAt = ipreputationDescUpdatedAt.Default.(func() time.Time)
+	// ipreputation.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	ipreputation.UpdateDefaultUpdatedAt = ipreputationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// ipreputationDescFetchedAt is the schema descriptor for fetched_at field.
+	ipreputationDescFetchedAt := ipreputationFields[4].Descriptor()
+	// ipreputation.DefaultFetchedAt holds the default value on creation for the fetched_at field.
+	ipreputation.DefaultFetchedAt = ipreputationDescFetchedAt.Default.(func() time.Time)
 	lockFields := schema.Lock{}.Fields()
 	_ = lockFields
 	// lockDescCreatedAt is the schema descriptor for created_at field.
@@ -200,4 +218,28 @@ func init() {
 	metaDescValue := metaFields[3].Descriptor()
 	// meta.ValueValidator is a validator for the "value" field. It is called by the builders before save.
 	meta.ValueValidator = metaDescValue.Validators[0].(func(string) error)
+	notificationFields := schema.Notification{}.Fields()
+	_ = notificationFields
+	// notificationDescCreatedAt is the schema descriptor for created_at field.
+	notificationDescCreatedAt := notificationFields[0].Descriptor()
+	// notification.DefaultCreatedAt holds the default value on creation for the created_at field.
+	notification.DefaultCreatedAt = notificationDescCreatedAt.Default.(func() time.Time)
+	// notificationDescUpdatedAt is the schema descriptor for updated_at field.
+	notificationDescUpdatedAt := notificationFields[1].Descriptor()
+	// notification.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	notification.DefaultUpdatedAt = notificationDescUpdatedAt.Default.(func() time.Time)
+	// notification.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	notification.UpdateDefaultUpdatedAt = notificationDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// notificationDescAlertCount is the schema descriptor for alert_count field.
+	notificationDescAlertCount := notificationFields[4].Descriptor()
+	// notification.DefaultAlertCount holds the default value on creation for the alert_count field.
+	notification.DefaultAlertCount = notificationDescAlertCount.Default.(int)
+	// notificationDescAttempts is the schema descriptor for attempts field.
+	notificationDescAttempts := notificationFields[6].Descriptor()
+	// notification.DefaultAttempts holds the default value on creation for the attempts field.
+	notification.DefaultAttempts = notificationDescAttempts.Default.(int)
+	// notificationDescNextAttemptAt is the schema descriptor for next_attempt_at field.
+	notificationDescNextAttemptAt := notificationFields[7].Descriptor()
+	// notification.DefaultNextAttemptAt holds the default value on creation for the next_attempt_at field.
+	notification.DefaultNextAttemptAt = notificationDescNextAttemptAt.Default.(func() time.Time)
 }
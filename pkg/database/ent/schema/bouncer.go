@@ -7,6 +7,18 @@ import (
 	"github.com/crowdsecurity/crowdsec/pkg/types"
 )
 
+// BouncerFilters is the server-side policy restricting the decisions sent to a bouncer.
+// It is defined here instead of pkg/database to avoid introducing a dependency
+// from the generated code to pkg/database, which imports it: that would be an import cycle.
+type BouncerFilters struct {
+	Scopes           []string `json:"scopes,omitempty"`
+	Origins          []string `json:"origins,omitempty"`
+	Scenarios        []string `json:"scenarios,omitempty"` // shell-style '*' wildcards are allowed
+	ExcludeCountries []string `json:"exclude_countries,omitempty"`
+	ExcludeAS        []string `json:"exclude_as,omitempty"`
+	MaxDecisions     int      `json:"max_decisions,omitempty"` // caps the full lists, not the stream deltas
+}
+
 // Bouncer holds the schema definition for the Bouncer entity.
 type Bouncer struct {
 	ent.Schema
@@ -35,6 +47,7 @@ func (Bouncer) Fields() []ent.Field {
 		field.String("featureflags").Optional(),
 		// Old auto-created TLS bouncers will have a wrong value for this field
 		field.Bool("auto_created").StructTag(`json:"auto_created"`).Default(false).Immutable(),
+		field.JSON("filters", &BouncerFilters{}).Optional().StructTag(`json:"filters,omitempty"`),
 	}
 }
 
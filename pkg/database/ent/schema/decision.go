@@ -40,6 +40,7 @@ func (Decision) Fields() []ent.Field {
 		field.Bool("simulated").Default(false).Immutable(),
 		field.String("uuid").Optional().Immutable(), // this uuid is mostly here to ensure that CAPI/PAPI has a unique id for each decision
 		field.Int("alert_decisions").Optional(),
+		field.Int("escalation_step").Optional().Immutable(),
 	}
 }
 
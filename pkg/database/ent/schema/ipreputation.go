@@ -0,0 +1,32 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// IPReputation caches the CTI lookups used to enrich the alerts.
+type IPReputation struct {
+	ent.Schema
+}
+
+// Fields of the IPReputation.
+func (IPReputation) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").
+			Default(types.UtcNow).
+			Immutable(),
+		field.Time("updated_at").
+			Default(types.UtcNow).
+			UpdateDefault(types.UtcNow),
+		field.String("ip").
+			Unique().
+			Immutable(),
+		field.Text("data").
+			Comment("JSON representation of models.CTIInfo"),
+		field.Time("fetched_at").
+			Default(types.UtcNow),
+	}
+}
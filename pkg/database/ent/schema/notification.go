@@ -0,0 +1,54 @@
+package schema
+
+import (
+	"entgo.io/ent"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// Notification holds a message waiting to be delivered to a notification plugin.
+type Notification struct {
+	ent.Schema
+}
+
+// Fields of the Notification.
+func (Notification) Fields() []ent.Field {
+	return []ent.Field{
+		field.Time("created_at").
+			Default(types.UtcNow).
+			Immutable(),
+		field.Time("updated_at").
+			Default(types.UtcNow).
+			UpdateDefault(types.UtcNow),
+		field.String("plugin").
+			Immutable().
+			Comment("Name of the notification plugin configuration"),
+		field.Text("message").
+			Immutable().
+			Comment("The formatted message, as sent to the plugin"),
+		field.Int("alert_count").
+			Default(0).
+			Immutable(),
+		field.Enum("status").
+			Values("pending", "sending", "delivered", "failed").
+			Default("pending").
+			Comment("sending means the notification is claimed by a delivery attempt, failed means it ran out of retries"),
+		field.Int("attempts").
+			Default(0),
+		field.Time("next_attempt_at").
+			Default(types.UtcNow),
+		field.Text("last_error").
+			Optional(),
+		field.Time("delivered_at").
+			Nillable().
+			Optional(),
+	}
+}
+
+func (Notification) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("status", "next_attempt_at"),
+	}
+}
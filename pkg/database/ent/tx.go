@@ -26,6 +26,8 @@ type Tx struct {
 	Decision *DecisionClient
 	// Event is the client for interacting with the Event builders.
 	Event *EventClient
+	// IPReputation is the client for interacting with the IPReputation builders.
+	IPReputation *IPReputationClient
 	// Lock is the client for interacting with the Lock builders.
 	Lock *LockClient
 	// Machine is the client for interacting with the Machine builders.
@@ -34,6 +36,8 @@ type Tx struct {
 	Meta *MetaClient
 	// Metric is the client for interacting with the Metric builders.
 	Metric *MetricClient
+	// Notification is the client for interacting with the Notification builders.
+	Notification *NotificationClient
 
 	// lazily loaded.
 	client     *Client
@@ -172,10 +176,12 @@ func (tx *Tx) init() {
 	tx.ConfigItem = NewConfigItemClient(tx.config)
 	tx.Decision = NewDecisionClient(tx.config)
 	tx.Event = NewEventClient(tx.config)
+	tx.IPReputation = NewIPReputationClient(tx.config)
 	tx.Lock = NewLockClient(tx.config)
 	tx.Machine = NewMachineClient(tx.config)
 	tx.Meta = NewMetaClient(tx.config)
 	tx.Metric = NewMetricClient(tx.config)
+	tx.Notification = NewNotificationClient(tx.config)
 }
 
 // txDriver wraps the given dialect.Tx with a nop dialect.Driver implementation.
@@ -56,7 +56,7 @@ func (c *Client) StartFlushScheduler(ctx context.Context, config *csconfig.Flush
 	// Init & Start cronjob every hour for bouncers/agents
 	if config.AgentsGC != nil {
 		if config.AgentsGC.Cert != nil {
-			duration, err := ParseDuration(*config.AgentsGC.Cert)
+			duration, err := types.ParseDuration(*config.AgentsGC.Cert)
 			if err != nil {
 				return nil, fmt.Errorf("while parsing agents cert auto-delete duration: %w", err)
 			}
@@ -65,7 +65,7 @@ func (c *Client) StartFlushScheduler(ctx context.Context, config *csconfig.Flush
 		}
 
 		if config.AgentsGC.LoginPassword != nil {
-			duration, err := ParseDuration(*config.AgentsGC.LoginPassword)
+			duration, err := types.ParseDuration(*config.AgentsGC.LoginPassword)
 			if err != nil {
 				return nil, fmt.Errorf("while parsing agents login/password auto-delete duration: %w", err)
 			}
@@ -80,7 +80,7 @@ func (c *Client) StartFlushScheduler(ctx context.Context, config *csconfig.Flush
 
 	if config.BouncersGC != nil {
 		if config.BouncersGC.Cert != nil {
-			duration, err := ParseDuration(*config.BouncersGC.Cert)
+			duration, err := types.ParseDuration(*config.BouncersGC.Cert)
 			if err != nil {
 				return nil, fmt.Errorf("while parsing bouncers cert auto-delete duration: %w", err)
 			}
@@ -89,7 +89,7 @@ func (c *Client) StartFlushScheduler(ctx context.Context, config *csconfig.Flush
 		}
 
 		if config.BouncersGC.Api != nil {
-			duration, err := ParseDuration(*config.BouncersGC.Api)
+			duration, err := types.ParseDuration(*config.BouncersGC.Api)
 			if err != nil {
 				return nil, fmt.Errorf("while parsing bouncers api auto-delete duration: %w", err)
 			}
@@ -123,6 +123,20 @@ func (c *Client) StartFlushScheduler(ctx context.Context, config *csconfig.Flush
 
 	allowlistsJob.SingletonMode()
 
+	notificationsJob, err := scheduler.Every(flushInterval).Do(c.flushNotifications, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while starting flushNotifications scheduler: %w", err)
+	}
+
+	notificationsJob.SingletonMode()
+
+	reputationsJob, err := scheduler.Every(flushInterval).Do(c.flushIPReputations, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("while starting flushIPReputations scheduler: %w", err)
+	}
+
+	reputationsJob.SingletonMode()
+
 	scheduler.StartAsync()
 
 	return scheduler, nil
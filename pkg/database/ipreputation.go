@@ -0,0 +1,99 @@
+package database
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/ipreputation"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+)
+
+// how long to keep the CTI lookups, even if they are stale they can still be displayed
+const defaultIPReputationMaxAge = 30 * 24 * time.Hour
+
+// GetIPReputation returns the cached CTI data of an IP, or nil if it's missing or was fetched before notBefore
+func (c *Client) GetIPReputation(ctx context.Context, ip string, notBefore time.Time) (*models.CTIInfo, error) {
+	rep, err := c.Ent.IPReputation.Query().
+		Where(ipreputation.IPEQ(ip), ipreputation.FetchedAtGTE(notBefore)).
+		Only(ctx)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			return nil, nil
+		}
+
+		c.Log.Warningf("GetIPReputation: %s", err)
+
+		return nil, errors.Wrapf(QueryFail, "reputation of '%s'", ip)
+	}
+
+	info := &models.CTIInfo{}
+
+	if err := json.Unmarshal([]byte(rep.Data), info); err != nil {
+		return nil, errors.Wrapf(ParseType, "reputation of '%s': %s", ip, err)
+	}
+
+	return info, nil
+}
+
+// SetIPReputation stores the CTI data of an IP, replacing the previous lookup
+func (c *Client) SetIPReputation(ctx context.Context, ip string, info *models.CTIInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return errors.Wrapf(MarshalFail, "reputation of '%s': %s", ip, err)
+	}
+
+	now := time.Now().UTC()
+
+	// the lookups of an IP can run concurrently, don't insert it twice
+	txClient, err := c.Ent.Tx(ctx)
+	if err != nil {
+		return errors.Wrapf(UpdateFail, "creating transaction: %s", err)
+	}
+
+	updated, err := txClient.IPReputation.Update().
+		Where(ipreputation.IPEQ(ip)).
+		SetData(string(data)).
+		SetFetchedAt(now).
+		Save(ctx)
+	if err != nil {
+		c.Log.Warningf("SetIPReputation: %s", err)
+		return rollbackOnError(txClient, errors.Wrapf(UpdateFail, "reputation of '%s'", ip), "updating ip reputation")
+	}
+
+	if updated == 0 {
+		_, err = txClient.IPReputation.Create().
+			SetIP(ip).
+			SetData(string(data)).
+			SetFetchedAt(now).
+			Save(ctx)
+		if err != nil {
+			c.Log.Warningf("SetIPReputation: %s", err)
+			return rollbackOnError(txClient, errors.Wrapf(InsertFail, "reputation of '%s'", ip), "inserting ip reputation")
+		}
+	}
+
+	if err := txClient.Commit(); err != nil {
+		return errors.Wrapf(UpdateFail, "reputation of '%s': %s", ip, err)
+	}
+
+	return nil
+}
+
+// flushIPReputations deletes the CTI lookups older than defaultIPReputationMaxAge
+func (c *Client) flushIPReputations(ctx context.Context) {
+	deleted, err := c.Ent.IPReputation.Delete().
+		Where(ipreputation.FetchedAtLT(time.Now().UTC().Add(-defaultIPReputationMaxAge))).
+		Exec(ctx)
+	if err != nil {
+		c.Log.Errorf("while flushing ip reputations: %s", err)
+		return
+	}
+
+	if deleted > 0 {
+		c.Log.Debugf("flushed %d ip reputations", deleted)
+	}
+}
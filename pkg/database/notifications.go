@@ -0,0 +1,206 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/notification"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+)
+
+const (
+	// how long to keep delivered notifications in the local database
+	defaultNotificationsMaxAge = 7 * 24 * time.Hour
+	// a notification claimed for longer than this is considered abandoned (crash during the delivery) and can be claimed again
+	notificationClaimTimeout = 10 * time.Minute
+)
+
+// NotificationFilter selects notifications for listing, retrying or purging. Empty fields match everything.
+type NotificationFilter struct {
+	IDs       []int
+	Statuses  []notification.Status
+	Plugin    string
+	OlderThan time.Time
+	Limit     int
+}
+
+func (f NotificationFilter) predicates() []predicate.Notification {
+	preds := []predicate.Notification{}
+
+	if len(f.IDs) > 0 {
+		preds = append(preds, notification.IDIn(f.IDs...))
+	}
+
+	if len(f.Statuses) > 0 {
+		preds = append(preds, notification.StatusIn(f.Statuses...))
+	}
+
+	if f.Plugin != "" {
+		preds = append(preds, notification.PluginEQ(f.Plugin))
+	}
+
+	if !f.OlderThan.IsZero() {
+		preds = append(preds, notification.CreatedAtLT(f.OlderThan))
+	}
+
+	return preds
+}
+
+func (c *Client) EnqueueNotification(ctx context.Context, plugin string, message string, alertCount int) (*ent.Notification, error) {
+	n, err := c.Ent.Notification.
+		Create().
+		SetPlugin(plugin).
+		SetMessage(message).
+		SetAlertCount(alertCount).
+		Save(ctx)
+	if err != nil {
+		c.Log.Warningf("EnqueueNotification: %s", err)
+		return nil, errors.Wrapf(InsertFail, "notification for plugin '%s'", plugin)
+	}
+
+	return n, nil
+}
+
+// claimablePredicate matches the notifications that can be claimed for delivery at the given time
+func claimablePredicate(now time.Time) predicate.Notification {
+	return notification.Or(
+		notification.And(
+			notification.StatusEQ(notification.StatusPending),
+			notification.NextAttemptAtLTE(now),
+		),
+		notification.And(
+			notification.StatusEQ(notification.StatusSending),
+			notification.UpdatedAtLT(now.Add(-notificationClaimTimeout)),
+		),
+	)
+}
+
+// PendingNotifications returns the notifications that are due for delivery, oldest first.
+// They must be claimed with ClaimNotification before being sent.
+func (c *Client) PendingNotifications(ctx context.Context, now time.Time, limit int) ([]*ent.Notification, error) {
+	ret, err := c.Ent.Notification.Query().
+		Where(claimablePredicate(now)).
+		Order(ent.Asc(notification.FieldID)).
+		Limit(limit).
+		All(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(QueryFail, "pending notifications: %s", err)
+	}
+
+	return ret, nil
+}
+
+// ClaimNotification atomically moves a due notification to the sending state. It returns false if the notification
+// is not due anymore, because it has been claimed by another delivery attempt (ie. another local API sharing the database).
+func (c *Client) ClaimNotification(ctx context.Context, id int, now time.Time) (bool, error) {
+	nbUpdated, err := c.Ent.Notification.Update().
+		Where(
+			notification.IDEQ(id),
+			claimablePredicate(now),
+		).
+		SetStatus(notification.StatusSending).
+		SetUpdatedAt(now).
+		Save(ctx)
+	if err != nil {
+		return false, fmt.Errorf("claiming notification %d: %w", id, err)
+	}
+
+	return nbUpdated == 1, nil
+}
+
+func (c *Client) MarkNotificationDelivered(ctx context.Context, id int) error {
+	_, err := c.Ent.Notification.UpdateOneID(id).
+		SetStatus(notification.StatusDelivered).
+		SetDeliveredAt(time.Now().UTC()).
+		AddAttempts(1).
+		ClearLastError().
+		Save(ctx)
+	if err != nil {
+		return fmt.Errorf("marking notification %d as delivered: %w", id, err)
+	}
+
+	return nil
+}
+
+// RecordNotificationFailure counts a failed attempt. If nextAttempt is nil, the notification
+// ran out of retries and is moved to the failed state.
+func (c *Client) RecordNotificationFailure(ctx context.Context, id int, lastError string, nextAttempt *time.Time) error {
+	update := c.Ent.Notification.UpdateOneID(id).
+		AddAttempts(1).
+		SetLastError(lastError)
+
+	if nextAttempt == nil {
+		update = update.SetStatus(notification.StatusFailed)
+	} else {
+		update = update.SetStatus(notification.StatusPending).SetNextAttemptAt(*nextAttempt)
+	}
+
+	if _, err := update.Save(ctx); err != nil {
+		return fmt.Errorf("updating notification %d: %w", id, err)
+	}
+
+	return nil
+}
+
+func (c *Client) ListNotifications(ctx context.Context, filter NotificationFilter) ([]*ent.Notification, error) {
+	query := c.Ent.Notification.Query().
+		Where(filter.predicates()...).
+		Order(ent.Desc(notification.FieldID))
+
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	ret, err := query.All(ctx)
+	if err != nil {
+		return nil, errors.Wrapf(QueryFail, "listing notifications: %s", err)
+	}
+
+	return ret, nil
+}
+
+// RetryNotifications puts the matching notifications back in the queue, for immediate delivery
+func (c *Client) RetryNotifications(ctx context.Context, filter NotificationFilter) (int, error) {
+	nbUpdated, err := c.Ent.Notification.Update().
+		Where(filter.predicates()...).
+		SetStatus(notification.StatusPending).
+		SetAttempts(0).
+		SetNextAttemptAt(time.Now().UTC()).
+		Save(ctx)
+	if err != nil {
+		return 0, errors.Wrapf(UpdateFail, "retrying notifications: %s", err)
+	}
+
+	return nbUpdated, nil
+}
+
+func (c *Client) PurgeNotifications(ctx context.Context, filter NotificationFilter) (int, error) {
+	nbDeleted, err := c.Ent.Notification.Delete().
+		Where(filter.predicates()...).
+		Exec(ctx)
+	if err != nil {
+		return 0, errors.Wrapf(DeleteFail, "purging notifications: %s", err)
+	}
+
+	return nbDeleted, nil
+}
+
+// flushNotifications deletes the delivered notifications older than defaultNotificationsMaxAge
+func (c *Client) flushNotifications(ctx context.Context) {
+	deleted, err := c.PurgeNotifications(ctx, NotificationFilter{
+		Statuses:  []notification.Status{notification.StatusDelivered},
+		OlderThan: time.Now().UTC().Add(-defaultNotificationsMaxAge),
+	})
+	if err != nil {
+		c.Log.Errorf("while flushing notifications: %s", err)
+		return
+	}
+
+	if deleted > 0 {
+		c.Log.Debugf("flushed %d delivered notifications", deleted)
+	}
+}
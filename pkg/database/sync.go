@@ -0,0 +1,187 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/crowdsecurity/go-cs-lib/slicetools"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/decision"
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent/predicate"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// the expiration of an existing decision is updated only if it changes by more than this
+const syncUntilTolerance = time.Minute
+
+// SyncResult counts the decisions changed by a synchronization
+type SyncResult struct {
+	Added     int `json:"added"`
+	Refreshed int `json:"refreshed"`
+	Expired   int `json:"expired"`
+}
+
+func syncDecisionKey(scope string, value string, decisionType string) string {
+	return scope + "|" + value + "|" + decisionType
+}
+
+// PeerScenarioPrefix is prepended to the scenario of the decisions received from a federation peer
+func PeerScenarioPrefix(peer string) string {
+	return types.FederationOrigin + ":" + peer + ":"
+}
+
+// SyncFeedDecisions makes the active decisions of a feed match its current content
+func (c *Client) SyncFeedDecisions(ctx context.Context, scenario string, decisions []*models.Decision) (SyncResult, error) {
+	return c.syncDecisions(ctx, types.FeedOrigin, scenario, decision.ScenarioEQ(scenario), decisions)
+}
+
+// SyncPeerDecisions makes the active decisions received from a federation peer match the
+// decisions it currently publishes. The scenarios must start with PeerScenarioPrefix.
+func (c *Client) SyncPeerDecisions(ctx context.Context, peer string, decisions []*models.Decision) (SyncResult, error) {
+	prefix := PeerScenarioPrefix(peer)
+
+	return c.syncDecisions(ctx, types.FederationOrigin, types.FederationOrigin+":"+peer, decision.ScenarioHasPrefix(prefix), decisions)
+}
+
+// syncDecisions makes the active decisions of an origin, selected by the scenario predicate, match
+// the given list: the new values are added under a new alert, the existing ones are extended
+// by the duration of the new decision and the ones that are not in the list are expired.
+func (c *Client) syncDecisions(ctx context.Context, origin string, alertScenario string, selector predicate.Decision, decisions []*models.Decision) (SyncResult, error) {
+	res := SyncResult{}
+	now := time.Now().UTC()
+
+	active, err := c.Ent.Decision.Query().
+		Where(
+			decision.OriginEQ(origin),
+			selector,
+			decision.UntilGT(now),
+		).
+		All(ctx)
+	if err != nil {
+		c.Log.Warningf("syncDecisions: %s", err)
+		return res, errors.Wrapf(QueryFail, "active decisions of '%s'", alertScenario)
+	}
+
+	existing := make(map[string]*ent.Decision, len(active))
+	toExpire := []*ent.Decision{}
+
+	for _, d := range active {
+		key := syncDecisionKey(d.Scope, d.Value, d.Type)
+		if _, ok := existing[key]; ok {
+			toExpire = append(toExpire, d)
+			continue
+		}
+
+		existing[key] = d
+	}
+
+	// the decisions to extend, by duration
+	toRefresh := map[time.Duration][]int{}
+	toAdd := []*models.Decision{}
+	seen := make(map[string]bool, len(decisions))
+
+	for _, d := range decisions {
+		key := syncDecisionKey(*d.Scope, *d.Value, *d.Type)
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		if e, ok := existing[key]; ok {
+			delete(existing, key)
+
+			duration, err := time.ParseDuration(*d.Duration)
+			if err != nil {
+				return res, errors.Wrapf(ParseDurationFail, "decision duration '%s': %s", *d.Duration, err)
+			}
+
+			// don't rewrite the decisions that would barely change, like the ones of a peer
+			// that are pulled again before their expiration
+			if diff := e.Until.Sub(now.Add(duration)).Abs(); diff < syncUntilTolerance {
+				res.Refreshed++
+				continue
+			}
+
+			toRefresh[duration] = append(toRefresh[duration], e.ID)
+
+			continue
+		}
+
+		toAdd = append(toAdd, d)
+	}
+
+	for _, d := range existing {
+		toExpire = append(toExpire, d)
+	}
+
+	if len(toExpire) > 0 {
+		res.Expired, err = c.ExpireDecisions(ctx, toExpire)
+		if err != nil {
+			return res, err
+		}
+	}
+
+	for duration, ids := range toRefresh {
+		for _, chunk := range slicetools.Chunks(ids, decisionDeleteBulkSize) {
+			rows, err := c.Ent.Decision.Update().
+				Where(decision.IDIn(chunk...)).
+				SetUntil(now.Add(duration)).
+				Save(ctx)
+			if err != nil {
+				c.Log.Warningf("syncDecisions: %s", err)
+				return res, errors.Wrapf(UpdateFail, "decisions of '%s'", alertScenario)
+			}
+
+			res.Refreshed += rows
+		}
+	}
+
+	if len(toAdd) == 0 {
+		return res, nil
+	}
+
+	alertRef, err := c.Ent.Alert.Create().
+		SetScenario(alertScenario).
+		SetMessage(fmt.Sprintf("%d new decisions from %s", len(toAdd), alertScenario)).
+		SetEventsCount(0).
+		SetStartedAt(now).
+		SetStoppedAt(now).
+		SetSourceScope(origin).
+		SetSourceValue("").
+		SetCapacity(0).
+		SetLeakSpeed("").
+		SetSimulated(false).
+		SetScenarioVersion("").
+		SetScenarioHash("").
+		SetRemediation(true).
+		Save(ctx)
+	if err != nil {
+		c.Log.Warningf("syncDecisions: %s", err)
+		return res, errors.Wrapf(InsertFail, "alert of '%s'", alertScenario)
+	}
+
+	for _, chunk := range slicetools.Chunks(toAdd, c.decisionBulkSize) {
+		created, err := c.createDecisionChunk(ctx, false, now, chunk)
+		if err != nil {
+			return res, errors.Wrapf(BulkError, "decisions of '%s': %s", alertScenario, err)
+		}
+
+		if len(created) == 0 {
+			continue
+		}
+
+		if err := c.Ent.Alert.UpdateOne(alertRef).AddDecisions(created...).Exec(ctx); err != nil {
+			return res, errors.Wrapf(UpdateFail, "alert of '%s': %s", alertScenario, err)
+		}
+
+		res.Added += len(created)
+	}
+
+	return res, nil
+}
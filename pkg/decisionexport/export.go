@@ -0,0 +1,231 @@
+// Package decisionexport renders decisions in formats that can be loaded directly by
+// firewalls and reverse proxies, without a dedicated bouncer.
+package decisionexport
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+const DefaultSetName = "crowdsec-blacklists"
+
+// Options controls the rendering of the decisions.
+type Options struct {
+	// SetName is the name of the set/list/map the decisions are added to, where relevant.
+	// The IPv6 set name is derived from it by appending "-v6".
+	SetName string
+	// Now is used to compute the remaining duration of each decision.
+	Now time.Time
+}
+
+type formatter struct {
+	contentType string
+	write       func(w io.Writer, decisions []*ent.Decision, opts Options) error
+}
+
+var formatters = map[string]formatter{
+	"plaintext": {"text/plain; charset=utf-8", writePlaintext},
+	"csv":       {"text/csv; charset=utf-8", writeCSV},
+	"nftables":  {"text/plain; charset=utf-8", writeNftables},
+	"ipset":     {"text/plain; charset=utf-8", writeIpset},
+	"haproxy":   {"text/plain; charset=utf-8", writeHaproxyMap},
+	"mikrotik":  {"text/plain; charset=utf-8", writeMikrotik},
+}
+
+// Formats returns the list of supported export formats.
+func Formats() []string {
+	ret := make([]string, 0, len(formatters))
+	for name := range formatters {
+		ret = append(ret, name)
+	}
+
+	slices.Sort(ret)
+
+	return ret
+}
+
+// ContentType returns the MIME type of the given format.
+func ContentType(format string) (string, error) {
+	f, ok := formatters[format]
+	if !ok {
+		return "", fmt.Errorf("unknown format '%s', supported formats: %s", format, strings.Join(Formats(), ", "))
+	}
+
+	return f.contentType, nil
+}
+
+// FormatFromAccept picks an export format from an Accept header, defaulting to plaintext.
+func FormatFromAccept(accept string) string {
+	if strings.Contains(accept, "text/csv") {
+		return "csv"
+	}
+
+	return "plaintext"
+}
+
+// Write renders the decisions in the requested format.
+func Write(w io.Writer, format string, decisions []*ent.Decision, opts Options) error {
+	f, ok := formatters[format]
+	if !ok {
+		return fmt.Errorf("unknown format '%s', supported formats: %s", format, strings.Join(Formats(), ", "))
+	}
+
+	if opts.SetName == "" {
+		opts.SetName = DefaultSetName
+	}
+
+	if opts.Now.IsZero() {
+		opts.Now = time.Now().UTC()
+	}
+
+	return f.write(w, decisions, opts)
+}
+
+// remaining returns the number of seconds left before the decision expires, at least 1
+func remaining(d *ent.Decision, now time.Time) int64 {
+	if d.Until == nil {
+		return 1
+	}
+
+	return max(int64(d.Until.Sub(now).Seconds()), 1)
+}
+
+// isIPv6 returns true if the value of an Ip or Range decision is an IPv6 address or network
+func isIPv6(value string) bool {
+	if ip, _, err := net.ParseCIDR(value); err == nil {
+		return ip.To4() == nil
+	}
+
+	if ip := net.ParseIP(value); ip != nil {
+		return ip.To4() == nil
+	}
+
+	return false
+}
+
+// firewallDecisions keeps only the decisions that can be enforced at the network level
+func firewallDecisions(decisions []*ent.Decision) []*ent.Decision {
+	ret := make([]*ent.Decision, 0, len(decisions))
+
+	for _, d := range decisions {
+		if d.Scope == types.Ip || d.Scope == types.Range {
+			ret = append(ret, d)
+		}
+	}
+
+	return ret
+}
+
+func writePlaintext(w io.Writer, decisions []*ent.Decision, _ Options) error {
+	for _, d := range decisions {
+		if _, err := fmt.Fprintln(w, d.Value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func writeCSV(w io.Writer, decisions []*ent.Decision, opts Options) error {
+	csvwriter := csv.NewWriter(w)
+
+	if err := csvwriter.Write([]string{"value", "scope", "type", "origin", "scenario", "duration"}); err != nil {
+		return err
+	}
+
+	for _, d := range decisions {
+		err := csvwriter.Write([]string{
+			d.Value,
+			d.Scope,
+			d.Type,
+			d.Origin,
+			d.Scenario,
+			strconv.FormatInt(remaining(d, opts.Now), 10),
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	csvwriter.Flush()
+
+	return csvwriter.Error()
+}
+
+// writeNftables generates a script for `nft -f`, the table and sets are expected to exist
+// with the timeout and interval flags
+func writeNftables(w io.Writer, decisions []*ent.Decision, opts Options) error {
+	for _, d := range firewallDecisions(decisions) {
+		family, set := "ip", opts.SetName
+		if isIPv6(d.Value) {
+			family, set = "ip6", opts.SetName+"-v6"
+		}
+
+		if _, err := fmt.Fprintf(w, "add element %s crowdsec %s { %s timeout %ds }\n", family, set, d.Value, remaining(d, opts.Now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeIpset generates an input file for `ipset restore`, used with iptables' set match
+func writeIpset(w io.Writer, decisions []*ent.Decision, opts Options) error {
+	setV6 := opts.SetName + "-v6"
+
+	header := fmt.Sprintf("create %s hash:net family inet timeout 0 -exist\ncreate %s hash:net family inet6 timeout 0 -exist\n", opts.SetName, setV6)
+	if _, err := io.WriteString(w, header); err != nil {
+		return err
+	}
+
+	for _, d := range firewallDecisions(decisions) {
+		set := opts.SetName
+		if isIPv6(d.Value) {
+			set = setV6
+		}
+
+		if _, err := fmt.Fprintf(w, "add %s %s timeout %d -exist\n", set, d.Value, remaining(d, opts.Now)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeHaproxyMap generates a map file for `map_ip()`, with the decision type as value
+func writeHaproxyMap(w io.Writer, decisions []*ent.Decision, _ Options) error {
+	for _, d := range firewallDecisions(decisions) {
+		if _, err := fmt.Fprintf(w, "%s %s\n", d.Value, d.Type); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// writeMikrotik generates a RouterOS script adding the decisions to an address list
+func writeMikrotik(w io.Writer, decisions []*ent.Decision, opts Options) error {
+	for _, d := range firewallDecisions(decisions) {
+		cmd := "/ip firewall address-list"
+		if isIPv6(d.Value) {
+			cmd = "/ipv6 firewall address-list"
+		}
+
+		comment := strconv.Quote(d.Origin + ": " + d.Scenario)
+
+		if _, err := fmt.Fprintf(w, "%s add list=%s address=%s timeout=%ds comment=%s\n", cmd, opts.SetName, d.Value, remaining(d, opts.Now), comment); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
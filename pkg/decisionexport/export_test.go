@@ -0,0 +1,92 @@
+package decisionexport
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+
+	"github.com/crowdsecurity/crowdsec/pkg/database/ent"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func TestWrite(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := now.Add(time.Hour)
+
+	decisions := []*ent.Decision{
+		{Value: "1.2.3.4", Scope: types.Ip, Type: "ban", Origin: "crowdsec", Scenario: "crowdsecurity/ssh-bf", Until: &until},
+		{Value: "2001:db8::/64", Scope: types.Range, Type: "captcha", Origin: "cscli", Scenario: "manual", Until: &until},
+		{Value: "FR", Scope: types.Country, Type: "ban", Origin: "cscli", Scenario: "manual", Until: &until},
+	}
+
+	tests := []struct {
+		format      string
+		expected    string
+		expectedErr string
+	}{
+		{
+			format:   "plaintext",
+			expected: "1.2.3.4\n2001:db8::/64\nFR\n",
+		},
+		{
+			format: "csv",
+			expected: "value,scope,type,origin,scenario,duration\n" +
+				"1.2.3.4,Ip,ban,crowdsec,crowdsecurity/ssh-bf,3600\n" +
+				"2001:db8::/64,Range,captcha,cscli,manual,3600\n" +
+				"FR,Country,ban,cscli,manual,3600\n",
+		},
+		{
+			format: "nftables",
+			expected: "add element ip crowdsec test { 1.2.3.4 timeout 3600s }\n" +
+				"add element ip6 crowdsec test-v6 { 2001:db8::/64 timeout 3600s }\n",
+		},
+		{
+			format: "ipset",
+			expected: "create test hash:net family inet timeout 0 -exist\n" +
+				"create test-v6 hash:net family inet6 timeout 0 -exist\n" +
+				"add test 1.2.3.4 timeout 3600 -exist\n" +
+				"add test-v6 2001:db8::/64 timeout 3600 -exist\n",
+		},
+		{
+			format:   "haproxy",
+			expected: "1.2.3.4 ban\n2001:db8::/64 captcha\n",
+		},
+		{
+			format: "mikrotik",
+			expected: "/ip firewall address-list add list=test address=1.2.3.4 timeout=3600s comment=\"crowdsec: crowdsecurity/ssh-bf\"\n" +
+				"/ipv6 firewall address-list add list=test address=2001:db8::/64 timeout=3600s comment=\"cscli: manual\"\n",
+		},
+		{
+			format:      "pf",
+			expectedErr: "unknown format 'pf', supported formats: csv, haproxy, ipset, mikrotik, nftables, plaintext",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.format, func(t *testing.T) {
+			buf := bytes.Buffer{}
+			err := Write(&buf, tc.format, decisions, Options{SetName: "test", Now: now})
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if tc.expectedErr != "" {
+				return
+			}
+
+			assert.Equal(t, tc.expected, buf.String())
+		})
+	}
+}
+
+func TestFormatFromAccept(t *testing.T) {
+	assert.Equal(t, "csv", FormatFromAccept("text/csv"))
+	assert.Equal(t, "plaintext", FormatFromAccept("*/*"))
+
+	ct, err := ContentType("csv")
+	require.NoError(t, err)
+	assert.Equal(t, "text/csv; charset=utf-8", ct)
+}
@@ -0,0 +1,369 @@
+// Package feeds imports external threat-intel feeds (plain lists, CSV, MISP and STIX exports)
+// as decisions of the local API. Each pull replaces the decisions of the previous one:
+// new values are added, the others are extended, and the values removed from the feed expire.
+// A feed that can't be fetched, or has no entry, keeps the decisions of the last pull.
+package feeds
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	log "github.com/sirupsen/logrus"
+	"gopkg.in/tomb.v2"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+	"github.com/crowdsecurity/go-cs-lib/trace"
+
+	"github.com/crowdsecurity/crowdsec/pkg/apiclient/useragent"
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// Store is the part of the database used by the feeds, implemented by database.Client
+type Store interface {
+	SyncFeedDecisions(ctx context.Context, scenario string, decisions []*models.Decision) (database.SyncResult, error)
+	SyncPeerDecisions(ctx context.Context, peer string, decisions []*models.Decision) (database.SyncResult, error)
+	GetAllowlistsContentForAPIC(ctx context.Context) ([]net.IP, []*net.IPNet, error)
+	GetConfigItem(ctx context.Context, key string) (*string, error)
+	SetConfigItem(ctx context.Context, key string, value string) error
+}
+
+// Status is the outcome of the last pull of a feed, stored in the database for cscli
+type Status struct {
+	LastAttempt time.Time `json:"last_attempt"`
+	LastSuccess time.Time `json:"last_success,omitzero"`
+	Entries     int       `json:"entries"`
+	Skipped     int       `json:"skipped"`
+	Allowlisted int       `json:"allowlisted"`
+	database.SyncResult
+	Error string `json:"error,omitempty"`
+}
+
+// Scenario is the scenario of the decisions of a feed
+func Scenario(name string) string {
+	return types.FeedOrigin + ":" + name
+}
+
+func statusKey(kind string, name string) string {
+	return kind + ":" + name + ":status"
+}
+
+func getStatus(ctx context.Context, store Store, key string) (*Status, error) {
+	value, err := store.GetConfigItem(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if value == nil {
+		return nil, nil
+	}
+
+	status := &Status{}
+
+	if err := json.Unmarshal([]byte(*value), status); err != nil {
+		return nil, fmt.Errorf("invalid status %s: %w", key, err)
+	}
+
+	return status, nil
+}
+
+// GetStatus returns the status of the last pull of a feed, or nil if it was never pulled
+func GetStatus(ctx context.Context, store Store, name string) (*Status, error) {
+	return getStatus(ctx, store, statusKey(types.FeedOrigin, name))
+}
+
+// GetPeerStatus returns the status of the last pull of a federation peer, or nil if it was never pulled
+func GetPeerStatus(ctx context.Context, store Store, name string) (*Status, error) {
+	return getStatus(ctx, store, statusKey("peer", name))
+}
+
+func setStatus(ctx context.Context, store Store, key string, status *Status) error {
+	value, err := json.Marshal(status)
+	if err != nil {
+		return err
+	}
+
+	return store.SetConfigItem(ctx, key, string(value))
+}
+
+// the feeds larger than this are refused, instead of being truncated
+const maxFeedSize = 100 * 1024 * 1024
+
+// open returns the content of a feed, from a file or an HTTP URL
+func open(ctx context.Context, feed *csconfig.FeedCfg) (io.ReadCloser, error) {
+	if feed.Path != "" {
+		f, err := os.Open(feed.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		return newLimitedReader(f), nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, feed.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("User-Agent", useragent.Default())
+
+	for key, value := range feed.Headers {
+		req.Header.Set(key, value)
+	}
+
+	// the timeout covers the whole download, including the body
+	client := &http.Client{Timeout: feed.Timeout}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("unexpected HTTP status %s", resp.Status)
+	}
+
+	return newLimitedReader(resp.Body), nil
+}
+
+// limitedReader fails when the content is larger than maxFeedSize
+type limitedReader struct {
+	io.Reader
+	io.Closer
+	read int64
+}
+
+func newLimitedReader(rc io.ReadCloser) *limitedReader {
+	return &limitedReader{Reader: io.LimitReader(rc, maxFeedSize+1), Closer: rc}
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.read += int64(n)
+
+	if r.read > maxFeedSize {
+		return n, fmt.Errorf("feed is larger than %d bytes", maxFeedSize)
+	}
+
+	return n, err
+}
+
+// allowlisted tells if an entry is covered by the local allowlists, which don't apply to other scopes
+func allowlisted(entry Entry, ips []net.IP, ranges []*net.IPNet) bool {
+	if entry.Scope != types.Ip && entry.Scope != types.Range {
+		return false
+	}
+
+	var ip net.IP
+
+	if entry.Scope == types.Ip {
+		ip = net.ParseIP(entry.Value)
+	} else {
+		ip, _, _ = net.ParseCIDR(entry.Value)
+	}
+
+	if ip == nil {
+		return false
+	}
+
+	for _, allowed := range ips {
+		if allowed.Equal(ip) {
+			return true
+		}
+	}
+
+	for _, allowed := range ranges {
+		if allowed.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Manager pulls the configured feeds and federation peers at their interval
+type Manager struct {
+	feeds   []*csconfig.FeedCfg
+	peers   []*csconfig.FederationPeerCfg
+	store   Store
+	logger  *log.Entry
+	started bool
+	t       tomb.Tomb
+}
+
+func NewManager(feeds []*csconfig.FeedCfg, peers []*csconfig.FederationPeerCfg, store Store) *Manager {
+	return &Manager{
+		feeds:  feeds,
+		peers:  peers,
+		store:  store,
+		logger: log.WithField("module", "feeds"),
+	}
+}
+
+// track runs a pull and stores its status, even if the pull fails
+func (m *Manager) track(ctx context.Context, key string, pull func(*Status) error) (*Status, error) {
+	status, err := getStatus(ctx, m.store, key)
+	if err != nil || status == nil {
+		status = &Status{}
+	}
+
+	status.LastAttempt = time.Now().UTC()
+	status.Error = ""
+
+	if err := pull(status); err != nil {
+		status.Error = err.Error()
+
+		if err := setStatus(ctx, m.store, key, status); err != nil {
+			m.logger.Errorf("while saving the status of %s: %s", key, err)
+		}
+
+		return status, err
+	}
+
+	status.LastSuccess = status.LastAttempt
+
+	if err := setStatus(ctx, m.store, key, status); err != nil {
+		return status, fmt.Errorf("while saving the status: %w", err)
+	}
+
+	return status, nil
+}
+
+// Update pulls a feed and synchronizes its decisions
+func (m *Manager) Update(ctx context.Context, feed *csconfig.FeedCfg) (*Status, error) {
+	return m.track(ctx, statusKey(types.FeedOrigin, feed.Name), func(status *Status) error {
+		return m.update(ctx, feed, status)
+	})
+}
+
+func (m *Manager) update(ctx context.Context, feed *csconfig.FeedCfg, status *Status) error {
+	body, err := open(ctx, feed)
+	if err != nil {
+		return fmt.Errorf("while fetching: %w", err)
+	}
+	defer body.Close()
+
+	parsed, err := Parse(feed, body)
+	if err != nil {
+		return fmt.Errorf("while parsing: %w", err)
+	}
+
+	// an unreachable or broken feed can look empty, keep the current decisions
+	if len(parsed.Entries) == 0 {
+		return errors.New("no entry in the feed, keeping the current decisions")
+	}
+
+	allowedIPs, allowedRanges, err := m.store.GetAllowlistsContentForAPIC(ctx)
+	if err != nil {
+		return fmt.Errorf("while reading the allowlists: %w", err)
+	}
+
+	scenario := Scenario(feed.Name)
+	decisions := make([]*models.Decision, 0, len(parsed.Entries))
+	status.Allowlisted = 0
+
+	for _, entry := range parsed.Entries {
+		if allowlisted(entry, allowedIPs, allowedRanges) {
+			status.Allowlisted++
+			continue
+		}
+
+		decisions = append(decisions, &models.Decision{
+			Duration: ptr.Of(feed.Duration.String()),
+			Origin:   ptr.Of(types.FeedOrigin),
+			Scenario: ptr.Of(scenario),
+			Scope:    ptr.Of(feed.Scope),
+			Type:     ptr.Of(feed.Type),
+			Value:    ptr.Of(entry.Value),
+			UUID:     uuid.NewString(),
+		})
+
+		if feed.Scope == "" {
+			decisions[len(decisions)-1].Scope = ptr.Of(entry.Scope)
+		}
+	}
+
+	result, err := m.store.SyncFeedDecisions(ctx, scenario, decisions)
+	if err != nil {
+		return fmt.Errorf("while saving the decisions: %w", err)
+	}
+
+	status.Entries = len(parsed.Entries)
+	status.Skipped = parsed.Skipped
+	status.SyncResult = result
+
+	return nil
+}
+
+// loop calls update at each interval, until the tomb is dying
+func (m *Manager) loop(logger *log.Entry, interval time.Duration, update func() (*Status, error)) {
+	defer trace.CatchPanic("lapi/feeds")
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		status, err := update()
+		if err != nil {
+			logger.Errorf("pull failed: %s", err)
+		} else {
+			logger.Infof("%d entries: added %d, refreshed %d, expired %d decisions (%d skipped, %d allowlisted)",
+				status.Entries, status.Added, status.Refreshed, status.Expired, status.Skipped, status.Allowlisted)
+		}
+
+		select {
+		case <-m.t.Dying():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Start pulls each feed and peer in its own goroutine, until Shutdown is called
+func (m *Manager) Start(ctx context.Context) {
+	m.started = len(m.feeds)+len(m.peers) > 0
+
+	for _, feed := range m.feeds {
+		m.t.Go(func() error {
+			m.loop(m.logger.WithField("feed", feed.Name), feed.Interval, func() (*Status, error) {
+				return m.Update(ctx, feed)
+			})
+
+			return nil
+		})
+	}
+
+	for _, peer := range m.peers {
+		m.t.Go(func() error {
+			m.loop(m.logger.WithField("peer", peer.Name), peer.Interval, func() (*Status, error) {
+				return m.UpdatePeer(ctx, peer)
+			})
+
+			return nil
+		})
+	}
+}
+
+func (m *Manager) Shutdown() {
+	// the tomb can't be waited for if it has no goroutine
+	if !m.started {
+		return
+	}
+
+	m.t.Kill(nil)
+
+	if err := m.t.Wait(); err != nil {
+		m.logger.Errorf("while stopping the feeds: %s", err)
+	}
+}
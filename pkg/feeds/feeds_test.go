@@ -0,0 +1,152 @@
+package feeds
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+func newStore(t *testing.T) *database.Client {
+	dbClient, err := database.NewClient(t.Context(), &csconfig.DatabaseCfg{
+		Type:   "sqlite",
+		DbName: "crowdsec",
+		DbPath: ":memory:",
+	})
+	require.NoError(t, err)
+
+	return dbClient
+}
+
+func activeValues(t *testing.T, db *database.Client, scenario string) []string {
+	decisions, err := db.QueryDecisionWithFilter(t.Context(), map[string][]string{
+		"scenarios_containing": {scenario},
+	})
+	require.NoError(t, err)
+
+	values := []string{}
+
+	for _, d := range decisions {
+		assert.Equal(t, types.FeedOrigin, d.Origin)
+		assert.Equal(t, "ban", d.Type)
+
+		values = append(values, d.Value)
+	}
+
+	return values
+}
+
+func TestManagerUpdate(t *testing.T) {
+	ctx := t.Context()
+
+	content := "1.2.3.4\n5.6.7.8\n10.0.0.0/8\n"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(content))
+	}))
+	defer server.Close()
+
+	feed := &csconfig.FeedCfg{
+		Name:    "test",
+		URL:     server.URL,
+		Format:  csconfig.FeedFormatPlain,
+		Headers: map[string]string{"Authorization": "Bearer secret"},
+	}
+	require.NoError(t, feed.Load())
+
+	db := newStore(t)
+
+	list, err := db.CreateAllowList(ctx, "local", "", "", false)
+	require.NoError(t, err)
+
+	_, err = db.AddToAllowlist(ctx, list, []*models.AllowlistItem{{Value: "5.6.7.8"}})
+	require.NoError(t, err)
+
+	m := NewManager([]*csconfig.FeedCfg{feed}, nil, db)
+
+	status, err := m.Update(ctx, feed)
+	require.NoError(t, err)
+	assert.Equal(t, 3, status.Entries)
+	assert.Equal(t, 1, status.Allowlisted)
+	assert.Equal(t, database.SyncResult{Added: 2}, status.SyncResult)
+	assert.ElementsMatch(t, []string{"1.2.3.4", "10.0.0.0/8"}, activeValues(t, db, "feed:test"))
+
+	// one value removed, one added
+	content = "1.2.3.4\n9.9.9.9\n"
+
+	status, err = m.Update(ctx, feed)
+	require.NoError(t, err)
+	assert.Equal(t, database.SyncResult{Added: 1, Refreshed: 1, Expired: 1}, status.SyncResult)
+	assert.ElementsMatch(t, []string{"1.2.3.4", "9.9.9.9"}, activeValues(t, db, "feed:test"))
+
+	// a failed pull keeps the decisions and records the error
+	feed.Headers = nil
+
+	_, err = m.Update(ctx, feed)
+	cstest.RequireErrorContains(t, err, "unexpected HTTP status 401 Unauthorized")
+	assert.ElementsMatch(t, []string{"1.2.3.4", "9.9.9.9"}, activeValues(t, db, "feed:test"))
+
+	status, err = GetStatus(ctx, db, "test")
+	require.NoError(t, err)
+	assert.Contains(t, status.Error, "401")
+	assert.True(t, status.LastAttempt.After(status.LastSuccess))
+	assert.Equal(t, 2, status.Entries)
+
+	// an empty feed keeps the decisions
+	feed.Headers = map[string]string{"Authorization": "Bearer secret"}
+	content = "# nothing\n"
+
+	_, err = m.Update(ctx, feed)
+	cstest.RequireErrorContains(t, err, "no entry in the feed, keeping the current decisions")
+	assert.ElementsMatch(t, []string{"1.2.3.4", "9.9.9.9"}, activeValues(t, db, "feed:test"))
+}
+
+func TestOpenLimits(t *testing.T) {
+	ctx := t.Context()
+
+	release := make(chan struct{})
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/slow" {
+			<-release
+			return
+		}
+
+		_, _ = w.Write(bytes.Repeat([]byte("1.2.3.4\n"), maxFeedSize/8+1))
+	}))
+	defer server.Close()
+	defer close(release)
+
+	feed := &csconfig.FeedCfg{Name: "large", URL: server.URL, Format: csconfig.FeedFormatPlain}
+	require.NoError(t, feed.Load())
+
+	body, err := open(ctx, feed)
+	require.NoError(t, err)
+
+	_, err = io.Copy(io.Discard, body)
+	cstest.RequireErrorContains(t, err, "feed is larger than")
+	require.NoError(t, body.Close())
+
+	feed = &csconfig.FeedCfg{Name: "slow", URL: server.URL + "/slow", Format: csconfig.FeedFormatPlain, Timeout: 100 * time.Millisecond}
+	require.NoError(t, feed.Load())
+
+	_, err = open(ctx, feed)
+	cstest.RequireErrorContains(t, err, "Client.Timeout exceeded")
+}
@@ -0,0 +1,248 @@
+package feeds
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// Entry is a value of the feed, converted to a decision
+type Entry struct {
+	Scope string
+	Value string
+}
+
+// ParseResult is the content of a feed. Skipped counts the values that could not be used.
+type ParseResult struct {
+	Entries []Entry
+	Skipped int
+}
+
+// the MISP attribute types that carry an IP address. For the composite types, the IP is before the pipe.
+var mispIPTypes = []string{"ip-src", "ip-dst", "ip-src|port", "ip-dst|port"}
+
+// matches the comparisons of a STIX pattern, like [ipv4-addr:value = '198.51.100.1']
+var stixComparison = regexp.MustCompile(`(ipv4-addr|ipv6-addr):value\s*(?:=|ISSUBSET)\s*'([^']+)'`)
+
+// addIP appends an IP or a CIDR with the Ip or Range scope
+func (r *ParseResult) addIP(value string) {
+	value = strings.TrimSpace(value)
+
+	if ip := net.ParseIP(value); ip != nil {
+		r.Entries = append(r.Entries, Entry{Scope: types.Ip, Value: ip.String()})
+		return
+	}
+
+	if _, ipNet, err := net.ParseCIDR(value); err == nil {
+		r.Entries = append(r.Entries, Entry{Scope: types.Range, Value: ipNet.String()})
+		return
+	}
+
+	r.Skipped++
+}
+
+// add appends a value with the configured scope, or guesses it for IPs
+func (r *ParseResult) add(value string, scope string) {
+	if scope == "" {
+		r.addIP(value)
+		return
+	}
+
+	value = strings.TrimSpace(value)
+	if value == "" {
+		r.Skipped++
+		return
+	}
+
+	r.Entries = append(r.Entries, Entry{Scope: scope, Value: value})
+}
+
+// Parse reads the content of a feed according to its format
+func Parse(feed *csconfig.FeedCfg, r io.Reader) (*ParseResult, error) {
+	switch feed.Format {
+	case csconfig.FeedFormatPlain:
+		return parsePlain(r, feed.Scope)
+	case csconfig.FeedFormatCSV:
+		return parseCSV(r, feed.Scope, feed.CSVColumn)
+	case csconfig.FeedFormatMISP:
+		return parseMISP(r)
+	case csconfig.FeedFormatSTIX:
+		return parseSTIX(r, time.Now().UTC())
+	default:
+		return nil, fmt.Errorf("unknown feed format '%s'", feed.Format)
+	}
+}
+
+// parsePlain reads one value per line. Comments start with '#' or ';' and anything
+// after the first field is ignored, to accept lists like "1.2.3.0/24 ; SBL123".
+func parsePlain(r io.Reader, scope string) (*ParseResult, error) {
+	res := &ParseResult{}
+
+	scanner := bufio.NewScanner(r)
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if idx := strings.IndexAny(line, "#;"); idx >= 0 {
+			line = line[:idx]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		res.add(fields[0], scope)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// parseCSV reads the values in the given column, or in the first one. When a column
+// is named, the first record must be the header.
+func parseCSV(r io.Reader, scope string, column string) (*ParseResult, error) {
+	res := &ParseResult{}
+
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	idx := 0
+
+	if column != "" {
+		header, err := reader.Read()
+		if err != nil {
+			return nil, fmt.Errorf("while reading the csv header: %w", err)
+		}
+
+		idx = slices.Index(header, column)
+		if idx < 0 {
+			return nil, fmt.Errorf("column '%s' not found in the csv header", column)
+		}
+	}
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if idx >= len(record) {
+			res.Skipped++
+			continue
+		}
+
+		res.add(record[idx], scope)
+	}
+
+	return res, nil
+}
+
+// walkMISP collects the IP attributes anywhere in a MISP export: events from the UI or
+// restSearch, lists of attributes, and attributes of objects
+func walkMISP(node any, res *ParseResult) {
+	switch v := node.(type) {
+	case []any:
+		for _, item := range v {
+			walkMISP(item, res)
+		}
+	case map[string]any:
+		attrType, okType := v["type"].(string)
+		value, okValue := v["value"].(string)
+
+		if okType && okValue && slices.Contains(mispIPTypes, attrType) {
+			if deleted, _ := v["deleted"].(bool); deleted {
+				return
+			}
+
+			ip, _, _ := strings.Cut(value, "|")
+			res.addIP(ip)
+
+			return
+		}
+
+		for _, child := range v {
+			walkMISP(child, res)
+		}
+	}
+}
+
+func parseMISP(r io.Reader) (*ParseResult, error) {
+	var doc any
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid MISP export: %w", err)
+	}
+
+	res := &ParseResult{}
+	walkMISP(doc, res)
+
+	return res, nil
+}
+
+type stixObject struct {
+	Type       string `json:"type"`
+	Pattern    string `json:"pattern"`
+	Value      string `json:"value"`
+	Revoked    bool   `json:"revoked"`
+	ValidUntil string `json:"valid_until"`
+}
+
+// parseSTIX reads a STIX 2.1 bundle, or a TAXII 2.1 envelope which has the same "objects" list.
+// The IPs come from the patterns of the indicators that are still valid, and from the
+// ipv4-addr and ipv6-addr observables.
+func parseSTIX(r io.Reader, now time.Time) (*ParseResult, error) {
+	var doc struct {
+		Objects []stixObject `json:"objects"`
+	}
+
+	if err := json.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("invalid STIX bundle: %w", err)
+	}
+
+	res := &ParseResult{}
+
+	for _, obj := range doc.Objects {
+		switch obj.Type {
+		case "indicator":
+			if obj.Revoked {
+				continue
+			}
+
+			if obj.ValidUntil != "" {
+				validUntil, err := time.Parse(time.RFC3339, obj.ValidUntil)
+				if err == nil && validUntil.Before(now) {
+					continue
+				}
+			}
+
+			for _, match := range stixComparison.FindAllStringSubmatch(obj.Pattern, -1) {
+				res.addIP(match[2])
+			}
+		case "ipv4-addr", "ipv6-addr":
+			res.addIP(obj.Value)
+		}
+	}
+
+	return res, nil
+}
@@ -0,0 +1,134 @@
+package feeds
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name        string
+		feed        csconfig.FeedCfg
+		content     string
+		file        string
+		expected    []Entry
+		skipped     int
+		expectedErr string
+	}{
+		{
+			name: "plain",
+			feed: csconfig.FeedCfg{Format: csconfig.FeedFormatPlain},
+			content: `# comment
+1.2.3.4
+5.6.7.0/24 ; SBL123
+
+2001:db8::1 some description
+not-an-ip
+`,
+			expected: []Entry{
+				{Scope: "Ip", Value: "1.2.3.4"},
+				{Scope: "Range", Value: "5.6.7.0/24"},
+				{Scope: "Ip", Value: "2001:db8::1"},
+			},
+			skipped: 1,
+		},
+		{
+			name:    "plain with scope",
+			feed:    csconfig.FeedCfg{Format: csconfig.FeedFormatPlain, Scope: "Country"},
+			content: "FR\nDE # comment\n",
+			expected: []Entry{
+				{Scope: "Country", Value: "FR"},
+				{Scope: "Country", Value: "DE"},
+			},
+		},
+		{
+			name:    "csv first column",
+			feed:    csconfig.FeedCfg{Format: csconfig.FeedFormatCSV},
+			content: "ip,reason\n1.2.3.4,scanner\n# comment\n5.6.7.8,bruteforce\n",
+			expected: []Entry{
+				{Scope: "Ip", Value: "1.2.3.4"},
+				{Scope: "Ip", Value: "5.6.7.8"},
+			},
+			skipped: 1, // the header
+		},
+		{
+			name:    "csv named column",
+			feed:    csconfig.FeedCfg{Format: csconfig.FeedFormatCSV, CSVColumn: "address"},
+			content: "first_seen,address\n2024-01-01,1.2.3.4\n2024-01-02\n2024-01-03, 10.0.0.0/8\n",
+			expected: []Entry{
+				{Scope: "Ip", Value: "1.2.3.4"},
+				{Scope: "Range", Value: "10.0.0.0/8"},
+			},
+			skipped: 1,
+		},
+		{
+			name:        "csv missing column",
+			feed:        csconfig.FeedCfg{Format: csconfig.FeedFormatCSV, CSVColumn: "address"},
+			content:     "ip\n1.2.3.4\n",
+			expectedErr: "column 'address' not found in the csv header",
+		},
+		{
+			name: "misp",
+			feed: csconfig.FeedCfg{Format: csconfig.FeedFormatMISP},
+			file: "testdata/misp.json",
+			expected: []Entry{
+				{Scope: "Ip", Value: "192.0.2.1"},
+				{Scope: "Ip", Value: "192.0.2.2"},
+				{Scope: "Ip", Value: "2001:db8::1"},
+			},
+			skipped: 1,
+		},
+		{
+			name:        "misp invalid",
+			feed:        csconfig.FeedCfg{Format: csconfig.FeedFormatMISP},
+			content:     "ip-src,1.2.3.4",
+			expectedErr: "invalid MISP export",
+		},
+		{
+			name: "stix",
+			feed: csconfig.FeedCfg{Format: csconfig.FeedFormatSTIX},
+			file: "testdata/stix.json",
+			expected: []Entry{
+				{Scope: "Ip", Value: "198.51.100.1"},
+				{Scope: "Range", Value: "203.0.113.0/24"},
+				{Scope: "Ip", Value: "2001:db8::2"},
+			},
+		},
+		{
+			name:        "unknown format",
+			feed:        csconfig.FeedCfg{Format: "xml"},
+			expectedErr: "unknown feed format 'xml'",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			content := tc.content
+
+			if tc.file != "" {
+				raw, err := os.ReadFile(tc.file)
+				require.NoError(t, err)
+
+				content = string(raw)
+			}
+
+			res, err := Parse(&tc.feed, strings.NewReader(content))
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+
+			if tc.expectedErr != "" {
+				return
+			}
+
+			assert.ElementsMatch(t, tc.expected, res.Entries)
+			assert.Equal(t, tc.skipped, res.Skipped)
+		})
+	}
+}
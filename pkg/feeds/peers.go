@@ -0,0 +1,138 @@
+package feeds
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/apiclient"
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+// newPeerClient returns an API client that authenticates to the peer with a TLS client certificate.
+// It's created for each pull, so that renewed certificates are taken into account.
+func newPeerClient(peer *csconfig.FederationPeerCfg) (*apiclient.ApiClient, error) {
+	cert, err := tls.LoadX509KeyPair(peer.CertPath, peer.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("while loading the client certificate: %w", err)
+	}
+
+	tlsConfig := &tls.Config{
+		Certificates:       []tls.Certificate{cert},
+		InsecureSkipVerify: peer.InsecureSkipVerify, //nolint:gosec // explicitly configured
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	if peer.CACertPath != "" {
+		caCert, err := os.ReadFile(peer.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("while loading the CA certificate: %w", err)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		if !pool.AppendCertsFromPEM(caCert) {
+			return nil, fmt.Errorf("no certificate found in %s", peer.CACertPath)
+		}
+
+		tlsConfig.RootCAs = pool
+	}
+
+	transport, ok := http.DefaultTransport.(*http.Transport)
+	if !ok {
+		return nil, errors.New("unexpected default transport")
+	}
+
+	transport = transport.Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	u, err := url.Parse(strings.TrimSuffix(peer.URL, "/") + "/")
+	if err != nil {
+		return nil, err
+	}
+
+	return apiclient.NewDefaultClient(u, "v1", "", &http.Client{
+		Transport: transport,
+		Timeout:   peer.Timeout,
+	})
+}
+
+// UpdatePeer pulls the decisions published by a federation peer and synchronizes them
+func (m *Manager) UpdatePeer(ctx context.Context, peer *csconfig.FederationPeerCfg) (*Status, error) {
+	return m.track(ctx, statusKey("peer", peer.Name), func(status *Status) error {
+		return m.updatePeer(ctx, peer, status)
+	})
+}
+
+func (m *Manager) updatePeer(ctx context.Context, peer *csconfig.FederationPeerCfg, status *Status) error {
+	client, err := newPeerClient(peer)
+	if err != nil {
+		return err
+	}
+
+	published, _, err := client.Federation.GetDecisions(ctx)
+	if err != nil {
+		return fmt.Errorf("while fetching: %w", err)
+	}
+
+	allowedIPs, allowedRanges, err := m.store.GetAllowlistsContentForAPIC(ctx)
+	if err != nil {
+		return fmt.Errorf("while reading the allowlists: %w", err)
+	}
+
+	prefix := database.PeerScenarioPrefix(peer.Name)
+	decisions := make([]*models.Decision, 0, len(published))
+	status.Skipped = 0
+	status.Allowlisted = 0
+
+	for _, d := range published {
+		// a peer must not send back the decisions it received from us, or from another peer
+		if d.Duration == nil || d.Scope == nil || d.Value == nil || d.Type == nil || d.Scenario == nil ||
+			d.Origin == nil || *d.Origin == types.FederationOrigin {
+			status.Skipped++
+			continue
+		}
+
+		if allowlisted(Entry{Scope: *d.Scope, Value: *d.Value}, allowedIPs, allowedRanges) {
+			status.Allowlisted++
+			continue
+		}
+
+		// the origin of the peer is kept in the scenario, since the decision is now of origin federation
+		decisions = append(decisions, &models.Decision{
+			Duration: d.Duration,
+			Origin:   ptr.Of(types.FederationOrigin),
+			Scenario: ptr.Of(prefix + *d.Origin + "/" + *d.Scenario),
+			Scope:    d.Scope,
+			Type:     d.Type,
+			Value:    d.Value,
+			UUID:     uuid.NewString(),
+		})
+	}
+
+	result, err := m.store.SyncPeerDecisions(ctx, peer.Name, decisions)
+	if err != nil {
+		return fmt.Errorf("while saving the decisions: %w", err)
+	}
+
+	status.Entries = len(published)
+	status.SyncResult = result
+
+	return nil
+}
@@ -0,0 +1,188 @@
+package feeds
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+	"github.com/crowdsecurity/go-cs-lib/ptr"
+
+	"github.com/crowdsecurity/crowdsec/pkg/csconfig"
+	"github.com/crowdsecurity/crowdsec/pkg/database"
+	"github.com/crowdsecurity/crowdsec/pkg/models"
+	"github.com/crowdsecurity/crowdsec/pkg/types"
+)
+
+type testCert struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	der  []byte
+}
+
+func newTestCert(t *testing.T, tmpl *x509.Certificate, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	tmpl.NotBefore = time.Now().Add(-time.Hour)
+	tmpl.NotAfter = time.Now().Add(time.Hour)
+
+	parentCert, parentKey := tmpl, key
+	if parent != nil {
+		parentCert, parentKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, parentCert, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return &testCert{cert: cert, key: key, der: der}
+}
+
+func (c *testCert) write(t *testing.T, dir string, name string) (string, string) {
+	certPath := filepath.Join(dir, name+".pem")
+	keyPath := filepath.Join(dir, name+"-key.pem")
+
+	keyDer, err := x509.MarshalECPrivateKey(c.key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: c.der}), 0o600))
+	require.NoError(t, os.WriteFile(keyPath, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600))
+
+	return certPath, keyPath
+}
+
+func TestManagerUpdatePeer(t *testing.T) {
+	ctx := t.Context()
+	dir := t.TempDir()
+
+	ca := newTestCert(t, &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}, nil)
+
+	server := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(2),
+		Subject:      pkix.Name{CommonName: "lapi-dc2"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}, ca)
+
+	client := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(3),
+		Subject:      pkix.Name{CommonName: "lapi-dc1", OrganizationalUnit: []string{"peer"}},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, ca)
+
+	caPath, _ := ca.write(t, dir, "ca")
+	certPath, keyPath := client.write(t, dir, "client")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.cert)
+
+	published := []*models.Decision{
+		{
+			Duration: ptr.Of("3h59m0s"),
+			Origin:   ptr.Of(types.CrowdSecOrigin),
+			Scenario: ptr.Of("crowdsecurity/ssh-bf"),
+			Scope:    ptr.Of(types.Ip),
+			Type:     ptr.Of("ban"),
+			Value:    ptr.Of("1.2.3.4"),
+		},
+		{
+			// received by the peer from another LAPI
+			Duration: ptr.Of("1h"),
+			Origin:   ptr.Of(types.FederationOrigin),
+			Scenario: ptr.Of("federation:dc3:crowdsec/crowdsecurity/http-probing"),
+			Scope:    ptr.Of(types.Ip),
+			Type:     ptr.Of("ban"),
+			Value:    ptr.Of("5.6.7.8"),
+		},
+	}
+
+	srv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/federation/decisions", r.URL.Path)
+		require.NotEmpty(t, r.TLS.VerifiedChains)
+		assert.Equal(t, []string{"peer"}, r.TLS.VerifiedChains[0][0].Subject.OrganizationalUnit)
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(published)
+	}))
+	srv.TLS = &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{server.der}, PrivateKey: server.key}},
+		ClientCAs:    pool,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS12,
+	}
+	srv.StartTLS()
+	defer srv.Close()
+
+	peer := &csconfig.FederationPeerCfg{
+		Name:       "dc2",
+		URL:        srv.URL,
+		CACertPath: caPath,
+		CertPath:   certPath,
+		KeyPath:    keyPath,
+	}
+	require.NoError(t, peer.Load())
+
+	db := newStore(t)
+	m := NewManager(nil, []*csconfig.FederationPeerCfg{peer}, db)
+
+	status, err := m.UpdatePeer(ctx, peer)
+	require.NoError(t, err)
+	assert.Equal(t, 2, status.Entries)
+	assert.Equal(t, 1, status.Skipped)
+	assert.Equal(t, database.SyncResult{Added: 1}, status.SyncResult)
+
+	decisions, err := db.QueryDecisionWithFilter(ctx, map[string][]string{"origins": {types.FederationOrigin}})
+	require.NoError(t, err)
+	require.Len(t, decisions, 1)
+	assert.Equal(t, "1.2.3.4", decisions[0].Value)
+	assert.Equal(t, "federation:dc2:crowdsec/crowdsecurity/ssh-bf", decisions[0].Scenario)
+	assert.WithinDuration(t, time.Now().Add(3*time.Hour+59*time.Minute), *decisions[0].Until, time.Minute)
+
+	// the decision is no longer published by the peer
+	published = slices.Delete(published, 0, 1)
+
+	status, err = m.UpdatePeer(ctx, peer)
+	require.NoError(t, err)
+	assert.Equal(t, database.SyncResult{Expired: 1}, status.SyncResult)
+
+	// a certificate not signed by the shared CA is refused
+	rogue := newTestCert(t, &x509.Certificate{
+		SerialNumber: big.NewInt(4),
+		Subject:      pkix.Name{CommonName: "rogue", OrganizationalUnit: []string{"peer"}},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}, nil)
+	peer.CertPath, peer.KeyPath = rogue.write(t, dir, "rogue")
+
+	_, err = m.UpdatePeer(ctx, peer)
+	cstest.RequireErrorContains(t, err, "while fetching")
+
+	status, err = GetPeerStatus(ctx, db, "dc2")
+	require.NoError(t, err)
+	assert.Contains(t, status.Error, "while fetching")
+}
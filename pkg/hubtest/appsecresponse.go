@@ -0,0 +1,131 @@
+package hubtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+
+	"github.com/crowdsecurity/crowdsec/pkg/appsec"
+)
+
+// AppsecResponseTest is an upstream response forwarded to the appsec component like a remediation
+// component would do, to test the rules of the response phases without a web server.
+type AppsecResponseTest struct {
+	Name        string            `yaml:"name"`
+	URI         string            `yaml:"uri"`
+	Method      string            `yaml:"method,omitempty"`
+	StatusCode  int               `yaml:"status_code,omitempty"`
+	Headers     map[string]string `yaml:"headers,omitempty"`
+	Body        string            `yaml:"body,omitempty"`
+	BodyFile    string            `yaml:"body_file,omitempty"` // relative to the test directory
+	ExpectBlock bool              `yaml:"expect_block,omitempty"`
+}
+
+func (t *HubTestItem) RunWithResponseTests() error {
+	crowdsecDaemon, err := t.startAppsecRuntime()
+	if err != nil {
+		return err
+	}
+
+	defer crowdsecDaemon.Process.Kill()
+
+	t.Success = t.runResponseTests()
+
+	return nil
+}
+
+// runResponseTests returns true if all the response tests had the expected outcome
+func (t *HubTestItem) runResponseTests() bool {
+	client := &http.Client{Timeout: 10 * time.Second}
+	success := true
+
+	for idx, test := range t.Config.ResponseTests {
+		name := test.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", idx)
+		}
+
+		blocked, err := t.sendResponse(client, &test)
+		if err != nil {
+			t.ErrorsList = append(t.ErrorsList, fmt.Sprintf("response test %s: %s", name, err))
+			success = false
+
+			continue
+		}
+
+		if blocked != test.ExpectBlock {
+			t.ErrorsList = append(t.ErrorsList, fmt.Sprintf("response test %s: expected blocked=%t, got blocked=%t", name, test.ExpectBlock, blocked))
+			success = false
+		}
+	}
+
+	if !success {
+		for _, e := range t.ErrorsList {
+			log.Errorf("Appsec test %s failed: %s", t.Name, e)
+		}
+
+		t.dumpCrowdsecLog()
+	}
+
+	return success
+}
+
+// sendResponse forwards the response to the appsec component and returns true if it was blocked
+func (t *HubTestItem) sendResponse(client *http.Client, test *AppsecResponseTest) (bool, error) {
+	body := []byte(test.Body)
+
+	if test.BodyFile != "" {
+		var err error
+
+		body, err = os.ReadFile(filepath.Join(t.Path, test.BodyFile))
+		if err != nil {
+			return false, fmt.Errorf("unable to read body file: %w", err)
+		}
+	}
+
+	method := test.Method
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	statusCode := test.StatusCode
+	if statusCode == 0 {
+		statusCode = http.StatusOK
+	}
+
+	req, err := http.NewRequest(http.MethodPost, "http://"+t.AppSecHost+"/", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	for k, v := range test.Headers {
+		req.Header.Set(k, v)
+	}
+
+	req.Header.Set(appsec.IPHeaderName, "127.0.0.1")
+	req.Header.Set(appsec.URIHeaderName, test.URI)
+	req.Header.Set(appsec.VerbHeaderName, method)
+	req.Header.Set(appsec.APIKeyHeaderName, TestBouncerApiKey)
+	req.Header.Set(appsec.ResponseCodeHeaderName, strconv.Itoa(statusCode))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	var appsecResponse appsec.BodyResponse
+
+	if err := json.NewDecoder(resp.Body).Decode(&appsecResponse); err != nil {
+		return false, fmt.Errorf("unable to decode appsec response (status %d): %w", resp.StatusCode, err)
+	}
+
+	return appsecResponse.Action != appsec.AllowRemediation, nil
+}
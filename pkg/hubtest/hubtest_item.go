@@ -24,18 +24,19 @@ import (
 var downloadMutex sync.Mutex
 
 type HubTestItemConfig struct {
-	Parsers               []string            `yaml:"parsers,omitempty"`
-	Scenarios             []string            `yaml:"scenarios,omitempty"`
-	PostOverflows         []string            `yaml:"postoverflows,omitempty"`
-	AppsecRules           []string            `yaml:"appsec-rules,omitempty"`
-	NucleiTemplate        string              `yaml:"nuclei_template,omitempty"`
-	ExpectedNucleiFailure bool                `yaml:"expect_failure,omitempty"`
-	LogFile               string              `yaml:"log_file,omitempty"`
-	LogType               string              `yaml:"log_type,omitempty"`
-	Labels                map[string]string   `yaml:"labels,omitempty"`
-	IgnoreParsers         bool                `yaml:"ignore_parsers,omitempty"`   // if we test a scenario, we don't want to assert on Parser
-	OverrideStatics       []parser.ExtraField `yaml:"override_statics,omitempty"` // Allow to override statics. Executed before s00
-	OwnDataDir            bool                `yaml:"own_data_dir,omitempty"`     // Don't share dataDir with the other tests
+	Parsers               []string             `yaml:"parsers,omitempty"`
+	Scenarios             []string             `yaml:"scenarios,omitempty"`
+	PostOverflows         []string             `yaml:"postoverflows,omitempty"`
+	AppsecRules           []string             `yaml:"appsec-rules,omitempty"`
+	NucleiTemplate        string               `yaml:"nuclei_template,omitempty"`
+	ExpectedNucleiFailure bool                 `yaml:"expect_failure,omitempty"`
+	ResponseTests         []AppsecResponseTest `yaml:"response_tests,omitempty"`
+	LogFile               string               `yaml:"log_file,omitempty"`
+	LogType               string               `yaml:"log_type,omitempty"`
+	Labels                map[string]string    `yaml:"labels,omitempty"`
+	IgnoreParsers         bool                 `yaml:"ignore_parsers,omitempty"`   // if we test a scenario, we don't want to assert on Parser
+	OverrideStatics       []parser.ExtraField  `yaml:"override_statics,omitempty"` // Allow to override statics. Executed before s00
+	OwnDataDir            bool                 `yaml:"own_data_dir,omitempty"`     // Don't share dataDir with the other tests
 }
 
 type HubTestItem struct {
@@ -284,14 +285,13 @@ func (t *HubTestItem) Clean() {
 	}
 }
 
-func (t *HubTestItem) RunWithNucleiTemplate() error {
+// startAppsecRuntime registers the test machine and bouncer, then starts crowdsec and waits for the appsec component
+func (t *HubTestItem) startAppsecRuntime() (*exec.Cmd, error) {
 	testPath := filepath.Join(t.HubTestPath, t.Name)
 	if _, err := os.Stat(testPath); os.IsNotExist(err) {
-		return fmt.Errorf("test '%s' doesn't exist in '%s', exiting", t.Name, t.HubTestPath)
+		return nil, fmt.Errorf("test '%s' doesn't exist in '%s', exiting", t.Name, t.HubTestPath)
 	}
 
-	crowdsecLogFile := filepath.Join(t.RuntimePath, "log", "crowdsec.log")
-
 	// machine add
 	cmdArgs := []string{"-c", t.RuntimeConfigFilePath, "machines", "add", "testMachine", "--force", "--auto"}
 	cscliRegisterCmd := exec.Command(t.CscliPath, cmdArgs...)
@@ -302,7 +302,7 @@ func (t *HubTestItem) RunWithNucleiTemplate() error {
 	if err != nil {
 		if !strings.Contains(string(output), "unable to create machine: user 'testMachine': user already exist") {
 			fmt.Println(string(output))
-			return fmt.Errorf("fail to run '%s' for test '%s': %w", cscliRegisterCmd.String(), t.Name, err)
+			return nil, fmt.Errorf("fail to run '%s' for test '%s': %w", cscliRegisterCmd.String(), t.Name, err)
 		}
 	}
 
@@ -316,7 +316,7 @@ func (t *HubTestItem) RunWithNucleiTemplate() error {
 	if err != nil {
 		if !strings.Contains(string(output), "unable to create bouncer: bouncer appsectests already exists") {
 			fmt.Println(string(output))
-			return fmt.Errorf("fail to run '%s' for test '%s': %w", cscliRegisterCmd.String(), t.Name, err)
+			return nil, fmt.Errorf("fail to run '%s' for test '%s': %w", cscliRegisterCmd.String(), t.Name, err)
 		}
 	}
 
@@ -326,21 +326,42 @@ func (t *HubTestItem) RunWithNucleiTemplate() error {
 	crowdsecDaemon.Dir = testPath
 	crowdsecDaemon.Env = []string{"TESTDIR="+testPath, "DATADIR="+t.RuntimeHubConfig.InstallDataDir, "TZ=UTC"}
 
-	crowdsecDaemon.Start()
+	if err = crowdsecDaemon.Start(); err != nil {
+		return nil, fmt.Errorf("unable to start crowdsec: %w", err)
+	}
 
 	// wait for the appsec port to be available
 	if _, err = IsAlive(t.AppSecHost); err != nil {
-		crowdsecLog, err2 := os.ReadFile(crowdsecLogFile)
-		if err2 != nil {
-			log.Errorf("unable to read crowdsec log file '%s': %s", crowdsecLogFile, err)
-		} else {
-			log.Errorf("crowdsec log file '%s'", crowdsecLogFile)
-			log.Errorf("%s\n", string(crowdsecLog))
-		}
+		t.dumpCrowdsecLog()
+		crowdsecDaemon.Process.Kill()
 
-		return fmt.Errorf("appsec is down: %w", err)
+		return nil, fmt.Errorf("appsec is down: %w", err)
 	}
 
+	return crowdsecDaemon, nil
+}
+
+func (t *HubTestItem) dumpCrowdsecLog() {
+	crowdsecLogFile := filepath.Join(t.RuntimePath, "log", "crowdsec.log")
+
+	crowdsecLog, err := os.ReadFile(crowdsecLogFile)
+	if err != nil {
+		log.Errorf("unable to read crowdsec log file '%s': %s", crowdsecLogFile, err)
+		return
+	}
+
+	log.Errorf("crowdsec log file '%s'", crowdsecLogFile)
+	log.Errorf("%s\n", string(crowdsecLog))
+}
+
+func (t *HubTestItem) RunWithNucleiTemplate() error {
+	crowdsecDaemon, err := t.startAppsecRuntime()
+	if err != nil {
+		return err
+	}
+
+	defer crowdsecDaemon.Process.Kill()
+
 	// check if the target is available
 	nucleiTargetParsedURL, err := url.Parse(t.NucleiTargetHost)
 	if err != nil {
@@ -373,32 +394,20 @@ func (t *HubTestItem) RunWithNucleiTemplate() error {
 			t.Success = true
 		} else {
 			log.Errorf("Appsec test %s failed:  %s", t.Name, err)
-
-			crowdsecLog, err := os.ReadFile(crowdsecLogFile)
-			if err != nil {
-				log.Errorf("unable to read crowdsec log file '%s': %s", crowdsecLogFile, err)
-			} else {
-				log.Errorf("crowdsec log file '%s'", crowdsecLogFile)
-				log.Errorf("%s\n", string(crowdsecLog))
-			}
+			t.dumpCrowdsecLog()
 		}
 	} else {
 		if err == nil {
 			t.Success = true
 		} else {
 			log.Errorf("Appsec test %s failed:  %s", t.Name, err)
-
-			crowdsecLog, err := os.ReadFile(crowdsecLogFile)
-			if err != nil {
-				log.Errorf("unable to read crowdsec log file '%s': %s", crowdsecLogFile, err)
-			} else {
-				log.Errorf("crowdsec log file '%s'", crowdsecLogFile)
-				log.Errorf("%s\n", string(crowdsecLog))
-			}
+			t.dumpCrowdsecLog()
 		}
 	}
 
-	crowdsecDaemon.Process.Kill()
+	if t.Success && len(t.Config.ResponseTests) > 0 {
+		t.Success = t.runResponseTests()
+	}
 
 	return nil
 }
@@ -628,5 +637,9 @@ func (t *HubTestItem) Run(ctx context.Context, patternDir string) error {
 		return t.RunWithNucleiTemplate()
 	}
 
-	return fmt.Errorf("log file or nuclei template must be set in '%s'", t.Name)
+	if len(t.Config.ResponseTests) > 0 {
+		return t.RunWithResponseTests()
+	}
+
+	return fmt.Errorf("log file, nuclei template or response tests must be set in '%s'", t.Name)
 }
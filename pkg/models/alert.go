@@ -28,6 +28,9 @@ type Alert struct {
 	// Read Only: true
 	CreatedAt string `json:"created_at,omitempty"`
 
+	// cti
+	Cti *CTIInfo `json:"cti,omitempty"`
+
 	// decisions
 	Decisions []*Decision `json:"decisions"`
 
@@ -105,6 +108,10 @@ func (m *Alert) Validate(formats strfmt.Registry) error {
 		res = append(res, err)
 	}
 
+	if err := m.validateCti(formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.validateDecisions(formats); err != nil {
 		res = append(res, err)
 	}
@@ -172,6 +179,25 @@ func (m *Alert) validateCapacity(formats strfmt.Registry) error {
 	return nil
 }
 
+func (m *Alert) validateCti(formats strfmt.Registry) error {
+	if swag.IsZero(m.Cti) { // not required
+		return nil
+	}
+
+	if m.Cti != nil {
+		if err := m.Cti.Validate(formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("cti")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("cti")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Alert) validateDecisions(formats strfmt.Registry) error {
 	if swag.IsZero(m.Decisions) { // not required
 		return nil
@@ -351,6 +377,10 @@ func (m *Alert) ContextValidate(ctx context.Context, formats strfmt.Registry) er
 		res = append(res, err)
 	}
 
+	if err := m.contextValidateCti(ctx, formats); err != nil {
+		res = append(res, err)
+	}
+
 	if err := m.contextValidateDecisions(ctx, formats); err != nil {
 		res = append(res, err)
 	}
@@ -394,6 +424,27 @@ func (m *Alert) contextValidateCreatedAt(ctx context.Context, formats strfmt.Reg
 	return nil
 }
 
+func (m *Alert) contextValidateCti(ctx context.Context, formats strfmt.Registry) error {
+
+	if m.Cti != nil {
+
+		if swag.IsZero(m.Cti) { // not required
+			return nil
+		}
+
+		if err := m.Cti.ContextValidate(ctx, formats); err != nil {
+			if ve, ok := err.(*errors.Validation); ok {
+				return ve.ValidateName("cti")
+			} else if ce, ok := err.(*errors.CompositeError); ok {
+				return ce.ValidateName("cti")
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
 func (m *Alert) contextValidateDecisions(ctx context.Context, formats strfmt.Registry) error {
 
 	for i := 0; i < len(m.Decisions); i++ {
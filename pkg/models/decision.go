@@ -23,6 +23,9 @@ type Decision struct {
 	// Required: true
 	Duration *string `json:"duration"`
 
+	// the escalation step (starting at 1) applied by the profile to compute the duration, 0 if the profile has no escalation policy
+	EscalationStep int64 `json:"escalation_step,omitempty"`
+
 	// (only relevant for GET ops) the unique id
 	// Read Only: true
 	ID int64 `json:"id,omitempty"`
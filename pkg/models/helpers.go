@@ -39,6 +39,70 @@ func (a *Alert) GetEventsCount() int32 {
 	return *a.EventsCount
 }
 
+func (a *Alert) GetCountry() string {
+	if a.Source == nil {
+		return ""
+	}
+
+	return a.Source.Cn
+}
+
+// GetOrigin returns the origin of the first decision (crowdsec, cscli, console...)
+func (a *Alert) GetOrigin() string {
+	for _, decision := range a.Decisions {
+		if decision.Origin != nil {
+			return *decision.Origin
+		}
+	}
+
+	return ""
+}
+
+// GetSeverity ranks the alert by its strongest decision: "high" for a ban,
+// "medium" for any other remediation and "low" if there is no decision
+func (a *Alert) GetSeverity() string {
+	severity := "low"
+
+	for _, decision := range a.Decisions {
+		if decision.Type == nil {
+			continue
+		}
+
+		if *decision.Type == "ban" {
+			return "high"
+		}
+
+		severity = "medium"
+	}
+
+	return severity
+}
+
+// GetReputation returns the CTI reputation of the source, or "" if the alert was not enriched
+func (a *Alert) GetReputation() string {
+	if a.Cti == nil {
+		return ""
+	}
+
+	return a.Cti.Reputation
+}
+
+func (a *Alert) GetBehaviors() []string {
+	if a.Cti == nil {
+		return nil
+	}
+
+	return a.Cti.Behaviors
+}
+
+func (a *Alert) GetClassifications() []string {
+	if a.Cti == nil {
+		return nil
+	}
+
+	return a.Cti.Classifications
+}
+
 func (e *Event) GetMeta(key string) string {
 	for _, meta := range e.Meta {
 		if meta.Key == key {
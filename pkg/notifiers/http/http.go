@@ -0,0 +1,237 @@
+// Package httpnotifier sends the notifications to a webhook.
+package httpnotifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"gopkg.in/yaml.v3"
+
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+type PluginConfig struct {
+	Name                string            `yaml:"name"`
+	URL                 string            `yaml:"url"`
+	UnixSocket          string            `yaml:"unix_socket"`
+	Headers             map[string]string `yaml:"headers"`
+	SkipTLSVerification bool              `yaml:"skip_tls_verification"`
+	Method              string            `yaml:"method"`
+	LogLevel            *string           `yaml:"log_level"`
+	Client              *http.Client      `yaml:"-"`
+	CertPath            string            `yaml:"cert_path"`
+	KeyPath             string            `yaml:"key_path"`
+	CAPath              string            `yaml:"ca_cert_path"`
+	Signing             *SigningConfig    `yaml:"signing"`
+	SplitAlerts         bool              `yaml:"split_alerts"`
+	OutputMode          string            `yaml:"output_mode"`
+	ResponsePolicy      ResponsePolicy    `yaml:"response_policy"`
+
+	urlTemplate     *template.Template
+	headerTemplates map[string]*template.Template
+}
+
+type Notifier struct {
+	protobufs.UnimplementedNotifierServer
+	PluginConfigByName map[string]PluginConfig
+	logger             hclog.Logger
+	progress           *deliveryProgress
+}
+
+func New(logger hclog.Logger) *Notifier {
+	return &Notifier{
+		PluginConfigByName: make(map[string]PluginConfig),
+		logger:             logger,
+		progress:           newDeliveryProgress(),
+	}
+}
+
+func getCertPool(logger hclog.Logger, caPath string) (*x509.CertPool, error) {
+	cp, err := x509.SystemCertPool()
+	if err != nil {
+		return nil, fmt.Errorf("unable to load system CA certificates: %w", err)
+	}
+
+	if cp == nil {
+		cp = x509.NewCertPool()
+	}
+
+	if caPath == "" {
+		return cp, nil
+	}
+
+	logger.Info(fmt.Sprintf("Using CA cert '%s'", caPath))
+
+	caCert, err := os.ReadFile(caPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load CA certificate '%s': %w", caPath, err)
+	}
+
+	cp.AppendCertsFromPEM(caCert)
+
+	return cp, nil
+}
+
+func getTLSClient(logger hclog.Logger, c *PluginConfig) error {
+	caCertPool, err := getCertPool(logger, c.CAPath)
+	if err != nil {
+		return err
+	}
+
+	tlsConfig := &tls.Config{
+		RootCAs:            caCertPool,
+		InsecureSkipVerify: c.SkipTLSVerification,
+	}
+
+	if c.CertPath != "" && c.KeyPath != "" {
+		logger.Info(fmt.Sprintf("Using client certificate '%s' and key '%s'", c.CertPath, c.KeyPath))
+
+		cert, err := tls.LoadX509KeyPair(c.CertPath, c.KeyPath)
+		if err != nil {
+			return fmt.Errorf("unable to load client certificate '%s' and key '%s': %w", c.CertPath, c.KeyPath, err)
+		}
+
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: tlsConfig,
+	}
+
+	if c.UnixSocket != "" {
+		logger.Info(fmt.Sprintf("Using socket '%s'", c.UnixSocket))
+
+		transport.DialContext = func(_ context.Context, _, _ string) (net.Conn, error) {
+			return net.Dial("unix", strings.TrimSuffix(c.UnixSocket, "/"))
+		}
+	}
+
+	c.Client = &http.Client{
+		Transport: transport,
+	}
+
+	return nil
+}
+
+func (s *Notifier) Notify(ctx context.Context, notification *protobufs.Notification) (*protobufs.Empty, error) {
+	if _, ok := s.PluginConfigByName[notification.Name]; !ok {
+		return nil, fmt.Errorf("invalid plugin config name %s", notification.Name)
+	}
+
+	cfg := s.PluginConfigByName[notification.Name]
+
+	logger := s.logger
+
+	if cfg.LogLevel != nil && *cfg.LogLevel != "" {
+		logger.SetLevel(hclog.LevelFromString(*cfg.LogLevel))
+	}
+
+	logger.Info(fmt.Sprintf("received signal for %s config", notification.Name))
+
+	now := time.Now()
+
+	payloads, err := cfg.buildPayloads(notification.Text, now)
+	if err != nil {
+		return nil, err
+	}
+
+	// with split_alerts, a failed request doesn't make the broker send the other ones again
+	key := progressKey(notification.Name, notification.Text)
+
+	for idx, p := range payloads {
+		if s.progress.isSent(key, idx) {
+			logger.Debug(fmt.Sprintf("request %d/%d already sent", idx+1, len(payloads)))
+			continue
+		}
+
+		if err := cfg.send(ctx, logger, p, now); err != nil {
+			return nil, err
+		}
+
+		if len(payloads) > 1 {
+			s.progress.markSent(key, idx, now)
+		}
+	}
+
+	s.progress.done(key, now)
+
+	return &protobufs.Empty{}, nil
+}
+
+func (c *PluginConfig) send(ctx context.Context, logger hclog.Logger, p payload, now time.Time) error {
+	url, headers, err := c.requestParams(p, now)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, c.Method, url, bytes.NewReader(p.body))
+	if err != nil {
+		return err
+	}
+
+	for headerName, headerValue := range headers {
+		logger.Debug(fmt.Sprintf("adding header %s: %s", headerName, headerValue))
+		request.Header.Add(headerName, headerValue)
+	}
+
+	logger.Debug(fmt.Sprintf("making HTTP %s call to %s with body %s", c.Method, url, string(p.body)))
+
+	resp, err := c.Client.Do(request)
+	if err != nil {
+		logger.Error(fmt.Sprintf("Failed to make HTTP request : %s", err))
+		return err
+	}
+	defer resp.Body.Close()
+
+	respData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response body got error %w", err)
+	}
+
+	logger.Debug(fmt.Sprintf("got response %s", string(respData)))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if c.ResponsePolicy.shouldRetry(resp.StatusCode) {
+			return &errRetry{code: resp.StatusCode, body: respData}
+		}
+
+		logger.Warn(fmt.Sprintf("HTTP server returned non 200 status code: %d", resp.StatusCode))
+		logger.Debug(fmt.Sprintf("HTTP server returned body: %s", string(respData)))
+	}
+
+	return nil
+}
+
+func (s *Notifier) Configure(ctx context.Context, config *protobufs.Config) (*protobufs.Empty, error) {
+	d := PluginConfig{}
+
+	err := yaml.Unmarshal(config.Config, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	err = getTLSClient(s.logger, &d)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = d.compile(); err != nil {
+		return nil, fmt.Errorf("%s: %w", d.Name, err)
+	}
+
+	s.PluginConfigByName[d.Name] = d
+	s.logger.Debug(fmt.Sprintf("HTTP plugin '%s' use URL '%s'", d.Name, d.URL))
+
+	return &protobufs.Empty{}, err
+}
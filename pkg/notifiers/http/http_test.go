@@ -0,0 +1,276 @@
+package httpnotifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/crowdsecurity/go-cs-lib/cstest"
+
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+type receivedRequest struct {
+	path    string
+	headers http.Header
+	body    []byte
+}
+
+type recorder struct {
+	mu       sync.Mutex
+	requests []receivedRequest
+	status   int
+}
+
+func newRecorder(t *testing.T, status int) (*recorder, *httptest.Server) {
+	rec := &recorder{status: status}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, receivedRequest{path: r.URL.Path, headers: r.Header, body: body})
+		status := rec.status
+		rec.mu.Unlock()
+
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(srv.Close)
+
+	return rec, srv
+}
+
+func (r *recorder) setStatus(status int) {
+	r.mu.Lock()
+	r.status = status
+	r.mu.Unlock()
+}
+
+func configure(t *testing.T, config string) *Notifier {
+	t.Helper()
+
+	p := New(hclog.NewNullLogger())
+
+	_, err := p.Configure(t.Context(), &protobufs.Config{Config: []byte(config)})
+	require.NoError(t, err)
+
+	return p
+}
+
+func notify(p *Notifier, text string) error {
+	_, err := p.Notify(context.Background(), &protobufs.Notification{Name: "webhook", Text: text})
+	return err
+}
+
+const alertsJSON = `[{"scenario":"crowdsecurity/ssh-bf","source":{"cn":"FR","value":"1.2.3.4"}},` +
+	`{"scenario":"crowdsecurity/http-probing","source":{"cn":"DE","value":"5.6.7.8"}}]`
+
+func TestConfigureErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		expectedErr string
+	}{
+		{
+			name:        "bad output mode",
+			config:      "name: webhook\nurl: http://localhost\noutput_mode: xml",
+			expectedErr: "invalid output_mode 'xml': must be template or json_schema",
+		},
+		{
+			name:        "bad url template",
+			config:      "name: webhook\nurl: http://localhost/{{.Nope",
+			expectedErr: "invalid url template",
+		},
+		{
+			name:        "no secret",
+			config:      "name: webhook\nurl: http://localhost\nsigning:\n  header: X-Sig",
+			expectedErr: "signing secret is required",
+		},
+		{
+			name:        "bad status code",
+			config:      "name: webhook\nurl: http://localhost\nresponse_policy:\n  retry: [\"6xx\"]",
+			expectedErr: "invalid status code '6xx' in response_policy",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			p := New(hclog.NewNullLogger())
+			_, err := p.Configure(t.Context(), &protobufs.Config{Config: []byte(tc.config)})
+			cstest.RequireErrorContains(t, err, tc.expectedErr)
+		})
+	}
+}
+
+func TestSigning(t *testing.T) {
+	rec, srv := newRecorder(t, http.StatusOK)
+
+	p := configure(t, `
+name: webhook
+url: `+srv.URL+`
+method: POST
+headers:
+  Content-Type: application/json
+signing:
+  secret: s3cr3t
+`)
+
+	before := time.Now().Unix()
+
+	require.NoError(t, notify(p, alertsJSON))
+	require.Len(t, rec.requests, 1)
+
+	req := rec.requests[0]
+	assert.Equal(t, alertsJSON, string(req.body))
+	assert.Equal(t, "application/json", req.headers.Get("Content-Type"))
+
+	timestamp := req.headers.Get(defaultTimestampHeader)
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, ts, before)
+
+	// what a receiver would compute
+	assert.Equal(t, sign("s3cr3t", timestamp, req.body), req.headers.Get(defaultSignatureHeader))
+	assert.NotEqual(t, sign("wrong", timestamp, req.body), req.headers.Get(defaultSignatureHeader))
+}
+
+func TestTemplatedRouting(t *testing.T) {
+	rec, srv := newRecorder(t, http.StatusNoContent)
+
+	p := configure(t, `
+name: webhook
+url: '`+srv.URL+`/{{ .source.cn | lower }}'
+method: POST
+split_alerts: true
+headers:
+  X-Scenario: '{{ .scenario }}'
+`)
+
+	require.NoError(t, notify(p, alertsJSON))
+	require.Len(t, rec.requests, 2)
+
+	assert.Equal(t, "/fr", rec.requests[0].path)
+	assert.Equal(t, "crowdsecurity/ssh-bf", rec.requests[0].headers.Get("X-Scenario"))
+	assert.JSONEq(t, `{"scenario":"crowdsecurity/ssh-bf","source":{"cn":"FR","value":"1.2.3.4"}}`, string(rec.requests[0].body))
+
+	assert.Equal(t, "/de", rec.requests[1].path)
+	assert.Equal(t, "crowdsecurity/http-probing", rec.requests[1].headers.Get("X-Scenario"))
+
+	// split_alerts needs JSON
+	cstest.RequireErrorContains(t, notify(p, "not json"), "require the format to produce JSON")
+}
+
+func TestJSONSchemaOutput(t *testing.T) {
+	rec, srv := newRecorder(t, http.StatusOK)
+
+	p := configure(t, `
+name: webhook
+url: `+srv.URL+`
+method: POST
+output_mode: json_schema
+`)
+
+	require.NoError(t, notify(p, alertsJSON))
+	require.Len(t, rec.requests, 1)
+
+	envelope := Envelope{}
+	require.NoError(t, json.Unmarshal(rec.requests[0].body, &envelope))
+	assert.Equal(t, envelopeSchemaVersion, envelope.SchemaVersion)
+	assert.Equal(t, "webhook", envelope.Notification)
+	assert.Len(t, envelope.Alerts, 2)
+
+	_, err := time.Parse(time.RFC3339, envelope.SentAt)
+	require.NoError(t, err)
+}
+
+func TestResponsePolicy(t *testing.T) {
+	rec, srv := newRecorder(t, http.StatusServiceUnavailable)
+
+	p := configure(t, `
+name: webhook
+url: `+srv.URL+`
+method: POST
+response_policy:
+  retry: ["5xx", "429"]
+`)
+
+	// retried by the broker
+	err := notify(p, alertsJSON)
+
+	var retry *errRetry
+
+	require.ErrorAs(t, err, &retry)
+	assert.Equal(t, http.StatusServiceUnavailable, retry.code)
+
+	rec.setStatus(http.StatusTooManyRequests)
+	require.Error(t, notify(p, alertsJSON))
+
+	// dropped
+	rec.setStatus(http.StatusBadRequest)
+	require.NoError(t, notify(p, alertsJSON))
+
+	assert.Len(t, rec.requests, 3)
+
+	// without policy, errors are never retried
+	p = configure(t, "name: webhook\nurl: "+srv.URL+"\nmethod: POST")
+
+	rec.setStatus(http.StatusInternalServerError)
+	require.NoError(t, notify(p, alertsJSON))
+}
+
+func TestSplitAlertsRetry(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		paths  []string
+		failDE = true
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if r.URL.Path == "/de" && failDE {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		paths = append(paths, r.URL.Path)
+	}))
+	t.Cleanup(srv.Close)
+
+	p := configure(t, `
+name: webhook
+url: '`+srv.URL+`/{{ .source.cn | lower }}'
+method: POST
+split_alerts: true
+response_policy:
+  retry: ["5xx"]
+`)
+
+	// the second request fails, the first one is sent
+	require.Error(t, notify(p, alertsJSON))
+	assert.Equal(t, []string{"/fr"}, paths)
+
+	// the broker retries: only the failed request is sent again
+	mu.Lock()
+	failDE = false
+	mu.Unlock()
+
+	require.NoError(t, notify(p, alertsJSON))
+	assert.Equal(t, []string{"/fr", "/de"}, paths)
+
+	// once delivered, the same notification is sent entirely
+	require.NoError(t, notify(p, alertsJSON))
+	assert.Equal(t, []string{"/fr", "/de", "/fr", "/de"}, paths)
+}
@@ -0,0 +1,332 @@
+package httpnotifier
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+const (
+	outputModeTemplate   = "template"
+	outputModeJSONSchema = "json_schema"
+
+	// version of the Envelope sent in json_schema output mode
+	envelopeSchemaVersion = "1"
+
+	defaultSignatureHeader = "X-Crowdsec-Signature"
+	defaultTimestampHeader = "X-Crowdsec-Timestamp"
+
+	// how long to remember the requests already sent for a notification that failed, waiting for a retry
+	progressTTL = 24 * time.Hour
+)
+
+// SigningConfig adds an HMAC-SHA256 signature of the timestamp and body to the requests.
+// The signature is computed over "<timestamp>.<body>" and sent as "sha256=<hex digest>".
+type SigningConfig struct {
+	Secret          string `yaml:"secret"`
+	Header          string `yaml:"header"`
+	TimestampHeader string `yaml:"timestamp_header"`
+}
+
+// ResponsePolicy tells which non-2xx status codes must be retried. The others are dropped.
+// Codes can be exact ("429") or classes ("5xx").
+type ResponsePolicy struct {
+	Retry []string `yaml:"retry"`
+}
+
+// Envelope is the body sent in json_schema output mode
+type Envelope struct {
+	SchemaVersion string `json:"schema_version"`
+	Notification  string `json:"notification"`
+	SentAt        string `json:"sent_at"`
+	Alerts        []any  `json:"alerts"`
+}
+
+// payload is a request body, with the decoded JSON used to render the url and header templates
+type payload struct {
+	body []byte
+	data any
+}
+
+func sign(secret string, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func statusMatches(pattern string, code int) bool {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+
+	if len(pattern) == 3 && strings.HasSuffix(pattern, "xx") {
+		return strconv.Itoa(code/100) == pattern[:1]
+	}
+
+	return pattern == strconv.Itoa(code)
+}
+
+func (p ResponsePolicy) validate() error {
+	for _, pattern := range p.Retry {
+		valid := false
+
+		for code := 100; code < 600; code++ {
+			if statusMatches(pattern, code) {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return fmt.Errorf("invalid status code '%s' in response_policy", pattern)
+		}
+	}
+
+	return nil
+}
+
+func (p ResponsePolicy) shouldRetry(code int) bool {
+	for _, pattern := range p.Retry {
+		if statusMatches(pattern, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func newTemplate(name string, text string) (*template.Template, error) {
+	return template.New(name).Funcs(sprig.TxtFuncMap()).Parse(text)
+}
+
+func render(tmpl *template.Template, data any) (string, error) {
+	b := new(strings.Builder)
+
+	if err := tmpl.Execute(b, data); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+// compile prepares the url and header templates, and checks the webhook options
+func (c *PluginConfig) compile() error {
+	var err error
+
+	switch c.OutputMode {
+	case "":
+		c.OutputMode = outputModeTemplate
+	case outputModeTemplate, outputModeJSONSchema:
+	default:
+		return fmt.Errorf("invalid output_mode '%s': must be %s or %s", c.OutputMode, outputModeTemplate, outputModeJSONSchema)
+	}
+
+	if c.urlTemplate, err = newTemplate("url", c.URL); err != nil {
+		return fmt.Errorf("invalid url template: %w", err)
+	}
+
+	c.headerTemplates = make(map[string]*template.Template, len(c.Headers))
+
+	for name, value := range c.Headers {
+		if c.headerTemplates[name], err = newTemplate(name, value); err != nil {
+			return fmt.Errorf("invalid template for header %s: %w", name, err)
+		}
+	}
+
+	if c.Signing != nil {
+		if c.Signing.Secret == "" {
+			return errors.New("signing secret is required")
+		}
+
+		if c.Signing.Header == "" {
+			c.Signing.Header = defaultSignatureHeader
+		}
+
+		if c.Signing.TimestampHeader == "" {
+			c.Signing.TimestampHeader = defaultTimestampHeader
+		}
+	}
+
+	return c.ResponsePolicy.validate()
+}
+
+// buildPayloads turns the formatted notification into one or more request bodies.
+// With split_alerts, the notification must be a JSON array and each element is sent separately.
+func (c *PluginConfig) buildPayloads(text string, now time.Time) ([]payload, error) {
+	var decoded any
+
+	isJSON := json.Unmarshal([]byte(text), &decoded) == nil
+
+	if !c.SplitAlerts && c.OutputMode == outputModeTemplate {
+		if !isJSON {
+			decoded = text
+		}
+
+		return []payload{{body: []byte(text), data: decoded}}, nil
+	}
+
+	if !isJSON {
+		return nil, errors.New("split_alerts and json_schema output mode require the format to produce JSON")
+	}
+
+	alerts, ok := decoded.([]any)
+	if !ok {
+		alerts = []any{decoded}
+	}
+
+	groups := [][]any{alerts}
+
+	if c.SplitAlerts {
+		groups = make([][]any, 0, len(alerts))
+		for _, alert := range alerts {
+			groups = append(groups, []any{alert})
+		}
+	}
+
+	payloads := make([]payload, 0, len(groups))
+
+	for _, group := range groups {
+		var data any = group
+		if c.SplitAlerts {
+			data = group[0]
+		}
+
+		if c.OutputMode == outputModeTemplate {
+			body, err := json.Marshal(data)
+			if err != nil {
+				return nil, err
+			}
+
+			payloads = append(payloads, payload{body: body, data: data})
+
+			continue
+		}
+
+		body, err := json.Marshal(Envelope{
+			SchemaVersion: envelopeSchemaVersion,
+			Notification:  c.Name,
+			SentAt:        now.UTC().Format(time.RFC3339),
+			Alerts:        group,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		payloads = append(payloads, payload{body: body, data: data})
+	}
+
+	return payloads, nil
+}
+
+// requestParams renders the url and headers of a payload, and signs it
+func (c *PluginConfig) requestParams(p payload, now time.Time) (string, map[string]string, error) {
+	url, err := render(c.urlTemplate, p.data)
+	if err != nil {
+		return "", nil, fmt.Errorf("while rendering url: %w", err)
+	}
+
+	headers := make(map[string]string, len(c.headerTemplates)+2)
+
+	for name, tmpl := range c.headerTemplates {
+		value, err := render(tmpl, p.data)
+		if err != nil {
+			return "", nil, fmt.Errorf("while rendering header %s: %w", name, err)
+		}
+
+		headers[name] = value
+	}
+
+	if c.Signing != nil {
+		timestamp := strconv.FormatInt(now.Unix(), 10)
+		headers[c.Signing.TimestampHeader] = timestamp
+		headers[c.Signing.Header] = sign(c.Signing.Secret, timestamp, p.body)
+	}
+
+	return strings.TrimSpace(url), headers, nil
+}
+
+// errRetry is returned to the broker, so that the notification is sent again later
+type errRetry struct {
+	code int
+	body []byte
+}
+
+func (e *errRetry) Error() string {
+	return fmt.Sprintf("HTTP server returned status code %d: %s", e.code, bytes.TrimSpace(e.body))
+}
+
+// deliveryProgress remembers which requests of a notification have been sent, when another one failed.
+// When the broker retries the notification, only the requests that failed are sent again.
+type deliveryProgress struct {
+	mu      sync.Mutex
+	pending map[string]*notificationProgress
+}
+
+type notificationProgress struct {
+	sent    map[int]struct{}
+	updated time.Time
+}
+
+func newDeliveryProgress() *deliveryProgress {
+	return &deliveryProgress{pending: make(map[string]*notificationProgress)}
+}
+
+// progressKey identifies a notification: the broker retries with the same text
+func progressKey(name string, text string) string {
+	sum := sha256.Sum256([]byte(name + "\x00" + text))
+	return hex.EncodeToString(sum[:])
+}
+
+func (d *deliveryProgress) isSent(key string, idx int) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p, ok := d.pending[key]
+	if !ok {
+		return false
+	}
+
+	_, ok = p.sent[idx]
+
+	return ok
+}
+
+func (d *deliveryProgress) markSent(key string, idx int, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	p, ok := d.pending[key]
+	if !ok {
+		p = &notificationProgress{sent: make(map[int]struct{})}
+		d.pending[key] = p
+	}
+
+	p.sent[idx] = struct{}{}
+	p.updated = now
+}
+
+// done forgets a notification whose requests have all been sent, and the ones that were never retried
+func (d *deliveryProgress) done(key string, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.pending, key)
+
+	for k, p := range d.pending {
+		if now.Sub(p.updated) > progressTTL {
+			delete(d.pending, k)
+		}
+	}
+}
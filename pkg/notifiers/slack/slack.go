@@ -0,0 +1,79 @@
+// Package slacknotifier posts the notifications to a Slack webhook.
+package slacknotifier
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/slack-go/slack"
+	"gopkg.in/yaml.v3"
+
+	"github.com/crowdsecurity/crowdsec/pkg/protobufs"
+)
+
+type PluginConfig struct {
+	Name      string  `yaml:"name"`
+	Webhook   string  `yaml:"webhook"`
+	Channel   string  `yaml:"channel"`
+	Username  string  `yaml:"username"`
+	IconEmoji string  `yaml:"icon_emoji"`
+	IconURL   string  `yaml:"icon_url"`
+	LogLevel  *string `yaml:"log_level"`
+}
+
+type Notifier struct {
+	protobufs.UnimplementedNotifierServer
+	ConfigByName map[string]PluginConfig
+	logger       hclog.Logger
+}
+
+func New(logger hclog.Logger) *Notifier {
+	return &Notifier{
+		ConfigByName: make(map[string]PluginConfig),
+		logger:       logger,
+	}
+}
+
+func (n *Notifier) Notify(ctx context.Context, notification *protobufs.Notification) (*protobufs.Empty, error) {
+	if _, ok := n.ConfigByName[notification.Name]; !ok {
+		return nil, fmt.Errorf("invalid plugin config name %s", notification.Name)
+	}
+
+	cfg := n.ConfigByName[notification.Name]
+
+	logger := n.logger
+
+	if cfg.LogLevel != nil && *cfg.LogLevel != "" {
+		logger.SetLevel(hclog.LevelFromString(*cfg.LogLevel))
+	}
+
+	logger.Info(fmt.Sprintf("found notify signal for %s config", notification.Name))
+	logger.Debug(fmt.Sprintf("posting to %s webhook, message %s", cfg.Webhook, notification.Text))
+
+	err := slack.PostWebhookContext(ctx, cfg.Webhook, &slack.WebhookMessage{
+		Text:      notification.Text,
+		Channel:   cfg.Channel,
+		Username:  cfg.Username,
+		IconEmoji: cfg.IconEmoji,
+		IconURL:   cfg.IconURL,
+	})
+	if err != nil {
+		logger.Error(err.Error())
+	}
+
+	return &protobufs.Empty{}, err
+}
+
+func (n *Notifier) Configure(ctx context.Context, config *protobufs.Config) (*protobufs.Empty, error) {
+	d := PluginConfig{}
+
+	if err := yaml.Unmarshal(config.Config, &d); err != nil {
+		return nil, err
+	}
+
+	n.ConfigByName[d.Name] = d
+	n.logger.Debug(fmt.Sprintf("Slack plugin '%s' use URL '%s'", d.Name, d.Webhook))
+
+	return &protobufs.Empty{}, nil
+}
@@ -22,6 +22,8 @@ const (
 	CscliImportOrigin                 = "cscli-import"
 	ListOrigin                        = "lists"
 	CAPIOrigin                        = "CAPI"
+	FeedOrigin                        = "feed"
+	FederationOrigin                  = "federation"
 	CommunityBlocklistPullSourceScope = "crowdsecurity/community-blocklist"
 )
 
@@ -35,6 +37,8 @@ func GetOrigins() []string {
 		CscliImportOrigin,
 		ListOrigin,
 		CAPIOrigin,
+		FeedOrigin,
+		FederationOrigin,
 	}
 }
 
@@ -3,6 +3,7 @@ package types
 import (
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -109,3 +110,29 @@ func IsNetworkFS(path string) (bool, string, error) {
 
 	return fsType == "nfs" || fsType == "cifs" || fsType == "smb" || fsType == "smb2", fsType, nil
 }
+
+// ParseDuration parses a duration like time.ParseDuration, also accepting a number of days ("7d")
+func ParseDuration(d string) (time.Duration, error) {
+	durationStr := d
+
+	if strings.HasSuffix(d, "d") {
+		days := strings.Split(d, "d")[0]
+		if days == "" {
+			return 0, fmt.Errorf("'%s' can't be parsed as duration", d)
+		}
+
+		daysInt, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, err
+		}
+
+		durationStr = strconv.Itoa(daysInt*24) + "h"
+	}
+
+	duration, err := time.ParseDuration(durationStr)
+	if err != nil {
+		return 0, err
+	}
+
+	return duration, nil
+}
@@ -67,6 +67,10 @@ install -m 551 cmd/notification-http/notification-http %{buildroot}%{_libdir}/%{
 install -m 551 cmd/notification-splunk/notification-splunk %{buildroot}%{_libdir}/%{name}/plugins/
 install -m 551 cmd/notification-email/notification-email %{buildroot}%{_libdir}/%{name}/plugins/
 install -m 551 cmd/notification-sentinel/notification-sentinel %{buildroot}%{_libdir}/%{name}/plugins/
+install -m 551 cmd/notification-teams/notification-teams %{buildroot}%{_libdir}/%{name}/plugins/
+install -m 551 cmd/notification-mattermost/notification-mattermost %{buildroot}%{_libdir}/%{name}/plugins/
+install -m 551 cmd/notification-discord/notification-discord %{buildroot}%{_libdir}/%{name}/plugins/
+install -m 551 cmd/notification-syslog/notification-syslog %{buildroot}%{_libdir}/%{name}/plugins/
 install -m 551 cmd/notification-file/notification-file %{buildroot}%{_libdir}/%{name}/plugins/
 
 install -m 600 cmd/notification-slack/slack.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
@@ -74,6 +78,10 @@ install -m 600 cmd/notification-http/http.yaml %{buildroot}%{_sysconfdir}/crowds
 install -m 600 cmd/notification-splunk/splunk.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
 install -m 600 cmd/notification-email/email.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
 install -m 600 cmd/notification-sentinel/sentinel.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
+install -m 600 cmd/notification-teams/teams.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
+install -m 600 cmd/notification-mattermost/mattermost.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
+install -m 600 cmd/notification-discord/discord.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
+install -m 600 cmd/notification-syslog/syslog.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
 install -m 600 cmd/notification-file/file.yaml %{buildroot}%{_sysconfdir}/crowdsec/notifications/
 
 %clean
@@ -89,6 +97,10 @@ rm -rf %{buildroot}
 %{_libdir}/%{name}/plugins/notification-splunk
 %{_libdir}/%{name}/plugins/notification-email
 %{_libdir}/%{name}/plugins/notification-sentinel
+%{_libdir}/%{name}/plugins/notification-teams
+%{_libdir}/%{name}/plugins/notification-mattermost
+%{_libdir}/%{name}/plugins/notification-discord
+%{_libdir}/%{name}/plugins/notification-syslog
 %{_libdir}/%{name}/plugins/notification-file
 %{_sysconfdir}/%{name}/patterns/linux-syslog
 %{_sysconfdir}/%{name}/patterns/ruby
@@ -125,6 +137,10 @@ rm -rf %{buildroot}
 %config(noreplace) %{_sysconfdir}/%{name}/notifications/splunk.yaml
 %config(noreplace) %{_sysconfdir}/%{name}/notifications/email.yaml
 %config(noreplace) %{_sysconfdir}/%{name}/notifications/sentinel.yaml
+%config(noreplace) %{_sysconfdir}/%{name}/notifications/teams.yaml
+%config(noreplace) %{_sysconfdir}/%{name}/notifications/mattermost.yaml
+%config(noreplace) %{_sysconfdir}/%{name}/notifications/discord.yaml
+%config(noreplace) %{_sysconfdir}/%{name}/notifications/syslog.yaml
 %config(noreplace) %{_sysconfdir}/%{name}/notifications/file.yaml
 %config(noreplace) %{_sysconfdir}/cron.daily/%{name}
 
@@ -172,3 +172,32 @@ teardown() {
 
     assert_json '{action:"allow",http_status:200}'
 }
+
+@test "appsec-only mode" {
+    config_set '.common.log_media="stdout"'
+
+    rune -0 cscli collections install crowdsecurity/appsec-virtual-patching
+
+    socket="$BATS_TEST_TMPDIR"/sock
+
+    cat > "$ACQUIS_DIR"/appsec.yaml <<-EOT
+	source: appsec
+	listen_socket: $socket
+	labels:
+	  type: appsec
+	appsec_config: crowdsecurity/appsec-default
+	EOT
+
+    # the other datasources are rejected
+    rune -1 wait-for "$CROWDSEC" -appsec-only
+    assert_stderr --partial "can't run in appsec-only mode, only appsec datasources are allowed"
+
+    config_set '.crowdsec_service.acquisition_path=""'
+
+    rune -0 wait-for \
+        --err "Appsec Runner ready to process event" \
+        "$CROWDSEC" -appsec-only
+
+    assert_stderr --partial "Starting appsec-only processing"
+    refute_stderr --partial "Loading parsers"
+}
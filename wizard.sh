@@ -76,6 +76,10 @@ SLACK_PLUGIN_BINARY="./cmd/notification-slack/notification-slack"
 SPLUNK_PLUGIN_BINARY="./cmd/notification-splunk/notification-splunk"
 EMAIL_PLUGIN_BINARY="./cmd/notification-email/notification-email"
 SENTINEL_PLUGIN_BINARY="./cmd/notification-sentinel/notification-sentinel"
+TEAMS_PLUGIN_BINARY="./cmd/notification-teams/notification-teams"
+MATTERMOST_PLUGIN_BINARY="./cmd/notification-mattermost/notification-mattermost"
+DISCORD_PLUGIN_BINARY="./cmd/notification-discord/notification-discord"
+SYSLOG_PLUGIN_BINARY="./cmd/notification-syslog/notification-syslog"
 FILE_PLUGIN_BINARY="./cmd/notification-file/notification-file"
 
 HTTP_PLUGIN_CONFIG="./cmd/notification-http/http.yaml"
@@ -83,6 +87,10 @@ SLACK_PLUGIN_CONFIG="./cmd/notification-slack/slack.yaml"
 SPLUNK_PLUGIN_CONFIG="./cmd/notification-splunk/splunk.yaml"
 EMAIL_PLUGIN_CONFIG="./cmd/notification-email/email.yaml"
 SENTINEL_PLUGIN_CONFIG="./cmd/notification-sentinel/sentinel.yaml"
+TEAMS_PLUGIN_CONFIG="./cmd/notification-teams/teams.yaml"
+MATTERMOST_PLUGIN_CONFIG="./cmd/notification-mattermost/mattermost.yaml"
+DISCORD_PLUGIN_CONFIG="./cmd/notification-discord/discord.yaml"
+SYSLOG_PLUGIN_CONFIG="./cmd/notification-syslog/syslog.yaml"
 FILE_PLUGIN_CONFIG="./cmd/notification-file/file.yaml"
 
 
@@ -506,6 +514,10 @@ install_plugins(){
     cp ${HTTP_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
     cp ${EMAIL_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
     cp ${SENTINEL_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
+    cp ${TEAMS_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
+    cp ${MATTERMOST_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
+    cp ${DISCORD_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
+    cp ${SYSLOG_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
     cp ${FILE_PLUGIN_BINARY} ${CROWDSEC_PLUGIN_DIR}
 
     if [[ ${DOCKER_MODE} == "false" ]]; then
@@ -514,6 +526,10 @@ install_plugins(){
         cp -n ${HTTP_PLUGIN_CONFIG} /etc/crowdsec/notifications/
         cp -n ${EMAIL_PLUGIN_CONFIG} /etc/crowdsec/notifications/
         cp -n ${SENTINEL_PLUGIN_CONFIG} /etc/crowdsec/notifications/
+        cp -n ${TEAMS_PLUGIN_CONFIG} /etc/crowdsec/notifications/
+        cp -n ${MATTERMOST_PLUGIN_CONFIG} /etc/crowdsec/notifications/
+        cp -n ${DISCORD_PLUGIN_CONFIG} /etc/crowdsec/notifications/
+        cp -n ${SYSLOG_PLUGIN_CONFIG} /etc/crowdsec/notifications/
         cp -n ${FILE_PLUGIN_CONFIG} /etc/crowdsec/notifications/
     fi
 }